@@ -18,27 +18,45 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RXW2/bNhT+KwS3hxVQ66TpCtRvjq2gGtLEs50CxRYYtHhksZBIjaSceIH++0BSdyvx",
-	"lnpPtsRz+c53Ljx6wqFIM8GBa4XHT1iCygRXYB8uCV3AXzkobZ5CwTVw+5dkWcJCopngo+9KcPMOHkma",
-	"JWD+UtCEJXiMA74jCaNIOisoI5KkoEEq7GGlic4VHn84O/OwZtro9jWwh/U+MweXk9l64f9+5y9XuPCw",
-	"CmNIiXH2s4QIj/FPoyaQkTtVI19KIXFRFB6moELJMgN5wE3h4SshN4xS4K+M9ZvIERWIC41isgOk8ihi",
-	"IQOuUQYyZUoxwRXSwjxGQqZIx0whkYG0xjuMXDSMzGtlRIEzoA0nc3/xJVgug9ub9cy/CfzZCZhZxYBI",
-	"rmPg2kQNFOUKJKICVBNbE9AL8RQeDrgGyUmyBLkD6XweZ/eHc+ucImW9InCCHp6LhIX7qeBRwsLXlvS1",
-	"eAD5NpNMSKb3KLM2kZYMqOFC7EBKRgHFbBsfCnaS/KmVZGcmrLA1Kb69Dqbf1tPbm6vrYHqK0u+5QhvQ",
-	"DwAcJd3ACKfDMTBQBsUCvkOogb6SxhIFPBpxppM9kqVBpGNotX9D18cDuiqVhq6F/5s/XZ2kEXo+OrAK",
-	"D99x0yVCsr9fzcFXO4JazWb6KZRAzSNJFCLSuWTSFRcJQ1DK9ZkEJXIZQoei84aiSddsZaah6u5mcrf6",
-	"7N+sgunkNIz1XFqQJfhNrtEDcRMkk2LHKFAkpJFhbhTjogZg7556WGTSjBTN3JVUkffU8z2z74G6bkcp",
-	"KEW20ESrtGR8a4Ms2epb+LxazZE7RKGgRtdMNaLxGDOuL943xhjXsAU7U0q6+8aWsZC6xKLyNCVyP4TF",
-	"vegr29CROUPM1kLEQLbh5JK9lRCBBB4OxFh4uE73+A93WsddQb6v1cTGVLiB4+9IkhMNrUu/y76ZqCyE",
-	"NeNKE+P7SKksnXxQifehHdh7GZVbTQ5hQSlB1z8M0MMKEtvw67JO5UB2nVZVyRJVOmjTGpL/ofYm8/ni",
-	"9qs/Q29RyT3KeRgTvu3a/JN/uZ0FV0FH0vRVKqipkp4w9jDwPDVMVx6whysTLbKfqZwXeB3iqY5vKIt9",
-	"pg9rK4PQTk9KmaGFJPPWuZY5eM+kwWiyqBo7v0QJPLJNAsgl+Q0+QNOvQuP5ELMRYzwS1XQndmd4ft2Y",
-	"zAMUCVmtBCV5FpIZe/CYCeXmOHC3Vak3+OBO8/mWcUB+oz2ZB9jDO5DKOdydkySLyblhVWTAScbwGF+8",
-	"O3t3gT2cER1bPkdVHYxhoK2F++0NnlJQIYLKjKMq4/UOT7bEvEMkSVB50xmyK28mPmpW/NSEQTJTHSSx",
-	"64SrGERQq2LqdTGgLQCL+vovvV4Kuj/d5tjzUnQLwpSafdH6Enp/dvY/uC/n2cBV2kq/yu2tH+WJSfgH",
-	"B2TIfg141Ppusyrnx1U664xVujiu1HwyWY2PxzXqrdEqfDqu0NvZCw//+m8YGPrwsAtGeRc3pdb6Nt0n",
-	"gtC6vttznGyVGRRNVvC9TZr7wjBnTziXZqcbkYyNmg69r5WfhtfL1pSoW8G45CSFTh3g4r74JwAA///r",
-	"NDzprQ8AAA==",
+	"H4sIAAAAAAAC/+xZa28bN9b+Kwd8X6ANML41aYHqm2MrWxWJrbWdLorKsOmZIw27FDkhOXK8gf774pCc",
+	"+/iSxFlsgf2UyEOe+3nOhZ9YqteFVqicZZNPzKAttLLof7zm2Rl+KNE6+pVq5VD5//KikCLlTmi196fV",
+	"iv6GH/m6kEj/zdBxIdmEzdSGS5GBCVSg4Iav0aGxLGHWcVdaNnm1v58wJ5zE4Q2WMHdX0IfXh8dXZ9O/",
+	"v5+eX7Btwmya45oTs/83uGQT9n97jSJ74avdmxqjDdtutwnL0KZGFCTyCJttwqYbLkuv0oVYoy6/VOeL",
+	"HKHQUqR3kIkMlHawFErYHDByUCu4FS4XCg7advhx/1Vjh0Ya0MuK3ncfSu34Tppj+s/vwIk1ZqDLlpEu",
+	"Zu+mp++fw0CHT9bBkb5oduJ5F4wH2vgv2OiRIc+kUEjSvdHmRmQZqi808u+6hEx7wXK+QbDlcilSgcqR",
+	"LGthrdDKgtP0c6nNGlwuLOgCjSfeCb+Xjdnn9WXIUAnMGtvOp2fvZufns9OTq+PpyWx6/AxWpljhpctR",
+	"OdIaMygtGsg02ka3RqEH9NkmbKYcGsXlOZoNmsDzcet+dSIFpmA9V8BwMGFzHw5HWi2lSL80l97qW4os",
+	"I7QR7q4KSWcEZmQLvUFjRIaQi1U+PNhx8s8tJwcyaSVb4+LTt7Oj36+OTk/evJ0dPUca9VjBDbpbRAWy",
+	"qxhX2bgOAi1JcYZ/Yuow+0IzRinwIx0XTt6BiQR9jjZY25jrp4G5qiuNuc6mv06PLp4lEXo8OmJtE/Ze",
+	"UZZoI/71xTb4zeN9K9kon1KDGf3k0gI3gaUwIbh4mqK1Ic8MWl2aFDsmOmhMdNglW5FpTPX+5PD9xS/T",
+	"k4vZ0eHzWKzHUtiaK9yUDm55QJDC6I3IqE4YOiNC3WPbWgBf6I+44uYuOIF+F4aQxQm0tYmD4bsy/CNH",
+	"l2MA+pibxDdiPmawjFVgUM1vtJbIPWyFi1dihP7smIpfQ725b50RakXXjZZSl+6qQJOicnyFQzrnOTlX",
+	"Lys5bFti0RM4AaEgUmMJI7Dljk2YUO7lD40EQjlcofGWrP09+aOlzqhsSW3Ny5qUvqGoJ2WOtLLOcKGc",
+	"nRvcCLwdeiP1vrqq4WGg7Tx+8QUaOEQpoJECblDqWzjY3weXcwdr7tI8ZN1CRRslHpRuWx6OH7yLC25c",
+	"9I0wFYfdhTpdC0d2vM1RgdJgyzSvDB257C4US5hwuLaPBX8nLLe1vbgx3P9eoarq38AKf6u/VTFknabs",
+	"iNJYdEF5+oRqJRR6zUgMITFbqKXR6yBsOwZ+ejUSAxQC5EOh1UMO8fw6GEd2FFQ9C0x94LX6JW0yNE81",
+	"VTDSWSXFmLUawOFZJugUl/NWaDlTYh9lfj0/PYFzfxEynZZrVK6bkyBUUbpdoFbmmtS4hhivd5BrmdmF",
+	"osNLgTKDtInvBBRaMgOXWq1iIIVTBXe5DfHnI/I6gphpkfYORbMRKVYYZ9rkg+N6CdZL1WiQjvM6QTWW",
+	"onVf1c3Kqs70fX/s/45ZaIxgjdYGGBgAGVVnrvg9MVQYodz3L6jdL0rnbeMvCAXO8NSjG27Q1E3SAIRb",
+	"mT0SZwmUha96KgOhUllmInilokexu1DLoIxwu3CqfB/hSqOo5lAT06bqwec6ynhN6fYZiR+iedpYZDSe",
+	"YyXum+qXi4s5hI+Q6gyfAuJ1KR+WDm1cdJ4t12tuRqtQ+EP/so8VoG8gfJ+xFGja4pRG7BhcokGVjgRF",
+	"L17911rvSuTRII3Ob03v3XCNqXMllHWceD/ikPNwflYdH6RSn97DUoUdw/9q2wO1rc7fq6/21fNhSwMq",
+	"QW+CkoXqY0kfG7zBGigQ9lugwXP3Aq1FRzt0Oj0CfFaLYFH6wn9V1asRtOlXtOoO4WudFSP4cx8WHs7n",
+	"Z6e/TY9hByIWQKnSnKtVl+ZCvTs9nr2ZdU6SqmudEWr1DrOEoSrXlPkVB5awigS7HEjYg4sHgnvMTi3M",
+	"e6Q6D0NlgDFfOW/4ZLkKqTKk8FYotODPRJvF3Eq5lHZAvQ7/ewpKE94+EUcqTD9Puzu3BLRCkBTPBZp4",
+	"Cjdhsnkq8wcGnI4xKiHv90vTnj63VwxyO5b5NUcIJ2AlNqjIMQ/Ru1fjUdXCoNYa3f67qm6fyFCqAtPP",
+	"mwoiST+1iGW1gvh+KfGjuJEIQf4XjzfgxHkoMx0TaqmrTQ/3+8P7V4+H85lvcrudrxdJaUe1TNuw00EV",
+	"Nqz2BRvst6YB91uL98P5jCVsg8YGhpsDLoucH5BVdYGKF4JN2Mvd/d2XlAs0tZDIexVOTnCkDdN2BDYi",
+	"U7TA66mmcmrdm/AVp78BlxLi1ouMXdTTpYYMHZo1qcELQk8ufTUPiAocWohar45nWUuAs3pR03qqmfwx",
+	"TKtUm8wCV3A6P3xqt+BlCV2BXSjhQhPnE7HbgfQHzNg8xAbmOgEurV6o+PgQmvPq4You82bI1gbizNKE",
+	"xe5CKU3G3gFNjQoR8aJ//wLCh7YAvuARDTrv+X0sDFYPDNQ1BAYLtSylhJ2u8hRH1mERuh2y3YcS/Qyh",
+	"+BrZhEXbsfZ8XtVWLww1F8Sd/i2lHCuulyGt0LrXOrt7vqV/Lyi23fwlZPB/aL0Y/rC//w3YBwZjW9BW",
+	"ttrSL2yXpaT8fLW/fx/9WuC91vumv3Lw+JXOJtpfevn4pea1y9/46fEb9cLfX/j58Qu955Ztwn58igXG",
+	"3oz83VeP3x0+mPqtchySG0xpvf7eSc2zGsjaDS1fEc60/MkuiVqDpcWgzD4FTttZ3AZNp1tI6wd0wibJ",
+	"b1DaJA6XaqF0EQpib0/XwjHPobV3ot9hoODWN1+0QVuo3gotAdxd7fqiRYODx26b61tPjUt6HcqAlEAL",
+	"N7jUBrtDCI1Q5U2YR3dh7ofP8IKhtIO4YfZi1srHHVc1zrQVik8fhTaEmIbHqZgrj2z1Hig4IIw73eox",
+	"bIHYt0Gk+3ut/zA2jSzqx161wqe/EDT9NZGmsnM/F8m/dz5+te8J6keoUbQhkl640PGURrIJ2+OF2Gsa",
+	"v8v68qfxF8z2LqTKEdtU+xbH7eX23wMAUxNcc30jAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
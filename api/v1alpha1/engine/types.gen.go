@@ -9,11 +9,55 @@ const (
 	MODIFIED EvaluateResponseStatus = "MODIFIED"
 )
 
+// Defines values for EvaluateRequestParamsExplain.
+const (
+	Fails EvaluateRequestParamsExplain = "fails"
+	Full  EvaluateRequestParamsExplain = "full"
+	Notes EvaluateRequestParamsExplain = "notes"
+)
+
+// CanaryPolicy defines model for CanaryPolicy.
+type CanaryPolicy struct {
+	// Applied Whether the policy was evaluated for the request
+	Applied bool `json:"applied"`
+
+	// PolicyId ID of the policy
+	PolicyId string `json:"policy_id"`
+
+	// RolloutPercentage Share of requests the policy is evaluated for, in percent
+	RolloutPercentage int32 `json:"rollout_percentage"`
+}
+
+// ConstraintsPreview defines model for ConstraintsPreview.
+type ConstraintsPreview struct {
+	// CanaryPolicies Policies with a rollout percentage below 100 that matched the
+	// request, and whether the request was part of their rollout.
+	// Omitted when no such policy matched.
+	CanaryPolicies *[]CanaryPolicy `json:"canary_policies,omitempty"`
+
+	// Generation Generation of the stored policy set that the engine was compiled
+	// from.
+	Generation int64 `json:"generation"`
+
+	// Rejections Policies that rejected the partial spec, in evaluation order
+	Rejections []PolicyRejection `json:"rejections"`
+
+	// Schema JSON Schema document of the policy input. The `spec` property holds
+	// the field constraints, nested along their field paths, and the
+	// `provider` property the service provider constraints.
+	Schema map[string]interface{} `json:"schema"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Detail Detailed error message
 	Detail *string `json:"detail,omitempty"`
 
+	// Explanations print() output and explain trace of every policy evaluated for the
+	// request, in evaluation order, up to and including the policy that
+	// failed it. Only returned by an evaluation with `explain` set.
+	Explanations *[]PolicyExplanation `json:"explanations,omitempty"`
+
 	// Status HTTP status code
 	Status int32 `json:"status"`
 
@@ -31,8 +75,20 @@ type EvaluateRequest struct {
 
 // EvaluateResponse defines model for EvaluateResponse.
 type EvaluateResponse struct {
+	// CanaryPolicies Policies with a rollout percentage below 100 that matched the
+	// request, and whether the request was part of their rollout.
+	// Omitted when no such policy matched.
+	CanaryPolicies           *[]CanaryPolicy `json:"canary_policies,omitempty"`
 	EvaluatedServiceInstance ServiceInstance `json:"evaluated_service_instance"`
 
+	// Explanations print() output and explain trace of every evaluated policy, in
+	// evaluation order. Only returned when `explain` is set.
+	Explanations *[]PolicyExplanation `json:"explanations,omitempty"`
+
+	// Generation Generation of the stored policy set that the engine evaluating the
+	// request was compiled from.
+	Generation int64 `json:"generation"`
+
 	// SelectedProvider Service provider selected by policies
 	SelectedProvider string `json:"selected_provider"`
 
@@ -45,6 +101,32 @@ type EvaluateResponse struct {
 // MODIFIED - Request was modified by policies
 type EvaluateResponseStatus string
 
+// PolicyExplanation defines model for PolicyExplanation.
+type PolicyExplanation struct {
+	// PolicyId ID of the policy
+	PolicyId string `json:"policy_id"`
+
+	// PrintOutput Lines printed by print() calls of the policy
+	PrintOutput []string `json:"print_output"`
+
+	// Trace Explain trace of the evaluation, one line per trace event
+	Trace []string `json:"trace"`
+}
+
+// PolicyRejection defines model for PolicyRejection.
+type PolicyRejection struct {
+	// PolicyId ID of the policy
+	PolicyId string `json:"policy_id"`
+
+	// Reason Rejection reason given by the policy
+	Reason *string `json:"reason,omitempty"`
+}
+
+// PreviewConstraintsRequest defines model for PreviewConstraintsRequest.
+type PreviewConstraintsRequest struct {
+	ServiceInstance ServiceInstance `json:"service_instance"`
+}
+
 // ServiceInstance defines model for ServiceInstance.
 type ServiceInstance struct {
 	// Spec Service specification (flexible schema)
@@ -54,6 +136,9 @@ type ServiceInstance struct {
 // BadRequest defines model for BadRequest.
 type BadRequest = Error
 
+// EvaluationTimeout defines model for EvaluationTimeout.
+type EvaluationTimeout = Error
+
 // Forbidden defines model for Forbidden.
 type Forbidden = Error
 
@@ -69,5 +154,22 @@ type Rejected = Error
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = Error
 
+// EvaluateRequestParams defines parameters for EvaluateRequest.
+type EvaluateRequestParams struct {
+	// Explain Records an OPA explain trace of every evaluated policy and returns
+	// it with the print() output of the policy in `explanations`, also
+	// in the error response of a rejected or failed evaluation.
+	// notes - only the trace() notes of the policies
+	// fails - the expressions that failed
+	// full - every evaluation step
+	Explain *EvaluateRequestParamsExplain `form:"explain,omitempty" json:"explain,omitempty"`
+}
+
+// EvaluateRequestParamsExplain defines parameters for EvaluateRequest.
+type EvaluateRequestParamsExplain string
+
 // EvaluateRequestJSONRequestBody defines body for EvaluateRequest for application/json ContentType.
 type EvaluateRequestJSONRequestBody = EvaluateRequest
+
+// PreviewConstraintsJSONRequestBody defines body for PreviewConstraints for application/json ContentType.
+type PreviewConstraintsJSONRequestBody = PreviewConstraintsRequest
@@ -18,92 +18,277 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xbe3PbOJL/KijOVtmuE2VKtvzQVupKYymJdh1b68fc7IxyNkQ2JUxIgAOAtpWUv/tV",
-	"A+BLUmxP4pnaur9skXh1o/HrX3eDX7xQpJngwLXy+l+8jEqaggZpfk1EwsLlOJpQvcDfEahQskwzwb2+",
-	"d7UAIkGJXIZAWARcs5iBJLGQRC+AZKZ3m3zIlSYzIJTc0YRF7jkZD6dcL6gmoeCxkKkiWpDBaOJ3ul0i",
-	"4fecSUhxXf0p90nHP9gj4YJKGuLqSCL4HJ+finuQIVVAEtD4pkV4ns7MP5RHZLHMFsAVETxZYnuzGKWp",
-	"1OSe6QWhrl/5DnjUfEOEdENOudfy4IGmWQJe35snYkYTn+Z64VuZvJbHUDMZ6qvlcZpiu8xp0Wt5TqzI",
-	"62uZQ8tT4QJSiqpN6cMp8Dnq+WCv5aWMFz87LRxPg8SR//dX6n8O/OOP2+4f/+OXoHXQeSye7/z337yW",
-	"p5cZzqy0ZHzuPT4+4tQqE1yB2dhBIoFGy9EDU3bfQ8E1cI3/0ixLWEhxk3d/U7jTXyqh0QY0ZYnXd8Zh",
-	"dTUekq11dWwRauchYCdC9ShNeYiLC8KDw4PgIPAP4fjAP+iF4MNRcORDhx4c7c3i/eOjmdfylKY6V15/",
-	"PzhueZppo/qLwuzWJnCSD04vRoPhv29GP48vry69x7qq/yYh9vreD7uV6e/at2p3JKWQVmFNY//ajI8t",
-	"70caXcDvOSj9jZp8yyCJyJaEubgJRQRbJEVL5MIcG0gzvWyq7vB4bz+K98Dfnx3s+fvd45k/C+KePzuK",
-	"9noBhJ2DHjRUF1SqG3N7CqVdMqmd+FJ747OfBqfj4c3g4t31h9HZ1Svo74lpH1veWyFnLIqAf6MG/y1y",
-	"EgmjsQW9A6LyOGYhA65JBjJlSjHBDcBkIBFsiF4wRUQG0gzeVO+sG+5F+9Dz4wN66B8dBx1/Fkbgx53u",
-	"3n7v4BCfNNS7V6l3Uk5HIuAMokqrk9HFh/Hl5fj87GY4OhuPhq+gVsRgPHEIviHVEJFcgSSRAFVpo1LB",
-	"Exp4bHljjihDk0uQdyDtnN+2HwNOcg4PGYS4JMCRiAjDXEqIyP2CJUAyKUJQivG5cRbOLpob0YkOj4Lg",
-	"MPCPYnroHx5EsR8fB8d+3J0dHu+HtBcch7WN6DXt3ApDlJHGLqJu4leji7PB6auY9qaZHlvemdBvRc6j",
-	"7wPYjcBabrCBoabWjme9gzjoUf8gOur5vf1Z5EeH9NCPgrh32KWwd3RIG+a7vwFYcezYLL5U2dn51c3b",
-	"8+uz4WvCaTXPY8u75iikkOwzfKvSfjIoUzsSaPWhBENPaKIIlVCwiwiPAw3RDO1pKNhMU5+0YwHBh158",
-	"4OPp9+ksjHyo4UFDn51Kn4PmQkr/Xyr1+mxwffV+dHY1PhlcvQokrExpxHLiznJN7qk1nEyKOxZBhPSG",
-	"KcIsPuP8RoWm8/dAQAH4FzAXRC25pg+E8YaXi9HvNXXdhaPjTuew4x/H9Mg/OowDP6Ad6nfD4+OgF84O",
-	"guOorutut9J1te7Vw/52MD4dDW8mF6OT87Ph+Gp8fvYKil6b77Ec03CqUnvNbuYxKegYiUWSiHuEwYu3",
-	"J+TwKDgkEylmCaRkaHSpDI81xPh4rz3lUz6xW6eI0jIPdS5LjGWGSds1IQ0fTMYkpizJJai2oa+ZRMjX",
-	"zPLAYrdW1/g+Tyn3kejQWQIEHrKEcjusyiBkMQvx7FgXYnGdh0BEbIm/XX97yi8XIk+iwtYIDXEIM+Tq",
-	"SiO4gwSX5tZZ0ex1dvScSa8T4LqNrcp6zdnv+YYAhqlK1oYH4yG0ybWCOE+w6ZRrScNPuIO4URHM8vmc",
-	"8fmqHC8kbVYtXt/LJfMlxGAm3CRScQjWNu/qakLsS4IKq6/CUMFyCsb1XrcamnENczCuy52pZ+xC5WlK",
-	"5XJl34kZri76SzhnJZd9sLZNF2NSqqPYrWVBH+pTt8kVbh5T5k1IueAspMmU211Elbi94Xnq9X9dp7ut",
-	"mq9rrcYSLe9idHl+fXEyuhn9/H5wfYmw3dqIMS1v8OP5hX1/fn11c/725mJw9m7ktbzrs/GHyekIpzOv",
-	"Sz6CrwY/Dcangx9PseFwNBiejs9wspPRaGgarzqN1gZu+bGxAesSvtTOHutR66+e21tne4WhfCy7idlv",
-	"EGrcxvdAE5s2aGJOtjGZcFJsE8H3hUXVHHIlzMIOjOui0TlPlkU0/fITYkYgpRCrYy+fVYPruiZ3y3vw",
-	"KWR+uXArMBJEhf3c2j+2vCzJJU3q4iAXTkDjCl12gPF5nlBZb+SmszzQTymnc5DtKEzbTOy6VrhYSx7X",
-	"Rb+ATIJCB0coJ+eTAdk+z4ATRzYHc+B6p0jRFFJYr4PPGCgSQcy4DTuQqjlmkyegSG74vHH5eMwMIIaU",
-	"YxirQpFBNOVakIjFxtw0SRD1Fdl+d3r+4+AUqcj15ehiB08wLA1VS6kOFxAROqcI4VPuMKSYK6EzQOad",
-	"QKiFtL4S7miSm2CIcZJJJiTTSyJkBNJIcq0gMiA/E3qB5JBqINuT88urHdM/zyL7ZHB18n6nTc65a9Qi",
-	"EVNZQpc3nKbQmnKrpxvcFJtuKr1Uk2duA7q7EP3V0hg2hgosBDP4lNsJWyZJZVmRIm6bUIRafERmInKK",
-	"ATnHkQ1r2Ds+2Nnk3+2ybzRLNyDqFUtBaZpm5H4BvJayMw7Vdo0cmJpFIaSKXGe59m06DSWmuRbox0Oa",
-	"JEuiQNdFrBSuyPjynBwdBB1ikccmjnBlnwU3MarlOPvBquPsBt0DP+j4wfFVJ+jvBf0g+KUOYKg734j4",
-	"AkhoqGBVI+fmH5oQy4sAvXn5vvRyNgRTJMtlJpQ18hks6B0TKO5lnmVCakVSKj9F4p47gfUGTjCyZqFW",
-	"A5Z6+pPQUAqlCE2SwmxUYRWZFFEeWgrK75gUHLt4rXo6sRvsH21SRM2Sn3X02Ggtr1t62WVW7P4CxWVo",
-	"0QqQjWqQMTXy8QjfIhmcQaXVO1jVyDsT7JKVIGZSpFfrcvV6q3nSNSHBkM3IyhfTPNGFYTTF/Z8F6AXU",
-	"xUPBLNvTydIQ1ztokyFTZkDbCMEQjyIXesor0IlyachgAx8jCJlJQq0I3DDTmRAJUJOLYdHLmerKlmw6",
-	"q7gBU87SNNdmQ2msQdozzgQ3eEvGwwKrhTsHuKWWAkNE7hid8t9zkMuKvxHBy0H+TljcoOGtGgyQOXCQ",
-	"VKPGyPX1eGhw4a2JfVQt6++yArgUwe9QznWVbU68v24C/VkcMX7npvA7JjCOImbVNmlg8NMQ5P0Tlj6a",
-	"DpCMMoluzeYljOOzYYizSOcCCeOhSNHCClfYnvKrhuFWtmj2nsUGPMySVTlw5VNMdP+g21M+xh1c8ak4",
-	"YHNLN02EloiT1NY05SciTQV3432CpS3l1JCqX0OwFvIxDJxaRTCILbADgskNi/o2t1mZP75ziNgv/jFQ",
-	"hS8kzJngfTIHMZc0WxhuaR/ia81AVp3wF9kOJTN+zKyER1RGLQI6bO807e+LV8favleJYAxnbvc1Vz5Q",
-	"pf2OIcmAJK4YvwpyanR5My0u82SGFTvUdw506hUotPulqDE9Tj1jDU/AwFd89FfPopn5idNYLmLjsayd",
-	"vLLhKx3BGvlaV9wl0s2myya11NUqVH4VGafGWiw/7ZMBjgCmkFA39sJFG5UulYYUOyGVbXQpm5vDUmUX",
-	"0KwbDBudSoPELhhIKkNrxIbI9onzlP40D4I9INeqKFC6qNauGaPFy9FFMxwsX63r1LHlhsc0efWmeicF",
-	"q7bQhQI5Jbt1G8JkGLct0RZVWVORmPIFm6O/Lcm5YZINqWMmlTbqtyldSfkc+qTjd4IgsBXhThD0yYk7",
-	"VLtW8aVnNk2Cjt/DRpfuPDfe9gI7WB9X6JdLqZrUzbyzMXGS0geWorpxHON03M9NOZUyNthcSsdYzERO",
-	"TpEmT2HN1AQUCLcPEObalFAahH3K61hcVdzXMrBGnziZGdERsiKeIxkNP9E5uDSW5Ss2sGsTB+VFLGuA",
-	"fFh0dJaCZ0Lc70bATal9jJpDjET0KHwjScSchWRGlfFOhPEsNyB/UeZ2IqopiaVI62cX+JxxKJZfRZhM",
-	"WSmdtyuiuVoYV5a41pGrkDfXi884dEMO8obENFFmTvvgy5QTu+A2Htl2s/D25g1BoFppI0UC+Grq0Shl",
-	"fOpN+aNZRp2v9Hp7B89yWSvON8VyCVXaqeOPBnSuV9NhoKIpX5JURAhgFVK+XqDX6+/3viPQe/yjiZlV",
-	"V3rDosdGmqZo4DXyMqWfezIv41pVeZlTZi8LrDl7WxNIQSk0TITVhCmMHEtYMnouj5bz13tdgkOWvIWk",
-	"oBciasSRm/IDHB70TUbncKPFJ9gQEV/hY7MOCVoyuCsSrtiTYE/0sRJUnmjVJuPYXlcwd2YwKnJZDMMb",
-	"JbhoiaTCJEdsJ3umMY40c5miNMU4pxYzuqsQGZXK2mKYsEqmyoJg+Y+7X9JfPv/y87/Y+W/X9/G/3rzx",
-	"vkYdyhpIXWCjRhGvZsAcbV6pVhNkdCAZ9Voe05Cq52pJLo6trJNKSZdr2cVyeet51UdTy4hFUZWjIRrS",
-	"ehFwNPFx/oRRrsnF6PLK1oKEJMY2UZAnk3+sSjIMTz4ULT44uy73zA5qmSO2xd8jvqA8tMiPoZBQNFFk",
-	"ezCa7KwaqLIFlELLvpC4rTbvwua85QIPXO3JxfWwhuVGlMnKJpl1/fAD+ScsyVugOpfW07/Nk2TjAG6X",
-	"7XEtwg2XwDENrJ35VRRsCTSCoF+EtBEZD+00CTww5JAxS9AsXEUoQ3WbSbHRhErNaOKAVbksI9m1Cb0d",
-	"bNLcPFu1WFAeJYzPjcEnLASuDIa5O2aDjIYLIN124LW8XJq8staZ6u/u3t/ft6l53RZyvuv6qt3T8cno",
-	"7HLkd9tBe6HTpFb28Zrbjbvqtbw7kMpa112HJtmCdrCLyIDTjHl9b68dtPcs5V+Yk1Dko/tfvDnor6bh",
-	"wwWEn4y21y3NTV1u2zhC+gr6fVUDqF1r6wbBC6rVLyv7vi9y6Wtn69IFjUyRolxgKr+2GLYil3m1W8eb",
-	"japA2FHVyTMuU9XssLKhVmVdNuVsWLbNLRrjf1u8Nu7YnMpb2+W2lrgxM5yMTn2ll7bCK8HekjLU7rYW",
-	"XL3ZsvHC1q1543Jqb9Dv3q63xWhjiwzOhmSloVncuVtsbW1m/TezZW11bgllcKDCW7LtyNlO8x2q0a6i",
-	"ntA0XczTWga8aLtmT6j8SeXe63dgf/1Oh3gK9M5d4XO1YozfMbQxbU0d2aphxRXf1hiwhDsmcmVw0hIE",
-	"LcgcdHPeF/pCc0HVpPFqN1QrBlC/FbHGqVaV8cGGPy62K91mGe7qXHJD+Kys9l4ZSenSvZvyGDA2rPOH",
-	"nJdg2yoCCzNcL2iTYkIbdTJFMO5qb4jUNkmZ0gerYMU+Q0PQWqT7fUHeuorsaaydL5M7yLJkaS9QgCF4",
-	"bVJWDSrfMVuuHcXbPmmWyuon8rZvAhB8VQYuI6uV7z3Uru2mY72eC3qu01eM0Ar+xwwQo1LqK8Aji444",
-	"cezN1dC0sOhIZss2GdFw4cIem+aecstcLKPcoircQt1t4RRbbYxuS9vbqmPRlkFat2EmU4GTGQ0XzfD/",
-	"Oh7h7xoSbdiZOtatw1mFcqt41lrp2dyO2ruvaL1A383nYXWE1Q35+Cc64FqktMEJN3g64vZjy9u3s28a",
-	"tFzlbu22tunSeb5L41qi6bT3fKfqSvNjy+u9ZGWbrt82iYURuhaIajo3wWvpvD6a2GZTbHli7EYRSjjc",
-	"r9X3Tf0HA34Hz2sFoCWhU+7IMFXIzQ3tJXeMWvfFoluyUhwycL5WEJpyl0O4Z0lSloWqqtCad7Yrn1QJ",
-	"5Se8c1nAXePt4+FasexbVjflF/XS7HaZzeh2d578TuWy+uQkWflkBV+fYCjHuK3XJC/7pgX7jYqvVb75",
-	"W5UVPGCR962fpfzBb1I+2pgXlP5RRMtXho3im5f65zaPa2DV+VNmXcmUu2yuvU5BVG7uG8d5kqDWF0Aj",
-	"97HVqbAzb7765ioZxTC1i1HVAmvXmFzsRzPWdk/boUh37zq7T5dr6rfCNn1H9B+NsvvB8fM9mp8+Ya9u",
-	"9/leq3eiXw/TT1zCuobLm5G9HkfWCn7WXBLQG7LCQ/McQb9xQaDEV1f5gogVOfOQcpdry3kkODjIQ7Ku",
-	"SDfYJ2fCYBVwTQSvWTMxayjvElRTOHhVU660FHxOQsEVUxp4uCQ+QQhJM5PhNCEDjRoX/6rlJUtbmpvy",
-	"YiaL0S7K2Ddr08R8cLHJjVhdfM2NbNrFqslu40PIDaxnf1337tzbrWmee7LNBXGws/OXno/953uUH628",
-	"nolb1ZdW+DXisjExcmGjbGPE9oJkQV5mS8K0Is4tG8N2WW+2mh3vkHewlhzfZCTvQP9JFhL8da7GJSZW",
-	"nc3/fzvDTX7OyDKqww33LK5dNpZy+0VVWXFZunumWSNtS7ZttvZ509sndug16yNjTXIFipj875QbFveP",
-	"y/Mz8gGHJhNzTQf5ZXHDs03OkR2WH+64IJdKKKp0f59ykTKtmy8TiDXJebigfA6RTTnd8jxJbhFywwSo",
-	"LAm/61fkEotktZNh+4PLUV8Cd3eLbD7LzLUUObmnXJtRzWTWFzhGbTRmqwRmE6ZccJcCKVVeBSTOyfhX",
-	"ywzs57AYsN/Wj40Z0Ddj/Rceodti1ePyMof5dkTZmnH1oY1bb83XuSLnNptzISEiLCYKwdkG9VQv8C+L",
-	"zK8qxUG2qyGcdleuj+ysBf4+qdVxNyCQ1fTrgdBLCPaqIv8csv0XImCxn+v4959LXf8gZH4b130loHVw",
-	"8DTWmi5mCGu7tiqFIcluVT/6WHZdD+UblbpG1bKWAnFhaznv48fH/wsAAP//rIYEBNRCAAA=",
+	"H4sIAAAAAAAC/+y9e1MbSbI4+lXqp3MiDOe2ZIEB2zgmbjCAZ9hlMAF4vee38kWl7hKqdata21UCNLN8",
+	"9xuZWa9utR5g7H3NPzbqrq5HVlZWvvO3VlqMJ4USyujW/m+tCS/5WBhR4q9TOSh5OTvJzrkZwYNM6LSU",
+	"EyML1dpvXY0EK4UupmUqmMyEMnIoRcmGRcnMSLCcPmcbB8fn7a3t7c1OK2mJez6e5KK13yrFjSyUbiUt",
+	"Cb1NYIykpfgYXuZu6FbSKsXfprIUWWvflFORtHQ6EmMO8xnz+1OhbmBye6+S1lgq93MrgQ6NKKHr/+8v",
+	"vP1rt/3284b9o/35t26yt/Xgnm/+v//dSlpmNoGhtSmlumk9PCSt8yKX6ZPXP8GvO+yXqTZsIBhntzyX",
+	"mX3OTo56yoy4YWmhhkU51swUzIKK2TWPYWP2e6rNttp7r1g64iVPYXtYXqgbeH5a3Iky5VqwXMB6dcLU",
+	"dDzAP7jK2Gg2GQmlWaHyGbTHyWjDS8PupBkxbr/z74TKqm9YUdoue6qygTd5MeB5m0/NqE1rat7LiYXi",
+	"P3Yry2JSaJ4/eTPt91UcfjV8I7b5TtrezbYG7Z30jWi/5a+H7e3BXrYj3gy3+Kt0AVD8fJ4OlvlVXlBH",
+	"51xrqW6uhDZ6fqknQ4bD4LpgaKENk5qV4q8iNcJu/063iwgEg7D3Byenx0fX5xfHhx/Ojk6uTj6c9dTd",
+	"SCjG1YwZ7EBFSP9Csz48vU6LTPTZkMtcd9gHMxLlndQCv+gpeDwthWa8FCwvbm5ERkOOBKC6uhEwLc1v",
+	"RdbpKQfHv01FOQuAtLC7ntCarw0uOoZhJoZ8mpvW/pDnWniwDYoiF1wh3D5OMm7EL1x/mQfXYTEe87YW",
+	"QBsBOkMp8gwP6xS/svTt1c5mhx2wXGrfiOHxlrqn+EALZdiwLMa4ukGRzWBtaS54KbIO+yTNqJgaxtmY",
+	"6y/Yxo5TDP0XPQWAolEtRDwq/qUlhkORGnkrrmGYVhI9mCoj89ZnaD7Ji0x4UDQBlPq/hnlUwCiNGCM2",
+	"1dDOA5SXJZ/Bb21meDqAqsHvPwHZ40Z8UPlsfXS8tV9ljGs21VOes8HUMFVEuDHmmegp2oC9V5uLscR1",
+	"dg108LHY8UkMRkXx5amk444+X3QPpiNu2sVkwUV458b+B1LPBxhaTwqlBSLAQV4Kns2O76WlL2mhjFAG",
+	"/uSTSS5TDqB5+VcN8PktrBUgZ7jMW/v2aiVSc3LEXsxfJi8Yp3GYoIEAPtpwlcLkuune673uXrf9Wrzd",
+	"a+/tpqIt3nTftMUW33vzajDceftmAPtsuJnq1v5O923SMtIgxC/cZs0NYFd+cHpxfHD0v9fHfz65vLps",
+	"PcSg/u9SDFv7rf96GTinl/RWvzwuy6IkgFVRZNGID0nrR55dENI/EZLvkdS8KMVNgeT2BRvDPa4KZDrE",
+	"eGJmVdC9fvtqJxu+Eu2dwd6r9s7220F70B3utgdvsle7XZFu7e2KCui6AXQningYd04jhtFD7+TsTwen",
+	"J0fXBxc/ffzl+OzqGeC3ZNiHpPW+KAcyy4R6IgT/t5iyrECIjfitYHo6HMpUCmXYRJRjqTUwqkDxJ6IE",
+	"osbMSGpWTESJnVfBO9hOX2U7Yrc93OOv22/edrfagzQT7eHW9qud3b3X8KQC3lcBvOd+OJYJJUUWoHp+",
+	"fPHLyeXlyYez66Pjs5Pjo2cAK1AuOHFCGYCTyNhUi5JlhdABGgEESyDwkLROlBGl4vmlKG9FSWM+bT8O",
+	"FJsqcT8hbkRAT6xI02lZAnMykjmyY6nACz++NqobsZW9ftPtvu623wz56/brvWzYHr7tvm0Ptwev3+6k",
+	"fLf7No02YreK57QYpnE1NIkYxa+OL84OTp8FtZtGekhaZ4V5X0xV9nUEtpGw+g1GMlSF2tvB7t6wu8vb",
+	"e9mb3fbuziBrZ6/563bWHe6+3ubi1ZvXvIK+Ow2EFfoe4uQ9yM4+XF2///Dx7Og5yWkYB5g4BYssSvmr",
+	"eCrQkFOJjwRgfVoKvNR5Tpyqu4bhOPAU0JBOg+MBqvDkW0QQ2mJ3uNeG09/mgzRri4geVOC5FeB5UJ2I",
+	"GzgA9ePZwcern4/Prk4OD66ehSTUhpTaj4rc1x0nxJmUxa3MRMaKEtpIos8RsycL9TUkwBH8C3FTMD1T",
+	"ht8zqSq3HPLHVVhvizdvt7Zeb7XfDvmb9pvXw267y7d4ezt9+7a7mw72um+zGNbb2wHWYd71w94g/DwD",
+	"oOfGe/B9Ik/1Izfp6LAU3Ag8ylLoiE+onwd8wcZCa34jPOc5CH2wdKpNMWZjYUZFBhwoSKCiNJJ4OktB",
+	"dTN7O7EzAJRPsb9WEgSCZesnMkRzcPN/SIBhPaHPt4DsjqVyP+vixEPM9/4lzPOzb1gMQG4lXsqkoyOR",
+	"i6+FGfWxCmZETq9l1iRkH3nRLQZehh1XBTdShLUFaIFSVPm0PkfQnRO3ngy7aL4LoRfgRgx/E+DozVJs",
+	"AzEef5NkXYWkXgBK+/c8+hHKIbGx0q8HauIUD0WZidKB3GPJo7C09bAOAKVYAj5a7tchXxPIvubAEsge",
+	"Bwqawzc/sP6KqK4AHzMnc7JhkefFHfB6F+8P2es33dfsvCwGuRizI7wwNOIbitdvX3V6qqfO6X7STJty",
+	"mppp6RlJicpWIrwA9oPzE+YUUaQ/qMLZXUn1Of48HXPVLgXP+CAXDDQrXFG3eiJSOZQpAJ/4ZGJeVSo8",
+	"TaD5d3rqclRM88xdqIyn0AV2WZ9pJm5FDlPTNd1Pgwi46t6el/KTVib5jSq0kalu1IJNZO4nXiVuM6aF",
+	"SVgpzLRUyKMLRVc3zAbZzZ6C4VPqhea/FjpCL0d+Yk3apnD/1+f8Ucm/TRtUMlKHLapIFyoVHfZRi+E0",
+	"h6Y9ZUqefgHEA/zKxGB6cyPVTR38awrUtJugYStluxRDgQM27YRjUOZw7urqnNFLhGw8CxTT/RBSmVfb",
+	"oWupjLgRKFZYfmcFOuvpeAy2myq6ojK4svR19AF1ReHcNl2cMA8Ot1szJ9rFQ3fYFWye1Pgm5apQMuV5",
+	"T9EuAkjs3qjpGIjPnCoiieSQpK7nSVoXx5cfPl4cHl8f//nng4+XwFInjfxf0jr48cMFvf/w8er6w/vr",
+	"i4Ozn45bSevj2ckv56fHMBy+9rIivDr408HJ6cGPp9Dw6Pjg6PTkDAY7PD4+wsZ1hj5pkPs/VzZgfoXr",
+	"4lmNUNu9tbjnEKWJav8seE6K0CqpvBHK6QPmNvkn/87hlDZFKbKIgJC6HF4JdSOVpZVIyVBo6CmpHQXJ",
+	"UJfeYce3opw5hfAcSWI8uwXSgPjSU2F+ZBsT+LHtnZXC9q2JfknDhjzPNRuIkVR1ffvOdvW07e00nrZJ",
+	"o8b40GEug/eBafHyY9jfEcEatopnpEAn5e/6RAN7YH5f633PVmKG/zTa4Dm8SFr3bS4mbb+K/d+c5llD",
+	"J3Yhn5PWJJ+WPI/XBnqcXJhCucXBg2nOy7iRHY52tz3mit+IspOl444sXtpWMHNrtZ4HxAHTIw4ohzfT",
+	"uMimuSCc89xSyhWT40lRGiA1wjUakwW3pzKR5tjFVGWCWLZ+Lgd9NuHpF2DkAK28+jUTQ0Dj/phL1Ue2",
+	"5KMGzC1KNijMyLK1bOP8w+XVJn7qLErnB1eHP2922AdlGyXMX+54DNz2YBP6KkEbrzMbTUqBJifLHTv6",
+	"jIYnXoqeGosS7G0byFK9eru32cT80ODXRo4b6PaVHAtt+HhC5yU2+QO74Zh2MnvsdGtmj+3u9l67u9Xu",
+	"vr3a6u6/6u53u/83Jl6wpDYOvAbuVyZWnyfxiCJj0WN35uyEq9zUVCETRtLuSKRfmPVVYPyGA7uBn+rp",
+	"BBBFZGjzayWxEWa7u/OmaZpST3I+uybbzoorGBotm+YFzomNRD6h6zYef3e3YXiZrcMjVYe0Vy7ZM4GV",
+	"nZrJ1LQB1d4xLUxPScMKh6Zkz8FjIbM+QyNc4AnqfFPwAFm5wfZ8NUlSN4U/fdWpJ7B/A2EPNDDCs3DS",
+	"ue6pPr1hGTe805t2u69S2xP+EP0li8fjCtPGiwg8OUTgeOsLzeWg86jFNl0aXukJr925puPCetZhRgr9",
+	"8jfvO/PQa3V6avkStDAAF0RoVEA3zN12/IgVeGK1YMPgVW23LK2tjO32NYIfUNFw8vZ/YL/1WlPdFlyb",
+	"9lavlbBeS0zbd4J+PkBzDtKjyDaoh00mh/Y8AxB9Xz1VPUJ7u7uv9lZ4XCTOWP4kAplzbbw+Yw0qubu/",
+	"s/sVVPLhsTf2PEpdy+yhcoP7Jq3KnR3I1dJL2zWLbu1T2awuaVA5AdUFIcHPYV5HEqY3zwzkefgyIe0R",
+	"kYiTo3Vl01M3/xUqkDCNJm7a6p4aFm3vcNBusA/nB2zjw0QoRu3ZwY1QZtMxu24PSfnhiJxlQJxZxFoR",
+	"prkAhwrUp/jDCLQAuJ+BYDotJnAeTMEyOUTxwbAclA+abfx0+uHHg1NWlOzj5fHFJrJJyFGwMeiuRObu",
+	"yJ5ymh87Vs4HImda5CI1RaktB87zKeK/VGxSyqKUZkab8bX8UnzXJj1ldZ8A/cTSbkuiqjadDauGzWKq",
+	"KFOBnffUE1gtthanxdFTZx4LPo2EGYnYoRCoeIDbsCjdiJqVIhXyVmRMFXf7TJLvkUBtUuRZhZoOw4Bq",
+	"QF9wZUvFpNHMewyxO6my4m7l7QGHYmqqwJq7QogaLaBO3s8meTSzacER8ZpL7uuegvnyqSlAo5byPJ81",
+	"3n2Ic5qdXH5gb/a6W+6CJb5GjsWvhUKTOLP0un5dfj+29gP+wXOWLeFvvTveZFpOCm0V82LEb2UBy72k",
+	"6w88qcovWXGn7IJNg5rrmE6GrttHY19VxtOy0JrxPHcnR3vXwLLIpshZM6FuZVko+OQbMc51J1yvOJpN",
+	"3O6PYLkSDrUWJZPKiHLIcX3AF5FadiACVG/n+Lqf0LbOajbTc+cLW2fIV/ASNQe+xRtO7rt2h+mkAozv",
+	"RjIdLSIUPbUhVZpPtbwFWnYAe0B0ITRGj4BGytJTnrQMxLAoBSlmAI+Df7N906/5HfbxxPYUknBeCjh2",
+	"jadma7u93b3qwpFZdmqWwA3HWwI4obLHg03cPwfYuIE7kw+NKCPYzYPhNRKP3aeAgSZW8WkkurLWjUJf",
+	"d9iR1NECpfXOVYXpqbDIbFqiVrzCWGQilegp1Uj/5+m9UKacTQqpTGXOLVCXtOZcPqZ5XcHnpAN45MYm",
+	"wwd36sEj6hQk+Z6yehj2yV8gtgPvDmP5JYMqSDc5ZJgS0iDGw3CtxXjgVJHMOQizfibUrM82tMAzallW",
+	"vZn0VH8CPFI/Yf20UNqUXCqj4ac1y5R9oD091bek89o9v47bWw4OmCzB63rJlptiTZp5VfUDPWj/X97+",
+	"9fqz/aPbfnv9+X/+u/VkpUEjuV3ENvSUHI+nBok1nQi8v2WhOqh1OzlyrGhhT24+cxYbkbFbyXuqplrw",
+	"KghZqHcg4cXGriS64pnVYQJes48fT47wzn+PFkYdhV9YWRumUqhbWOc8YjdHQDyvL+5KHgHZ6mvHViMr",
+	"mWWSwHZeYTGXsxetP4pZGw44nAtZAtdOLk7I1xPNsgfPacGkSosx0AFH75BfbOZTce/lEBkDnLL2HQeW",
+	"GR2F7uF2OIEdrIkM0GF1SxcxxDBINKeeAh/+Qtn+vogZxdREXMh+xJ0kcMGCnS9xJldoAR8Ao3Ats338",
+	"I0J/eGeP7L77A9kQeEG6hn12I4qbkk9GqPenh/DaSFGGj+AX20hLiTwqzkRlvMwSJkza2azi32+taAUU",
+	"U2KXgIhzQ/vqtSNo0xFla7/l+m89NMijJKRn64oitrljpO2LTJa4ZzO28ZM0HyYa1DsCJJ9fbPvK9WL5",
+	"98SpQxJrcM2FFS5KkRYqlbmEqCck+H4AK7laf+dxkRGVMKOymN5YxD04P/lqTZiND1gtxzxFfeeg8fI3",
+	"Fy31VOXdEmKGIy8hZ34SjXQtIl2+4TPRsEg4nwfcZVpM6ld/5EZYv2sWXi09PG6kv9hnB9AD+cfE1MLJ",
+	"LwjSmTZiDB+BqqPyiW+O1CZ4EwBdqGhgALkrSo6RFCUvU6ICqOjYZ1aMaJPWGRwQyooVm+YM1uHL44uq",
+	"+de/moep1aZUeCv0ca6C99y2Y0T7YUEWyHbeKE2iRoaCDV18IXqH99RI3gBRcMMhXlZXPZSlNgh+cq8t",
+	"wVi7z7baW91ul2Ibt7rdfXZoqdJLArynENiku9XehUaXliBW3u52qbN9mGHbTyU0idF8q9FRYszv5RjA",
+	"Df3grW1/Nll1l6i34f4DXR3ZJQiQ0NKiKfyJ99W9SFFxUtNmoHHPgy7IVnPesAhPGAx7tNKq0/dVzJGe",
+	"Z0W2scPsXeg0vXgTHrkPLaYw1Jm/BE4WXp8A5OCSAerhmAuI2JMpG3CN1zuTajLFW/LC+3KAYSUEvTm0",
+	"QuO+m37QQFZCvoJFKVLz+XCDecrl1js1o1+h68o62A8Mibc3BrDfeorRhDtwZDvVIIgffsCItFqbssgF",
+	"vOq1eDaWqtfqqYenGQ1KINBTc/1FzBa7BhKvcjcqtLBHk/ggXXFwBLrIme0wYXqajnqKA28Lc2ZFyYxQ",
+	"XNnuEqYLsnhXaJ432mk+toNRqKGQeOGj4sA6e6vCWAkKI4vgkUdh26GXp3oq5WVJ95MdH/68GxV5aCyB",
+	"kOiRyBYJNLCQebZ6IVAnokyFMt5YaCnf1jzluxzBGouhBwT6ktrvlzKYCcqTMCKjoE0l7lxbBH7KFdpO",
+	"L/y1Urr9A51CJowogcRop4oczBAK3unJhYiHvSEcoGuwp/oRDvU9LC2QizJ8VgW226qewqZOe4Heew41",
+	"SjER3HgHP8QJ9/2NMP4hKDf0NDcdttXtsg2SwhHamx5aOl4NXC3O5Qa769QJ8wq6HJHlbqNrm4s4XqIC",
+	"QnoDDZ1vR11yPTEudvl/nKSN/hLlVAWoAoXHTsqpUqKs+AUQxCMnJE/LeioQM+JwLfPW34fO+jWX39gD",
+	"BTYKYBcrYezHRE877BL2pmDcnUw6Ggy5lXFxS2oNY0WixdTzmmKzQUYPxnFPVxEwSP+urVkVhDokp9im",
+	"g08JUEg74TygiVaUvdY+/FmhtfCMon97cIIF/PYE9uFhMYn9aktsZD2IDbGPNCHYr6pcOCsoLp5kkTSw",
+	"n89nWvhKW3DSuuOlkuqmwTJ6KpVh7rU/Hv6epksGY7LVFwUqdM9ggGDNwG2Wdp7MY2A3y2fBN3gwa7jZ",
+	"/a74YXoK7TrgfSOyd2E+VhlpvQe/ypF4AYyCDfdx5vK6CDdnLHcNqrZyL18tNZXbViEpyY9TleXiBM/o",
+	"BdLhR0Rq0NGmLtYKcmk0oXshpyE8YzCLBvqeURhN8UZNnoDocegwEZmoOHzFXVBz0JDZkrslzaVQph00",
+	"lSdHteslgXPj3aMi7eWQ9dMQ6zXrN/hJxQFC7dvt1pwy8XFR/U7qXndTGmOJlmzB8a1QjaAPzrrcQwXt",
+	"5w40cOP06X3f7fX+HWrOgX9QptFTEXtdoEL4oyTDDzXa76n/YYcXx+BgzdrsqtGUDG0+nh/ZNu8ruThs",
+	"68Jqw7hifWs+6dsh8POj49PjxiGsXgvaHJ+BM3hDG9sh9nNyuaBRZm01FW2BXRmoC2j+6GqOU2klLTsg",
+	"PLPdtj4vJIXLrQC4w+zkKCEeQ0/HgnGGGxUbunDLHqEFahrq5MjB3sKXebK5sttS3JJBpFFW70d8Q7+u",
+	"ZbKLcKN22BkxyxQc4vYXF2iZqic6ZsUnS2atpILOMWQWn7cneE65w4W8iZfxreLw1TaDLr0G2l4QFWt/",
+	"00FU4t5cTzhMvvgimqAOj62Z1JRS3DqhB75kE+vHSbKF7rCTYZA0Uaa07jYooJXCMgRsXJTCf0TKBakZ",
+	"TgHZuAn/25RYDqsrsfriCS+15UqQesOI5DeDWlbSu1QF5P5Q5sba6lgfVWPXg1nf0S97d9jkXp7vkead",
+	"pRgoyblQ8jjlUj2Wo84Aitkftk/+WtyfHv7hryd/nbw+GedfTv5ayPSnt5p/Ots9vTqRwz93O+l2rgbj",
+	"993sz3/IFxL+xisdd7wY1r3KrK2mlm2BpaU0opT8a+/3pGUKw/NrLX9t4t3hnVU6+rnJ+pxoT2L3E8Ck",
+	"uqiztb1OkNTj+Q2XXazpvqNMXyJruPgAp/Gn91hGxTbqv1LAVkQYQHmpUjnhObwvi1uhmTQd5vrmue1b",
+	"O41NuFkBscDw7CiIxVr63UddQTQ8tGZ9+9O17in3pN904EEBeL1c+lpBZ5EkWb9YL565ld2Bp/aYZyJB",
+	"mkF3Gi6C6C+JyQEQ3hJkQUWw7Knm8UZcW9hlTyfgjlxn1yTyzEOgmX8I04gMOdTVfjUFmJ2+NZhyYx0z",
+	"g27VOfXdilK7eFEClfVcqGYTi/RV0SzgI+IeOuwYaS48sWCupxsLOvClwerLhawnOP1FeNEcYvLEHSQA",
+	"rk/C7AfXeQg3WtNXeKlX36fRrJb/ztGPylVwgGoWMW2nQpmS5+2tIGnwGV3jRvBxVXdCto2viwtxG5B4",
+	"T4qa9uMpCRJXbs/joRyI2DJ20raiSB/i8S6cK3AjuYEPeU/Z795FQAinp+5/sjjuZYmHzhMEupCOqTkn",
+	"QPUiisQg1mZW8G0kT0EWYm20gs78BQKt/O1Aum90dyZaFaQgBjamXCweYU6A8fKLF1+qlk//sgoG0NJA",
+	"R+1bXio+FqiacdfzoUtd4h58nGTVBzTJ1uenmvFtN6AE8gk+nyUMJ/T8XEfqceL/muKZ3dm1j9M7H5X7",
+	"hBNUSZny3IfJnpaG7BTnnhvDqxh4kwplrMyU5zIV64qp4u46LcbjRsXJIb0IwcLQXJSP6Pppd2xgo0ov",
+	"rjz5lvWzXgXUplEXA3lQDNaNkBYrEd4OcImNn2JYiKG3OMbraQC89Vmj1l1HyDP1FJ32PD2bV2u7NjW9",
+	"dtio5Zpt1+5hTpp6ikrDTaZBke3n2YB89lUCnLHQhnxWHifXnvuFrNJf+4ksFigvBOoVHp1AqKTvniFz",
+	"FVlq3U1NRIeip+yllwmNdL3uJrQyuVXS5De7POPV4mxDK5zHzu19NJsI60tR8Tu04IKzWQtsa63jZdWc",
+	"X6uutFtrnx9pwXnMPi833kRba3dWligfNuzrd7biXAltLgRakNaHDJrlV4GFa73Mw1VZF4EhxVUVNisn",
+	"WIcvv8jJRGT4XrussWkxJfuFTyTV4Dg67yhq1ZUNFqWpSYuxCMfQ+hqEEIjIg+Fx+0IwRWxbuUMEpTDP",
+	"VTvVjMPRYrizuwUvDFjU/AZl04WCjBwLZjiodU3hcDT4NiB10gLc1KxfE1hndJ9y+t67K3i7er67nW63",
+	"u7O13Zi0yCLYYlG9GVUqA9i33LBcKsH29r+Jt0TT7Jsj5t5PwXnhb1Oek6EyzjXht6WyguAGgg4inXlH",
+	"kKbRScxpxAlYLZg9J6VUZmOzz1JMtoPc9CAAds4VbJ/RetVjEme12fnB5eXx0X51hZFXlymsz1/bFjuo",
+	"N73D451rgWZuRa6WGbQ/vrj4cLFfoZc1SFrsgMaXfzw5P3e9l9a1h7O+KbLiOnY7qojENHufiwrseDBo",
+	"K2nZ/qqysW+1/L5C3IgyPvlTt/igV/MCLjasO49Nb1inDxcb1r+xNOpzIK6QIRe5FY0bS0O8X1gKIhj6",
+	"ow5Q720fWDt/p6eaa0awuGSE17ZEJSMWl3/w1R7WrdiwKE2oZ2KaEcKysRco063PolrRzgbhQ28rs4M+",
+	"QSgO+3xaFF80uymKbA1l6MOShV468bHmRmo8pjvOHlVr58dnRydnP7E2+8QlCidk84QpwvuD8/OLD39C",
+	"s/6BlXbfxapfK3znkrwALo7/cHxI7gQXViCeaw68iP8kJh80FchaZwfFXHfU4Wr7/1Kd2rlQGTVyT9xq",
+	"WjGO0IxRsdYgmTaxd9Mc99VJvBBuyiY0WJCxY8dLZwNxnvTo1WqPYVqUlE4UP7d9CtK/eedYsAt7869P",
+	"bTAv2NsYU3SZlYaVYkiO2c4IR1VJtFSpWGizeEyq0eMot2g1IeM3SOMZjN0WsSxCPV/CzoUM1WVj0smE",
+	"ydj1o2rhszObT0jpHQlbi7QoywPcwmAOr/JZdcx5fn5dn8pFrizPAt8Gm1tM3GnpTfS81nGzJRs2hDLu",
+	"20IMlDMRDhVqYZSpubBrYZroeaOP9vkBC4k80TRdqHrkFwwhqAWFrmTp+BrrU6ibawjw3o9b1yO7KQTc",
+	"fWaz1l87B9Z9+965xXPFCDzWTB47uqLlGN29ywJWPJsI1631i/Xdgo++71oLA/we9OBuehhqEmV08MNs",
+	"WIzZrK+zsWe/VjjEfac07c/34uaHEse1TxBaARygEOYdilx5acLV2hHzvZcCDRjZtfUq368FUqxKoZjY",
+	"hAxUUAG7YkNvKbIBXzCQM/BVxrEPHVhXDVbvKyCQ64gQR9cwJ5PaSj3Xg6nMjfsqRDORNMMZvm5LxYY2",
+	"q2Cc4NTDEjsLntX9kTGTjhYq688bPgpUMF3XihREluwin45VE+mH53PEFZOJ4O1q2FY81t5a6YQfZ2Yl",
+	"FVwEpUIZuMDn6D1XswUm0wbTsBKNFFesv9bdtda68OI6aki84y/oSHx1UmNABhSro/R2nSXC9GNEnnUA",
+	"XXNn9CxBXqQuWtW7Bq1lgJubMWoR5iZ7FikboIWbXC3jcyMeLAGPFrciBLxWpX8roke9s0lJfpuVCCUQ",
+	"tgYCJoJVB+G4fzq4ODs5+2kfYfFF5DM2lhoUUDVyaPuDDzFIPWK/naxuu6rK6u7lGiz3pV3hsT367vcn",
+	"IsGtz/OJ55RoeYoQgShpWdbIoXQTQ2DL3jVK+dOBf2DhIHIJzkcheJiAiwFJmLdcqAzzqXxtYrdpmScQ",
+	"BgXONzBnm69JpKUwlVxuq3LjQpzKV2Rse6znkisD+N1z4z416aydcGVmh7ZYIaC8j21aK/lstGELXMm1",
+	"QyKrjgtTIHn6w+nJ4f9eB8f9gwVu+7Zh8N6vNiSuO/GJnIoy8qP3Xwfn/YMFrvuHH345Pzk9viZlm3XO",
+	"n09qblW/prCssnCJ+CBmjiZDJyjlmHFFUrGGssjhswFPv9Q1PVVAtBL3ILj7V9fQ+rymaGEPPG7GFezf",
+	"KtPboxzH7F5+y4TCUSnNb5Jk1y5Bv/zNl+V8Fuce3+9jFkAUr0GKxueEU1re2DvfHi0pdH0DpKpMFnQf",
+	"GGrqXdc7PdVwwOMY97356+uulEaE+T/BjyKml985VW7SmpZNvtwDXeRTIxiw50A34H/NPl6c4oTtlcdL",
+	"wSaFJqNCZYbYfP8l7nLHPu6kxfglbX4UH7gqI+KjHTnmMHfOjcO1qHpxhDtgqROHaxZVyz0ihGvKw68o",
+	"u1qQd6klJJQgPaojFfNZUo0R40mTyfTMxwf47lxj1Me1Gjj8BVgQcfyPveOJBQSM/dtUTC2L4yb0ZGSk",
+	"q3M53x+uzrXjrcKN/JSL4VG0PwLBGgnNtLHS7YLCULZTaMi8ZRX3ep3+MUDJtl+ys8FV/t647ikEwSn2",
+	"A0l98s4+IeQtEotWdu8qaC1IihCXE4ph6j6LhnrC2Vlki3WEwY5cs5WcFSbC5Zkw7+Z2Aa1jBtin/txe",
+	"9qG3y4+HVFDHMmVO8rALy1y8FWfb9/d2GvCd5+QgObinHs5222hP8UMF2+zTzCkOKsGc4p5cTtNUCKrZ",
+	"5Z69xzmhNWXNazXe32q5nK8kTw+LJUc32yf48VVZlhW3Qmi4ENukeKJvX/02W2U8jeayRKYOlHR+y2YT",
+	"UVmvJ+ge+x7L/yetqqzS+jy3i35mT9grxznMb4x705x+3719Yvb9T47nWLEjfhJL9uOcz/KCN1DhH0El",
+	"UNkNd1zAs90E5HSPWUh5RElyQKnwjvXdHd531cvgbXClrGC6snwEJBkKGfg9mB8b/X41ErUjHyUjKEUo",
+	"jlKPtu+pDYtkiYuFT1xUe+JD1yFEzIaSb9ZUilW06zT77Cy46nHWVmLGJnT51rpsPYpdWsifuCO2oK/V",
+	"XILrYM1UzV/FeT2Baajsyzow/GZB8zX1CkicTViyDhxr59xvewW+lS2cpwAPWEByWLgy1TwF6jdfFfv4",
+	"vA27k0uuDLs4vryiuqFFSflPgQwurdAhQxr8o8NfXItfrBTl49WpU0rfCW3h97EacUU6duYd1VEI3qwH",
+	"52uCsRMC20UphTJUGUDeqMTG08BsDy8+HkUJ9YixrUV947z+67/YH8WMvRfcTEuyvoH3YGMHFgUQJMIl",
+	"zbUlBrDBXIYUUo+ATqQd4oBOjmiYXNxLUEpSbLcrwzkBcOOg0Oicl0by3CoHtNUYs5ekw0WrZHXzyMI8",
+	"4irLnZ4+l6mwpY5JSdo6mPB0JNh2p9uyugAvvN/d3XU4vu4U5c1L+61+eXpyeHx2edze7nQ7IzPOo1qb",
+	"rep2w662kpYN1W3tt263eD4Z8S0bw6f4RELoZKfbeUVZXkZI5F2RO6y0aBYW+qOKZRi5O4dpdmi/bScZ",
+	"lmY0P4cqg3TR44Db3e4a5dvXq4P+syvQN3e2Lm3qY6mZK0gIjWwF0tq68NXLSl2fRlgAG0NpABYV+QmH",
+	"js4Ok+slwOiwU9cjJYYbijvES57f8ZkOCR8KFfydJ/zGJvuvgh4GOI1qKH0z8MfllRr2wOV9CIB9SFo7",
+	"3a1F3fp5vvyoXBEAkdFHr1Z/9L4oBzLLBHrN7Ha7q784UUaUiueXSCuOQ8F8jyW4hLgeleE3KFkF+GJU",
+	"Z9HE31JgqLaZHKmX2T7jlVKRxdAW23NpAVUw5Oo1akNuiM5Nh/Ubipr1N1Fda6sUVQtRso1KmbrKV3O4",
+	"6KujerW57xGqQEPGXqYK5zECy/D5eufSC1KG7XwWMs3yPBdw4c3m8/LPGHfEnWu4a5CMQ6p+r7yfy9mP",
+	"uV0W5um/k3nuo6vjXP1XVTcPv2RT3JD7Fsn2eV4xxkjaMBe5jRCheiVczQymEZHafoE5cgPjqZMoo24x",
+	"NVqCex/tLfoFVcBZiqgONsxkp9tFIGOETT3FS9JTToyyAVfWPC0V60eefP0m6kFYe+qLrUXFl/f/8qQs",
+	"Zb7oY9PO9NSyrWHvI74FhZuQfzxkDMLktD67qV4cGS9hzogwLRfEQJmRAr17TKGFx0X/PnxOXN16EACf",
+	"mwoTBQ68qzWQ1Ij/1rcZtk748ZU3SGtQNmk9hPgQIudrEOcfeeYiAr7ftbHTfbv6i4O8FDybHYOrmn7G",
+	"y+bQZrCMr4sFV06FX4mLc1rNlTCNDkW5oPso+N9UamtoYSrEDyhNMTXxIbZ51+EoczULXtEGTm8mJkJl",
+	"GvN221zHUULmalqqmID1VEM19KSitvN+ROQm6lUcMCCKBLFAtJLGESQW0rimjQxNHOafZOdg7aVjXTlk",
+	"O02so/P8y0X9SLANVTB7Jjf/2c/HzuovzgrzHnbpGY8GbRjjK45F0sy3k07tVujAOzt0HsywRuE6nPsW",
+	"+0nMM+4N2PWTMN8Mtbrfk37bVHqNFPzfGt1go1fjGmajadAHWqUBV+TNHMrJzmzR0j9cfjhjv2BGm3Po",
+	"I/IJQ/UpeU66PIpobYIbp11xN+NmlDCZJcHFObIrE1caKdSQhZQ3yuY3hgAx5yZUsL5Pu9V/HPNLyasD",
+	"8+suhxX0fo5h7Sk5ZNKwQSn4Fx3dLNadfUzlJCtluJtuh55qvh7Yo24H2sDnPMLrMH2oWmojTv0/35AB",
+	"/K4ExDnX/CsxgP8YikM4x/gafF+cfmGJmso1o+OnI31p0HUmQQtKpQNQmUVVWlFJ+969RudakrpdolKP",
+	"8zTCXw6PTz9veG8kkXcycbvJxP2kFBorOFofq73uJsbY9KPMFlAC5QUlxHjBoGLR9h796xwqMUiiH3ud",
+	"dpz3+caLv00Lw19ssr//Pa5W20HHfP1JmtHGiwuUAV9sUj++mA8VR/oBi01Uhq22SNl2t0ufVivjdYS6",
+	"xblPyiKrzfz/bLwwgo9fAM2pfmVnEdNrmggzzrS+8cJ7nm2Fsp1u/hYotfGo2jMUiUxHApRLlDngfiJj",
+	"mr1pt/ZDmdV3NmSd9Xu7X4UX12mfbbiKHNV3APr5bWLcPY2Xa9s2qi7Pg8faUt3DI1P+ngrQUwmXgZKS",
+	"X+KFhW07zIOhlmy4HxUbAgtWMcUSrNZ2bQpfv8SPmyzJ7dtTAcwddu6PItyYUOpAmDYWP8I40Ml8MnxQ",
+	"CdoCdsLcCaFwxJA0hE+1sCmB/demwArslGSkp4rSlmPBWlA8B7MYDOiq3GFg2lhqV3sdoeAKlnyP3MJN",
+	"apqwHxV1zZz1ro4lv1Cpl6ZUv6awSjU2EaVFAqLQqI6kd6iKF2UldfRUeT1X4srTYHe73Q5zA1JpHamB",
+	"tnQ7DdXCmlY55veEeZi1OF5oVG3t6wqNzYPo8PjU3gMRtQ7EGhbGMT+j9Z8Hzou40tC8pwhkrkgXeTiD",
+	"7g5j1JFnJVois35SJRH4O8yov2+rzOjEXVtIphjre5K/Cd9E9H3TZiAWKnMP6ldMf59VszFVqFd/n1kI",
+	"xQQWRrGlKPv7zAbl6oZ7oL/Pxhz9ofzUSS+/7Lrow5og1eWie2KObsJ8Yiv5frgwdIJ8Oy/d2exHd0mn",
+	"0/FXR6UeeD+Jn1Bl7bjTd1U2opiihxxs7gAZbfLpHlN2fjsBqQtFKiAc0N5KHnwQirmkVjTuo58SU8Ud",
+	"lVq1FeK0K+KbFuOB9OrwfnwTwqL+/neLEf+nT6iaixuOmiosdGcFsf4P0Pbg7Aj++3BhPzn7cIVyEM91",
+	"wXiaiomxQtMxHWD9VP7lK/iO1axPDdUAp3Ba/EW/qSTnmhNfQI6JXjyOFENeDd52Ja0zpCNwZIY+4Qne",
+	"jGww67Bjno7ohd3wniJyQpbWF1ynL+DsvIAhXlRqcrMXHojQCjfOugu4fCi0ga4Z/B2DF35Hh65h42N2",
+	"aJ7jCYxQneVJal9WdyV6twDqjnNovhnqPTTaH76RMBiVi1hiCfaiyz+1DPictuMo/MLJc56/XddyXKub",
+	"0GFr2E17aoXhlK1lN11unWsst7lEe+TVYcF+jNbSnlptLl1hA+2pOSMoW9sGWi8wjoTfVgmrJIPxSRTg",
+	"zJQ8NftMGjaeQmEOXyY1NtzGlTaljnM/wMSl0aFLYP5tLT+NkksfY6j6uHAk1QtmRP4B2JgEDN+lPZwd",
+	"dkwgtImRwr1bK4HmwQuZ0BBR3Gvvj+cEEfqearxUPPVG3FU6RH1igos0vlxlOVWV+UdpdWxF0CDFOczv",
+	"sPc2AyIF3A7yIv0SZgMrhVgZqF9Jyq/rCaeUFjgu3uVaGKfTsBmCBJaQ66lPyD+45D3XMMIPppyKvuOA",
+	"X23WLVlktXGfZElPhakT+tOsEDmpBij+ORIVtSamBmIDuwyQzKSuofh2t5ugk4Uq6ufDruY856n1ijuh",
+	"UqfO2/ceaLk0EY+bsP7ENe8zogwa06GUAqZ8K9A72IzmJMi4bGeNqQaeuw8CApr+4j0NTpsYIFAokbA+",
+	"nfh9Ym+oZVtm+FNY/gu/W9RCAkWieE6Ut61MciNvhfL4gsfZXYZS29SOXowfyyxD1xurBCiFoPrRXqYm",
+	"BJQ3o0ExLWOK9c6HZZWC8oEokfTUwvaI9Hokh9YbGoBtCjaGUP+yKMY+8S35dZuSK80pi4NLMoXIgZU4",
+	"kZphbHvYUwJZtK1NmvfFfh7noRD717p5+FqnT7pHLiJ/DrbhC1Zub5OycKu99wosFkB0RalZXoBI2IbK",
+	"3aXNLgXMYZlyLVgujCFB7pAYZaIb9QY6cVXH6XyOZpORIPHuWFngUUssE4VNazxbY037f6yXSbLSQmFB",
+	"fU5E8kqQF8PKzxzdxLCdee7+QIe4YH/2IrrClpOVUE4opisrCUZRLqcXnZ469Km9ajJjOEMNKRvqqiwa",
+	"ZO399geysu3Rzm4Ilf19gxb3d+x8c99t7OIN3/yOfkW+RuN3tSrFo87HCESXMF6/78J9CWSoeo33oxrc",
+	"7v6MsjoAq/+cDlGLZ05vmt2hktZI8AzJ7m+t0yJdkLrw48VJnQXzUdkxhs3HhfOJrISF3255C9LLRnT2",
+	"ysVpKRuw7eHf0IFrZ3t79Vchs6SV9r6N45ffhwZ5MTb/uYrAazt9BeYISxdaXgCOkhyPRSZd9eSQCnGq",
+	"skIJez3DMdNsu7vDzgq8VzFFqoqwmZybwPKGDFgYwrICGvzEMLFdWigttREqnbG2C4r1dcV5Zqkx4XeY",
+	"Xm4DgawNBBl/4CesFn8H52YY2mZx1uehqh8qcV3CDJfID52Ri3IWrdlZV1CS8h13rUUHxLAGX7XFPmaL",
+	"+KsVd+253dpHeJid2zX97mD2VQ5my47f+u5lFsG+kXfZN0Kq7ve7Cv/DPcuWI9kj/MosnpE1Y1KJWmMb",
+	"FKy2GvV2GHU9h33gyDXVQjMMf+sppIFzzmsTUTLnv2br8jvZz6n2eemSt2fveqoYS2OqL3MxNGyqQtFM",
+	"1G3Yaf3C9Renp6kmPlc50fM413l1tCB11KtV+rxsziuOrZEzHUVZ/DIkTd+fM6pZW2TNrmZVbOMCcwA6",
+	"hRDOLZ55KbRwag1ZOvNyp2Yyta4EkeNeuMJCMnfSEFm0sNDc+MXGMF4KlQUQ2hnMiim74xgm7kNtr0L8",
+	"DKKUlfGoFl+haKoBJ4Oe2nIJbcw+AFpStOP0l3m99d2sT8bjqcHscZQPn3S0dZNyzKy4RE52P5kcYgV4",
+	"0h+AuyT8LzH9YmwA24gAR+hno42dcnFzzh7UZpERFn5a9sJOncIiM3buvSSfwIe45ZhRWUxvRqBisqG8",
+	"lOJ+bcYEp4RC/leqau2MeipECyzWzFJjUpYtUMuiuvPr9bJ2pEeoZYth9GElrd68wtavlmsmI5WtdYSN",
+	"FLZzetpAzBosGZbSWMVDxSDh5oetfdqcxY6qz8INrFb/0GBAi1vfS8f0HXxn/9mUHOf2Qv/3d5z9h4Za",
+	"eXfbJ0jb+8ZWqWm23V5MrRLUl6PySRqoixcVqttUbCImsZbcBwf4YmEZr8DpkamRuLu9Wt0yugvIqgik",
+	"Jy4P5JijnopKgb1rqCPkWBXrVMG18y7s+MymPeXCx+LVllaPwJV1xSS/OrQa1EqQMVX0FOj5MTM/UlDd",
+	"YDtkWJGjchM2xpPFtLqJmAJY/kUEq6iEXZOeFPe35AoynwqnSrGFTwLK/K4HaCIMF1Pl8dAmYVqXROxj",
+	"SSpbDXoheXCuHZg1m+eVyBQ0DkYWQGR0tl+9IhnuGLeurgHn9vyBW0MamfT6czZnSoPrOSKe50HfiBMI",
+	"vhtJUxj7XSmNEaoTeUs4A6cBNs++dwvJuOHgyVxZUeTD1VNeW1IKO7CPK3WrHHKJ9VOLyDuDVkmiXU8R",
+	"l0RFbjDRNFFJ+JCEEWvSIU2MVJm477ADoDHagPtdT9km3rMQpT1uWKHSRq7rx7DNi13V/6nYo8fRmIb1",
+	"+fP/fdkknEmYA42ykGuK/OS9VZ0cCn0hL9ro30PQV1ki5oiTPQ7rEsEjb4xoJoLOKPEIIri76R21SjG2",
+	"hR0WxK03EC+rFseoCt5TOEvS8JBGJhhISCOjWRzSzgo151fRU8tzcDSHrzd4n1VC6euVcIqSSa82qBFC",
+	"uyYgZz3l6BmrZHuJDRorKFpkr3Bpg74RbamO9CjasrOk5rBd6O98zRL7xtcebJKbFh9sp6N+xMHesdwN",
+	"OHy72zrlJaUfYpFkD2Qc+vB5JqloGjE/PVUrgYnpdvE7y6NE3JLKIq440JGeWoOQWC4nrpIYeKCeeiQT",
+	"xBp4oJ46J8eU6BCLe6ebjD3UKhosDBUnWuJ5mmb+yeqlYi6INTFBPRVoRk9dFawU6NYSSCUuAJhIWKsT",
+	"9zi7kyqDCsToJIf+ahC3poREXTcAi4JCXMh6zd/TV85wO6VsMAKGlMAz2wC/K/IMh5dRZrR+hKz9hRQv",
+	"Up/9W/Jw1fX9y/BwFqv/tXm4f0Fl2FfcDeJ+UpTmx6nKqDRXo5n8+D4osapVVUnPhUohyHc6wH7YRr9j",
+	"eNm5+bW/ua5mayTcx740GWf9zpgrORTa9BOwV4lK/r2J8BlGNyb5FD8gV0LnQ0ilycHS1ncfVRJ0uHA7",
+	"r+TaTJAgQS63l9Wuqr3A0Dbcn8gaZ/0sHbfHwnBMy4cGMaZlJlJesqHMRS0FHxVX8X24D10NWR8qp/R0",
+	"bLMxFxOORcX7Cf0N0+47b5c2ZdoIZqcoe7Mchz1upKm0v3T/UrPH5Z68+VVOqnTFe6INpLI5EerOaElD",
+	"uc8qCjhdR5yw7185JSWBOVpO7dyscVzjrVzMy52M6bw2A47s4c94YtE+bPfM6897is6KZmueD1s2DtYx",
+	"EJoJno5cHy98jkBf+HPGwpH5ImbBMOtOKDcjPHIuIIbmug+L6/f7MH5P/QYhsb2Wz4nT2mf4CB5S6kE8",
+	"9tFzeCMzeNBrqHnYayWhWSUuET+gTBbseMEHkWmb2lNwZ7WNC3xs7QPzF72pxpDSlFtC3cqyUDTUPn5f",
+	"ZFPkZXutB/oY/3tICBShqmoMCyCHriBlAEgNELrXSpYsW9sBH3rqAbeg4uVXRVBChSISpnkpgvbQ+ysU",
+	"Ckz0Gy7bAebx3YydSMCm4Y05DjU2+jTh6HbY9BEp3Ftwaq1dwwFw+0IvtAq9cz4r0ZC66q1ytaDAtj1O",
+	"TjpyVcel46ex1vFy0YaSMTlNhP+eMvoR8ZgPMzFRav2qigOmGl2X1V1apD6OBCaXE8srboJq25a8xcn4",
+	"VaLIQo5IYcLVrFVRsFvTVUakb+4qW4dPf+It9r1tz7QqWieVxW+6TalVgODviTzXuqIJrI++lkuBos7i",
+	"G/lSWPbZ5lNGZxYnHVV4nMhwherJNa/jvq8Coft0IWOMvg2gtUoSCE2NQ3WYuOepyWd47pKQe01LKhxW",
+	"naqjBpGKw1fCH4nc3cC6ZmEqhQ9khruY65RKGtn1B78w5w5YzZ/zRYgJC2OSbTqKvLPhfzz2iKOerYrH",
+	"6pPCrDEbzmKa9c6F71Vio72HY1LxLbJqJ7ioAhmzWfpwA/AqG0utYWtd9p6gssaEPWT7Clps0AS5S2Q2",
+	"EQkRybVT8nXYIUzVgtRvGHXs/diCVumR2V2bqO4F4f83VkET/bNj/YOUIrU5LCK/nrGxlOF35fYCoz2B",
+	"p5qavk4C1yDAd87xulF/cWlKwcdeiPC6X+1qjaDh2Nbr3OgbcW9e4q+2xi/XFopQFe4LU9tS02TdcAWG",
+	"q2rQaKmJl9zBpkQ9JhQAr5yOxdaTHBUavHEhF4Qv2xrKTZ5QGR1sxfogIkXNOOsTCmMJoX5PCQUkDnXx",
+	"4B8OHHNajLG6TG4ZNAedcsa2dpkWaaEo6QvSZqQxaaGUIB15MREqqJ1BfWG9cjms2jZMoFNiBEONIlts",
+	"MBXy1tWt9DW5+6dcmzZOun1y1GcUesc2uGaDsrjTotQsKzZZ4SotYNVIV2SrodbAVajQiuQ5Y1ra9FeK",
+	"Qr9h1TYt6ie0EJiRIL9zVTDr3cRvucxhJ6kalY3W8wsuBWa68r5ZmmqbJLANpdAzlfbtpjkwS8oEQLXp",
+	"KWYbvX1N4e6TCkMOPlWJHe5uJFObug6xFkVyqaZOzwVqelpxEwn/FGt7V0WV1/aMluB3bjCLZu+ibGnD",
+	"QphtZT8fl43oEaMnUZkhbYtqWE9tLcwSzCIEob8xCQGWiU9FJrwltil4uIJ2S1e12tdsjgxVb6mVCjWi",
+	"ej55j6vB/x+RwecTxbdU6P2Sa8SV0VqVmNW1q9aO7DBbpTM0iMr+eQ++nrKGTMfs0rya3Egr7hGgsbr1",
+	"Eaz9JxcqWpS00y9+VSoJzMQAPYdlInUG2mW4EQvOhHu3JoNl+77Er75H6is34soUWB5Q/zk5sCLc8GfH",
+	"P1snC9akdjIwOqnCIDEqiR3HcKLR2deeiepVWlHLacgwOssJS2xSSpXKCc/hfQmeQZhrxmWMsLN++Zv7",
+	"ExzCbUvrcklslHL995TjyLywGc4hfuBlPiczUjxQZOLx67CnkIrovYeqi1hKM3ESeZ+Nitym4QEtjc/z",
+	"FCR7zBDjUoYRHxmK5Mlq7haov1PaKp2NYwCz11PNqewreUPtWqJpUN9UsjHx2T+s2sEGQWGI0YFX1tv9",
+	"08Jo1rcPcUUUDGef9AOg7/jM8ULvAC8c+tjL2/aHHgpBZUuWhrkSKfitKB2XHPCkaplO6smG6PZH5nYo",
+	"b6Y+P+f5xcnZ4cn5wen1z8cHR8cXsFDbR+RKEYaRup59d6e7VcfryMllRViBry7vgSIj90lgiC0HVJR0",
+	"4DNMwuoGoFoEfs0um1lTrrZ4N5YkIrLT+KZSvx/kO9dKahq9Jup71AzJSX73b3ieS4hgG10MdF4W3EYV",
+	"Vq5C5xfydU0JANx+hhQA7GD+HnOObiiQhSiN78TiPSrnQDifjwyO8QD8XuExa52z//QMBGE7H3cIHLOz",
+	"2EpxQA0a+Ta8W2F7hcZjYUPM13cWKMWtFHfN1zCZ6N0dCkMhL0HFzuMk/vYm39jpviI7653UYtNaJsJl",
+	"6hjEXH4R83dhszPBqIAMB2RAtc6XsUpnIChBvzSR+qcpuK2n5k94EoUFx5kifZyPg7I2HHKJWuh32IGy",
+	"PBzzHFdFPRotAS0N1kjRU16ZFfqGBFpjnomn6PzZQejHZVcIYHDIUmF0VjEPFtmelzp9A/bDDnOB6Guz",
+	"aP0zkEF7mrPoZP7OezwjtbX4+VUUlw7DkohjfN9EbyGDi47FXkzXb7f5n4XqHvQUvVxBFZ7HxAhd/E4t",
+	"nsw00Q78boZsNkMCdNY76ndiMCqKL+vUmndNn63U/CfbIYrpQ3HXU19Tad719i0LzdsxVulWPVD/NfWk",
+	"dwGSDm08cNfRktrvmZ4OfANrTSbrlg2gsgor2/yF7qk+GZuAlFK69Ezk8hZxzRTIqPenJWSwIgkX3XAw",
+	"D9iEz/KCZz210bdTPacn/U0KyDn/cHnlLoeGKPZI/rWKMs36f24fHf7Stv21TzJw27cPj2heM3hKWbbo",
+	"ORneIM9UFPcOE+VmWgYdnG196V7sM/MDeehPlbzHujf4UyS3W/aF74Re9BMb1lQZAFMZ0Rru284W/vMv",
+	"B4fty58Ptnf3MJ1/40AdehpnHrYDkUOyTbpW2S/W1yIthel32AXZK0vN9AhTE5EueWrmJkiKZiRQPtYL",
+	"u+YKQ6h8zR/rd2QxwEoEKMqwDU4uTujdRnIIlqpXhWpv39/7XEWbloc3pXS3tbinYyFBJcLTL8VwaLOE",
+	"1tOZ+IGpjxSIXlTR077Mi5saHntN7YrKGGxFYYwGK39TRnO2PKH5Ai2nRepnybfu1v2PKqufjrhpF5N/",
+	"27r6bq++s664MmwVLeyr3+vqf0V65Tt/Ahvu2Jgze/mb/WvtBMu2fa3wDRV5CWQr2PntU++RnpUFplBa",
+	"mEx4IfVYIbZ8cgt5RDph+826+YT/M5IDL0We9bMDO0SJbAOUYxNu9SCnRxEKz6jI/2ZI1P2eJPA/XH2/",
+	"AhEfkUHY4eK3qExfK0hfzZpaCWZyxW0cZ8vKwuBUHRcLL+nduwrdRO4UuqJ0LU5bhE+IvfP8M1B/6mJx",
+	"Ls3nPBzfNnHlo7iT73o0fy/6/ugslE9iSl6Gc7BCi9QkOflBq16I+4yHY9RT9isXtgcdQbYQTBtSilQo",
+	"g9YrYhEKJfQKLdFRmPI///3jlA2L1E5hMTVR9D8AdU+lL0AYA2EVKkMf2Cdt+bTMW/utl3wiX95u8Xwy",
+	"4lu4s/bTebHYohWFg2IQElB7iLKLCm1aAfQ8FBtdtyM9wlqKGLPljboUfBv5nEe5A9btuEk1pyO/OOtU",
+	"7cf4FDSZK4YgXTPeeajhAYtBZJ0OXssOKsH/9PPD/z8AEz1ooaojAQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
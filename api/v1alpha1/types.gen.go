@@ -30,6 +30,82 @@ const (
 	USER   PolicyPolicyType = "USER"
 )
 
+// Defines values for PolicyEventChangeType.
+const (
+	CREATED  PolicyEventChangeType = "CREATED"
+	DELETED  PolicyEventChangeType = "DELETED"
+	DISABLED PolicyEventChangeType = "DISABLED"
+	ENABLED  PolicyEventChangeType = "ENABLED"
+	UPDATED  PolicyEventChangeType = "UPDATED"
+)
+
+// Defines values for PolicyProposalOperation.
+const (
+	ProposalCreate PolicyProposalOperation = "CREATE"
+	ProposalDelete PolicyProposalOperation = "DELETE"
+	ProposalUpdate PolicyProposalOperation = "UPDATE"
+)
+
+// Defines values for PolicyTestResultStatus.
+const (
+	ERROR   PolicyTestResultStatus = "ERROR"
+	FAILED  PolicyTestResultStatus = "FAILED"
+	PASSED  PolicyTestResultStatus = "PASSED"
+	SKIPPED PolicyTestResultStatus = "SKIPPED"
+)
+
+// Defines values for ProposalState.
+const (
+	ProposalApproved ProposalState = "APPROVED"
+	ProposalPending  ProposalState = "PENDING"
+	ProposalRejected ProposalState = "REJECTED"
+)
+
+// Defines values for RegoDiagnosticSeverity.
+const (
+	SeverityError   RegoDiagnosticSeverity = "ERROR"
+	SeverityWarning RegoDiagnosticSeverity = "WARNING"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	DeliveryFailed    WebhookDeliveryStatus = "FAILED"
+	DeliveryPending   WebhookDeliveryStatus = "PENDING"
+	DeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+)
+
+// Defines values for WebhookEventType.
+const (
+	COMPILEFAILED WebhookEventType = "COMPILE_FAILED"
+	POLICYCREATED WebhookEventType = "POLICY_CREATED"
+	POLICYDELETED WebhookEventType = "POLICY_DELETED"
+	POLICYUPDATED WebhookEventType = "POLICY_UPDATED"
+)
+
+// BatchCreatePoliciesRequest Request message for the batchCreate custom method.
+type BatchCreatePoliciesRequest struct {
+	// Requests The policies to create
+	Requests []PolicyCreateRequest `json:"requests"`
+}
+
+// BatchDeletePoliciesRequest Request message for the batchDelete custom method.
+type BatchDeletePoliciesRequest struct {
+	// PolicyIds IDs of the policies to delete
+	PolicyIds []string `json:"policy_ids"`
+}
+
+// BatchPoliciesResponse Response message for the batchCreate and batchUpdate custom methods.
+type BatchPoliciesResponse struct {
+	// Policies The created or updated policies, in the order of the requests
+	Policies []Policy `json:"policies"`
+}
+
+// BatchUpdatePoliciesRequest Request message for the batchUpdate custom method.
+type BatchUpdatePoliciesRequest struct {
+	// Requests The policies to update
+	Requests []PolicyUpdateRequest `json:"requests"`
+}
+
 // Error Error response following RFC 7807 Problem Details and AEP-193.
 //
 // Provides structured error information for API failures.
@@ -38,6 +114,10 @@ type Error struct {
 	// Should provide actionable information for developers.
 	Detail *string `json:"detail,omitempty"`
 
+	// Diagnostics Compile problems of the policy set, returned when Rego code does
+	// not compile.
+	Diagnostics *[]RegoDiagnostic `json:"diagnostics,omitempty"`
+
 	// Instance Unique identifier for this specific error occurrence. Useful for
 	// tracking and debugging.
 	Instance *string `json:"instance,omitempty"`
@@ -59,6 +139,11 @@ type ErrorType string
 
 // Health defines model for Health.
 type Health struct {
+	// Generation Generation of the stored policy set that the engine of this replica
+	// is compiled from. Every change of the policy set advances the
+	// generation, and every replica recompiles when it falls behind.
+	Generation int64 `json:"generation"`
+
 	// Path Canonical path of the resource
 	Path *string `json:"path,omitempty"`
 
@@ -66,6 +151,48 @@ type Health struct {
 	Status string `json:"status"`
 }
 
+// Library A shared Rego module that policies can import. The module must be
+// declared under the `lib` package and must not define `main`.
+//
+// Used for both create (POST) and update (PATCH). On create, rego_code
+// is required. On update, only fields present in the request body are
+// merged (RFC 7396).
+type Library struct {
+	// CreateTime Timestamp when the library was created (AEP-140).
+	CreateTime *time.Time `json:"create_time,omitempty"`
+
+	// Description Detailed description of the library
+	Description *string `json:"description,omitempty"`
+
+	// DisplayName Human-readable name of the library
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// Id Unique identifier of the library. This field is output-only; set
+	// it on create with the `id` query parameter.
+	Id *string `json:"id,omitempty"`
+
+	// Package Rego package of the library, to be imported by policies as
+	// `import data.<package>`. This field is output-only and read from
+	// the Rego code.
+	Package *string `json:"package,omitempty"`
+
+	// Path Resource path in the format "libraries/{libraryId}".
+	// This field is output-only and set by the server.
+	Path *string `json:"path,omitempty"`
+
+	// RegoCode Rego code of the library module
+	RegoCode *string `json:"rego_code,omitempty"`
+
+	// UpdateTime Timestamp when the library was last updated (AEP-140).
+	UpdateTime *time.Time `json:"update_time,omitempty"`
+}
+
+// LibraryList Response message for listing libraries.
+type LibraryList struct {
+	// Libraries All libraries, ordered by ID
+	Libraries []Library `json:"libraries"`
+}
+
 // Policy Represents an OPA (Open Policy Agent) policy resource.
 //
 // Policies define authorization rules using Rego code and can be scoped
@@ -76,6 +203,11 @@ type Health struct {
 // policy_type, and rego_code are required (enforced by the service). On
 // update, only fields present in the request body are merged (RFC 7396).
 type Policy struct {
+	// Active Whether the policy is evaluated for requests received now: it is
+	// enabled and the current time is within its effective window.
+	// This field is output-only and computed by the server.
+	Active *bool `json:"active,omitempty"`
+
 	// CreateTime Timestamp when the policy was created. This field is output-only
 	// and automatically set by the server.
 	//
@@ -90,10 +222,28 @@ type Policy struct {
 	// user interfaces and should be descriptive.
 	DisplayName *string `json:"display_name,omitempty"`
 
-	// Enabled Whether the policy is currently active. Disabled policies are not
+	// EffectiveFrom Optional start of the window in which the policy is evaluated
+	// (inclusive). An enabled policy is not evaluated for requests
+	// received before this time. Must be before `effective_until` when
+	// both are set.
+	EffectiveFrom *time.Time `json:"effective_from,omitempty"`
+
+	// EffectiveUntil Optional end of the window in which the policy is evaluated
+	// (exclusive). An enabled policy is not evaluated for requests
+	// received at or after this time.
+	EffectiveUntil *time.Time `json:"effective_until,omitempty"`
+
+	// Enabled Whether the policy is enabled. Disabled policies are not
 	// evaluated during authorization decisions.
 	Enabled *bool `json:"enabled,omitempty"`
 
+	// Entrypoint Rule of the policy package the decision is read from. Defaults to
+	// `main`. When the package does not define the entrypoint rule, the
+	// decision is assembled from separate `deny` (set of messages),
+	// `patch`, `constraints`, `provider` and
+	// `service_provider_constraints` rules instead.
+	Entrypoint *string `json:"entrypoint,omitempty"`
+
 	// Id Unique identifier for the policy. This field is output-only and
 	// immutable after creation. The ID can be optionally specified via
 	// query parameter on creation; if not provided, the server generates a UUID.
@@ -113,6 +263,12 @@ type Policy struct {
 	// - tier: service tier (critical, standard, etc.)
 	LabelSelector *map[string]string `json:"label_selector,omitempty"`
 
+	// Managed Whether the policy is managed by the policy directory (GitOps mode).
+	// Managed policies are created, updated, and deleted by reconciling
+	// the directory and cannot be modified through this API.
+	// This field is output-only and set by the server.
+	Managed *bool `json:"managed,omitempty"`
+
 	// Path Resource path in the format "policies/{policyId}".
 	// This field is output-only and set by the server.
 	//
@@ -147,11 +303,36 @@ type Policy struct {
 	// The Rego code is validated on create and update operations.
 	RegoCode *string `json:"rego_code,omitempty"`
 
+	// RolloutKey Request label whose value selects the requests of a rollout, such
+	// as a user or tenant label, so that all requests with the same value
+	// are either included or not. When empty or when the request does not
+	// carry the label, the whole request is hashed instead.
+	RolloutKey *string `json:"rollout_key,omitempty"`
+
+	// RolloutPercentage Share of requests, in percent, the policy is evaluated for, to
+	// roll out a new policy as a canary. Requests are selected
+	// deterministically by hashing the policy ID with the value of the
+	// `rollout_key` request label, or with the whole request when the
+	// label is not set, so that repeats of the same request get the same
+	// result. 100 (the default) evaluates the policy for every request.
+	RolloutPercentage *int32 `json:"rollout_percentage,omitempty"`
+
+	// TestCode Optional Rego test module for the policy. Its `test_*` rules are
+	// run with the OPA test runner against the whole policy set on create
+	// and update, and by the `:test` custom method. The module is never
+	// evaluated by the engine. Set to an empty string to remove the tests.
+	TestCode *string `json:"test_code,omitempty"`
+
 	// UpdateTime Timestamp when the policy was last updated. This field is output-only
 	// and automatically updated by the server on any modification.
 	//
 	// Uses ISO 8601 format with timezone per AEP-140.
 	UpdateTime *time.Time `json:"update_time,omitempty"`
+
+	// Warnings Lint warnings for the Rego code, such as unknown decision keys or
+	// input fields. Only returned by create and update when the Rego code
+	// was checked; warnings are not stored.
+	Warnings *[]RegoDiagnostic `json:"warnings,omitempty"`
 }
 
 // PolicyPolicyType Scope of the policy application. This field is immutable after creation.
@@ -162,6 +343,58 @@ type Policy struct {
 // Policies are evaluated in hierarchical order: Global -> User
 type PolicyPolicyType string
 
+// PolicyBundleImportResult Response message for the importBundle custom method.
+type PolicyBundleImportResult struct {
+	// Policies Policies created or updated by the import
+	Policies []Policy `json:"policies"`
+}
+
+// PolicyCreateRequest A single create of a batchCreate request.
+type PolicyCreateRequest struct {
+	// Id Optional client-specified ID for the policy, as the `id` parameter of `createPolicy`
+	Id *string `json:"id,omitempty"`
+
+	// Policy Represents an OPA (Open Policy Agent) policy resource.
+	//
+	// Policies define authorization rules using Rego code and can be scoped
+	// to different levels (GLOBAL or USER). They are matched against
+	// requests using label selectors and evaluated in priority order.
+	//
+	// Used for both create (POST) and update (PATCH). On create, display_name,
+	// policy_type, and rego_code are required (enforced by the service). On
+	// update, only fields present in the request body are merged (RFC 7396).
+	Policy Policy `json:"policy"`
+}
+
+// PolicyEvent A change of a policy, sent as the `data` of a `policies:watch` event.
+type PolicyEvent struct {
+	// ChangeType Kind of change:
+	// * CREATED - The policy was created
+	// * UPDATED - Fields of the policy other than `enabled` changed
+	// * DELETED - The policy was deleted
+	// * ENABLED - The policy was enabled
+	// * DISABLED - The policy was disabled
+	ChangeType *PolicyEventChangeType `json:"change_type,omitempty"`
+
+	// Id Event ID, to resume a watch after this event
+	Id *string `json:"id,omitempty"`
+
+	// PolicyId ID of the changed policy
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// Revision The `update_time` of the policy after the change. Not set for
+	// DELETED events.
+	Revision *time.Time `json:"revision,omitempty"`
+}
+
+// PolicyEventChangeType Kind of change:
+// * CREATED - The policy was created
+// * UPDATED - Fields of the policy other than `enabled` changed
+// * DELETED - The policy was deleted
+// * ENABLED - The policy was enabled
+// * DISABLED - The policy was disabled
+type PolicyEventChangeType string
+
 // PolicyList Response message for listing policies.
 //
 // Implements AEP-132 List standard method requirements.
@@ -169,16 +402,436 @@ type PolicyList struct {
 	// NextPageToken Token for retrieving the next page of results. If empty or not
 	// present, there are no more results.
 	//
-	// This token is opaque and should not be parsed by clients.
+	// This token is opaque and should not be parsed by clients. It is
+	// only valid with the same `filter` and `order_by` as the request
+	// that returned it; other values are rejected with INVALID_ARGUMENT.
 	NextPageToken *string `json:"next_page_token,omitempty"`
 
 	// Policies List of policy resources matching the request criteria
 	Policies []Policy `json:"policies"`
+
+	// TotalSize Total number of policies matching the filter across all pages.
+	TotalSize *int32 `json:"total_size,omitempty"`
+}
+
+// PolicyProposal A proposed change of a policy or of a library, applied once another
+// principal approves it. A proposal changes either a policy, with
+// `policy_id` and `policy`, or a library, with `library_id` and
+// `library`.
+type PolicyProposal struct {
+	// BaseUpdateTime The `update_time` of the policy or library when the proposal was
+	// made, for UPDATE and DELETE. The proposal cannot be approved once
+	// the policy or library has changed.
+	BaseUpdateTime *time.Time `json:"base_update_time,omitempty"`
+
+	// ChangedFields Fields of the policy or library set by the change: the fields of
+	// the patch that differ from the current version for UPDATE, the
+	// fields of the new policy or library for CREATE. Empty for DELETE.
+	ChangedFields *[]string `json:"changed_fields,omitempty"`
+
+	// CreateTime Timestamp when the proposal was created (AEP-140).
+	CreateTime *time.Time `json:"create_time,omitempty"`
+
+	// Current Represents an OPA (Open Policy Agent) policy resource.
+	//
+	// Policies define authorization rules using Rego code and can be scoped
+	// to different levels (GLOBAL or USER). They are matched against
+	// requests using label selectors and evaluated in priority order.
+	//
+	// Used for both create (POST) and update (PATCH). On create, display_name,
+	// policy_type, and rego_code are required (enforced by the service). On
+	// update, only fields present in the request body are merged (RFC 7396).
+	Current *Policy `json:"current,omitempty"`
+
+	// CurrentLibrary A shared Rego module that policies can import. The module must be
+	// declared under the `lib` package and must not define `main`.
+	//
+	// Used for both create (POST) and update (PATCH). On create, rego_code
+	// is required. On update, only fields present in the request body are
+	// merged (RFC 7396).
+	CurrentLibrary *Library `json:"current_library,omitempty"`
+
+	// Description Why the change is proposed
+	Description *string `json:"description,omitempty"`
+
+	// Id Unique identifier of the proposal, generated by the server
+	Id *string `json:"id,omitempty"`
+
+	// Library A shared Rego module that policies can import. The module must be
+	// declared under the `lib` package and must not define `main`.
+	//
+	// Used for both create (POST) and update (PATCH). On create, rego_code
+	// is required. On update, only fields present in the request body are
+	// merged (RFC 7396).
+	Library *Library `json:"library,omitempty"`
+
+	// LibraryId ID of the library to change. Required for UPDATE and DELETE of a
+	// library; generated for CREATE if not provided.
+	LibraryId *string `json:"library_id,omitempty"`
+
+	// Operation The proposed change:
+	// * CREATE - Create the policy or library
+	// * UPDATE - Apply `policy` or `library` as a merge patch
+	// * DELETE - Delete the policy or library
+	Operation *PolicyProposalOperation `json:"operation,omitempty"`
+
+	// Path Resource path in the format "proposals/{proposalId}".
+	// This field is output-only and set by the server.
+	Path *string `json:"path,omitempty"`
+
+	// Policy Represents an OPA (Open Policy Agent) policy resource.
+	//
+	// Policies define authorization rules using Rego code and can be scoped
+	// to different levels (GLOBAL or USER). They are matched against
+	// requests using label selectors and evaluated in priority order.
+	//
+	// Used for both create (POST) and update (PATCH). On create, display_name,
+	// policy_type, and rego_code are required (enforced by the service). On
+	// update, only fields present in the request body are merged (RFC 7396).
+	Policy *Policy `json:"policy,omitempty"`
+
+	// PolicyId ID of the policy to change. Required for UPDATE and DELETE;
+	// generated for CREATE if not provided.
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// Proposer Principal that made the proposal
+	Proposer *string `json:"proposer,omitempty"`
+
+	// ReviewComment Comment of the reviewer
+	ReviewComment *string `json:"review_comment,omitempty"`
+
+	// ReviewTime Timestamp when the proposal was approved or rejected
+	ReviewTime *time.Time `json:"review_time,omitempty"`
+
+	// Reviewer Principal that approved or rejected the proposal
+	Reviewer *string `json:"reviewer,omitempty"`
+
+	// State State of a proposal:
+	// * PENDING - Waiting for review
+	// * APPROVED - Approved; the change was applied
+	// * REJECTED - Rejected; the change was not applied
+	State *ProposalState `json:"state,omitempty"`
+
+	// UpdateTime Timestamp when the proposal was last updated (AEP-140).
+	UpdateTime *time.Time `json:"update_time,omitempty"`
+
+	// Validation Result of validating a pending proposal against the current policy
+	// set, as the corresponding validate-only request would. It is
+	// computed when the proposal is read, so it reflects changes made
+	// since the proposal was created.
+	Validation *ProposalValidation `json:"validation,omitempty"`
+}
+
+// PolicyProposalOperation The proposed change:
+// * CREATE - Create the policy or library
+// * UPDATE - Apply `policy` or `library` as a merge patch
+// * DELETE - Delete the policy or library
+type PolicyProposalOperation string
+
+// PolicyProposalList Response message for listing proposals.
+type PolicyProposalList struct {
+	// Proposals Proposals, newest first
+	Proposals []PolicyProposal `json:"proposals"`
+}
+
+// PolicyReorderRequest Request message for the reorder custom method.
+type PolicyReorderRequest struct {
+	// PolicyIds IDs of every policy of the type, in the desired evaluation order
+	PolicyIds []string `json:"policy_ids"`
+
+	// PolicyType Policy type whose policies are reordered (GLOBAL or USER)
+	PolicyType string `json:"policy_type"`
+}
+
+// PolicyReorderResult Response message for the reorder custom method.
+type PolicyReorderResult struct {
+	// Policies Policies of the type in their new evaluation order
+	Policies []Policy `json:"policies"`
+}
+
+// PolicyTestReport Response message for the test custom method.
+type PolicyTestReport struct {
+	// Passed Whether no test failed or errored. Skipped tests do not count as failures.
+	Passed bool `json:"passed"`
+
+	// Results Outcome of every test rule of the test module
+	Results []PolicyTestResult `json:"results"`
+}
+
+// PolicyTestResult Outcome of a single Rego test rule.
+type PolicyTestResult struct {
+	// Duration Time taken to evaluate the test, in seconds with an `s` suffix (AEP-142)
+	Duration string `json:"duration"`
+
+	// Message Why the test failed or errored
+	Message *string `json:"message,omitempty"`
+
+	// Name Fully qualified name of the test rule
+	Name string `json:"name"`
+
+	// Output Output of `print()` calls made by the test
+	Output *string `json:"output,omitempty"`
+
+	// Status - PASSED: the test rule evaluated to true
+	// - FAILED: the test rule was false or undefined
+	// - ERROR: evaluation of the test rule failed
+	// - SKIPPED: the rule is a `todo_test_*` rule
+	Status PolicyTestResultStatus `json:"status"`
+}
+
+// PolicyTestResultStatus - PASSED: the test rule evaluated to true
+// - FAILED: the test rule was false or undefined
+// - ERROR: evaluation of the test rule failed
+// - SKIPPED: the rule is a `todo_test_*` rule
+type PolicyTestResultStatus string
+
+// PolicyUpdateRequest A single update of a batchUpdate request.
+type PolicyUpdateRequest struct {
+	// Policy Represents an OPA (Open Policy Agent) policy resource.
+	//
+	// Policies define authorization rules using Rego code and can be scoped
+	// to different levels (GLOBAL or USER). They are matched against
+	// requests using label selectors and evaluated in priority order.
+	//
+	// Used for both create (POST) and update (PATCH). On create, display_name,
+	// policy_type, and rego_code are required (enforced by the service). On
+	// update, only fields present in the request body are merged (RFC 7396).
+	Policy Policy `json:"policy"`
+
+	// PolicyId ID of the policy to update
+	PolicyId string `json:"policy_id"`
+
+	// UpdateMask Fields to update (AEP-134), as the `update_mask` of `updatePolicy`.
+	// A listed field that is absent from `policy` is cleared.
+	UpdateMask *[]string `json:"update_mask,omitempty"`
+}
+
+// ProposalReview Request message for the approve and reject custom methods.
+type ProposalReview struct {
+	// Comment Comment of the reviewer
+	Comment *string `json:"comment,omitempty"`
+}
+
+// ProposalState State of a proposal:
+// * PENDING - Waiting for review
+// * APPROVED - Approved; the change was applied
+// * REJECTED - Rejected; the change was not applied
+type ProposalState string
+
+// ProposalValidation Result of validating a pending proposal against the current policy
+// set, as the corresponding validate-only request would. It is
+// computed when the proposal is read, so it reflects changes made
+// since the proposal was created.
+type ProposalValidation struct {
+	// Detail Explanation of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Diagnostics Compile problems of the policy set with the change applied
+	Diagnostics *[]RegoDiagnostic `json:"diagnostics,omitempty"`
+
+	// Message Summary of the problem, if the change cannot be applied
+	Message *string `json:"message,omitempty"`
+
+	// Valid Whether the change can currently be applied
+	Valid bool `json:"valid"`
+
+	// Warnings Lint warnings of the changed policy
+	Warnings *[]RegoDiagnostic `json:"warnings,omitempty"`
+}
+
+// RegoDiagnostic A problem found while compiling or linting the policy set.
+type RegoDiagnostic struct {
+	// Code OPA error code, or one of the policy linter codes:
+	// - dcm_missing_main: the policy does not define `main`
+	// - dcm_invalid_decision: `main` is not an object, or a decision key has the wrong type
+	// - dcm_unknown_decision_key: `main` sets a key that is not part of the decision (warning)
+	// - dcm_missing_decision_key: `main` does not set `rejected` (warning)
+	// - dcm_unknown_input_reference: the policy reads an input field that does not exist (warning)
+	// - dcm_reserved_package: the policy is declared under the `lib` package, which is reserved for libraries
+	// - dcm_library_package: the library is not declared under the `lib` package
+	// - dcm_library_main: the library defines `main`
+	// - dcm_disallowed_builtin: the Rego code calls a built-in function the engine does not allow, such as `http.send`
+	Code string `json:"code"`
+
+	// Column Column of the problem, starting at 1
+	Column int32 `json:"column"`
+
+	// LibraryId ID of the library whose Rego code contains the problem, if any
+	LibraryId *string `json:"library_id,omitempty"`
+
+	// Line Line of the problem, starting at 1
+	Line int32 `json:"line"`
+
+	// Message Description of the problem
+	Message string `json:"message"`
+
+	// PolicyId ID of the policy whose Rego code contains the problem. Not set for
+	// problems located in a library.
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// Rule Name of the rule containing the problem, if any
+	Rule *string `json:"rule,omitempty"`
+
+	// Severity - ERROR: the problem prevents the policy from being saved
+	// - WARNING: a likely mistake that does not prevent saving
+	Severity RegoDiagnosticSeverity `json:"severity"`
+}
+
+// RegoDiagnosticSeverity - ERROR: the problem prevents the policy from being saved
+// - WARNING: a likely mistake that does not prevent saving
+type RegoDiagnosticSeverity string
+
+// Webhook A subscription that delivers policy events to an HTTP endpoint.
+//
+// Used for both create (POST) and update (PATCH). On create, url,
+// event_types and secret are required. On update, only fields present
+// in the request body are merged (RFC 7396).
+type Webhook struct {
+	// CreateTime Timestamp when the webhook was created (AEP-140).
+	CreateTime *time.Time `json:"create_time,omitempty"`
+
+	// DisplayName Human-readable name of the webhook
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// EventTypes Events delivered to the webhook:
+	// * POLICY_CREATED - A policy was created
+	// * POLICY_UPDATED - A policy was changed, enabled or disabled
+	// * POLICY_DELETED - A policy was deleted
+	// * COMPILE_FAILED - The stored policy set failed to compile and the
+	//   change that caused it was rolled back
+	EventTypes *[]WebhookEventType `json:"event_types,omitempty"`
+
+	// Id Unique identifier of the webhook. This field is output-only; set
+	// it on create with the `id` query parameter.
+	Id *string `json:"id,omitempty"`
+
+	// Path Resource path in the format "webhooks/{webhookId}".
+	// This field is output-only and set by the server.
+	Path *string `json:"path,omitempty"`
+
+	// Secret Secret that signs the deliveries. This field is input-only and is
+	// never returned.
+	Secret *string `json:"secret,omitempty"`
+
+	// UpdateTime Timestamp when the webhook was last updated (AEP-140).
+	UpdateTime *time.Time `json:"update_time,omitempty"`
+
+	// Url Absolute http or https URL the events are posted to
+	Url *string `json:"url,omitempty"`
+}
+
+// WebhookDelivery An entry of the delivery log of a webhook.
+type WebhookDelivery struct {
+	// Attempts Number of delivery attempts made
+	Attempts *int32 `json:"attempts,omitempty"`
+
+	// CreateTime Timestamp when the event was queued for delivery
+	CreateTime *time.Time `json:"create_time,omitempty"`
+
+	// EventId ID of the delivered event
+	EventId *string `json:"event_id,omitempty"`
+
+	// EventType Type of a webhook event
+	EventType *WebhookEventType `json:"event_type,omitempty"`
+
+	// Id Unique identifier of the delivery
+	Id *string `json:"id,omitempty"`
+
+	// LastError Error of the last failed attempt
+	LastError *string `json:"last_error,omitempty"`
+
+	// NextAttemptTime Time of the next attempt, for PENDING deliveries
+	NextAttemptTime *time.Time `json:"next_attempt_time,omitempty"`
+
+	// PolicyId ID of the changed policy, if any
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// ResponseCode HTTP status of the last response, if any
+	ResponseCode *int32 `json:"response_code,omitempty"`
+
+	// Status Delivery status:
+	// * PENDING - Not delivered yet; the next attempt is at
+	//   `next_attempt_time`
+	// * SUCCEEDED - The endpoint responded with a 2xx status
+	// * FAILED - All attempts failed
+	Status *WebhookDeliveryStatus `json:"status,omitempty"`
+
+	// UpdateTime Timestamp of the last change of the delivery
+	UpdateTime *time.Time `json:"update_time,omitempty"`
+}
+
+// WebhookDeliveryStatus Delivery status:
+//   - PENDING - Not delivered yet; the next attempt is at
+//     `next_attempt_time`
+//   - SUCCEEDED - The endpoint responded with a 2xx status
+//   - FAILED - All attempts failed
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryList Response message for listing the deliveries of a webhook.
+type WebhookDeliveryList struct {
+	// Deliveries Deliveries, newest first
+	Deliveries []WebhookDelivery `json:"deliveries"`
 }
 
+// WebhookEventType Type of a webhook event
+type WebhookEventType string
+
+// WebhookList Response message for listing webhooks.
+type WebhookList struct {
+	// Webhooks All webhooks, ordered by ID
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// WebhookPayload Body of a webhook delivery. Retries of a delivery carry the same
+// body; `event_id` is the same in the deliveries of an event to
+// different webhooks.
+type WebhookPayload struct {
+	// ChangeType The change of the policy, as reported by `policies:watch`
+	// (CREATED, UPDATED, DELETED, ENABLED or DISABLED). Not set for
+	// COMPILE_FAILED.
+	ChangeType *string `json:"change_type,omitempty"`
+
+	// Error The compile error, for COMPILE_FAILED.
+	Error *string `json:"error,omitempty"`
+
+	// EventId Unique identifier of the event
+	EventId string `json:"event_id"`
+
+	// EventTime Time of the event
+	EventTime time.Time `json:"event_time"`
+
+	// EventType Type of a webhook event
+	EventType WebhookEventType `json:"event_type"`
+
+	// PolicyId ID of the changed policy. Not set for COMPILE_FAILED.
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// Revision The `update_time` of the policy after the change. Not set for
+	// POLICY_DELETED and COMPILE_FAILED.
+	Revision *time.Time `json:"revision,omitempty"`
+}
+
+// LibraryIdPath defines model for LibraryIdPath.
+type LibraryIdPath = string
+
 // PolicyIdPath defines model for PolicyIdPath.
 type PolicyIdPath = string
 
+// ProposalIdPath defines model for ProposalIdPath.
+type ProposalIdPath = string
+
+// RequirePassingTests defines model for RequirePassingTests.
+type RequirePassingTests = bool
+
+// UpdateMask defines model for UpdateMask.
+type UpdateMask = []string
+
+// ValidateOnly defines model for ValidateOnly.
+type ValidateOnly = bool
+
+// WebhookIdPath defines model for WebhookIdPath.
+type WebhookIdPath = string
+
 // AlreadyExists Error response following RFC 7807 Problem Details and AEP-193.
 //
 // Provides structured error information for API failures.
@@ -214,25 +867,50 @@ type Unauthorized = Error
 // Provides structured error information for API failures.
 type ValidationError = Error
 
+// CreateLibraryParams defines parameters for CreateLibrary.
+type CreateLibraryParams struct {
+	// Id Optional client-specified ID for the library. If not provided, the
+	// server will generate a UUID. Follows the same AEP-122 requirements
+	// as policy IDs.
+	Id *string `form:"id,omitempty" json:"id,omitempty"`
+}
+
 // ListPoliciesParams defines parameters for ListPolicies.
 type ListPoliciesParams struct {
 	// PageToken Token for retrieving the next page of results. Leave empty for
 	// the first page. Use the `next_page_token` from the previous
-	// response to get the next page.
+	// response to get the next page, with the same `filter` and
+	// `order_by`. Pagination is keyset-based, so policies created or
+	// deleted between pages do not cause other policies to be skipped
+	// or repeated. Malformed, modified, or mismatched tokens are
+	// rejected with INVALID_ARGUMENT.
 	PageToken *string `form:"page_token,omitempty" json:"page_token,omitempty"`
 
 	// MaxPageSize Maximum number of policies to return per page. Server may return
 	// fewer results. If unspecified, defaults to 50. Maximum value is 1000.
 	MaxPageSize *int32 `form:"max_page_size,omitempty" json:"max_page_size,omitempty"`
 
-	// Filter Filter expression to apply to the list. Supports filtering by:
+	// Filter CEL filter expression (AEP-160) to apply to the list. The expression
+	// may reference the following fields:
+	// - `id`, `display_name`, `description`: strings, supporting
+	//   `contains()`, `startsWith()` and `endsWith()`
 	// - `policy_type`: GLOBAL or USER
-	// - `enabled`: true or false
+	// - `priority`: integer
+	// - `enabled`, `managed`: booleans
+	// - `label_selector`: map of strings, e.g. `label_selector.env == 'prod'`
+	//   or `'team' in label_selector`
+	// - `create_time`, `update_time`: timestamps, compared with `timestamp('...')`
+	// - `effective_from`, `effective_until`: timestamps; policies without
+	//   the bound never match a comparison on it
+	// - `active`: boolean, whether the policy is enabled and effective now
+	//
+	// Conditions can be combined with `&&`, `||` and `!`. The legacy
+	// syntax using `=`, `AND`, `OR` and `NOT` is also accepted.
 	//
 	// Examples:
-	// - `policy_type='GLOBAL'`
-	// - `enabled=true`
-	// - `policy_type='GLOBAL' AND enabled=true`
+	// - `policy_type == 'GLOBAL' && enabled`
+	// - `priority >= 100 && priority < 200`
+	// - `display_name.contains('quota') || label_selector.team == 'a'`
 	Filter *string `form:"filter,omitempty" json:"filter,omitempty"`
 
 	// OrderBy Comma-separated list of fields to order by. Each field can be
@@ -261,10 +939,122 @@ type CreatePolicyParams struct {
 	// - Contain only lowercase letters, numbers, and hyphens
 	// - End with letter or number
 	Id *string `form:"id,omitempty" json:"id,omitempty"`
+
+	// RequirePassingTests If true, the request is rejected with 400 and type FAILED_PRECONDITION
+	// when any test in the policy's `test_code` fails. Otherwise test
+	// failures are logged and the change is saved.
+	RequirePassingTests *RequirePassingTests `form:"require_passing_tests,omitempty" json:"require_passing_tests,omitempty"`
+
+	// ValidateOnly If true, the request is validated as usual but no change is made
+	// (AEP-163).
+	ValidateOnly *ValidateOnly `form:"validate_only,omitempty" json:"validate_only,omitempty"`
+
+	// Placement Assigns the priority relative to the other policies of the same
+	// `policy_type`: `end`, `before:<policy-id>` or `after:<policy-id>`.
+	// Cannot be combined with `priority` in the request body.
+	Placement *string `form:"placement,omitempty" json:"placement,omitempty"`
+}
+
+// UpdatePolicyParams defines parameters for UpdatePolicy.
+type UpdatePolicyParams struct {
+	// UpdateMask Comma-separated fields to update (AEP-134). A listed field that is
+	// absent from the body is cleared. Without a mask the fields of the body
+	// are updated.
+	UpdateMask *UpdateMask `form:"update_mask,omitempty" json:"update_mask,omitempty"`
+
+	// RequirePassingTests If true, the request is rejected with 400 and type FAILED_PRECONDITION
+	// when any test in the policy's `test_code` fails. Otherwise test
+	// failures are logged and the change is saved.
+	RequirePassingTests *RequirePassingTests `form:"require_passing_tests,omitempty" json:"require_passing_tests,omitempty"`
+
+	// ValidateOnly If true, the request is validated as usual but no change is made
+	// (AEP-163).
+	ValidateOnly *ValidateOnly `form:"validate_only,omitempty" json:"validate_only,omitempty"`
+}
+
+// BatchCreatePoliciesParams defines parameters for BatchCreatePolicies.
+type BatchCreatePoliciesParams struct {
+	// RequirePassingTests If true, the request is rejected with 400 and type FAILED_PRECONDITION
+	// when any test in the policy's `test_code` fails. Otherwise test
+	// failures are logged and the change is saved.
+	RequirePassingTests *RequirePassingTests `form:"require_passing_tests,omitempty" json:"require_passing_tests,omitempty"`
+
+	// ValidateOnly If true, the request is validated as usual but no change is made
+	// (AEP-163).
+	ValidateOnly *ValidateOnly `form:"validate_only,omitempty" json:"validate_only,omitempty"`
+}
+
+// BatchUpdatePoliciesParams defines parameters for BatchUpdatePolicies.
+type BatchUpdatePoliciesParams struct {
+	// RequirePassingTests If true, the request is rejected with 400 and type FAILED_PRECONDITION
+	// when any test in the policy's `test_code` fails. Otherwise test
+	// failures are logged and the change is saved.
+	RequirePassingTests *RequirePassingTests `form:"require_passing_tests,omitempty" json:"require_passing_tests,omitempty"`
+
+	// ValidateOnly If true, the request is validated as usual but no change is made
+	// (AEP-163).
+	ValidateOnly *ValidateOnly `form:"validate_only,omitempty" json:"validate_only,omitempty"`
+}
+
+// WatchPoliciesParams defines parameters for WatchPolicies.
+type WatchPoliciesParams struct {
+	// LastEventId ID of the last event received by the client, for clients that
+	// cannot set the `Last-Event-ID` header. The header takes precedence.
+	LastEventId *string `form:"last_event_id,omitempty" json:"last_event_id,omitempty"`
+
+	// LastEventID ID of the last event received by the client
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// ListProposalsParams defines parameters for ListProposals.
+type ListProposalsParams struct {
+	// State Only list proposals in this state
+	State *ProposalState `form:"state,omitempty" json:"state,omitempty"`
+}
+
+// CreateWebhookParams defines parameters for CreateWebhook.
+type CreateWebhookParams struct {
+	// Id Optional client-specified ID for the webhook. If not provided, the
+	// server will generate a UUID. Follows the same AEP-122 requirements
+	// as policy IDs.
+	Id *string `form:"id,omitempty" json:"id,omitempty"`
 }
 
+// CreateLibraryJSONRequestBody defines body for CreateLibrary for application/json ContentType.
+type CreateLibraryJSONRequestBody = Library
+
+// UpdateLibraryApplicationMergePatchPlusJSONRequestBody defines body for UpdateLibrary for application/merge-patch+json ContentType.
+type UpdateLibraryApplicationMergePatchPlusJSONRequestBody = Library
+
 // CreatePolicyJSONRequestBody defines body for CreatePolicy for application/json ContentType.
 type CreatePolicyJSONRequestBody = Policy
 
 // UpdatePolicyApplicationMergePatchPlusJSONRequestBody defines body for UpdatePolicy for application/merge-patch+json ContentType.
 type UpdatePolicyApplicationMergePatchPlusJSONRequestBody = Policy
+
+// BatchCreatePoliciesJSONRequestBody defines body for BatchCreatePolicies for application/json ContentType.
+type BatchCreatePoliciesJSONRequestBody = BatchCreatePoliciesRequest
+
+// BatchDeletePoliciesJSONRequestBody defines body for BatchDeletePolicies for application/json ContentType.
+type BatchDeletePoliciesJSONRequestBody = BatchDeletePoliciesRequest
+
+// BatchUpdatePoliciesJSONRequestBody defines body for BatchUpdatePolicies for application/json ContentType.
+type BatchUpdatePoliciesJSONRequestBody = BatchUpdatePoliciesRequest
+
+// ReorderPoliciesJSONRequestBody defines body for ReorderPolicies for application/json ContentType.
+type ReorderPoliciesJSONRequestBody = PolicyReorderRequest
+
+// CreateProposalJSONRequestBody defines body for CreateProposal for application/json ContentType.
+type CreateProposalJSONRequestBody = PolicyProposal
+
+// ApproveProposalJSONRequestBody defines body for ApproveProposal for application/json ContentType.
+type ApproveProposalJSONRequestBody = ProposalReview
+
+// RejectProposalJSONRequestBody defines body for RejectProposal for application/json ContentType.
+type RejectProposalJSONRequestBody = ProposalReview
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = Webhook
+
+// UpdateWebhookApplicationMergePatchPlusJSONRequestBody defines body for UpdateWebhook for application/merge-patch+json ContentType.
+type UpdateWebhookApplicationMergePatchPlusJSONRequestBody = Webhook
@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
@@ -11,13 +13,20 @@ import (
 
 	"github.com/dcm-project/policy-manager/internal/apiserver"
 	"github.com/dcm-project/policy-manager/internal/config"
+	"github.com/dcm-project/policy-manager/internal/decisionlog"
 	"github.com/dcm-project/policy-manager/internal/engineserver"
+	"github.com/dcm-project/policy-manager/internal/enginesync"
 	"github.com/dcm-project/policy-manager/internal/handlers/engine"
 	"github.com/dcm-project/policy-manager/internal/handlers/v1alpha1"
 	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/metricsserver"
 	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/policydir"
 	"github.com/dcm-project/policy-manager/internal/service"
 	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/google/uuid"
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/version"
 )
 
 type Server interface {
@@ -42,9 +51,11 @@ func run() int {
 	slog.Info("Configuration loaded",
 		"bind_address", cfg.Service.BindAddress,
 		"engine_bind_address", cfg.Service.EngineBindAddress,
+		"metrics_bind_address", cfg.Service.MetricsBindAddress,
 		"log_level", cfg.Service.LogLevel,
 		"db_type", cfg.Database.Type,
 		"db_host", cfg.Database.Hostname,
+		"policy_dir", cfg.PolicyDir.Path,
 	)
 
 	// Initialize database
@@ -64,18 +75,54 @@ func run() int {
 	}()
 
 	// Initialize embedded OPA engine
-	opaEngine := opa.NewEngine()
+	var engineOpts []opa.EngineOption
+	if cfg.Engine.ProviderCatalog != "" {
+		providers, err := opa.LoadProviderCatalog(cfg.Engine.ProviderCatalog)
+		if err != nil {
+			slog.Error("Failed to load provider catalog", "error", err)
+			return 1
+		}
+		engineOpts = append(engineOpts, opa.WithProviders(providers))
+		slog.Info("Provider catalog loaded", "path", cfg.Engine.ProviderCatalog, "providers", len(providers))
+	}
+	capabilities, err := newCapabilities(cfg.Engine)
+	if err != nil {
+		slog.Error("Failed to set up engine capabilities", "error", err)
+		return 1
+	}
+	engineOpts = append(engineOpts, opa.WithCapabilities(capabilities))
+	if len(cfg.Engine.AllowedBuiltins) > 0 {
+		slog.Info("Restricted built-ins allowed", "builtins", cfg.Engine.AllowedBuiltins)
+	}
+	var decisionLogger *decisionlog.Logger
+	if cfg.DecisionLog.Sink != "" {
+		decisionLogger, err = newDecisionLogger(cfg.DecisionLog)
+		if err != nil {
+			slog.Error("Failed to set up decision logging", "error", err)
+			return 1
+		}
+		engineOpts = append(engineOpts, opa.WithDecisionLogger(decisionLogger))
+		slog.Info("Decision logging enabled", "sink", cfg.DecisionLog.Sink, "masked_paths", cfg.DecisionLog.MaskedPaths)
+	}
+	opaEngine := opa.NewEngine(engineOpts...)
 
 	// Create services
-	policyService := service.NewPolicyService(dataStore, opaEngine)
-	evaluationService := service.NewEvaluationService(dataStore.Policy(), opaEngine)
+	var serviceOpts []service.PolicyServiceOption
+	if cfg.Service.RequireApproval {
+		serviceOpts = append(serviceOpts, service.WithRequireApproval())
+		slog.Info("Policy changes require approval")
+	}
+	policyService := service.NewPolicyService(dataStore, opaEngine, serviceOpts...)
+	evaluationService := service.NewEvaluationService(dataStore.Policy(), opaEngine,
+		service.WithCompiledGeneration(policyService.CompiledGeneration),
+		service.WithEvaluationTimeouts(cfg.Evaluation.PolicyTimeout, cfg.Evaluation.Timeout))
 
 	// Load all policies from DB and compile into engine on startup
 	if err := policyService.CompileAll(context.Background()); err != nil {
 		slog.Error("Failed to compile policies on startup", "error", err)
 		return 1
 	}
-	slog.Info("Embedded OPA engine initialized")
+	slog.Info("Embedded OPA engine initialized", "generation", policyService.CompiledGeneration())
 
 	// Create public API handler
 	policyHandler := v1alpha1.NewPolicyHandler(policyService)
@@ -105,18 +152,103 @@ func run() int {
 	// Create private engine API server
 	engineSrv := engineserver.New(cfg, engineListener, engineHandler)
 
+	// Deliver webhook events queued by the policy service
+	webhookDispatcher := service.NewWebhookDispatcher(dataStore, service.WebhookDeliveryOptions{
+		MaxAttempts:  cfg.Webhook.MaxAttempts,
+		Backoff:      cfg.Webhook.RetryBackoff,
+		Timeout:      cfg.Webhook.Timeout,
+		PollInterval: cfg.Webhook.PollInterval,
+	})
+
+	// Recompile after changes made through other replicas
+	engineSyncer := enginesync.NewSyncer(dataStore.Generation(), policyService, cfg.Sync.PollInterval)
+
+	servers := []Server{publicSrv, engineSrv, webhookDispatcher, engineSyncer}
+
+	// Expose the Prometheus metrics on their own listener, unless disabled
+	if cfg.Service.MetricsBindAddress != "" {
+		metricsListener, err := net.Listen("tcp", cfg.Service.MetricsBindAddress)
+		if err != nil {
+			slog.Error("Failed to create metrics listener", "error", err, "address", cfg.Service.MetricsBindAddress)
+			return 1
+		}
+		defer func() { _ = metricsListener.Close() }()
+		servers = append(servers, metricsserver.New(metricsListener))
+	}
+
+	// Reconcile managed policies from the policy directory (GitOps mode)
+	if cfg.PolicyDir.Path != "" {
+		servers = append(servers, policydir.NewWatcher(cfg.PolicyDir.Path, cfg.PolicyDir.PollInterval, policyService))
+	}
+
+	if decisionLogger != nil {
+		servers = append(servers, decisionLogger)
+	}
+
 	slog.Info("Starting servers")
-	if err := runServers([]Server{publicSrv, engineSrv}); err != nil {
+	// Policy watches stream until the client disconnects; end them so they do not hold up the shutdown
+	if err := runServers(servers, policyService.CloseWatches); err != nil {
 		return 1
 	}
 
 	return 0
 }
 
-func runServers(servers []Server) error {
+// newCapabilities returns the capabilities policies are compiled against: those of the configured
+// capabilities file, or of the embedded OPA version, without the restricted built-ins not allowed
+func newCapabilities(cfg config.EngineConfig) (*ast.Capabilities, error) {
+	var base *ast.Capabilities
+	if cfg.CapabilitiesFile != "" {
+		var err error
+		if base, err = opa.LoadCapabilities(cfg.CapabilitiesFile); err != nil {
+			return nil, err
+		}
+	}
+	return opa.NewCapabilities(base, cfg.AllowedBuiltins)
+}
+
+// newDecisionLogger creates a decision logger writing to the configured sink
+func newDecisionLogger(cfg config.DecisionLogConfig) (*decisionlog.Logger, error) {
+	var sink decisionlog.Sink
+	switch cfg.Sink {
+	case "stdout":
+		sink = decisionlog.NewWriterSink(os.Stdout)
+	case "file":
+		if cfg.FilePath == "" {
+			return nil, fmt.Errorf("DECISION_LOG_FILE is required for the file decision log sink")
+		}
+		fileSink, err := decisionlog.NewFileSink(cfg.FilePath, cfg.FileMaxSize, cfg.FileMaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		sink = fileSink
+	case "http":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("DECISION_LOG_URL is required for the http decision log sink")
+		}
+		sink = decisionlog.NewHTTPSink(cfg.URL, &http.Client{Timeout: cfg.Timeout})
+	default:
+		return nil, fmt.Errorf("unknown decision log sink %q, expected stdout, file or http", cfg.Sink)
+	}
+
+	return decisionlog.NewLogger(sink, decisionlog.Options{
+		Labels:        map[string]string{"id": uuid.NewString(), "version": version.Version},
+		MaskedPaths:   cfg.MaskedPaths,
+		BatchSize:     cfg.BatchSize,
+		BufferSize:    cfg.BufferSize,
+		FlushInterval: cfg.FlushInterval,
+	})
+}
+
+// runServers runs the servers until a signal is received or one of them fails, then calls onShutdown
+// while the servers shut down.
+func runServers(servers []Server, onShutdown ...func()) error {
 	// Setup signal handling for graceful shutdown
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
+	for _, f := range onShutdown {
+		context.AfterFunc(ctx, f)
+	}
 
 	var wg sync.WaitGroup
 	errChan := make(chan error, len(servers))
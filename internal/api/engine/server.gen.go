@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
@@ -19,11 +20,55 @@ const (
 	MODIFIED EvaluateResponseStatus = "MODIFIED"
 )
 
+// Defines values for EvaluateRequestParamsExplain.
+const (
+	Fails EvaluateRequestParamsExplain = "fails"
+	Full  EvaluateRequestParamsExplain = "full"
+	Notes EvaluateRequestParamsExplain = "notes"
+)
+
+// CanaryPolicy defines model for CanaryPolicy.
+type CanaryPolicy struct {
+	// Applied Whether the policy was evaluated for the request
+	Applied bool `json:"applied"`
+
+	// PolicyId ID of the policy
+	PolicyId string `json:"policy_id"`
+
+	// RolloutPercentage Share of requests the policy is evaluated for, in percent
+	RolloutPercentage int32 `json:"rollout_percentage"`
+}
+
+// ConstraintsPreview defines model for ConstraintsPreview.
+type ConstraintsPreview struct {
+	// CanaryPolicies Policies with a rollout percentage below 100 that matched the
+	// request, and whether the request was part of their rollout.
+	// Omitted when no such policy matched.
+	CanaryPolicies *[]CanaryPolicy `json:"canary_policies,omitempty"`
+
+	// Generation Generation of the stored policy set that the engine was compiled
+	// from.
+	Generation int64 `json:"generation"`
+
+	// Rejections Policies that rejected the partial spec, in evaluation order
+	Rejections []PolicyRejection `json:"rejections"`
+
+	// Schema JSON Schema document of the policy input. The `spec` property holds
+	// the field constraints, nested along their field paths, and the
+	// `provider` property the service provider constraints.
+	Schema map[string]interface{} `json:"schema"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Detail Detailed error message
 	Detail *string `json:"detail,omitempty"`
 
+	// Explanations print() output and explain trace of every policy evaluated for the
+	// request, in evaluation order, up to and including the policy that
+	// failed it. Only returned by an evaluation with `explain` set.
+	Explanations *[]PolicyExplanation `json:"explanations,omitempty"`
+
 	// Status HTTP status code
 	Status int32 `json:"status"`
 
@@ -41,8 +86,20 @@ type EvaluateRequest struct {
 
 // EvaluateResponse defines model for EvaluateResponse.
 type EvaluateResponse struct {
+	// CanaryPolicies Policies with a rollout percentage below 100 that matched the
+	// request, and whether the request was part of their rollout.
+	// Omitted when no such policy matched.
+	CanaryPolicies           *[]CanaryPolicy `json:"canary_policies,omitempty"`
 	EvaluatedServiceInstance ServiceInstance `json:"evaluated_service_instance"`
 
+	// Explanations print() output and explain trace of every evaluated policy, in
+	// evaluation order. Only returned when `explain` is set.
+	Explanations *[]PolicyExplanation `json:"explanations,omitempty"`
+
+	// Generation Generation of the stored policy set that the engine evaluating the
+	// request was compiled from.
+	Generation int64 `json:"generation"`
+
 	// SelectedProvider Service provider selected by policies
 	SelectedProvider string `json:"selected_provider"`
 
@@ -55,6 +112,32 @@ type EvaluateResponse struct {
 // MODIFIED - Request was modified by policies
 type EvaluateResponseStatus string
 
+// PolicyExplanation defines model for PolicyExplanation.
+type PolicyExplanation struct {
+	// PolicyId ID of the policy
+	PolicyId string `json:"policy_id"`
+
+	// PrintOutput Lines printed by print() calls of the policy
+	PrintOutput []string `json:"print_output"`
+
+	// Trace Explain trace of the evaluation, one line per trace event
+	Trace []string `json:"trace"`
+}
+
+// PolicyRejection defines model for PolicyRejection.
+type PolicyRejection struct {
+	// PolicyId ID of the policy
+	PolicyId string `json:"policy_id"`
+
+	// Reason Rejection reason given by the policy
+	Reason *string `json:"reason,omitempty"`
+}
+
+// PreviewConstraintsRequest defines model for PreviewConstraintsRequest.
+type PreviewConstraintsRequest struct {
+	ServiceInstance ServiceInstance `json:"service_instance"`
+}
+
 // ServiceInstance defines model for ServiceInstance.
 type ServiceInstance struct {
 	// Spec Service specification (flexible schema)
@@ -64,6 +147,9 @@ type ServiceInstance struct {
 // BadRequest defines model for BadRequest.
 type BadRequest = Error
 
+// EvaluationTimeout defines model for EvaluationTimeout.
+type EvaluationTimeout = Error
+
 // Forbidden defines model for Forbidden.
 type Forbidden = Error
 
@@ -79,14 +165,34 @@ type Rejected = Error
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = Error
 
+// EvaluateRequestParams defines parameters for EvaluateRequest.
+type EvaluateRequestParams struct {
+	// Explain Records an OPA explain trace of every evaluated policy and returns
+	// it with the print() output of the policy in `explanations`, also
+	// in the error response of a rejected or failed evaluation.
+	// notes - only the trace() notes of the policies
+	// fails - the expressions that failed
+	// full - every evaluation step
+	Explain *EvaluateRequestParamsExplain `form:"explain,omitempty" json:"explain,omitempty"`
+}
+
+// EvaluateRequestParamsExplain defines parameters for EvaluateRequest.
+type EvaluateRequestParamsExplain string
+
 // EvaluateRequestJSONRequestBody defines body for EvaluateRequest for application/json ContentType.
 type EvaluateRequestJSONRequestBody = EvaluateRequest
 
+// PreviewConstraintsJSONRequestBody defines body for PreviewConstraints for application/json ContentType.
+type PreviewConstraintsJSONRequestBody = PreviewConstraintsRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Evaluate request payload against policies
 	// (POST /policies:evaluateRequest)
-	EvaluateRequest(w http.ResponseWriter, r *http.Request)
+	EvaluateRequest(w http.ResponseWriter, r *http.Request, params EvaluateRequestParams)
+	// Preview the constraints applying to a request
+	// (POST /policies:previewConstraints)
+	PreviewConstraints(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -95,7 +201,13 @@ type Unimplemented struct{}
 
 // Evaluate request payload against policies
 // (POST /policies:evaluateRequest)
-func (_ Unimplemented) EvaluateRequest(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) EvaluateRequest(w http.ResponseWriter, r *http.Request, params EvaluateRequestParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Preview the constraints applying to a request
+// (POST /policies:previewConstraints)
+func (_ Unimplemented) PreviewConstraints(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -111,8 +223,35 @@ type MiddlewareFunc func(http.Handler) http.Handler
 // EvaluateRequest operation middleware
 func (siw *ServerInterfaceWrapper) EvaluateRequest(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params EvaluateRequestParams
+
+	// ------------- Optional query parameter "explain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "explain", r.URL.Query(), &params.Explain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "explain", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.EvaluateRequest(w, r)
+		siw.Handler.EvaluateRequest(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PreviewConstraints operation middleware
+func (siw *ServerInterfaceWrapper) PreviewConstraints(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PreviewConstraints(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -238,12 +377,17 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/policies:evaluateRequest", wrapper.EvaluateRequest)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/policies:previewConstraints", wrapper.PreviewConstraints)
+	})
 
 	return r
 }
 
 type BadRequestJSONResponse Error
 
+type EvaluationTimeoutJSONResponse Error
+
 type ForbiddenJSONResponse Error
 
 type InternalServerErrorJSONResponse Error
@@ -255,7 +399,8 @@ type RejectedJSONResponse Error
 type UnauthorizedJSONResponse Error
 
 type EvaluateRequestRequestObject struct {
-	Body *EvaluateRequestJSONRequestBody
+	Params EvaluateRequestParams
+	Body   *EvaluateRequestJSONRequestBody
 }
 
 type EvaluateRequestResponseObject interface {
@@ -327,11 +472,96 @@ func (response EvaluateRequest500JSONResponse) VisitEvaluateRequestResponse(w ht
 	return json.NewEncoder(w).Encode(response)
 }
 
+type EvaluateRequest504JSONResponse struct{ EvaluationTimeoutJSONResponse }
+
+func (response EvaluateRequest504JSONResponse) VisitEvaluateRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(504)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PreviewConstraintsRequestObject struct {
+	Body *PreviewConstraintsJSONRequestBody
+}
+
+type PreviewConstraintsResponseObject interface {
+	VisitPreviewConstraintsResponse(w http.ResponseWriter) error
+}
+
+type PreviewConstraints200JSONResponse ConstraintsPreview
+
+func (response PreviewConstraints200JSONResponse) VisitPreviewConstraintsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PreviewConstraints400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PreviewConstraints400JSONResponse) VisitPreviewConstraintsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PreviewConstraints401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PreviewConstraints401JSONResponse) VisitPreviewConstraintsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PreviewConstraints403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PreviewConstraints403JSONResponse) VisitPreviewConstraintsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PreviewConstraints409JSONResponse struct{ PolicyConflictJSONResponse }
+
+func (response PreviewConstraints409JSONResponse) VisitPreviewConstraintsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PreviewConstraints500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response PreviewConstraints500JSONResponse) VisitPreviewConstraintsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PreviewConstraints504JSONResponse struct{ EvaluationTimeoutJSONResponse }
+
+func (response PreviewConstraints504JSONResponse) VisitPreviewConstraintsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(504)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// Evaluate request payload against policies
 	// (POST /policies:evaluateRequest)
 	EvaluateRequest(ctx context.Context, request EvaluateRequestRequestObject) (EvaluateRequestResponseObject, error)
+	// Preview the constraints applying to a request
+	// (POST /policies:previewConstraints)
+	PreviewConstraints(ctx context.Context, request PreviewConstraintsRequestObject) (PreviewConstraintsResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -364,9 +594,11 @@ type strictHandler struct {
 }
 
 // EvaluateRequest operation middleware
-func (sh *strictHandler) EvaluateRequest(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) EvaluateRequest(w http.ResponseWriter, r *http.Request, params EvaluateRequestParams) {
 	var request EvaluateRequestRequestObject
 
+	request.Params = params
+
 	var body EvaluateRequestJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
@@ -393,3 +625,34 @@ func (sh *strictHandler) EvaluateRequest(w http.ResponseWriter, r *http.Request)
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// PreviewConstraints operation middleware
+func (sh *strictHandler) PreviewConstraints(w http.ResponseWriter, r *http.Request) {
+	var request PreviewConstraintsRequestObject
+
+	var body PreviewConstraintsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PreviewConstraints(ctx, request.(PreviewConstraintsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PreviewConstraints")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PreviewConstraintsResponseObject); ok {
+		if err := validResponse.VisitPreviewConstraintsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -38,6 +39,82 @@ const (
 	USER   PolicyPolicyType = "USER"
 )
 
+// Defines values for PolicyEventChangeType.
+const (
+	CREATED  PolicyEventChangeType = "CREATED"
+	DELETED  PolicyEventChangeType = "DELETED"
+	DISABLED PolicyEventChangeType = "DISABLED"
+	ENABLED  PolicyEventChangeType = "ENABLED"
+	UPDATED  PolicyEventChangeType = "UPDATED"
+)
+
+// Defines values for PolicyProposalOperation.
+const (
+	ProposalCreate PolicyProposalOperation = "CREATE"
+	ProposalDelete PolicyProposalOperation = "DELETE"
+	ProposalUpdate PolicyProposalOperation = "UPDATE"
+)
+
+// Defines values for PolicyTestResultStatus.
+const (
+	ERROR   PolicyTestResultStatus = "ERROR"
+	FAILED  PolicyTestResultStatus = "FAILED"
+	PASSED  PolicyTestResultStatus = "PASSED"
+	SKIPPED PolicyTestResultStatus = "SKIPPED"
+)
+
+// Defines values for ProposalState.
+const (
+	ProposalApproved ProposalState = "APPROVED"
+	ProposalPending  ProposalState = "PENDING"
+	ProposalRejected ProposalState = "REJECTED"
+)
+
+// Defines values for RegoDiagnosticSeverity.
+const (
+	SeverityError   RegoDiagnosticSeverity = "ERROR"
+	SeverityWarning RegoDiagnosticSeverity = "WARNING"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	DeliveryFailed    WebhookDeliveryStatus = "FAILED"
+	DeliveryPending   WebhookDeliveryStatus = "PENDING"
+	DeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+)
+
+// Defines values for WebhookEventType.
+const (
+	COMPILEFAILED WebhookEventType = "COMPILE_FAILED"
+	POLICYCREATED WebhookEventType = "POLICY_CREATED"
+	POLICYDELETED WebhookEventType = "POLICY_DELETED"
+	POLICYUPDATED WebhookEventType = "POLICY_UPDATED"
+)
+
+// BatchCreatePoliciesRequest Request message for the batchCreate custom method.
+type BatchCreatePoliciesRequest struct {
+	// Requests The policies to create
+	Requests []PolicyCreateRequest `json:"requests"`
+}
+
+// BatchDeletePoliciesRequest Request message for the batchDelete custom method.
+type BatchDeletePoliciesRequest struct {
+	// PolicyIds IDs of the policies to delete
+	PolicyIds []string `json:"policy_ids"`
+}
+
+// BatchPoliciesResponse Response message for the batchCreate and batchUpdate custom methods.
+type BatchPoliciesResponse struct {
+	// Policies The created or updated policies, in the order of the requests
+	Policies []Policy `json:"policies"`
+}
+
+// BatchUpdatePoliciesRequest Request message for the batchUpdate custom method.
+type BatchUpdatePoliciesRequest struct {
+	// Requests The policies to update
+	Requests []PolicyUpdateRequest `json:"requests"`
+}
+
 // Error Error response following RFC 7807 Problem Details and AEP-193.
 //
 // Provides structured error information for API failures.
@@ -46,6 +123,10 @@ type Error struct {
 	// Should provide actionable information for developers.
 	Detail *string `json:"detail,omitempty"`
 
+	// Diagnostics Compile problems of the policy set, returned when Rego code does
+	// not compile.
+	Diagnostics *[]RegoDiagnostic `json:"diagnostics,omitempty"`
+
 	// Instance Unique identifier for this specific error occurrence. Useful for
 	// tracking and debugging.
 	Instance *string `json:"instance,omitempty"`
@@ -67,6 +148,11 @@ type ErrorType string
 
 // Health defines model for Health.
 type Health struct {
+	// Generation Generation of the stored policy set that the engine of this replica
+	// is compiled from. Every change of the policy set advances the
+	// generation, and every replica recompiles when it falls behind.
+	Generation int64 `json:"generation"`
+
 	// Path Canonical path of the resource
 	Path *string `json:"path,omitempty"`
 
@@ -74,6 +160,48 @@ type Health struct {
 	Status string `json:"status"`
 }
 
+// Library A shared Rego module that policies can import. The module must be
+// declared under the `lib` package and must not define `main`.
+//
+// Used for both create (POST) and update (PATCH). On create, rego_code
+// is required. On update, only fields present in the request body are
+// merged (RFC 7396).
+type Library struct {
+	// CreateTime Timestamp when the library was created (AEP-140).
+	CreateTime *time.Time `json:"create_time,omitempty"`
+
+	// Description Detailed description of the library
+	Description *string `json:"description,omitempty"`
+
+	// DisplayName Human-readable name of the library
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// Id Unique identifier of the library. This field is output-only; set
+	// it on create with the `id` query parameter.
+	Id *string `json:"id,omitempty"`
+
+	// Package Rego package of the library, to be imported by policies as
+	// `import data.<package>`. This field is output-only and read from
+	// the Rego code.
+	Package *string `json:"package,omitempty"`
+
+	// Path Resource path in the format "libraries/{libraryId}".
+	// This field is output-only and set by the server.
+	Path *string `json:"path,omitempty"`
+
+	// RegoCode Rego code of the library module
+	RegoCode *string `json:"rego_code,omitempty"`
+
+	// UpdateTime Timestamp when the library was last updated (AEP-140).
+	UpdateTime *time.Time `json:"update_time,omitempty"`
+}
+
+// LibraryList Response message for listing libraries.
+type LibraryList struct {
+	// Libraries All libraries, ordered by ID
+	Libraries []Library `json:"libraries"`
+}
+
 // Policy Represents an OPA (Open Policy Agent) policy resource.
 //
 // Policies define authorization rules using Rego code and can be scoped
@@ -84,6 +212,11 @@ type Health struct {
 // policy_type, and rego_code are required (enforced by the service). On
 // update, only fields present in the request body are merged (RFC 7396).
 type Policy struct {
+	// Active Whether the policy is evaluated for requests received now: it is
+	// enabled and the current time is within its effective window.
+	// This field is output-only and computed by the server.
+	Active *bool `json:"active,omitempty"`
+
 	// CreateTime Timestamp when the policy was created. This field is output-only
 	// and automatically set by the server.
 	//
@@ -98,10 +231,28 @@ type Policy struct {
 	// user interfaces and should be descriptive.
 	DisplayName *string `json:"display_name,omitempty"`
 
-	// Enabled Whether the policy is currently active. Disabled policies are not
+	// EffectiveFrom Optional start of the window in which the policy is evaluated
+	// (inclusive). An enabled policy is not evaluated for requests
+	// received before this time. Must be before `effective_until` when
+	// both are set.
+	EffectiveFrom *time.Time `json:"effective_from,omitempty"`
+
+	// EffectiveUntil Optional end of the window in which the policy is evaluated
+	// (exclusive). An enabled policy is not evaluated for requests
+	// received at or after this time.
+	EffectiveUntil *time.Time `json:"effective_until,omitempty"`
+
+	// Enabled Whether the policy is enabled. Disabled policies are not
 	// evaluated during authorization decisions.
 	Enabled *bool `json:"enabled,omitempty"`
 
+	// Entrypoint Rule of the policy package the decision is read from. Defaults to
+	// `main`. When the package does not define the entrypoint rule, the
+	// decision is assembled from separate `deny` (set of messages),
+	// `patch`, `constraints`, `provider` and
+	// `service_provider_constraints` rules instead.
+	Entrypoint *string `json:"entrypoint,omitempty"`
+
 	// Id Unique identifier for the policy. This field is output-only and
 	// immutable after creation. The ID can be optionally specified via
 	// query parameter on creation; if not provided, the server generates a UUID.
@@ -121,6 +272,12 @@ type Policy struct {
 	// - tier: service tier (critical, standard, etc.)
 	LabelSelector *map[string]string `json:"label_selector,omitempty"`
 
+	// Managed Whether the policy is managed by the policy directory (GitOps mode).
+	// Managed policies are created, updated, and deleted by reconciling
+	// the directory and cannot be modified through this API.
+	// This field is output-only and set by the server.
+	Managed *bool `json:"managed,omitempty"`
+
 	// Path Resource path in the format "policies/{policyId}".
 	// This field is output-only and set by the server.
 	//
@@ -155,11 +312,36 @@ type Policy struct {
 	// The Rego code is validated on create and update operations.
 	RegoCode *string `json:"rego_code,omitempty"`
 
+	// RolloutKey Request label whose value selects the requests of a rollout, such
+	// as a user or tenant label, so that all requests with the same value
+	// are either included or not. When empty or when the request does not
+	// carry the label, the whole request is hashed instead.
+	RolloutKey *string `json:"rollout_key,omitempty"`
+
+	// RolloutPercentage Share of requests, in percent, the policy is evaluated for, to
+	// roll out a new policy as a canary. Requests are selected
+	// deterministically by hashing the policy ID with the value of the
+	// `rollout_key` request label, or with the whole request when the
+	// label is not set, so that repeats of the same request get the same
+	// result. 100 (the default) evaluates the policy for every request.
+	RolloutPercentage *int32 `json:"rollout_percentage,omitempty"`
+
+	// TestCode Optional Rego test module for the policy. Its `test_*` rules are
+	// run with the OPA test runner against the whole policy set on create
+	// and update, and by the `:test` custom method. The module is never
+	// evaluated by the engine. Set to an empty string to remove the tests.
+	TestCode *string `json:"test_code,omitempty"`
+
 	// UpdateTime Timestamp when the policy was last updated. This field is output-only
 	// and automatically updated by the server on any modification.
 	//
 	// Uses ISO 8601 format with timezone per AEP-140.
 	UpdateTime *time.Time `json:"update_time,omitempty"`
+
+	// Warnings Lint warnings for the Rego code, such as unknown decision keys or
+	// input fields. Only returned by create and update when the Rego code
+	// was checked; warnings are not stored.
+	Warnings *[]RegoDiagnostic `json:"warnings,omitempty"`
 }
 
 // PolicyPolicyType Scope of the policy application. This field is immutable after creation.
@@ -170,6 +352,58 @@ type Policy struct {
 // Policies are evaluated in hierarchical order: Global -> User
 type PolicyPolicyType string
 
+// PolicyBundleImportResult Response message for the importBundle custom method.
+type PolicyBundleImportResult struct {
+	// Policies Policies created or updated by the import
+	Policies []Policy `json:"policies"`
+}
+
+// PolicyCreateRequest A single create of a batchCreate request.
+type PolicyCreateRequest struct {
+	// Id Optional client-specified ID for the policy, as the `id` parameter of `createPolicy`
+	Id *string `json:"id,omitempty"`
+
+	// Policy Represents an OPA (Open Policy Agent) policy resource.
+	//
+	// Policies define authorization rules using Rego code and can be scoped
+	// to different levels (GLOBAL or USER). They are matched against
+	// requests using label selectors and evaluated in priority order.
+	//
+	// Used for both create (POST) and update (PATCH). On create, display_name,
+	// policy_type, and rego_code are required (enforced by the service). On
+	// update, only fields present in the request body are merged (RFC 7396).
+	Policy Policy `json:"policy"`
+}
+
+// PolicyEvent A change of a policy, sent as the `data` of a `policies:watch` event.
+type PolicyEvent struct {
+	// ChangeType Kind of change:
+	// * CREATED - The policy was created
+	// * UPDATED - Fields of the policy other than `enabled` changed
+	// * DELETED - The policy was deleted
+	// * ENABLED - The policy was enabled
+	// * DISABLED - The policy was disabled
+	ChangeType *PolicyEventChangeType `json:"change_type,omitempty"`
+
+	// Id Event ID, to resume a watch after this event
+	Id *string `json:"id,omitempty"`
+
+	// PolicyId ID of the changed policy
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// Revision The `update_time` of the policy after the change. Not set for
+	// DELETED events.
+	Revision *time.Time `json:"revision,omitempty"`
+}
+
+// PolicyEventChangeType Kind of change:
+// * CREATED - The policy was created
+// * UPDATED - Fields of the policy other than `enabled` changed
+// * DELETED - The policy was deleted
+// * ENABLED - The policy was enabled
+// * DISABLED - The policy was disabled
+type PolicyEventChangeType string
+
 // PolicyList Response message for listing policies.
 //
 // Implements AEP-132 List standard method requirements.
@@ -177,16 +411,436 @@ type PolicyList struct {
 	// NextPageToken Token for retrieving the next page of results. If empty or not
 	// present, there are no more results.
 	//
-	// This token is opaque and should not be parsed by clients.
+	// This token is opaque and should not be parsed by clients. It is
+	// only valid with the same `filter` and `order_by` as the request
+	// that returned it; other values are rejected with INVALID_ARGUMENT.
 	NextPageToken *string `json:"next_page_token,omitempty"`
 
 	// Policies List of policy resources matching the request criteria
 	Policies []Policy `json:"policies"`
+
+	// TotalSize Total number of policies matching the filter across all pages.
+	TotalSize *int32 `json:"total_size,omitempty"`
+}
+
+// PolicyProposal A proposed change of a policy or of a library, applied once another
+// principal approves it. A proposal changes either a policy, with
+// `policy_id` and `policy`, or a library, with `library_id` and
+// `library`.
+type PolicyProposal struct {
+	// BaseUpdateTime The `update_time` of the policy or library when the proposal was
+	// made, for UPDATE and DELETE. The proposal cannot be approved once
+	// the policy or library has changed.
+	BaseUpdateTime *time.Time `json:"base_update_time,omitempty"`
+
+	// ChangedFields Fields of the policy or library set by the change: the fields of
+	// the patch that differ from the current version for UPDATE, the
+	// fields of the new policy or library for CREATE. Empty for DELETE.
+	ChangedFields *[]string `json:"changed_fields,omitempty"`
+
+	// CreateTime Timestamp when the proposal was created (AEP-140).
+	CreateTime *time.Time `json:"create_time,omitempty"`
+
+	// Current Represents an OPA (Open Policy Agent) policy resource.
+	//
+	// Policies define authorization rules using Rego code and can be scoped
+	// to different levels (GLOBAL or USER). They are matched against
+	// requests using label selectors and evaluated in priority order.
+	//
+	// Used for both create (POST) and update (PATCH). On create, display_name,
+	// policy_type, and rego_code are required (enforced by the service). On
+	// update, only fields present in the request body are merged (RFC 7396).
+	Current *Policy `json:"current,omitempty"`
+
+	// CurrentLibrary A shared Rego module that policies can import. The module must be
+	// declared under the `lib` package and must not define `main`.
+	//
+	// Used for both create (POST) and update (PATCH). On create, rego_code
+	// is required. On update, only fields present in the request body are
+	// merged (RFC 7396).
+	CurrentLibrary *Library `json:"current_library,omitempty"`
+
+	// Description Why the change is proposed
+	Description *string `json:"description,omitempty"`
+
+	// Id Unique identifier of the proposal, generated by the server
+	Id *string `json:"id,omitempty"`
+
+	// Library A shared Rego module that policies can import. The module must be
+	// declared under the `lib` package and must not define `main`.
+	//
+	// Used for both create (POST) and update (PATCH). On create, rego_code
+	// is required. On update, only fields present in the request body are
+	// merged (RFC 7396).
+	Library *Library `json:"library,omitempty"`
+
+	// LibraryId ID of the library to change. Required for UPDATE and DELETE of a
+	// library; generated for CREATE if not provided.
+	LibraryId *string `json:"library_id,omitempty"`
+
+	// Operation The proposed change:
+	// * CREATE - Create the policy or library
+	// * UPDATE - Apply `policy` or `library` as a merge patch
+	// * DELETE - Delete the policy or library
+	Operation *PolicyProposalOperation `json:"operation,omitempty"`
+
+	// Path Resource path in the format "proposals/{proposalId}".
+	// This field is output-only and set by the server.
+	Path *string `json:"path,omitempty"`
+
+	// Policy Represents an OPA (Open Policy Agent) policy resource.
+	//
+	// Policies define authorization rules using Rego code and can be scoped
+	// to different levels (GLOBAL or USER). They are matched against
+	// requests using label selectors and evaluated in priority order.
+	//
+	// Used for both create (POST) and update (PATCH). On create, display_name,
+	// policy_type, and rego_code are required (enforced by the service). On
+	// update, only fields present in the request body are merged (RFC 7396).
+	Policy *Policy `json:"policy,omitempty"`
+
+	// PolicyId ID of the policy to change. Required for UPDATE and DELETE;
+	// generated for CREATE if not provided.
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// Proposer Principal that made the proposal
+	Proposer *string `json:"proposer,omitempty"`
+
+	// ReviewComment Comment of the reviewer
+	ReviewComment *string `json:"review_comment,omitempty"`
+
+	// ReviewTime Timestamp when the proposal was approved or rejected
+	ReviewTime *time.Time `json:"review_time,omitempty"`
+
+	// Reviewer Principal that approved or rejected the proposal
+	Reviewer *string `json:"reviewer,omitempty"`
+
+	// State State of a proposal:
+	// * PENDING - Waiting for review
+	// * APPROVED - Approved; the change was applied
+	// * REJECTED - Rejected; the change was not applied
+	State *ProposalState `json:"state,omitempty"`
+
+	// UpdateTime Timestamp when the proposal was last updated (AEP-140).
+	UpdateTime *time.Time `json:"update_time,omitempty"`
+
+	// Validation Result of validating a pending proposal against the current policy
+	// set, as the corresponding validate-only request would. It is
+	// computed when the proposal is read, so it reflects changes made
+	// since the proposal was created.
+	Validation *ProposalValidation `json:"validation,omitempty"`
+}
+
+// PolicyProposalOperation The proposed change:
+// * CREATE - Create the policy or library
+// * UPDATE - Apply `policy` or `library` as a merge patch
+// * DELETE - Delete the policy or library
+type PolicyProposalOperation string
+
+// PolicyProposalList Response message for listing proposals.
+type PolicyProposalList struct {
+	// Proposals Proposals, newest first
+	Proposals []PolicyProposal `json:"proposals"`
+}
+
+// PolicyReorderRequest Request message for the reorder custom method.
+type PolicyReorderRequest struct {
+	// PolicyIds IDs of every policy of the type, in the desired evaluation order
+	PolicyIds []string `json:"policy_ids"`
+
+	// PolicyType Policy type whose policies are reordered (GLOBAL or USER)
+	PolicyType string `json:"policy_type"`
+}
+
+// PolicyReorderResult Response message for the reorder custom method.
+type PolicyReorderResult struct {
+	// Policies Policies of the type in their new evaluation order
+	Policies []Policy `json:"policies"`
+}
+
+// PolicyTestReport Response message for the test custom method.
+type PolicyTestReport struct {
+	// Passed Whether no test failed or errored. Skipped tests do not count as failures.
+	Passed bool `json:"passed"`
+
+	// Results Outcome of every test rule of the test module
+	Results []PolicyTestResult `json:"results"`
+}
+
+// PolicyTestResult Outcome of a single Rego test rule.
+type PolicyTestResult struct {
+	// Duration Time taken to evaluate the test, in seconds with an `s` suffix (AEP-142)
+	Duration string `json:"duration"`
+
+	// Message Why the test failed or errored
+	Message *string `json:"message,omitempty"`
+
+	// Name Fully qualified name of the test rule
+	Name string `json:"name"`
+
+	// Output Output of `print()` calls made by the test
+	Output *string `json:"output,omitempty"`
+
+	// Status - PASSED: the test rule evaluated to true
+	// - FAILED: the test rule was false or undefined
+	// - ERROR: evaluation of the test rule failed
+	// - SKIPPED: the rule is a `todo_test_*` rule
+	Status PolicyTestResultStatus `json:"status"`
+}
+
+// PolicyTestResultStatus - PASSED: the test rule evaluated to true
+// - FAILED: the test rule was false or undefined
+// - ERROR: evaluation of the test rule failed
+// - SKIPPED: the rule is a `todo_test_*` rule
+type PolicyTestResultStatus string
+
+// PolicyUpdateRequest A single update of a batchUpdate request.
+type PolicyUpdateRequest struct {
+	// Policy Represents an OPA (Open Policy Agent) policy resource.
+	//
+	// Policies define authorization rules using Rego code and can be scoped
+	// to different levels (GLOBAL or USER). They are matched against
+	// requests using label selectors and evaluated in priority order.
+	//
+	// Used for both create (POST) and update (PATCH). On create, display_name,
+	// policy_type, and rego_code are required (enforced by the service). On
+	// update, only fields present in the request body are merged (RFC 7396).
+	Policy Policy `json:"policy"`
+
+	// PolicyId ID of the policy to update
+	PolicyId string `json:"policy_id"`
+
+	// UpdateMask Fields to update (AEP-134), as the `update_mask` of `updatePolicy`.
+	// A listed field that is absent from `policy` is cleared.
+	UpdateMask *[]string `json:"update_mask,omitempty"`
+}
+
+// ProposalReview Request message for the approve and reject custom methods.
+type ProposalReview struct {
+	// Comment Comment of the reviewer
+	Comment *string `json:"comment,omitempty"`
+}
+
+// ProposalState State of a proposal:
+// * PENDING - Waiting for review
+// * APPROVED - Approved; the change was applied
+// * REJECTED - Rejected; the change was not applied
+type ProposalState string
+
+// ProposalValidation Result of validating a pending proposal against the current policy
+// set, as the corresponding validate-only request would. It is
+// computed when the proposal is read, so it reflects changes made
+// since the proposal was created.
+type ProposalValidation struct {
+	// Detail Explanation of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Diagnostics Compile problems of the policy set with the change applied
+	Diagnostics *[]RegoDiagnostic `json:"diagnostics,omitempty"`
+
+	// Message Summary of the problem, if the change cannot be applied
+	Message *string `json:"message,omitempty"`
+
+	// Valid Whether the change can currently be applied
+	Valid bool `json:"valid"`
+
+	// Warnings Lint warnings of the changed policy
+	Warnings *[]RegoDiagnostic `json:"warnings,omitempty"`
+}
+
+// RegoDiagnostic A problem found while compiling or linting the policy set.
+type RegoDiagnostic struct {
+	// Code OPA error code, or one of the policy linter codes:
+	// - dcm_missing_main: the policy does not define `main`
+	// - dcm_invalid_decision: `main` is not an object, or a decision key has the wrong type
+	// - dcm_unknown_decision_key: `main` sets a key that is not part of the decision (warning)
+	// - dcm_missing_decision_key: `main` does not set `rejected` (warning)
+	// - dcm_unknown_input_reference: the policy reads an input field that does not exist (warning)
+	// - dcm_reserved_package: the policy is declared under the `lib` package, which is reserved for libraries
+	// - dcm_library_package: the library is not declared under the `lib` package
+	// - dcm_library_main: the library defines `main`
+	// - dcm_disallowed_builtin: the Rego code calls a built-in function the engine does not allow, such as `http.send`
+	Code string `json:"code"`
+
+	// Column Column of the problem, starting at 1
+	Column int32 `json:"column"`
+
+	// LibraryId ID of the library whose Rego code contains the problem, if any
+	LibraryId *string `json:"library_id,omitempty"`
+
+	// Line Line of the problem, starting at 1
+	Line int32 `json:"line"`
+
+	// Message Description of the problem
+	Message string `json:"message"`
+
+	// PolicyId ID of the policy whose Rego code contains the problem. Not set for
+	// problems located in a library.
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// Rule Name of the rule containing the problem, if any
+	Rule *string `json:"rule,omitempty"`
+
+	// Severity - ERROR: the problem prevents the policy from being saved
+	// - WARNING: a likely mistake that does not prevent saving
+	Severity RegoDiagnosticSeverity `json:"severity"`
+}
+
+// RegoDiagnosticSeverity - ERROR: the problem prevents the policy from being saved
+// - WARNING: a likely mistake that does not prevent saving
+type RegoDiagnosticSeverity string
+
+// Webhook A subscription that delivers policy events to an HTTP endpoint.
+//
+// Used for both create (POST) and update (PATCH). On create, url,
+// event_types and secret are required. On update, only fields present
+// in the request body are merged (RFC 7396).
+type Webhook struct {
+	// CreateTime Timestamp when the webhook was created (AEP-140).
+	CreateTime *time.Time `json:"create_time,omitempty"`
+
+	// DisplayName Human-readable name of the webhook
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// EventTypes Events delivered to the webhook:
+	// * POLICY_CREATED - A policy was created
+	// * POLICY_UPDATED - A policy was changed, enabled or disabled
+	// * POLICY_DELETED - A policy was deleted
+	// * COMPILE_FAILED - The stored policy set failed to compile and the
+	//   change that caused it was rolled back
+	EventTypes *[]WebhookEventType `json:"event_types,omitempty"`
+
+	// Id Unique identifier of the webhook. This field is output-only; set
+	// it on create with the `id` query parameter.
+	Id *string `json:"id,omitempty"`
+
+	// Path Resource path in the format "webhooks/{webhookId}".
+	// This field is output-only and set by the server.
+	Path *string `json:"path,omitempty"`
+
+	// Secret Secret that signs the deliveries. This field is input-only and is
+	// never returned.
+	Secret *string `json:"secret,omitempty"`
+
+	// UpdateTime Timestamp when the webhook was last updated (AEP-140).
+	UpdateTime *time.Time `json:"update_time,omitempty"`
+
+	// Url Absolute http or https URL the events are posted to
+	Url *string `json:"url,omitempty"`
+}
+
+// WebhookDelivery An entry of the delivery log of a webhook.
+type WebhookDelivery struct {
+	// Attempts Number of delivery attempts made
+	Attempts *int32 `json:"attempts,omitempty"`
+
+	// CreateTime Timestamp when the event was queued for delivery
+	CreateTime *time.Time `json:"create_time,omitempty"`
+
+	// EventId ID of the delivered event
+	EventId *string `json:"event_id,omitempty"`
+
+	// EventType Type of a webhook event
+	EventType *WebhookEventType `json:"event_type,omitempty"`
+
+	// Id Unique identifier of the delivery
+	Id *string `json:"id,omitempty"`
+
+	// LastError Error of the last failed attempt
+	LastError *string `json:"last_error,omitempty"`
+
+	// NextAttemptTime Time of the next attempt, for PENDING deliveries
+	NextAttemptTime *time.Time `json:"next_attempt_time,omitempty"`
+
+	// PolicyId ID of the changed policy, if any
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// ResponseCode HTTP status of the last response, if any
+	ResponseCode *int32 `json:"response_code,omitempty"`
+
+	// Status Delivery status:
+	// * PENDING - Not delivered yet; the next attempt is at
+	//   `next_attempt_time`
+	// * SUCCEEDED - The endpoint responded with a 2xx status
+	// * FAILED - All attempts failed
+	Status *WebhookDeliveryStatus `json:"status,omitempty"`
+
+	// UpdateTime Timestamp of the last change of the delivery
+	UpdateTime *time.Time `json:"update_time,omitempty"`
+}
+
+// WebhookDeliveryStatus Delivery status:
+//   - PENDING - Not delivered yet; the next attempt is at
+//     `next_attempt_time`
+//   - SUCCEEDED - The endpoint responded with a 2xx status
+//   - FAILED - All attempts failed
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryList Response message for listing the deliveries of a webhook.
+type WebhookDeliveryList struct {
+	// Deliveries Deliveries, newest first
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// WebhookEventType Type of a webhook event
+type WebhookEventType string
+
+// WebhookList Response message for listing webhooks.
+type WebhookList struct {
+	// Webhooks All webhooks, ordered by ID
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// WebhookPayload Body of a webhook delivery. Retries of a delivery carry the same
+// body; `event_id` is the same in the deliveries of an event to
+// different webhooks.
+type WebhookPayload struct {
+	// ChangeType The change of the policy, as reported by `policies:watch`
+	// (CREATED, UPDATED, DELETED, ENABLED or DISABLED). Not set for
+	// COMPILE_FAILED.
+	ChangeType *string `json:"change_type,omitempty"`
+
+	// Error The compile error, for COMPILE_FAILED.
+	Error *string `json:"error,omitempty"`
+
+	// EventId Unique identifier of the event
+	EventId string `json:"event_id"`
+
+	// EventTime Time of the event
+	EventTime time.Time `json:"event_time"`
+
+	// EventType Type of a webhook event
+	EventType WebhookEventType `json:"event_type"`
+
+	// PolicyId ID of the changed policy. Not set for COMPILE_FAILED.
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// Revision The `update_time` of the policy after the change. Not set for
+	// POLICY_DELETED and COMPILE_FAILED.
+	Revision *time.Time `json:"revision,omitempty"`
 }
 
+// LibraryIdPath defines model for LibraryIdPath.
+type LibraryIdPath = string
+
 // PolicyIdPath defines model for PolicyIdPath.
 type PolicyIdPath = string
 
+// ProposalIdPath defines model for ProposalIdPath.
+type ProposalIdPath = string
+
+// RequirePassingTests defines model for RequirePassingTests.
+type RequirePassingTests = bool
+
+// UpdateMask defines model for UpdateMask.
+type UpdateMask = []string
+
+// ValidateOnly defines model for ValidateOnly.
+type ValidateOnly = bool
+
+// WebhookIdPath defines model for WebhookIdPath.
+type WebhookIdPath = string
+
 // AlreadyExists Error response following RFC 7807 Problem Details and AEP-193.
 //
 // Provides structured error information for API failures.
@@ -222,25 +876,50 @@ type Unauthorized = Error
 // Provides structured error information for API failures.
 type ValidationError = Error
 
+// CreateLibraryParams defines parameters for CreateLibrary.
+type CreateLibraryParams struct {
+	// Id Optional client-specified ID for the library. If not provided, the
+	// server will generate a UUID. Follows the same AEP-122 requirements
+	// as policy IDs.
+	Id *string `form:"id,omitempty" json:"id,omitempty"`
+}
+
 // ListPoliciesParams defines parameters for ListPolicies.
 type ListPoliciesParams struct {
 	// PageToken Token for retrieving the next page of results. Leave empty for
 	// the first page. Use the `next_page_token` from the previous
-	// response to get the next page.
+	// response to get the next page, with the same `filter` and
+	// `order_by`. Pagination is keyset-based, so policies created or
+	// deleted between pages do not cause other policies to be skipped
+	// or repeated. Malformed, modified, or mismatched tokens are
+	// rejected with INVALID_ARGUMENT.
 	PageToken *string `form:"page_token,omitempty" json:"page_token,omitempty"`
 
 	// MaxPageSize Maximum number of policies to return per page. Server may return
 	// fewer results. If unspecified, defaults to 50. Maximum value is 1000.
 	MaxPageSize *int32 `form:"max_page_size,omitempty" json:"max_page_size,omitempty"`
 
-	// Filter Filter expression to apply to the list. Supports filtering by:
+	// Filter CEL filter expression (AEP-160) to apply to the list. The expression
+	// may reference the following fields:
+	// - `id`, `display_name`, `description`: strings, supporting
+	//   `contains()`, `startsWith()` and `endsWith()`
 	// - `policy_type`: GLOBAL or USER
-	// - `enabled`: true or false
+	// - `priority`: integer
+	// - `enabled`, `managed`: booleans
+	// - `label_selector`: map of strings, e.g. `label_selector.env == 'prod'`
+	//   or `'team' in label_selector`
+	// - `create_time`, `update_time`: timestamps, compared with `timestamp('...')`
+	// - `effective_from`, `effective_until`: timestamps; policies without
+	//   the bound never match a comparison on it
+	// - `active`: boolean, whether the policy is enabled and effective now
+	//
+	// Conditions can be combined with `&&`, `||` and `!`. The legacy
+	// syntax using `=`, `AND`, `OR` and `NOT` is also accepted.
 	//
 	// Examples:
-	// - `policy_type='GLOBAL'`
-	// - `enabled=true`
-	// - `policy_type='GLOBAL' AND enabled=true`
+	// - `policy_type == 'GLOBAL' && enabled`
+	// - `priority >= 100 && priority < 200`
+	// - `display_name.contains('quota') || label_selector.team == 'a'`
 	Filter *string `form:"filter,omitempty" json:"filter,omitempty"`
 
 	// OrderBy Comma-separated list of fields to order by. Each field can be
@@ -269,19 +948,146 @@ type CreatePolicyParams struct {
 	// - Contain only lowercase letters, numbers, and hyphens
 	// - End with letter or number
 	Id *string `form:"id,omitempty" json:"id,omitempty"`
+
+	// RequirePassingTests If true, the request is rejected with 400 and type FAILED_PRECONDITION
+	// when any test in the policy's `test_code` fails. Otherwise test
+	// failures are logged and the change is saved.
+	RequirePassingTests *RequirePassingTests `form:"require_passing_tests,omitempty" json:"require_passing_tests,omitempty"`
+
+	// ValidateOnly If true, the request is validated as usual but no change is made
+	// (AEP-163).
+	ValidateOnly *ValidateOnly `form:"validate_only,omitempty" json:"validate_only,omitempty"`
+
+	// Placement Assigns the priority relative to the other policies of the same
+	// `policy_type`: `end`, `before:<policy-id>` or `after:<policy-id>`.
+	// Cannot be combined with `priority` in the request body.
+	Placement *string `form:"placement,omitempty" json:"placement,omitempty"`
+}
+
+// UpdatePolicyParams defines parameters for UpdatePolicy.
+type UpdatePolicyParams struct {
+	// UpdateMask Comma-separated fields to update (AEP-134). A listed field that is
+	// absent from the body is cleared. Without a mask the fields of the body
+	// are updated.
+	UpdateMask *UpdateMask `form:"update_mask,omitempty" json:"update_mask,omitempty"`
+
+	// RequirePassingTests If true, the request is rejected with 400 and type FAILED_PRECONDITION
+	// when any test in the policy's `test_code` fails. Otherwise test
+	// failures are logged and the change is saved.
+	RequirePassingTests *RequirePassingTests `form:"require_passing_tests,omitempty" json:"require_passing_tests,omitempty"`
+
+	// ValidateOnly If true, the request is validated as usual but no change is made
+	// (AEP-163).
+	ValidateOnly *ValidateOnly `form:"validate_only,omitempty" json:"validate_only,omitempty"`
+}
+
+// BatchCreatePoliciesParams defines parameters for BatchCreatePolicies.
+type BatchCreatePoliciesParams struct {
+	// RequirePassingTests If true, the request is rejected with 400 and type FAILED_PRECONDITION
+	// when any test in the policy's `test_code` fails. Otherwise test
+	// failures are logged and the change is saved.
+	RequirePassingTests *RequirePassingTests `form:"require_passing_tests,omitempty" json:"require_passing_tests,omitempty"`
+
+	// ValidateOnly If true, the request is validated as usual but no change is made
+	// (AEP-163).
+	ValidateOnly *ValidateOnly `form:"validate_only,omitempty" json:"validate_only,omitempty"`
+}
+
+// BatchUpdatePoliciesParams defines parameters for BatchUpdatePolicies.
+type BatchUpdatePoliciesParams struct {
+	// RequirePassingTests If true, the request is rejected with 400 and type FAILED_PRECONDITION
+	// when any test in the policy's `test_code` fails. Otherwise test
+	// failures are logged and the change is saved.
+	RequirePassingTests *RequirePassingTests `form:"require_passing_tests,omitempty" json:"require_passing_tests,omitempty"`
+
+	// ValidateOnly If true, the request is validated as usual but no change is made
+	// (AEP-163).
+	ValidateOnly *ValidateOnly `form:"validate_only,omitempty" json:"validate_only,omitempty"`
+}
+
+// WatchPoliciesParams defines parameters for WatchPolicies.
+type WatchPoliciesParams struct {
+	// LastEventId ID of the last event received by the client, for clients that
+	// cannot set the `Last-Event-ID` header. The header takes precedence.
+	LastEventId *string `form:"last_event_id,omitempty" json:"last_event_id,omitempty"`
+
+	// LastEventID ID of the last event received by the client
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// ListProposalsParams defines parameters for ListProposals.
+type ListProposalsParams struct {
+	// State Only list proposals in this state
+	State *ProposalState `form:"state,omitempty" json:"state,omitempty"`
+}
+
+// CreateWebhookParams defines parameters for CreateWebhook.
+type CreateWebhookParams struct {
+	// Id Optional client-specified ID for the webhook. If not provided, the
+	// server will generate a UUID. Follows the same AEP-122 requirements
+	// as policy IDs.
+	Id *string `form:"id,omitempty" json:"id,omitempty"`
 }
 
+// CreateLibraryJSONRequestBody defines body for CreateLibrary for application/json ContentType.
+type CreateLibraryJSONRequestBody = Library
+
+// UpdateLibraryApplicationMergePatchPlusJSONRequestBody defines body for UpdateLibrary for application/merge-patch+json ContentType.
+type UpdateLibraryApplicationMergePatchPlusJSONRequestBody = Library
+
 // CreatePolicyJSONRequestBody defines body for CreatePolicy for application/json ContentType.
 type CreatePolicyJSONRequestBody = Policy
 
 // UpdatePolicyApplicationMergePatchPlusJSONRequestBody defines body for UpdatePolicy for application/merge-patch+json ContentType.
 type UpdatePolicyApplicationMergePatchPlusJSONRequestBody = Policy
 
+// BatchCreatePoliciesJSONRequestBody defines body for BatchCreatePolicies for application/json ContentType.
+type BatchCreatePoliciesJSONRequestBody = BatchCreatePoliciesRequest
+
+// BatchDeletePoliciesJSONRequestBody defines body for BatchDeletePolicies for application/json ContentType.
+type BatchDeletePoliciesJSONRequestBody = BatchDeletePoliciesRequest
+
+// BatchUpdatePoliciesJSONRequestBody defines body for BatchUpdatePolicies for application/json ContentType.
+type BatchUpdatePoliciesJSONRequestBody = BatchUpdatePoliciesRequest
+
+// ReorderPoliciesJSONRequestBody defines body for ReorderPolicies for application/json ContentType.
+type ReorderPoliciesJSONRequestBody = PolicyReorderRequest
+
+// CreateProposalJSONRequestBody defines body for CreateProposal for application/json ContentType.
+type CreateProposalJSONRequestBody = PolicyProposal
+
+// ApproveProposalJSONRequestBody defines body for ApproveProposal for application/json ContentType.
+type ApproveProposalJSONRequestBody = ProposalReview
+
+// RejectProposalJSONRequestBody defines body for RejectProposal for application/json ContentType.
+type RejectProposalJSONRequestBody = ProposalReview
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = Webhook
+
+// UpdateWebhookApplicationMergePatchPlusJSONRequestBody defines body for UpdateWebhook for application/merge-patch+json ContentType.
+type UpdateWebhookApplicationMergePatchPlusJSONRequestBody = Webhook
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Health check
 	// (GET /health)
 	GetHealth(w http.ResponseWriter, r *http.Request)
+	// List libraries
+	// (GET /libraries)
+	ListLibraries(w http.ResponseWriter, r *http.Request)
+	// Create a new library
+	// (POST /libraries)
+	CreateLibrary(w http.ResponseWriter, r *http.Request, params CreateLibraryParams)
+	// Delete a library
+	// (DELETE /libraries/{libraryId})
+	DeleteLibrary(w http.ResponseWriter, r *http.Request, libraryId LibraryIdPath)
+	// Get a library
+	// (GET /libraries/{libraryId})
+	GetLibrary(w http.ResponseWriter, r *http.Request, libraryId LibraryIdPath)
+	// Update a library
+	// (PATCH /libraries/{libraryId})
+	UpdateLibrary(w http.ResponseWriter, r *http.Request, libraryId LibraryIdPath)
 	// List policies
 	// (GET /policies)
 	ListPolicies(w http.ResponseWriter, r *http.Request, params ListPoliciesParams)
@@ -296,7 +1102,64 @@ type ServerInterface interface {
 	GetPolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath)
 	// Update a policy
 	// (PATCH /policies/{policyId})
-	UpdatePolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath)
+	UpdatePolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath, params UpdatePolicyParams)
+	// Run the tests of a policy
+	// (POST /policies/{policyId}:test)
+	TestPolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath)
+	// Create several policies at once
+	// (POST /policies:batchCreate)
+	BatchCreatePolicies(w http.ResponseWriter, r *http.Request, params BatchCreatePoliciesParams)
+	// Delete several policies at once
+	// (POST /policies:batchDelete)
+	BatchDeletePolicies(w http.ResponseWriter, r *http.Request)
+	// Update several policies at once
+	// (POST /policies:batchUpdate)
+	BatchUpdatePolicies(w http.ResponseWriter, r *http.Request, params BatchUpdatePoliciesParams)
+	// Export policies as an OPA bundle
+	// (GET /policies:exportBundle)
+	ExportPolicyBundle(w http.ResponseWriter, r *http.Request)
+	// Import an OPA bundle
+	// (POST /policies:importBundle)
+	ImportPolicyBundle(w http.ResponseWriter, r *http.Request)
+	// Reorder the policies of a policy type
+	// (POST /policies:reorder)
+	ReorderPolicies(w http.ResponseWriter, r *http.Request)
+	// Watch policy changes
+	// (GET /policies:watch)
+	WatchPolicies(w http.ResponseWriter, r *http.Request, params WatchPoliciesParams)
+	// List proposals
+	// (GET /proposals)
+	ListProposals(w http.ResponseWriter, r *http.Request, params ListProposalsParams)
+	// Propose a policy change
+	// (POST /proposals)
+	CreateProposal(w http.ResponseWriter, r *http.Request)
+	// Get a proposal
+	// (GET /proposals/{proposalId})
+	GetProposal(w http.ResponseWriter, r *http.Request, proposalId ProposalIdPath)
+	// Approve a proposal
+	// (POST /proposals/{proposalId}:approve)
+	ApproveProposal(w http.ResponseWriter, r *http.Request, proposalId ProposalIdPath)
+	// Reject a proposal
+	// (POST /proposals/{proposalId}:reject)
+	RejectProposal(w http.ResponseWriter, r *http.Request, proposalId ProposalIdPath)
+	// List webhooks
+	// (GET /webhooks)
+	ListWebhooks(w http.ResponseWriter, r *http.Request)
+	// Create a new webhook
+	// (POST /webhooks)
+	CreateWebhook(w http.ResponseWriter, r *http.Request, params CreateWebhookParams)
+	// Delete a webhook
+	// (DELETE /webhooks/{webhookId})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath)
+	// Get a webhook
+	// (GET /webhooks/{webhookId})
+	GetWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath)
+	// Update a webhook
+	// (PATCH /webhooks/{webhookId})
+	UpdateWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath)
+	// List the deliveries of a webhook
+	// (GET /webhooks/{webhookId}/deliveries)
+	ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -309,6 +1172,36 @@ func (_ Unimplemented) GetHealth(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List libraries
+// (GET /libraries)
+func (_ Unimplemented) ListLibraries(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new library
+// (POST /libraries)
+func (_ Unimplemented) CreateLibrary(w http.ResponseWriter, r *http.Request, params CreateLibraryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a library
+// (DELETE /libraries/{libraryId})
+func (_ Unimplemented) DeleteLibrary(w http.ResponseWriter, r *http.Request, libraryId LibraryIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a library
+// (GET /libraries/{libraryId})
+func (_ Unimplemented) GetLibrary(w http.ResponseWriter, r *http.Request, libraryId LibraryIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a library
+// (PATCH /libraries/{libraryId})
+func (_ Unimplemented) UpdateLibrary(w http.ResponseWriter, r *http.Request, libraryId LibraryIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List policies
 // (GET /policies)
 func (_ Unimplemented) ListPolicies(w http.ResponseWriter, r *http.Request, params ListPoliciesParams) {
@@ -335,25 +1228,255 @@ func (_ Unimplemented) GetPolicy(w http.ResponseWriter, r *http.Request, policyI
 
 // Update a policy
 // (PATCH /policies/{policyId})
-func (_ Unimplemented) UpdatePolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath) {
+func (_ Unimplemented) UpdatePolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath, params UpdatePolicyParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// Run the tests of a policy
+// (POST /policies/{policyId}:test)
+func (_ Unimplemented) TestPolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// Create several policies at once
+// (POST /policies:batchCreate)
+func (_ Unimplemented) BatchCreatePolicies(w http.ResponseWriter, r *http.Request, params BatchCreatePoliciesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-// GetHealth operation middleware
-func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+// Delete several policies at once
+// (POST /policies:batchDelete)
+func (_ Unimplemented) BatchDeletePolicies(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetHealth(w, r)
-	}))
+// Update several policies at once
+// (POST /policies:batchUpdate)
+func (_ Unimplemented) BatchUpdatePolicies(w http.ResponseWriter, r *http.Request, params BatchUpdatePoliciesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export policies as an OPA bundle
+// (GET /policies:exportBundle)
+func (_ Unimplemented) ExportPolicyBundle(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import an OPA bundle
+// (POST /policies:importBundle)
+func (_ Unimplemented) ImportPolicyBundle(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reorder the policies of a policy type
+// (POST /policies:reorder)
+func (_ Unimplemented) ReorderPolicies(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Watch policy changes
+// (GET /policies:watch)
+func (_ Unimplemented) WatchPolicies(w http.ResponseWriter, r *http.Request, params WatchPoliciesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List proposals
+// (GET /proposals)
+func (_ Unimplemented) ListProposals(w http.ResponseWriter, r *http.Request, params ListProposalsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Propose a policy change
+// (POST /proposals)
+func (_ Unimplemented) CreateProposal(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a proposal
+// (GET /proposals/{proposalId})
+func (_ Unimplemented) GetProposal(w http.ResponseWriter, r *http.Request, proposalId ProposalIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Approve a proposal
+// (POST /proposals/{proposalId}:approve)
+func (_ Unimplemented) ApproveProposal(w http.ResponseWriter, r *http.Request, proposalId ProposalIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject a proposal
+// (POST /proposals/{proposalId}:reject)
+func (_ Unimplemented) RejectProposal(w http.ResponseWriter, r *http.Request, proposalId ProposalIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List webhooks
+// (GET /webhooks)
+func (_ Unimplemented) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new webhook
+// (POST /webhooks)
+func (_ Unimplemented) CreateWebhook(w http.ResponseWriter, r *http.Request, params CreateWebhookParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a webhook
+// (DELETE /webhooks/{webhookId})
+func (_ Unimplemented) DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a webhook
+// (GET /webhooks/{webhookId})
+func (_ Unimplemented) GetWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a webhook
+// (PATCH /webhooks/{webhookId})
+func (_ Unimplemented) UpdateWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the deliveries of a webhook
+// (GET /webhooks/{webhookId}/deliveries)
+func (_ Unimplemented) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListLibraries operation middleware
+func (siw *ServerInterfaceWrapper) ListLibraries(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListLibraries(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateLibrary operation middleware
+func (siw *ServerInterfaceWrapper) CreateLibrary(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateLibraryParams
+
+	// ------------- Optional query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "id", r.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateLibrary(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteLibrary operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLibrary(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "libraryId" -------------
+	var libraryId LibraryIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "libraryId", chi.URLParam(r, "libraryId"), &libraryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "libraryId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteLibrary(w, r, libraryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLibrary operation middleware
+func (siw *ServerInterfaceWrapper) GetLibrary(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "libraryId" -------------
+	var libraryId LibraryIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "libraryId", chi.URLParam(r, "libraryId"), &libraryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "libraryId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLibrary(w, r, libraryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateLibrary operation middleware
+func (siw *ServerInterfaceWrapper) UpdateLibrary(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "libraryId" -------------
+	var libraryId LibraryIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "libraryId", chi.URLParam(r, "libraryId"), &libraryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "libraryId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateLibrary(w, r, libraryId)
+	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
@@ -429,6 +1552,30 @@ func (siw *ServerInterfaceWrapper) CreatePolicy(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// ------------- Optional query parameter "require_passing_tests" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "require_passing_tests", r.URL.Query(), &params.RequirePassingTests)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "require_passing_tests", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "validate_only" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "validate_only", r.URL.Query(), &params.ValidateOnly)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "validate_only", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "placement" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "placement", r.URL.Query(), &params.Placement)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "placement", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.CreatePolicy(w, r, params)
 	}))
@@ -504,8 +1651,35 @@ func (siw *ServerInterfaceWrapper) UpdatePolicy(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdatePolicyParams
+
+	// ------------- Optional query parameter "update_mask" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "update_mask", r.URL.Query(), &params.UpdateMask)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "update_mask", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "require_passing_tests" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "require_passing_tests", r.URL.Query(), &params.RequirePassingTests)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "require_passing_tests", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "validate_only" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "validate_only", r.URL.Query(), &params.ValidateOnly)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "validate_only", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdatePolicy(w, r, policyId)
+		siw.Handler.UpdatePolicy(w, r, policyId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -515,559 +1689,3312 @@ func (siw *ServerInterfaceWrapper) UpdatePolicy(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// TestPolicy operation middleware
+func (siw *ServerInterfaceWrapper) TestPolicy(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "policyId" -------------
+	var policyId PolicyIdPath
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "policyId", chi.URLParam(r, "policyId"), &policyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "policyId", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TestPolicy(w, r, policyId)
+	}))
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RequiredParamError struct {
-	ParamName string
+	handler.ServeHTTP(w, r)
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+// BatchCreatePolicies operation middleware
+func (siw *ServerInterfaceWrapper) BatchCreatePolicies(w http.ResponseWriter, r *http.Request) {
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BatchCreatePoliciesParams
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Optional query parameter "require_passing_tests" -------------
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindQueryParameter("form", true, false, "require_passing_tests", r.URL.Query(), &params.RequirePassingTests)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "require_passing_tests", Err: err})
+		return
+	}
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Optional query parameter "validate_only" -------------
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindQueryParameter("form", true, false, "validate_only", r.URL.Query(), &params.ValidateOnly)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "validate_only", Err: err})
+		return
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchCreatePolicies(w, r, params)
+	}))
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
-
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
-
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
+	handler.ServeHTTP(w, r)
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
-}
+// BatchDeletePolicies operation middleware
+func (siw *ServerInterfaceWrapper) BatchDeletePolicies(w http.ResponseWriter, r *http.Request) {
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchDeletePolicies(w, r)
+	}))
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/health", wrapper.GetHealth)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/policies", wrapper.ListPolicies)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/policies", wrapper.CreatePolicy)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/policies/{policyId}", wrapper.DeletePolicy)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/policies/{policyId}", wrapper.GetPolicy)
-	})
-	r.Group(func(r chi.Router) {
-		r.Patch(options.BaseURL+"/policies/{policyId}", wrapper.UpdatePolicy)
-	})
-
-	return r
+	handler.ServeHTTP(w, r)
 }
 
-type AlreadyExistsJSONResponse Error
+// BatchUpdatePolicies operation middleware
+func (siw *ServerInterfaceWrapper) BatchUpdatePolicies(w http.ResponseWriter, r *http.Request) {
 
-type BadRequestJSONResponse Error
+	var err error
 
-type ForbiddenJSONResponse Error
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BatchUpdatePoliciesParams
 
-type InternalServerErrorJSONResponse Error
+	// ------------- Optional query parameter "require_passing_tests" -------------
 
-type NotFoundJSONResponse Error
+	err = runtime.BindQueryParameter("form", true, false, "require_passing_tests", r.URL.Query(), &params.RequirePassingTests)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "require_passing_tests", Err: err})
+		return
+	}
 
-type UnauthorizedJSONResponse Error
+	// ------------- Optional query parameter "validate_only" -------------
 
-type ValidationErrorJSONResponse Error
+	err = runtime.BindQueryParameter("form", true, false, "validate_only", r.URL.Query(), &params.ValidateOnly)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "validate_only", Err: err})
+		return
+	}
 
-type GetHealthRequestObject struct {
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchUpdatePolicies(w, r, params)
+	}))
 
-type GetHealthResponseObject interface {
-	VisitGetHealthResponse(w http.ResponseWriter) error
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type GetHealth200JSONResponse Health
+// ExportPolicyBundle operation middleware
+func (siw *ServerInterfaceWrapper) ExportPolicyBundle(w http.ResponseWriter, r *http.Request) {
 
-func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportPolicyBundle(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ListPoliciesRequestObject struct {
-	Params ListPoliciesParams
+	handler.ServeHTTP(w, r)
 }
 
-type ListPoliciesResponseObject interface {
-	VisitListPoliciesResponse(w http.ResponseWriter) error
-}
+// ImportPolicyBundle operation middleware
+func (siw *ServerInterfaceWrapper) ImportPolicyBundle(w http.ResponseWriter, r *http.Request) {
 
-type ListPolicies200JSONResponse PolicyList
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportPolicyBundle(w, r)
+	}))
 
-func (response ListPolicies200JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type ListPolicies400JSONResponse struct{ BadRequestJSONResponse }
+// ReorderPolicies operation middleware
+func (siw *ServerInterfaceWrapper) ReorderPolicies(w http.ResponseWriter, r *http.Request) {
 
-func (response ListPolicies400JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReorderPolicies(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type ListPolicies401JSONResponse struct{ UnauthorizedJSONResponse }
+// WatchPolicies operation middleware
+func (siw *ServerInterfaceWrapper) WatchPolicies(w http.ResponseWriter, r *http.Request) {
 
-func (response ListPolicies401JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params WatchPoliciesParams
 
-type ListPolicies403JSONResponse struct{ ForbiddenJSONResponse }
+	// ------------- Optional query parameter "last_event_id" -------------
 
-func (response ListPolicies403JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	err = runtime.BindQueryParameter("form", true, false, "last_event_id", r.URL.Query(), &params.LastEventId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "last_event_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	headers := r.Header
 
-type ListPolicies500JSONResponse struct {
-	InternalServerErrorJSONResponse
-}
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
 
-func (response ListPolicies500JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
 
-	return json.NewEncoder(w).Encode(response)
-}
+		params.LastEventID = &LastEventID
 
-type CreatePolicyRequestObject struct {
-	Params CreatePolicyParams
-	Body   *CreatePolicyJSONRequestBody
-}
+	}
 
-type CreatePolicyResponseObject interface {
-	VisitCreatePolicyResponse(w http.ResponseWriter) error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.WatchPolicies(w, r, params)
+	}))
 
-type CreatePolicy201ResponseHeaders struct {
-	Location string
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type CreatePolicy201JSONResponse struct {
-	Body    Policy
-	Headers CreatePolicy201ResponseHeaders
+	handler.ServeHTTP(w, r)
 }
 
-func (response CreatePolicy201JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(201)
+// ListProposals operation middleware
+func (siw *ServerInterfaceWrapper) ListProposals(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	var err error
 
-type CreatePolicy400JSONResponse struct{ BadRequestJSONResponse }
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListProposalsParams
 
-func (response CreatePolicy400JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Optional query parameter "state" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
 
-type CreatePolicy401JSONResponse struct{ UnauthorizedJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListProposals(w, r, params)
+	}))
 
-func (response CreatePolicy401JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type CreatePolicy403JSONResponse struct{ ForbiddenJSONResponse }
+// CreateProposal operation middleware
+func (siw *ServerInterfaceWrapper) CreateProposal(w http.ResponseWriter, r *http.Request) {
 
-func (response CreatePolicy403JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProposal(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type CreatePolicy409JSONResponse struct{ AlreadyExistsJSONResponse }
+// GetProposal operation middleware
+func (siw *ServerInterfaceWrapper) GetProposal(w http.ResponseWriter, r *http.Request) {
 
-func (response CreatePolicy409JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "proposalId" -------------
+	var proposalId ProposalIdPath
 
-type CreatePolicy422JSONResponse struct{ ValidationErrorJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "proposalId", chi.URLParam(r, "proposalId"), &proposalId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "proposalId", Err: err})
+		return
+	}
 
-func (response CreatePolicy422JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProposal(w, r, proposalId)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type CreatePolicy500JSONResponse struct {
-	InternalServerErrorJSONResponse
+	handler.ServeHTTP(w, r)
 }
 
-func (response CreatePolicy500JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// ApproveProposal operation middleware
+func (siw *ServerInterfaceWrapper) ApproveProposal(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type DeletePolicyRequestObject struct {
-	PolicyId PolicyIdPath `json:"policyId"`
-}
+	// ------------- Path parameter "proposalId" -------------
+	var proposalId ProposalIdPath
 
-type DeletePolicyResponseObject interface {
-	VisitDeletePolicyResponse(w http.ResponseWriter) error
+	err = runtime.BindStyledParameterWithOptions("simple", "proposalId", chi.URLParam(r, "proposalId"), &proposalId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "proposalId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveProposal(w, r, proposalId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type DeletePolicy204Response struct {
+// RejectProposal operation middleware
+func (siw *ServerInterfaceWrapper) RejectProposal(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "proposalId" -------------
+	var proposalId ProposalIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "proposalId", chi.URLParam(r, "proposalId"), &proposalId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "proposalId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RejectProposal(w, r, proposalId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-func (response DeletePolicy204Response) VisitDeletePolicyResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type DeletePolicy401JSONResponse struct{ UnauthorizedJSONResponse }
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(w http.ResponseWriter, r *http.Request) {
 
-func (response DeletePolicy401JSONResponse) VisitDeletePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateWebhookParams
+
+	// ------------- Optional query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "id", r.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhook(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type DeletePolicy403JSONResponse struct{ ForbiddenJSONResponse }
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
 
-func (response DeletePolicy403JSONResponse) VisitDeletePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId WebhookIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", chi.URLParam(r, "webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhook(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type DeletePolicy404JSONResponse struct{ NotFoundJSONResponse }
+// GetWebhook operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhook(w http.ResponseWriter, r *http.Request) {
 
-func (response DeletePolicy404JSONResponse) VisitDeletePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId WebhookIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", chi.URLParam(r, "webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhook(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type DeletePolicy500JSONResponse struct {
-	InternalServerErrorJSONResponse
+// UpdateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId WebhookIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", chi.URLParam(r, "webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateWebhook(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-func (response DeletePolicy500JSONResponse) VisitDeletePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId WebhookIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", chi.URLParam(r, "webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookDeliveries(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type GetPolicyRequestObject struct {
-	PolicyId PolicyIdPath `json:"policyId"`
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
 }
 
-type GetPolicyResponseObject interface {
-	VisitGetPolicyResponse(w http.ResponseWriter) error
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
 }
 
-type GetPolicy200JSONResponse Policy
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
 
-func (response GetPolicy200JSONResponse) VisitGetPolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
 }
 
-type GetPolicy401JSONResponse struct{ UnauthorizedJSONResponse }
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
 
-func (response GetPolicy401JSONResponse) VisitGetPolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+type RequiredParamError struct {
+	ParamName string
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
 }
 
-type GetPolicy403JSONResponse struct{ ForbiddenJSONResponse }
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
 
-func (response GetPolicy403JSONResponse) VisitGetPolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
 }
 
-type GetPolicy404JSONResponse struct{ NotFoundJSONResponse }
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
 
-func (response GetPolicy404JSONResponse) VisitGetPolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
 }
 
-type GetPolicy500JSONResponse struct {
-	InternalServerErrorJSONResponse
-}
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/health", wrapper.GetHealth)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/libraries", wrapper.ListLibraries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/libraries", wrapper.CreateLibrary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/libraries/{libraryId}", wrapper.DeleteLibrary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/libraries/{libraryId}", wrapper.GetLibrary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/libraries/{libraryId}", wrapper.UpdateLibrary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/policies", wrapper.ListPolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/policies", wrapper.CreatePolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/policies/{policyId}", wrapper.DeletePolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/policies/{policyId}", wrapper.GetPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/policies/{policyId}", wrapper.UpdatePolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/policies/{policyId}:test", wrapper.TestPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/policies:batchCreate", wrapper.BatchCreatePolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/policies:batchDelete", wrapper.BatchDeletePolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/policies:batchUpdate", wrapper.BatchUpdatePolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/policies:exportBundle", wrapper.ExportPolicyBundle)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/policies:importBundle", wrapper.ImportPolicyBundle)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/policies:reorder", wrapper.ReorderPolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/policies:watch", wrapper.WatchPolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/proposals", wrapper.ListProposals)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/proposals", wrapper.CreateProposal)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/proposals/{proposalId}", wrapper.GetProposal)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/proposals/{proposalId}:approve", wrapper.ApproveProposal)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/proposals/{proposalId}:reject", wrapper.RejectProposal)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks", wrapper.ListWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/webhooks", wrapper.CreateWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/webhooks/{webhookId}", wrapper.DeleteWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks/{webhookId}", wrapper.GetWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/webhooks/{webhookId}", wrapper.UpdateWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks/{webhookId}/deliveries", wrapper.ListWebhookDeliveries)
+	})
+
+	return r
+}
+
+type AlreadyExistsJSONResponse Error
+
+type BadRequestJSONResponse Error
+
+type ForbiddenJSONResponse Error
+
+type InternalServerErrorJSONResponse Error
+
+type NotFoundJSONResponse Error
+
+type UnauthorizedJSONResponse Error
+
+type ValidationErrorJSONResponse Error
+
+type GetHealthRequestObject struct {
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200JSONResponse Health
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLibrariesRequestObject struct {
+}
+
+type ListLibrariesResponseObject interface {
+	VisitListLibrariesResponse(w http.ResponseWriter) error
+}
+
+type ListLibraries200JSONResponse LibraryList
+
+func (response ListLibraries200JSONResponse) VisitListLibrariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLibraries401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListLibraries401JSONResponse) VisitListLibrariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLibraries403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListLibraries403JSONResponse) VisitListLibrariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLibraries500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response ListLibraries500JSONResponse) VisitListLibrariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLibraryRequestObject struct {
+	Params CreateLibraryParams
+	Body   *CreateLibraryJSONRequestBody
+}
+
+type CreateLibraryResponseObject interface {
+	VisitCreateLibraryResponse(w http.ResponseWriter) error
+}
+
+type CreateLibrary201JSONResponse Library
+
+func (response CreateLibrary201JSONResponse) VisitCreateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLibrary400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateLibrary400JSONResponse) VisitCreateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLibrary401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateLibrary401JSONResponse) VisitCreateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLibrary403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateLibrary403JSONResponse) VisitCreateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLibrary409JSONResponse struct{ AlreadyExistsJSONResponse }
+
+func (response CreateLibrary409JSONResponse) VisitCreateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLibrary500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response CreateLibrary500JSONResponse) VisitCreateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLibraryRequestObject struct {
+	LibraryId LibraryIdPath `json:"libraryId"`
+}
+
+type DeleteLibraryResponseObject interface {
+	VisitDeleteLibraryResponse(w http.ResponseWriter) error
+}
+
+type DeleteLibrary204Response struct {
+}
+
+func (response DeleteLibrary204Response) VisitDeleteLibraryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteLibrary400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response DeleteLibrary400JSONResponse) VisitDeleteLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLibrary401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteLibrary401JSONResponse) VisitDeleteLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLibrary403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteLibrary403JSONResponse) VisitDeleteLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLibrary404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteLibrary404JSONResponse) VisitDeleteLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLibrary500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response DeleteLibrary500JSONResponse) VisitDeleteLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLibraryRequestObject struct {
+	LibraryId LibraryIdPath `json:"libraryId"`
+}
+
+type GetLibraryResponseObject interface {
+	VisitGetLibraryResponse(w http.ResponseWriter) error
+}
+
+type GetLibrary200JSONResponse Library
+
+func (response GetLibrary200JSONResponse) VisitGetLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLibrary401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetLibrary401JSONResponse) VisitGetLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLibrary403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetLibrary403JSONResponse) VisitGetLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLibrary404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetLibrary404JSONResponse) VisitGetLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLibrary500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetLibrary500JSONResponse) VisitGetLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateLibraryRequestObject struct {
+	LibraryId LibraryIdPath `json:"libraryId"`
+	Body      *UpdateLibraryApplicationMergePatchPlusJSONRequestBody
+}
+
+type UpdateLibraryResponseObject interface {
+	VisitUpdateLibraryResponse(w http.ResponseWriter) error
+}
+
+type UpdateLibrary200JSONResponse Library
+
+func (response UpdateLibrary200JSONResponse) VisitUpdateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateLibrary400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateLibrary400JSONResponse) VisitUpdateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateLibrary401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateLibrary401JSONResponse) VisitUpdateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateLibrary403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateLibrary403JSONResponse) VisitUpdateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateLibrary404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateLibrary404JSONResponse) VisitUpdateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateLibrary500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response UpdateLibrary500JSONResponse) VisitUpdateLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPoliciesRequestObject struct {
+	Params ListPoliciesParams
+}
+
+type ListPoliciesResponseObject interface {
+	VisitListPoliciesResponse(w http.ResponseWriter) error
+}
+
+type ListPolicies200JSONResponse PolicyList
+
+func (response ListPolicies200JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPolicies400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListPolicies400JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPolicies401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListPolicies401JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPolicies403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListPolicies403JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPolicies500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response ListPolicies500JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePolicyRequestObject struct {
+	Params CreatePolicyParams
+	Body   *CreatePolicyJSONRequestBody
+}
+
+type CreatePolicyResponseObject interface {
+	VisitCreatePolicyResponse(w http.ResponseWriter) error
+}
+
+type CreatePolicy200JSONResponse Policy
+
+func (response CreatePolicy200JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePolicy201ResponseHeaders struct {
+	Location string
+}
+
+type CreatePolicy201JSONResponse struct {
+	Body    Policy
+	Headers CreatePolicy201ResponseHeaders
+}
+
+func (response CreatePolicy201JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreatePolicy400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreatePolicy400JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePolicy401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreatePolicy401JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePolicy403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreatePolicy403JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePolicy409JSONResponse struct{ AlreadyExistsJSONResponse }
+
+func (response CreatePolicy409JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePolicy422JSONResponse struct{ ValidationErrorJSONResponse }
+
+func (response CreatePolicy422JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePolicy500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response CreatePolicy500JSONResponse) VisitCreatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeletePolicyRequestObject struct {
+	PolicyId PolicyIdPath `json:"policyId"`
+}
+
+type DeletePolicyResponseObject interface {
+	VisitDeletePolicyResponse(w http.ResponseWriter) error
+}
+
+type DeletePolicy204Response struct {
+}
+
+func (response DeletePolicy204Response) VisitDeletePolicyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeletePolicy400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response DeletePolicy400JSONResponse) VisitDeletePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeletePolicy401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeletePolicy401JSONResponse) VisitDeletePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeletePolicy403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeletePolicy403JSONResponse) VisitDeletePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeletePolicy404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeletePolicy404JSONResponse) VisitDeletePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeletePolicy500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response DeletePolicy500JSONResponse) VisitDeletePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPolicyRequestObject struct {
+	PolicyId PolicyIdPath `json:"policyId"`
+}
+
+type GetPolicyResponseObject interface {
+	VisitGetPolicyResponse(w http.ResponseWriter) error
+}
+
+type GetPolicy200JSONResponse Policy
+
+func (response GetPolicy200JSONResponse) VisitGetPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPolicy401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetPolicy401JSONResponse) VisitGetPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPolicy403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetPolicy403JSONResponse) VisitGetPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPolicy404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetPolicy404JSONResponse) VisitGetPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPolicy500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetPolicy500JSONResponse) VisitGetPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePolicyRequestObject struct {
+	PolicyId PolicyIdPath `json:"policyId"`
+	Params   UpdatePolicyParams
+	Body     *UpdatePolicyApplicationMergePatchPlusJSONRequestBody
+}
+
+type UpdatePolicyResponseObject interface {
+	VisitUpdatePolicyResponse(w http.ResponseWriter) error
+}
+
+type UpdatePolicy200JSONResponse Policy
+
+func (response UpdatePolicy200JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePolicy400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdatePolicy400JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePolicy401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdatePolicy401JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePolicy403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdatePolicy403JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePolicy404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdatePolicy404JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePolicy409JSONResponse struct{ AlreadyExistsJSONResponse }
+
+func (response UpdatePolicy409JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePolicy500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response UpdatePolicy500JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestPolicyRequestObject struct {
+	PolicyId PolicyIdPath `json:"policyId"`
+}
+
+type TestPolicyResponseObject interface {
+	VisitTestPolicyResponse(w http.ResponseWriter) error
+}
+
+type TestPolicy200JSONResponse PolicyTestReport
+
+func (response TestPolicy200JSONResponse) VisitTestPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestPolicy400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response TestPolicy400JSONResponse) VisitTestPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestPolicy401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response TestPolicy401JSONResponse) VisitTestPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestPolicy403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response TestPolicy403JSONResponse) VisitTestPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestPolicy404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response TestPolicy404JSONResponse) VisitTestPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestPolicy500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response TestPolicy500JSONResponse) VisitTestPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchCreatePoliciesRequestObject struct {
+	Params BatchCreatePoliciesParams
+	Body   *BatchCreatePoliciesJSONRequestBody
+}
+
+type BatchCreatePoliciesResponseObject interface {
+	VisitBatchCreatePoliciesResponse(w http.ResponseWriter) error
+}
+
+type BatchCreatePolicies200JSONResponse BatchPoliciesResponse
+
+func (response BatchCreatePolicies200JSONResponse) VisitBatchCreatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchCreatePolicies400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response BatchCreatePolicies400JSONResponse) VisitBatchCreatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchCreatePolicies401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response BatchCreatePolicies401JSONResponse) VisitBatchCreatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchCreatePolicies403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response BatchCreatePolicies403JSONResponse) VisitBatchCreatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchCreatePolicies409JSONResponse struct{ AlreadyExistsJSONResponse }
+
+func (response BatchCreatePolicies409JSONResponse) VisitBatchCreatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchCreatePolicies500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response BatchCreatePolicies500JSONResponse) VisitBatchCreatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchDeletePoliciesRequestObject struct {
+	Body *BatchDeletePoliciesJSONRequestBody
+}
+
+type BatchDeletePoliciesResponseObject interface {
+	VisitBatchDeletePoliciesResponse(w http.ResponseWriter) error
+}
+
+type BatchDeletePolicies204Response struct {
+}
+
+func (response BatchDeletePolicies204Response) VisitBatchDeletePoliciesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type BatchDeletePolicies400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response BatchDeletePolicies400JSONResponse) VisitBatchDeletePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchDeletePolicies401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response BatchDeletePolicies401JSONResponse) VisitBatchDeletePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchDeletePolicies403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response BatchDeletePolicies403JSONResponse) VisitBatchDeletePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchDeletePolicies404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response BatchDeletePolicies404JSONResponse) VisitBatchDeletePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchDeletePolicies500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response BatchDeletePolicies500JSONResponse) VisitBatchDeletePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUpdatePoliciesRequestObject struct {
+	Params BatchUpdatePoliciesParams
+	Body   *BatchUpdatePoliciesJSONRequestBody
+}
+
+type BatchUpdatePoliciesResponseObject interface {
+	VisitBatchUpdatePoliciesResponse(w http.ResponseWriter) error
+}
+
+type BatchUpdatePolicies200JSONResponse BatchPoliciesResponse
+
+func (response BatchUpdatePolicies200JSONResponse) VisitBatchUpdatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUpdatePolicies400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response BatchUpdatePolicies400JSONResponse) VisitBatchUpdatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUpdatePolicies401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response BatchUpdatePolicies401JSONResponse) VisitBatchUpdatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUpdatePolicies403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response BatchUpdatePolicies403JSONResponse) VisitBatchUpdatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUpdatePolicies404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response BatchUpdatePolicies404JSONResponse) VisitBatchUpdatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUpdatePolicies409JSONResponse struct{ AlreadyExistsJSONResponse }
+
+func (response BatchUpdatePolicies409JSONResponse) VisitBatchUpdatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUpdatePolicies500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response BatchUpdatePolicies500JSONResponse) VisitBatchUpdatePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportPolicyBundleRequestObject struct {
+}
+
+type ExportPolicyBundleResponseObject interface {
+	VisitExportPolicyBundleResponse(w http.ResponseWriter) error
+}
+
+type ExportPolicyBundle200ApplicationgzipResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportPolicyBundle200ApplicationgzipResponse) VisitExportPolicyBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/gzip")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportPolicyBundle401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ExportPolicyBundle401JSONResponse) VisitExportPolicyBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportPolicyBundle403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ExportPolicyBundle403JSONResponse) VisitExportPolicyBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportPolicyBundle500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response ExportPolicyBundle500JSONResponse) VisitExportPolicyBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportPolicyBundleRequestObject struct {
+	Body io.Reader
+}
+
+type ImportPolicyBundleResponseObject interface {
+	VisitImportPolicyBundleResponse(w http.ResponseWriter) error
+}
+
+type ImportPolicyBundle200JSONResponse PolicyBundleImportResult
+
+func (response ImportPolicyBundle200JSONResponse) VisitImportPolicyBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportPolicyBundle400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ImportPolicyBundle400JSONResponse) VisitImportPolicyBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportPolicyBundle401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ImportPolicyBundle401JSONResponse) VisitImportPolicyBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportPolicyBundle403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ImportPolicyBundle403JSONResponse) VisitImportPolicyBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportPolicyBundle409JSONResponse struct{ AlreadyExistsJSONResponse }
+
+func (response ImportPolicyBundle409JSONResponse) VisitImportPolicyBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportPolicyBundle500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response ImportPolicyBundle500JSONResponse) VisitImportPolicyBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReorderPoliciesRequestObject struct {
+	Body *ReorderPoliciesJSONRequestBody
+}
+
+type ReorderPoliciesResponseObject interface {
+	VisitReorderPoliciesResponse(w http.ResponseWriter) error
+}
+
+type ReorderPolicies200JSONResponse PolicyReorderResult
+
+func (response ReorderPolicies200JSONResponse) VisitReorderPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReorderPolicies400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ReorderPolicies400JSONResponse) VisitReorderPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReorderPolicies401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ReorderPolicies401JSONResponse) VisitReorderPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReorderPolicies403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ReorderPolicies403JSONResponse) VisitReorderPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReorderPolicies404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ReorderPolicies404JSONResponse) VisitReorderPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReorderPolicies500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response ReorderPolicies500JSONResponse) VisitReorderPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WatchPoliciesRequestObject struct {
+	Params WatchPoliciesParams
+}
+
+type WatchPoliciesResponseObject interface {
+	VisitWatchPoliciesResponse(w http.ResponseWriter) error
+}
+
+type WatchPolicies200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response WatchPolicies200TexteventStreamResponse) VisitWatchPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type WatchPolicies400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response WatchPolicies400JSONResponse) VisitWatchPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WatchPolicies401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response WatchPolicies401JSONResponse) VisitWatchPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WatchPolicies403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response WatchPolicies403JSONResponse) VisitWatchPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WatchPolicies500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response WatchPolicies500JSONResponse) VisitWatchPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProposalsRequestObject struct {
+	Params ListProposalsParams
+}
+
+type ListProposalsResponseObject interface {
+	VisitListProposalsResponse(w http.ResponseWriter) error
+}
+
+type ListProposals200JSONResponse PolicyProposalList
+
+func (response ListProposals200JSONResponse) VisitListProposalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProposals400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListProposals400JSONResponse) VisitListProposalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProposals401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListProposals401JSONResponse) VisitListProposalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProposals403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListProposals403JSONResponse) VisitListProposalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProposals500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response ListProposals500JSONResponse) VisitListProposalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProposalRequestObject struct {
+	Body *CreateProposalJSONRequestBody
+}
+
+type CreateProposalResponseObject interface {
+	VisitCreateProposalResponse(w http.ResponseWriter) error
+}
+
+type CreateProposal201JSONResponse PolicyProposal
+
+func (response CreateProposal201JSONResponse) VisitCreateProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProposal400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateProposal400JSONResponse) VisitCreateProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProposal401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateProposal401JSONResponse) VisitCreateProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProposal403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateProposal403JSONResponse) VisitCreateProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProposal404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response CreateProposal404JSONResponse) VisitCreateProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProposal409JSONResponse struct{ AlreadyExistsJSONResponse }
+
+func (response CreateProposal409JSONResponse) VisitCreateProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProposal500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response CreateProposal500JSONResponse) VisitCreateProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProposalRequestObject struct {
+	ProposalId ProposalIdPath `json:"proposalId"`
+}
+
+type GetProposalResponseObject interface {
+	VisitGetProposalResponse(w http.ResponseWriter) error
+}
+
+type GetProposal200JSONResponse PolicyProposal
+
+func (response GetProposal200JSONResponse) VisitGetProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProposal401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetProposal401JSONResponse) VisitGetProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProposal403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetProposal403JSONResponse) VisitGetProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProposal404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetProposal404JSONResponse) VisitGetProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProposal500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetProposal500JSONResponse) VisitGetProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveProposalRequestObject struct {
+	ProposalId ProposalIdPath `json:"proposalId"`
+	Body       *ApproveProposalJSONRequestBody
+}
+
+type ApproveProposalResponseObject interface {
+	VisitApproveProposalResponse(w http.ResponseWriter) error
+}
+
+type ApproveProposal200JSONResponse PolicyProposal
+
+func (response ApproveProposal200JSONResponse) VisitApproveProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveProposal400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ApproveProposal400JSONResponse) VisitApproveProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveProposal401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ApproveProposal401JSONResponse) VisitApproveProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveProposal403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ApproveProposal403JSONResponse) VisitApproveProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveProposal404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ApproveProposal404JSONResponse) VisitApproveProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveProposal409JSONResponse struct{ AlreadyExistsJSONResponse }
+
+func (response ApproveProposal409JSONResponse) VisitApproveProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveProposal500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response ApproveProposal500JSONResponse) VisitApproveProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectProposalRequestObject struct {
+	ProposalId ProposalIdPath `json:"proposalId"`
+	Body       *RejectProposalJSONRequestBody
+}
+
+type RejectProposalResponseObject interface {
+	VisitRejectProposalResponse(w http.ResponseWriter) error
+}
+
+type RejectProposal200JSONResponse PolicyProposal
+
+func (response RejectProposal200JSONResponse) VisitRejectProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectProposal400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response RejectProposal400JSONResponse) VisitRejectProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectProposal401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RejectProposal401JSONResponse) VisitRejectProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectProposal403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RejectProposal403JSONResponse) VisitRejectProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectProposal404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RejectProposal404JSONResponse) VisitRejectProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectProposal500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response RejectProposal500JSONResponse) VisitRejectProposalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhooksRequestObject struct {
+}
+
+type ListWebhooksResponseObject interface {
+	VisitListWebhooksResponse(w http.ResponseWriter) error
+}
+
+type ListWebhooks200JSONResponse WebhookList
+
+func (response ListWebhooks200JSONResponse) VisitListWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhooks401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListWebhooks401JSONResponse) VisitListWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhooks403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListWebhooks403JSONResponse) VisitListWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhooks500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response ListWebhooks500JSONResponse) VisitListWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhookRequestObject struct {
+	Params CreateWebhookParams
+	Body   *CreateWebhookJSONRequestBody
+}
+
+type CreateWebhookResponseObject interface {
+	VisitCreateWebhookResponse(w http.ResponseWriter) error
+}
+
+type CreateWebhook201JSONResponse Webhook
+
+func (response CreateWebhook201JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateWebhook400JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateWebhook401JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateWebhook403JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook409JSONResponse struct{ AlreadyExistsJSONResponse }
+
+func (response CreateWebhook409JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response CreateWebhook500JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhookRequestObject struct {
+	WebhookId WebhookIdPath `json:"webhookId"`
+}
+
+type DeleteWebhookResponseObject interface {
+	VisitDeleteWebhookResponse(w http.ResponseWriter) error
+}
+
+type DeleteWebhook204Response struct {
+}
+
+func (response DeleteWebhook204Response) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteWebhook401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteWebhook401JSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhook403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteWebhook403JSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhook404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteWebhook404JSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhook500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response DeleteWebhook500JSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhookRequestObject struct {
+	WebhookId WebhookIdPath `json:"webhookId"`
+}
+
+type GetWebhookResponseObject interface {
+	VisitGetWebhookResponse(w http.ResponseWriter) error
+}
+
+type GetWebhook200JSONResponse Webhook
+
+func (response GetWebhook200JSONResponse) VisitGetWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhook401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetWebhook401JSONResponse) VisitGetWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhook403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetWebhook403JSONResponse) VisitGetWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhook404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetWebhook404JSONResponse) VisitGetWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhook500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetWebhook500JSONResponse) VisitGetWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWebhookRequestObject struct {
+	WebhookId WebhookIdPath `json:"webhookId"`
+	Body      *UpdateWebhookApplicationMergePatchPlusJSONRequestBody
+}
+
+type UpdateWebhookResponseObject interface {
+	VisitUpdateWebhookResponse(w http.ResponseWriter) error
+}
+
+type UpdateWebhook200JSONResponse Webhook
+
+func (response UpdateWebhook200JSONResponse) VisitUpdateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWebhook400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateWebhook400JSONResponse) VisitUpdateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWebhook401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateWebhook401JSONResponse) VisitUpdateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWebhook403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateWebhook403JSONResponse) VisitUpdateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWebhook404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateWebhook404JSONResponse) VisitUpdateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWebhook500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response UpdateWebhook500JSONResponse) VisitUpdateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveriesRequestObject struct {
+	WebhookId WebhookIdPath `json:"webhookId"`
+}
+
+type ListWebhookDeliveriesResponseObject interface {
+	VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error
+}
+
+type ListWebhookDeliveries200JSONResponse WebhookDeliveryList
+
+func (response ListWebhookDeliveries200JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveries401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListWebhookDeliveries401JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveries403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListWebhookDeliveries403JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveries404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ListWebhookDeliveries404JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveries500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response ListWebhookDeliveries500JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Health check
+	// (GET /health)
+	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// List libraries
+	// (GET /libraries)
+	ListLibraries(ctx context.Context, request ListLibrariesRequestObject) (ListLibrariesResponseObject, error)
+	// Create a new library
+	// (POST /libraries)
+	CreateLibrary(ctx context.Context, request CreateLibraryRequestObject) (CreateLibraryResponseObject, error)
+	// Delete a library
+	// (DELETE /libraries/{libraryId})
+	DeleteLibrary(ctx context.Context, request DeleteLibraryRequestObject) (DeleteLibraryResponseObject, error)
+	// Get a library
+	// (GET /libraries/{libraryId})
+	GetLibrary(ctx context.Context, request GetLibraryRequestObject) (GetLibraryResponseObject, error)
+	// Update a library
+	// (PATCH /libraries/{libraryId})
+	UpdateLibrary(ctx context.Context, request UpdateLibraryRequestObject) (UpdateLibraryResponseObject, error)
+	// List policies
+	// (GET /policies)
+	ListPolicies(ctx context.Context, request ListPoliciesRequestObject) (ListPoliciesResponseObject, error)
+	// Create a new policy
+	// (POST /policies)
+	CreatePolicy(ctx context.Context, request CreatePolicyRequestObject) (CreatePolicyResponseObject, error)
+	// Delete a policy
+	// (DELETE /policies/{policyId})
+	DeletePolicy(ctx context.Context, request DeletePolicyRequestObject) (DeletePolicyResponseObject, error)
+	// Get a policy
+	// (GET /policies/{policyId})
+	GetPolicy(ctx context.Context, request GetPolicyRequestObject) (GetPolicyResponseObject, error)
+	// Update a policy
+	// (PATCH /policies/{policyId})
+	UpdatePolicy(ctx context.Context, request UpdatePolicyRequestObject) (UpdatePolicyResponseObject, error)
+	// Run the tests of a policy
+	// (POST /policies/{policyId}:test)
+	TestPolicy(ctx context.Context, request TestPolicyRequestObject) (TestPolicyResponseObject, error)
+	// Create several policies at once
+	// (POST /policies:batchCreate)
+	BatchCreatePolicies(ctx context.Context, request BatchCreatePoliciesRequestObject) (BatchCreatePoliciesResponseObject, error)
+	// Delete several policies at once
+	// (POST /policies:batchDelete)
+	BatchDeletePolicies(ctx context.Context, request BatchDeletePoliciesRequestObject) (BatchDeletePoliciesResponseObject, error)
+	// Update several policies at once
+	// (POST /policies:batchUpdate)
+	BatchUpdatePolicies(ctx context.Context, request BatchUpdatePoliciesRequestObject) (BatchUpdatePoliciesResponseObject, error)
+	// Export policies as an OPA bundle
+	// (GET /policies:exportBundle)
+	ExportPolicyBundle(ctx context.Context, request ExportPolicyBundleRequestObject) (ExportPolicyBundleResponseObject, error)
+	// Import an OPA bundle
+	// (POST /policies:importBundle)
+	ImportPolicyBundle(ctx context.Context, request ImportPolicyBundleRequestObject) (ImportPolicyBundleResponseObject, error)
+	// Reorder the policies of a policy type
+	// (POST /policies:reorder)
+	ReorderPolicies(ctx context.Context, request ReorderPoliciesRequestObject) (ReorderPoliciesResponseObject, error)
+	// Watch policy changes
+	// (GET /policies:watch)
+	WatchPolicies(ctx context.Context, request WatchPoliciesRequestObject) (WatchPoliciesResponseObject, error)
+	// List proposals
+	// (GET /proposals)
+	ListProposals(ctx context.Context, request ListProposalsRequestObject) (ListProposalsResponseObject, error)
+	// Propose a policy change
+	// (POST /proposals)
+	CreateProposal(ctx context.Context, request CreateProposalRequestObject) (CreateProposalResponseObject, error)
+	// Get a proposal
+	// (GET /proposals/{proposalId})
+	GetProposal(ctx context.Context, request GetProposalRequestObject) (GetProposalResponseObject, error)
+	// Approve a proposal
+	// (POST /proposals/{proposalId}:approve)
+	ApproveProposal(ctx context.Context, request ApproveProposalRequestObject) (ApproveProposalResponseObject, error)
+	// Reject a proposal
+	// (POST /proposals/{proposalId}:reject)
+	RejectProposal(ctx context.Context, request RejectProposalRequestObject) (RejectProposalResponseObject, error)
+	// List webhooks
+	// (GET /webhooks)
+	ListWebhooks(ctx context.Context, request ListWebhooksRequestObject) (ListWebhooksResponseObject, error)
+	// Create a new webhook
+	// (POST /webhooks)
+	CreateWebhook(ctx context.Context, request CreateWebhookRequestObject) (CreateWebhookResponseObject, error)
+	// Delete a webhook
+	// (DELETE /webhooks/{webhookId})
+	DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error)
+	// Get a webhook
+	// (GET /webhooks/{webhookId})
+	GetWebhook(ctx context.Context, request GetWebhookRequestObject) (GetWebhookResponseObject, error)
+	// Update a webhook
+	// (PATCH /webhooks/{webhookId})
+	UpdateWebhook(ctx context.Context, request UpdateWebhookRequestObject) (UpdateWebhookResponseObject, error)
+	// List the deliveries of a webhook
+	// (GET /webhooks/{webhookId}/deliveries)
+	ListWebhookDeliveries(ctx context.Context, request ListWebhookDeliveriesRequestObject) (ListWebhookDeliveriesResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetHealth operation middleware
+func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
+	var request GetHealthRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealth(ctx, request.(GetHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealth")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
+		if err := validResponse.VisitGetHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListLibraries operation middleware
+func (sh *strictHandler) ListLibraries(w http.ResponseWriter, r *http.Request) {
+	var request ListLibrariesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListLibraries(ctx, request.(ListLibrariesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListLibraries")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListLibrariesResponseObject); ok {
+		if err := validResponse.VisitListLibrariesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateLibrary operation middleware
+func (sh *strictHandler) CreateLibrary(w http.ResponseWriter, r *http.Request, params CreateLibraryParams) {
+	var request CreateLibraryRequestObject
+
+	request.Params = params
+
+	var body CreateLibraryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateLibrary(ctx, request.(CreateLibraryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateLibrary")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateLibraryResponseObject); ok {
+		if err := validResponse.VisitCreateLibraryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteLibrary operation middleware
+func (sh *strictHandler) DeleteLibrary(w http.ResponseWriter, r *http.Request, libraryId LibraryIdPath) {
+	var request DeleteLibraryRequestObject
+
+	request.LibraryId = libraryId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteLibrary(ctx, request.(DeleteLibraryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteLibrary")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteLibraryResponseObject); ok {
+		if err := validResponse.VisitDeleteLibraryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLibrary operation middleware
+func (sh *strictHandler) GetLibrary(w http.ResponseWriter, r *http.Request, libraryId LibraryIdPath) {
+	var request GetLibraryRequestObject
+
+	request.LibraryId = libraryId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLibrary(ctx, request.(GetLibraryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLibrary")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLibraryResponseObject); ok {
+		if err := validResponse.VisitGetLibraryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateLibrary operation middleware
+func (sh *strictHandler) UpdateLibrary(w http.ResponseWriter, r *http.Request, libraryId LibraryIdPath) {
+	var request UpdateLibraryRequestObject
+
+	request.LibraryId = libraryId
+
+	var body UpdateLibraryApplicationMergePatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateLibrary(ctx, request.(UpdateLibraryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateLibrary")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateLibraryResponseObject); ok {
+		if err := validResponse.VisitUpdateLibraryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListPolicies operation middleware
+func (sh *strictHandler) ListPolicies(w http.ResponseWriter, r *http.Request, params ListPoliciesParams) {
+	var request ListPoliciesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPolicies(ctx, request.(ListPoliciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPolicies")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPoliciesResponseObject); ok {
+		if err := validResponse.VisitListPoliciesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreatePolicy operation middleware
+func (sh *strictHandler) CreatePolicy(w http.ResponseWriter, r *http.Request, params CreatePolicyParams) {
+	var request CreatePolicyRequestObject
+
+	request.Params = params
+
+	var body CreatePolicyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreatePolicy(ctx, request.(CreatePolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreatePolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreatePolicyResponseObject); ok {
+		if err := validResponse.VisitCreatePolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeletePolicy operation middleware
+func (sh *strictHandler) DeletePolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath) {
+	var request DeletePolicyRequestObject
+
+	request.PolicyId = policyId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeletePolicy(ctx, request.(DeletePolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeletePolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeletePolicyResponseObject); ok {
+		if err := validResponse.VisitDeletePolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPolicy operation middleware
+func (sh *strictHandler) GetPolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath) {
+	var request GetPolicyRequestObject
+
+	request.PolicyId = policyId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPolicy(ctx, request.(GetPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPolicyResponseObject); ok {
+		if err := validResponse.VisitGetPolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdatePolicy operation middleware
+func (sh *strictHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath, params UpdatePolicyParams) {
+	var request UpdatePolicyRequestObject
+
+	request.PolicyId = policyId
+	request.Params = params
+
+	var body UpdatePolicyApplicationMergePatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdatePolicy(ctx, request.(UpdatePolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdatePolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdatePolicyResponseObject); ok {
+		if err := validResponse.VisitUpdatePolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// TestPolicy operation middleware
+func (sh *strictHandler) TestPolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath) {
+	var request TestPolicyRequestObject
+
+	request.PolicyId = policyId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.TestPolicy(ctx, request.(TestPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "TestPolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(TestPolicyResponseObject); ok {
+		if err := validResponse.VisitTestPolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// BatchCreatePolicies operation middleware
+func (sh *strictHandler) BatchCreatePolicies(w http.ResponseWriter, r *http.Request, params BatchCreatePoliciesParams) {
+	var request BatchCreatePoliciesRequestObject
+
+	request.Params = params
+
+	var body BatchCreatePoliciesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.BatchCreatePolicies(ctx, request.(BatchCreatePoliciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BatchCreatePolicies")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(BatchCreatePoliciesResponseObject); ok {
+		if err := validResponse.VisitBatchCreatePoliciesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// BatchDeletePolicies operation middleware
+func (sh *strictHandler) BatchDeletePolicies(w http.ResponseWriter, r *http.Request) {
+	var request BatchDeletePoliciesRequestObject
+
+	var body BatchDeletePoliciesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.BatchDeletePolicies(ctx, request.(BatchDeletePoliciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BatchDeletePolicies")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(BatchDeletePoliciesResponseObject); ok {
+		if err := validResponse.VisitBatchDeletePoliciesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// BatchUpdatePolicies operation middleware
+func (sh *strictHandler) BatchUpdatePolicies(w http.ResponseWriter, r *http.Request, params BatchUpdatePoliciesParams) {
+	var request BatchUpdatePoliciesRequestObject
+
+	request.Params = params
+
+	var body BatchUpdatePoliciesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.BatchUpdatePolicies(ctx, request.(BatchUpdatePoliciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BatchUpdatePolicies")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(BatchUpdatePoliciesResponseObject); ok {
+		if err := validResponse.VisitBatchUpdatePoliciesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExportPolicyBundle operation middleware
+func (sh *strictHandler) ExportPolicyBundle(w http.ResponseWriter, r *http.Request) {
+	var request ExportPolicyBundleRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportPolicyBundle(ctx, request.(ExportPolicyBundleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportPolicyBundle")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportPolicyBundleResponseObject); ok {
+		if err := validResponse.VisitExportPolicyBundleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ImportPolicyBundle operation middleware
+func (sh *strictHandler) ImportPolicyBundle(w http.ResponseWriter, r *http.Request) {
+	var request ImportPolicyBundleRequestObject
+
+	request.Body = r.Body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportPolicyBundle(ctx, request.(ImportPolicyBundleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportPolicyBundle")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ImportPolicyBundleResponseObject); ok {
+		if err := validResponse.VisitImportPolicyBundleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReorderPolicies operation middleware
+func (sh *strictHandler) ReorderPolicies(w http.ResponseWriter, r *http.Request) {
+	var request ReorderPoliciesRequestObject
+
+	var body ReorderPoliciesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReorderPolicies(ctx, request.(ReorderPoliciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReorderPolicies")
+	}
 
-func (response GetPolicy500JSONResponse) VisitGetPolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReorderPoliciesResponseObject); ok {
+		if err := validResponse.VisitReorderPoliciesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type UpdatePolicyRequestObject struct {
-	PolicyId PolicyIdPath `json:"policyId"`
-	Body     *UpdatePolicyApplicationMergePatchPlusJSONRequestBody
-}
+// WatchPolicies operation middleware
+func (sh *strictHandler) WatchPolicies(w http.ResponseWriter, r *http.Request, params WatchPoliciesParams) {
+	var request WatchPoliciesRequestObject
 
-type UpdatePolicyResponseObject interface {
-	VisitUpdatePolicyResponse(w http.ResponseWriter) error
-}
+	request.Params = params
 
-type UpdatePolicy200JSONResponse Policy
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.WatchPolicies(ctx, request.(WatchPoliciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "WatchPolicies")
+	}
 
-func (response UpdatePolicy200JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(WatchPoliciesResponseObject); ok {
+		if err := validResponse.VisitWatchPoliciesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type UpdatePolicy400JSONResponse struct{ BadRequestJSONResponse }
-
-func (response UpdatePolicy400JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// ListProposals operation middleware
+func (sh *strictHandler) ListProposals(w http.ResponseWriter, r *http.Request, params ListProposalsParams) {
+	var request ListProposalsRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Params = params
 
-type UpdatePolicy401JSONResponse struct{ UnauthorizedJSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListProposals(ctx, request.(ListProposalsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListProposals")
+	}
 
-func (response UpdatePolicy401JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListProposalsResponseObject); ok {
+		if err := validResponse.VisitListProposalsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type UpdatePolicy403JSONResponse struct{ ForbiddenJSONResponse }
-
-func (response UpdatePolicy403JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+// CreateProposal operation middleware
+func (sh *strictHandler) CreateProposal(w http.ResponseWriter, r *http.Request) {
+	var request CreateProposalRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body CreateProposalJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type UpdatePolicy404JSONResponse struct{ NotFoundJSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateProposal(ctx, request.(CreateProposalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateProposal")
+	}
 
-func (response UpdatePolicy404JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateProposalResponseObject); ok {
+		if err := validResponse.VisitCreateProposalResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type UpdatePolicy409JSONResponse struct{ AlreadyExistsJSONResponse }
+// GetProposal operation middleware
+func (sh *strictHandler) GetProposal(w http.ResponseWriter, r *http.Request, proposalId ProposalIdPath) {
+	var request GetProposalRequestObject
 
-func (response UpdatePolicy409JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	request.ProposalId = proposalId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProposal(ctx, request.(GetProposalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProposal")
+	}
 
-type UpdatePolicy500JSONResponse struct {
-	InternalServerErrorJSONResponse
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProposalResponseObject); ok {
+		if err := validResponse.VisitGetProposalResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response UpdatePolicy500JSONResponse) VisitUpdatePolicyResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// ApproveProposal operation middleware
+func (sh *strictHandler) ApproveProposal(w http.ResponseWriter, r *http.Request, proposalId ProposalIdPath) {
+	var request ApproveProposalRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.ProposalId = proposalId
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Health check
-	// (GET /health)
-	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
-	// List policies
-	// (GET /policies)
-	ListPolicies(ctx context.Context, request ListPoliciesRequestObject) (ListPoliciesResponseObject, error)
-	// Create a new policy
-	// (POST /policies)
-	CreatePolicy(ctx context.Context, request CreatePolicyRequestObject) (CreatePolicyResponseObject, error)
-	// Delete a policy
-	// (DELETE /policies/{policyId})
-	DeletePolicy(ctx context.Context, request DeletePolicyRequestObject) (DeletePolicyResponseObject, error)
-	// Get a policy
-	// (GET /policies/{policyId})
-	GetPolicy(ctx context.Context, request GetPolicyRequestObject) (GetPolicyResponseObject, error)
-	// Update a policy
-	// (PATCH /policies/{policyId})
-	UpdatePolicy(ctx context.Context, request UpdatePolicyRequestObject) (UpdatePolicyResponseObject, error)
-}
+	var body ApproveProposalJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApproveProposal(ctx, request.(ApproveProposalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApproveProposal")
+	}
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApproveProposalResponseObject); ok {
+		if err := validResponse.VisitApproveProposalResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+// RejectProposal operation middleware
+func (sh *strictHandler) RejectProposal(w http.ResponseWriter, r *http.Request, proposalId ProposalIdPath) {
+	var request RejectProposalRequestObject
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+	request.ProposalId = proposalId
 
-// GetHealth operation middleware
-func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
-	var request GetHealthRequestObject
+	var body RejectProposalJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetHealth(ctx, request.(GetHealthRequestObject))
+		return sh.ssi.RejectProposal(ctx, request.(RejectProposalRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetHealth")
+		handler = middleware(handler, "RejectProposal")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
-		if err := validResponse.VisitGetHealthResponse(w); err != nil {
+	} else if validResponse, ok := response.(RejectProposalResponseObject); ok {
+		if err := validResponse.VisitRejectProposalResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1075,25 +5002,23 @@ func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// ListPolicies operation middleware
-func (sh *strictHandler) ListPolicies(w http.ResponseWriter, r *http.Request, params ListPoliciesParams) {
-	var request ListPoliciesRequestObject
-
-	request.Params = params
+// ListWebhooks operation middleware
+func (sh *strictHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	var request ListWebhooksRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListPolicies(ctx, request.(ListPoliciesRequestObject))
+		return sh.ssi.ListWebhooks(ctx, request.(ListWebhooksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListPolicies")
+		handler = middleware(handler, "ListWebhooks")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListPoliciesResponseObject); ok {
-		if err := validResponse.VisitListPoliciesResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListWebhooksResponseObject); ok {
+		if err := validResponse.VisitListWebhooksResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1101,13 +5026,13 @@ func (sh *strictHandler) ListPolicies(w http.ResponseWriter, r *http.Request, pa
 	}
 }
 
-// CreatePolicy operation middleware
-func (sh *strictHandler) CreatePolicy(w http.ResponseWriter, r *http.Request, params CreatePolicyParams) {
-	var request CreatePolicyRequestObject
+// CreateWebhook operation middleware
+func (sh *strictHandler) CreateWebhook(w http.ResponseWriter, r *http.Request, params CreateWebhookParams) {
+	var request CreateWebhookRequestObject
 
 	request.Params = params
 
-	var body CreatePolicyJSONRequestBody
+	var body CreateWebhookJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1115,18 +5040,18 @@ func (sh *strictHandler) CreatePolicy(w http.ResponseWriter, r *http.Request, pa
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreatePolicy(ctx, request.(CreatePolicyRequestObject))
+		return sh.ssi.CreateWebhook(ctx, request.(CreateWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreatePolicy")
+		handler = middleware(handler, "CreateWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreatePolicyResponseObject); ok {
-		if err := validResponse.VisitCreatePolicyResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateWebhookResponseObject); ok {
+		if err := validResponse.VisitCreateWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1134,25 +5059,25 @@ func (sh *strictHandler) CreatePolicy(w http.ResponseWriter, r *http.Request, pa
 	}
 }
 
-// DeletePolicy operation middleware
-func (sh *strictHandler) DeletePolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath) {
-	var request DeletePolicyRequestObject
+// DeleteWebhook operation middleware
+func (sh *strictHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath) {
+	var request DeleteWebhookRequestObject
 
-	request.PolicyId = policyId
+	request.WebhookId = webhookId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeletePolicy(ctx, request.(DeletePolicyRequestObject))
+		return sh.ssi.DeleteWebhook(ctx, request.(DeleteWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeletePolicy")
+		handler = middleware(handler, "DeleteWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeletePolicyResponseObject); ok {
-		if err := validResponse.VisitDeletePolicyResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteWebhookResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1160,25 +5085,25 @@ func (sh *strictHandler) DeletePolicy(w http.ResponseWriter, r *http.Request, po
 	}
 }
 
-// GetPolicy operation middleware
-func (sh *strictHandler) GetPolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath) {
-	var request GetPolicyRequestObject
+// GetWebhook operation middleware
+func (sh *strictHandler) GetWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath) {
+	var request GetWebhookRequestObject
 
-	request.PolicyId = policyId
+	request.WebhookId = webhookId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetPolicy(ctx, request.(GetPolicyRequestObject))
+		return sh.ssi.GetWebhook(ctx, request.(GetWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetPolicy")
+		handler = middleware(handler, "GetWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetPolicyResponseObject); ok {
-		if err := validResponse.VisitGetPolicyResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetWebhookResponseObject); ok {
+		if err := validResponse.VisitGetWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1186,13 +5111,13 @@ func (sh *strictHandler) GetPolicy(w http.ResponseWriter, r *http.Request, polic
 	}
 }
 
-// UpdatePolicy operation middleware
-func (sh *strictHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request, policyId PolicyIdPath) {
-	var request UpdatePolicyRequestObject
+// UpdateWebhook operation middleware
+func (sh *strictHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath) {
+	var request UpdateWebhookRequestObject
 
-	request.PolicyId = policyId
+	request.WebhookId = webhookId
 
-	var body UpdatePolicyApplicationMergePatchPlusJSONRequestBody
+	var body UpdateWebhookApplicationMergePatchPlusJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1200,18 +5125,44 @@ func (sh *strictHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request, po
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdatePolicy(ctx, request.(UpdatePolicyRequestObject))
+		return sh.ssi.UpdateWebhook(ctx, request.(UpdateWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdatePolicy")
+		handler = middleware(handler, "UpdateWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UpdatePolicyResponseObject); ok {
-		if err := validResponse.VisitUpdatePolicyResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateWebhookResponseObject); ok {
+		if err := validResponse.VisitUpdateWebhookResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListWebhookDeliveries operation middleware
+func (sh *strictHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId WebhookIdPath) {
+	var request ListWebhookDeliveriesRequestObject
+
+	request.WebhookId = webhookId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWebhookDeliveries(ctx, request.(ListWebhookDeliveriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWebhookDeliveries")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListWebhookDeliveriesResponseObject); ok {
+		if err := validResponse.VisitListWebhookDeliveriesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -14,6 +14,8 @@ import (
 	"github.com/dcm-project/policy-manager/internal/api/server"
 	"github.com/dcm-project/policy-manager/internal/config"
 	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/metrics"
+	"github.com/dcm-project/policy-manager/internal/principal"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
@@ -41,6 +43,8 @@ func (s *Server) Run(ctx context.Context) error {
 	router := chi.NewRouter()
 	router.Use(middleware.RequestID)
 	router.Use(logging.RequestLogger)
+	router.Use(metrics.HTTPMiddleware(metrics.ServerAPI))
+	router.Use(principal.Middleware(s.config.Service.PrincipalHeader))
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
 
@@ -56,7 +60,7 @@ func (s *Server) Run(ctx context.Context) error {
 
 	// Mount the generated handler with base URL from OpenAPI spec
 	server.HandlerFromMuxWithBaseURL(
-		server.NewStrictHandler(s.handler, nil),
+		server.NewStrictHandler(s.handler, []server.StrictMiddlewareFunc{metrics.OperationMiddleware}),
 		router,
 		baseURL,
 	)
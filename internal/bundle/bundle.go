@@ -0,0 +1,323 @@
+// Package bundle reads and writes OPA bundles carrying DCM policy metadata.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	opabundle "github.com/open-policy-agent/opa/v1/bundle"
+)
+
+const (
+	// MetadataFile is the sidecar file holding DCM policy and library metadata, keyed by module path.
+	// OPA ignores files it does not recognise, so the sidecar does not affect `opa eval` or `opa test`.
+	MetadataFile = "dcm-metadata.json"
+
+	// MaxSizeBytes is the maximum accepted size of a compressed bundle.
+	MaxSizeBytes = 10 << 20
+
+	regoExt     = ".rego"
+	testRegoExt = "_test.rego"
+
+	// libraryDir is the directory that libraries without a Path are written to, apart from the policies
+	libraryDir = "lib/"
+)
+
+// ErrInvalidBundle indicates that the bundle archive or its metadata is malformed
+var ErrInvalidBundle = errors.New("invalid policy bundle")
+
+// PolicyMetadata describes the DCM attributes of a single bundled Rego module
+type PolicyMetadata struct {
+	ID                string            `json:"id,omitempty"`
+	DisplayName       string            `json:"display_name,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	PolicyType        string            `json:"policy_type,omitempty"`
+	Priority          *int32            `json:"priority,omitempty"`
+	Enabled           *bool             `json:"enabled,omitempty"`
+	EffectiveFrom     *time.Time        `json:"effective_from,omitempty"`
+	EffectiveUntil    *time.Time        `json:"effective_until,omitempty"`
+	RolloutPercentage *int32            `json:"rollout_percentage,omitempty"`
+	RolloutKey        string            `json:"rollout_key,omitempty"`
+	Entrypoint        string            `json:"entrypoint,omitempty"`
+	LabelSelector     map[string]string `json:"label_selector,omitempty"`
+}
+
+// LibraryMetadata describes the DCM attributes of a bundled library module
+type LibraryMetadata struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Metadata is the content of the sidecar metadata file
+type Metadata struct {
+	Policies  map[string]PolicyMetadata  `json:"policies"`
+	Libraries map[string]LibraryMetadata `json:"libraries,omitempty"`
+}
+
+// Policy is a single Rego module with its metadata
+type Policy struct {
+	Path     string // module path within the bundle, e.g. "region.rego"
+	RegoCode string
+	TestCode string // content of the test module next to the policy module, e.g. "region_test.rego"
+	Metadata PolicyMetadata
+}
+
+// Library is a single library module with its metadata
+type Library struct {
+	Path     string // module path within the bundle, e.g. "lib/regions.rego"
+	RegoCode string
+	Metadata LibraryMetadata
+}
+
+// Bundle is the DCM view of an OPA bundle
+type Bundle struct {
+	Revision  string
+	Policies  []Policy
+	Libraries []Library
+}
+
+// Read parses a gzipped OPA bundle. Every non-test Rego module must have a policy or library entry in
+// the sidecar metadata file. If an entry has no ID, the module file name (without extension) is used.
+// A test module next to a policy module (region_test.rego for region.rego) is read as its TestCode;
+// other test modules are ignored.
+func Read(r io.Reader) (*Bundle, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxSizeBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	if len(data) > MaxSizeBytes {
+		return nil, fmt.Errorf("%w: bundle exceeds %d bytes", ErrInvalidBundle, MaxSizeBytes)
+	}
+
+	// Let OPA parse the archive so that the bundle is accepted exactly as `opa` would accept it.
+	opaBundle, err := opabundle.NewReader(bytes.NewReader(data)).
+		WithRegoVersion(ast.RegoV1).
+		WithSkipBundleVerification(true).
+		Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+
+	metadata, err := readMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Bundle{Revision: opaBundle.Manifest.Revision}
+	testModules := map[string]string{}
+	for _, mf := range opaBundle.Modules {
+		modulePath := strings.TrimPrefix(mf.Path, "/")
+		if strings.HasSuffix(modulePath, testRegoExt) {
+			testModules[modulePath] = string(mf.Raw)
+			continue
+		}
+
+		if libraryMeta, ok := metadata.Libraries[modulePath]; ok {
+			if _, ok := metadata.Policies[modulePath]; ok {
+				return nil, fmt.Errorf("%w: module '%s' is both a policy and a library in %s", ErrInvalidBundle, modulePath, MetadataFile)
+			}
+			if libraryMeta.ID == "" {
+				libraryMeta.ID = strings.TrimSuffix(path.Base(modulePath), regoExt)
+			}
+			result.Libraries = append(result.Libraries, Library{
+				Path:     modulePath,
+				RegoCode: string(mf.Raw),
+				Metadata: libraryMeta,
+			})
+			continue
+		}
+
+		meta, ok := metadata.Policies[modulePath]
+		if !ok {
+			return nil, fmt.Errorf("%w: module '%s' has no entry in %s", ErrInvalidBundle, modulePath, MetadataFile)
+		}
+		if meta.ID == "" {
+			meta.ID = strings.TrimSuffix(path.Base(modulePath), regoExt)
+		}
+
+		result.Policies = append(result.Policies, Policy{
+			Path:     modulePath,
+			RegoCode: string(mf.Raw),
+			Metadata: meta,
+		})
+	}
+
+	for i := range result.Policies {
+		result.Policies[i].TestCode = testModules[TestModulePath(result.Policies[i].Path)]
+	}
+
+	for modulePath := range metadata.Policies {
+		if !hasModule(result.Policies, modulePath) {
+			return nil, fmt.Errorf("%w: %s references missing module '%s'", ErrInvalidBundle, MetadataFile, modulePath)
+		}
+	}
+	for modulePath := range metadata.Libraries {
+		if !hasLibrary(result.Libraries, modulePath) {
+			return nil, fmt.Errorf("%w: %s references missing module '%s'", ErrInvalidBundle, MetadataFile, modulePath)
+		}
+	}
+
+	sort.Slice(result.Policies, func(i, j int) bool {
+		return result.Policies[i].Path < result.Policies[j].Path
+	})
+	sort.Slice(result.Libraries, func(i, j int) bool {
+		return result.Libraries[i].Path < result.Libraries[j].Path
+	})
+
+	return result, nil
+}
+
+// readMetadata extracts the sidecar metadata file from the gzipped tar archive
+func readMetadata(data []byte) (*Metadata, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	defer func() { _ = gr.Close() }()
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+		if strings.TrimPrefix(path.Clean("/"+header.Name), "/") != MetadataFile {
+			continue
+		}
+
+		metadata := &Metadata{}
+		if err := json.NewDecoder(tr).Decode(metadata); err != nil {
+			return nil, fmt.Errorf("%w: failed to decode %s: %v", ErrInvalidBundle, MetadataFile, err)
+		}
+		return metadata, nil
+	}
+
+	return nil, fmt.Errorf("%w: missing %s", ErrInvalidBundle, MetadataFile)
+}
+
+// TestModulePath returns the path of the test module that accompanies a policy module
+func TestModulePath(modulePath string) string {
+	return strings.TrimSuffix(modulePath, regoExt) + testRegoExt
+}
+
+func hasModule(policies []Policy, modulePath string) bool {
+	for _, p := range policies {
+		if p.Path == modulePath {
+			return true
+		}
+	}
+	return false
+}
+
+func hasLibrary(libraries []Library, modulePath string) bool {
+	for _, l := range libraries {
+		if l.Path == modulePath {
+			return true
+		}
+	}
+	return false
+}
+
+// Write writes b as a gzipped OPA bundle: a .manifest, one Rego module per policy and library, a test
+// module per policy with TestCode and the sidecar metadata file. Policies without a Path are written to
+// "<id>.rego", libraries without a Path to "lib/<id>.rego".
+func Write(w io.Writer, b *Bundle) error {
+	regoVersion := ast.RegoV1.Int()
+	manifest := opabundle.Manifest{
+		Revision:    b.Revision,
+		RegoVersion: &regoVersion,
+	}
+
+	metadata := Metadata{Policies: make(map[string]PolicyMetadata, len(b.Policies))}
+	if len(b.Libraries) > 0 {
+		metadata.Libraries = make(map[string]LibraryMetadata, len(b.Libraries))
+	}
+	files := make([]tarFile, 0, len(b.Policies)+len(b.Libraries)+2)
+	modulePaths := make(map[string]bool, len(b.Policies)+len(b.Libraries))
+	for _, p := range b.Policies {
+		modulePath := p.Path
+		if modulePath == "" {
+			modulePath = p.Metadata.ID + regoExt
+		}
+		if modulePaths[modulePath] {
+			return fmt.Errorf("duplicate module path '%s'", modulePath)
+		}
+		modulePaths[modulePath] = true
+		metadata.Policies[modulePath] = p.Metadata
+		files = append(files, tarFile{name: modulePath, content: []byte(p.RegoCode)})
+		if p.TestCode != "" {
+			files = append(files, tarFile{name: TestModulePath(modulePath), content: []byte(p.TestCode)})
+		}
+	}
+	for _, l := range b.Libraries {
+		modulePath := l.Path
+		if modulePath == "" {
+			modulePath = libraryDir + l.Metadata.ID + regoExt
+		}
+		if modulePaths[modulePath] {
+			return fmt.Errorf("duplicate module path '%s'", modulePath)
+		}
+		modulePaths[modulePath] = true
+		metadata.Libraries[modulePath] = l.Metadata
+		files = append(files, tarFile{name: modulePath, content: []byte(l.RegoCode)})
+	}
+
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	metadataJSON, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", MetadataFile, err)
+	}
+	files = append(files,
+		tarFile{name: opabundle.ManifestExt, content: manifestJSON},
+		tarFile{name: MetadataFile, content: metadataJSON},
+	)
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	modTime := time.Now().UTC()
+	for _, f := range files {
+		header := &tar.Header{
+			Name:     "/" + f.name,
+			Mode:     0o644,
+			Size:     int64(len(f.content)),
+			ModTime:  modTime,
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+		if _, err := tw.Write(f.content); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
+
+type tarFile struct {
+	name    string
+	content []byte
+}
@@ -0,0 +1,276 @@
+package bundle_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dcm-project/policy-manager/internal/bundle"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	opabundle "github.com/open-policy-agent/opa/v1/bundle"
+	"github.com/open-policy-agent/opa/v1/rego"
+)
+
+// buildArchive writes the given files into a gzipped tar archive
+func buildArchive(files map[string]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		Expect(tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		})).To(Succeed())
+		_, err := tw.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	Expect(gw.Close()).To(Succeed())
+	return &buf
+}
+
+func int32Ptr(i int32) *int32 { return &i }
+
+var _ = Describe("Bundle", func() {
+	Describe("Read", func() {
+		It("reads modules with their sidecar metadata", func() {
+			archive := buildArchive(map[string]string{
+				"/.manifest":        `{"revision": "rev-1"}`,
+				"/region.rego":      "package policies.region\nmain := {\"rejected\": false}",
+				"/region_test.rego": "package policies.region_test\ntest_ok if true",
+				"/dcm-metadata.json": `{"policies": {"region.rego": {
+					"id": "region-enforcement",
+					"display_name": "Region Enforcement",
+					"policy_type": "GLOBAL",
+					"priority": 100,
+					"label_selector": {"environment": "production"}
+				}}}`,
+			})
+
+			b, err := bundle.Read(archive)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Revision).To(Equal("rev-1"))
+			Expect(b.Policies).To(HaveLen(1))
+			Expect(b.Policies[0].Path).To(Equal("region.rego"))
+			Expect(b.Policies[0].RegoCode).To(ContainSubstring("package policies.region"))
+			Expect(b.Policies[0].Metadata.ID).To(Equal("region-enforcement"))
+			Expect(b.Policies[0].Metadata.PolicyType).To(Equal("GLOBAL"))
+			Expect(*b.Policies[0].Metadata.Priority).To(Equal(int32(100)))
+			Expect(b.Policies[0].Metadata.LabelSelector).To(HaveKeyWithValue("environment", "production"))
+			Expect(b.Policies[0].TestCode).To(Equal("package policies.region_test\ntest_ok if true"))
+		})
+
+		It("ignores test modules without a policy module", func() {
+			archive := buildArchive(map[string]string{
+				"/region.rego":       "package policies.region\nmain := {\"rejected\": false}",
+				"/shared_test.rego":  "package shared_test\ntest_ok if true",
+				"/dcm-metadata.json": `{"policies": {"region.rego": {"display_name": "Region", "policy_type": "GLOBAL"}}}`,
+			})
+
+			b, err := bundle.Read(archive)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Policies).To(HaveLen(1))
+			Expect(b.Policies[0].TestCode).To(BeEmpty())
+		})
+
+		It("defaults the ID to the module file name", func() {
+			archive := buildArchive(map[string]string{
+				"/policies/quota.rego": "package policies.quota\nmain := {\"rejected\": false}",
+				"/dcm-metadata.json":   `{"policies": {"policies/quota.rego": {"display_name": "Quota", "policy_type": "USER"}}}`,
+			})
+
+			b, err := bundle.Read(archive)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Policies).To(HaveLen(1))
+			Expect(b.Policies[0].Metadata.ID).To(Equal("quota"))
+		})
+
+		It("reads library modules with their metadata", func() {
+			archive := buildArchive(map[string]string{
+				"/region.rego":       "package policies.region\nimport data.lib.regions\nmain := {\"rejected\": regions.allowed}",
+				"/lib/regions.rego":  "package lib.regions\nallowed := true",
+				"/dcm-metadata.json": `{"policies": {"region.rego": {"display_name": "Region", "policy_type": "GLOBAL"}}, "libraries": {"lib/regions.rego": {"display_name": "Regions"}}}`,
+			})
+
+			b, err := bundle.Read(archive)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Policies).To(HaveLen(1))
+			Expect(b.Libraries).To(HaveLen(1))
+			Expect(b.Libraries[0].Path).To(Equal("lib/regions.rego"))
+			Expect(b.Libraries[0].RegoCode).To(ContainSubstring("package lib.regions"))
+			Expect(b.Libraries[0].Metadata.ID).To(Equal("regions"))
+			Expect(b.Libraries[0].Metadata.DisplayName).To(Equal("Regions"))
+		})
+
+		It("rejects a module that is both a policy and a library", func() {
+			archive := buildArchive(map[string]string{
+				"/regions.rego":      "package lib.regions\nallowed := true",
+				"/dcm-metadata.json": `{"policies": {"regions.rego": {}}, "libraries": {"regions.rego": {}}}`,
+			})
+
+			_, err := bundle.Read(archive)
+			Expect(errors.Is(err, bundle.ErrInvalidBundle)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("both a policy and a library"))
+		})
+
+		It("rejects a bundle without the metadata file", func() {
+			archive := buildArchive(map[string]string{
+				"/region.rego": "package policies.region\nmain := {\"rejected\": false}",
+			})
+
+			_, err := bundle.Read(archive)
+			Expect(errors.Is(err, bundle.ErrInvalidBundle)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("missing dcm-metadata.json"))
+		})
+
+		It("rejects a module without metadata", func() {
+			archive := buildArchive(map[string]string{
+				"/region.rego":       "package policies.region\nmain := {\"rejected\": false}",
+				"/dcm-metadata.json": `{"policies": {}}`,
+			})
+
+			_, err := bundle.Read(archive)
+			Expect(errors.Is(err, bundle.ErrInvalidBundle)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("region.rego"))
+		})
+
+		It("rejects metadata for a missing module", func() {
+			archive := buildArchive(map[string]string{
+				"/dcm-metadata.json": `{"policies": {"missing.rego": {"display_name": "Missing"}}}`,
+			})
+
+			_, err := bundle.Read(archive)
+			Expect(errors.Is(err, bundle.ErrInvalidBundle)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("missing.rego"))
+		})
+
+		It("rejects invalid Rego", func() {
+			archive := buildArchive(map[string]string{
+				"/bad.rego":          "package bad\n{invalid",
+				"/dcm-metadata.json": `{"policies": {"bad.rego": {}}}`,
+			})
+
+			_, err := bundle.Read(archive)
+			Expect(errors.Is(err, bundle.ErrInvalidBundle)).To(BeTrue())
+		})
+
+		It("rejects data that is not a gzipped archive", func() {
+			_, err := bundle.Read(strings.NewReader("not a bundle"))
+			Expect(errors.Is(err, bundle.ErrInvalidBundle)).To(BeTrue())
+		})
+	})
+
+	Describe("Write", func() {
+		var b *bundle.Bundle
+
+		BeforeEach(func() {
+			b = &bundle.Bundle{
+				Revision: "rev-2",
+				Policies: []bundle.Policy{
+					{
+						RegoCode: "package policies.region\nmain := {\"rejected\": false, \"patch\": {\"region\": \"us-east-1\"}}",
+						Metadata: bundle.PolicyMetadata{
+							ID:          "region",
+							DisplayName: "Region",
+							PolicyType:  "GLOBAL",
+							Priority:    int32Ptr(10),
+						},
+					},
+					{
+						RegoCode: "package policies.quota\nmain := {\"rejected\": true} if input.spec.cpu > 8",
+						Metadata: bundle.PolicyMetadata{
+							ID:          "quota",
+							DisplayName: "Quota",
+							PolicyType:  "USER",
+							Priority:    int32Ptr(20),
+						},
+					},
+				},
+			}
+		})
+
+		It("round-trips through Read", func() {
+			var buf bytes.Buffer
+			Expect(bundle.Write(&buf, b)).To(Succeed())
+
+			read, err := bundle.Read(&buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Revision).To(Equal("rev-2"))
+			Expect(read.Policies).To(HaveLen(2))
+			Expect(read.Policies[0].Path).To(Equal("quota.rego"))
+			Expect(read.Policies[0].Metadata.ID).To(Equal("quota"))
+			Expect(read.Policies[1].Path).To(Equal("region.rego"))
+			Expect(read.Policies[1].RegoCode).To(Equal(b.Policies[0].RegoCode))
+			Expect(*read.Policies[1].Metadata.Priority).To(Equal(int32(10)))
+		})
+
+		It("writes test modules next to their policy modules", func() {
+			b.Policies[0].TestCode = "package policies.region_test\n\nimport data.policies.region\n\ntest_not_rejected if not region.main.rejected"
+
+			var buf bytes.Buffer
+			Expect(bundle.Write(&buf, b)).To(Succeed())
+			data := buf.Bytes()
+
+			opaBundle, err := opabundle.NewReader(bytes.NewReader(data)).Read()
+			Expect(err).NotTo(HaveOccurred())
+			var paths []string
+			for _, m := range opaBundle.Modules {
+				paths = append(paths, m.Path)
+			}
+			Expect(paths).To(ConsistOf("/quota.rego", "/region.rego", "/region_test.rego"))
+
+			read, err := bundle.Read(bytes.NewReader(data))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Policies[0].TestCode).To(BeEmpty())
+			Expect(read.Policies[1].TestCode).To(Equal(b.Policies[0].TestCode))
+		})
+
+		It("produces a bundle that OPA can evaluate", func() {
+			var buf bytes.Buffer
+			Expect(bundle.Write(&buf, b)).To(Succeed())
+
+			opaBundle, err := opabundle.NewReader(&buf).Read()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(opaBundle.Manifest.Revision).To(Equal("rev-2"))
+
+			rs, err := rego.New(
+				rego.Query("data.policies.region.main"),
+				rego.ParsedBundle("export", &opaBundle),
+			).Eval(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rs).To(HaveLen(1))
+			Expect(rs[0].Expressions[0].Value).To(HaveKeyWithValue("patch", map[string]any{"region": "us-east-1"}))
+		})
+
+		It("writes libraries apart from the policies", func() {
+			b.Libraries = []bundle.Library{{
+				RegoCode: "package lib.region\nallowed := true",
+				Metadata: bundle.LibraryMetadata{ID: "region", DisplayName: "Regions"},
+			}}
+
+			var buf bytes.Buffer
+			Expect(bundle.Write(&buf, b)).To(Succeed())
+
+			read, err := bundle.Read(&buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read.Policies).To(HaveLen(2))
+			Expect(read.Libraries).To(HaveLen(1))
+			Expect(read.Libraries[0].Path).To(Equal("lib/region.rego"))
+			Expect(read.Libraries[0].RegoCode).To(Equal(b.Libraries[0].RegoCode))
+			Expect(read.Libraries[0].Metadata).To(Equal(b.Libraries[0].Metadata))
+		})
+
+		It("rejects duplicate module paths", func() {
+			b.Policies[1].Metadata.ID = "region"
+
+			var buf bytes.Buffer
+			Expect(bundle.Write(&buf, b)).To(MatchError(ContainSubstring("duplicate module path")))
+		})
+	})
+})
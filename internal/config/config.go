@@ -1,13 +1,20 @@
 // Package config provides application configuration loaded from environment variables.
 package config
 
-import "github.com/kelseyhightower/envconfig"
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
 
 // ServiceConfig holds service-level configuration
 type ServiceConfig struct {
-	BindAddress       string `envconfig:"BIND_ADDRESS" default:"0.0.0.0:8080"`
-	EngineBindAddress string `envconfig:"ENGINE_BIND_ADDRESS" default:"0.0.0.0:8081"`
-	LogLevel          string `envconfig:"LOG_LEVEL" default:"info"`
+	BindAddress        string `envconfig:"BIND_ADDRESS" default:"0.0.0.0:8080"`
+	EngineBindAddress  string `envconfig:"ENGINE_BIND_ADDRESS" default:"0.0.0.0:8081"`
+	MetricsBindAddress string `envconfig:"METRICS_BIND_ADDRESS" default:"0.0.0.0:9090"`
+	LogLevel           string `envconfig:"LOG_LEVEL" default:"info"`
+	PrincipalHeader    string `envconfig:"PRINCIPAL_HEADER" default:"X-Forwarded-User"`
+	RequireApproval    bool   `envconfig:"REQUIRE_APPROVAL" default:"false"`
 }
 
 // DBConfig holds database configuration
@@ -20,10 +27,62 @@ type DBConfig struct {
 	Password string `envconfig:"DB_PASSWORD" default:"adminpass"`
 }
 
+// PolicyDirConfig holds the configuration of directory-sourced (GitOps) policies
+type PolicyDirConfig struct {
+	Path         string        `envconfig:"POLICY_DIR"`
+	PollInterval time.Duration `envconfig:"POLICY_DIR_POLL_INTERVAL" default:"10s"`
+}
+
+// WebhookConfig holds the configuration of webhook event delivery
+type WebhookConfig struct {
+	MaxAttempts  int           `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"6"`
+	RetryBackoff time.Duration `envconfig:"WEBHOOK_RETRY_BACKOFF" default:"10s"`
+	Timeout      time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+	PollInterval time.Duration `envconfig:"WEBHOOK_POLL_INTERVAL" default:"1s"`
+}
+
+// SyncConfig holds the configuration of engine synchronization between replicas
+type SyncConfig struct {
+	PollInterval time.Duration `envconfig:"ENGINE_SYNC_POLL_INTERVAL" default:"5s"`
+}
+
+// EngineConfig holds the configuration of the embedded OPA engine
+type EngineConfig struct {
+	ProviderCatalog  string   `envconfig:"PROVIDER_CATALOG"`
+	CapabilitiesFile string   `envconfig:"ENGINE_CAPABILITIES_FILE"`
+	AllowedBuiltins  []string `envconfig:"ENGINE_ALLOWED_BUILTINS"`
+}
+
+// EvaluationConfig holds the limits of policy evaluation
+type EvaluationConfig struct {
+	PolicyTimeout time.Duration `envconfig:"EVALUATION_POLICY_TIMEOUT" default:"1s"`
+	Timeout       time.Duration `envconfig:"EVALUATION_TIMEOUT" default:"5s"`
+}
+
+// DecisionLogConfig holds the configuration of decision logging
+type DecisionLogConfig struct {
+	Sink           string        `envconfig:"DECISION_LOG_SINK"`
+	FilePath       string        `envconfig:"DECISION_LOG_FILE"`
+	FileMaxSize    int64         `envconfig:"DECISION_LOG_FILE_MAX_SIZE" default:"104857600"`
+	FileMaxBackups int           `envconfig:"DECISION_LOG_FILE_MAX_BACKUPS" default:"5"`
+	URL            string        `envconfig:"DECISION_LOG_URL"`
+	Timeout        time.Duration `envconfig:"DECISION_LOG_TIMEOUT" default:"10s"`
+	BatchSize      int           `envconfig:"DECISION_LOG_BATCH_SIZE" default:"100"`
+	BufferSize     int           `envconfig:"DECISION_LOG_BUFFER_SIZE" default:"10000"`
+	FlushInterval  time.Duration `envconfig:"DECISION_LOG_FLUSH_INTERVAL" default:"1s"`
+	MaskedPaths    []string      `envconfig:"DECISION_LOG_MASK"`
+}
+
 // Config is the root configuration structure
 type Config struct {
-	Service  ServiceConfig
-	Database *DBConfig
+	Service     ServiceConfig
+	Database    *DBConfig
+	PolicyDir   PolicyDirConfig
+	Webhook     WebhookConfig
+	Sync        SyncConfig
+	Engine      EngineConfig
+	Evaluation  EvaluationConfig
+	DecisionLog DecisionLogConfig
 }
 
 // Load reads configuration from environment variables
@@ -37,5 +96,23 @@ func Load() (*Config, error) {
 	if err := envconfig.Process("", cfg.Database); err != nil {
 		return nil, err
 	}
+	if err := envconfig.Process("", &cfg.PolicyDir); err != nil {
+		return nil, err
+	}
+	if err := envconfig.Process("", &cfg.Webhook); err != nil {
+		return nil, err
+	}
+	if err := envconfig.Process("", &cfg.Sync); err != nil {
+		return nil, err
+	}
+	if err := envconfig.Process("", &cfg.Engine); err != nil {
+		return nil, err
+	}
+	if err := envconfig.Process("", &cfg.Evaluation); err != nil {
+		return nil, err
+	}
+	if err := envconfig.Process("", &cfg.DecisionLog); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
@@ -0,0 +1,13 @@
+package decisionlog_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDecisionLog(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Decision Log Suite")
+}
@@ -0,0 +1,28 @@
+// Package decisionlog writes a decision log entry for every policy evaluation, in the format of OPA
+// decision logs, to stdout, a rotating file or an HTTP endpoint.
+package decisionlog
+
+import "time"
+
+// Event is a decision log entry. Its fields are those of OPA decision log events, so that tools
+// ingesting OPA decision logs can ingest it, with the ID of the HTTP request the evaluation was made
+// for in request_id.
+type Event struct {
+	Labels     map[string]string `json:"labels,omitempty"`
+	DecisionID string            `json:"decision_id"`
+	// Path is the path of the evaluated rule in the data document, e.g. policies/region/main
+	Path      string         `json:"path"`
+	Input     any            `json:"input,omitempty"`
+	Result    any            `json:"result,omitempty"`
+	Error     *EventError    `json:"error,omitempty"`
+	Erased    []string       `json:"erased,omitempty"`
+	Metrics   map[string]any `json:"metrics,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// EventError is the error an evaluation failed with
+type EventError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
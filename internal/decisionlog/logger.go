@@ -0,0 +1,160 @@
+package decisionlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// shutdownTimeout bounds the final flush of buffered events on shutdown
+const shutdownTimeout = 10 * time.Second
+
+// Logger buffers decision log events and writes them to a sink in batches. Logging never blocks an
+// evaluation: when the buffer is full, events are dropped and the number of dropped events is logged.
+type Logger struct {
+	sink          Sink
+	masks         []maskPath
+	labels        map[string]string
+	batchSize     int
+	bufferSize    int
+	flushInterval time.Duration
+	events        chan json.RawMessage
+	dropped       atomic.Int64
+}
+
+// Options configures a Logger
+type Options struct {
+	// Labels are added to every event, e.g. the ID of the instance
+	Labels map[string]string
+	// MaskedPaths are erased from every event, e.g. /input/spec/credentials
+	MaskedPaths []string
+	// BatchSize is the maximum number of events written to the sink at once
+	BatchSize int
+	// BufferSize is the maximum number of events waiting to be written
+	BufferSize int
+	// FlushInterval is how often buffered events are written, and failed writes retried
+	FlushInterval time.Duration
+}
+
+// NewLogger creates a Logger writing to sink. Events are only written while Run is running.
+func NewLogger(sink Sink, opts Options) (*Logger, error) {
+	if opts.BatchSize <= 0 || opts.BufferSize <= 0 || opts.FlushInterval <= 0 {
+		return nil, fmt.Errorf("decision log batch size, buffer size and flush interval must be positive")
+	}
+	l := &Logger{
+		sink:          sink,
+		labels:        opts.Labels,
+		batchSize:     opts.BatchSize,
+		bufferSize:    opts.BufferSize,
+		flushInterval: opts.FlushInterval,
+		events:        make(chan json.RawMessage, opts.BufferSize),
+	}
+	for _, path := range opts.MaskedPaths {
+		p, err := parseMaskPath(path)
+		if err != nil {
+			return nil, err
+		}
+		l.masks = append(l.masks, p)
+	}
+	return l, nil
+}
+
+// Log masks and buffers an event. The request ID is taken from ctx when the event has none.
+func (l *Logger) Log(ctx context.Context, event Event) {
+	if event.RequestID == "" {
+		event.RequestID = middleware.GetReqID(ctx)
+	}
+	if event.Labels == nil {
+		event.Labels = l.labels
+	}
+	mask(&event, l.masks)
+
+	// Encode now: the input and result may be modified once the evaluation goes on
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		slog.Warn("Failed to encode decision log event", "decision_id", event.DecisionID, "error", err)
+		return
+	}
+	select {
+	case l.events <- encoded:
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+// Run writes the buffered events until ctx is cancelled, then writes the remaining ones. Failed writes
+// are logged and retried on the next flush, dropping the oldest events beyond the buffer size.
+func (l *Logger) Run(ctx context.Context) error {
+	slog.Info("Writing decision logs", "batch_size", l.batchSize, "flush_interval", l.flushInterval)
+	ticker := time.NewTicker(l.flushInterval)
+	defer ticker.Stop()
+
+	var pending []json.RawMessage
+	failing := false
+	for {
+		select {
+		case <-ctx.Done():
+			pending = l.drain(pending)
+			flushCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			pending, _ = l.flush(flushCtx, pending)
+			cancel()
+			if len(pending) > 0 {
+				slog.Warn("Decision log events lost on shutdown", "count", len(pending))
+			}
+			if closer, ok := l.sink.(io.Closer); ok {
+				if err := closer.Close(); err != nil {
+					slog.Warn("Failed to close decision log sink", "error", err)
+				}
+			}
+			slog.Info("Decision logging stopped")
+			return nil
+		case event := <-l.events:
+			pending = append(pending, event)
+			// After a failed write, wait for the next tick rather than retrying on every event
+			if len(pending) >= l.batchSize && !failing {
+				pending, failing = l.flush(ctx, pending)
+			}
+		case <-ticker.C:
+			pending, failing = l.flush(ctx, pending)
+		}
+	}
+}
+
+// drain appends the events waiting in the channel to pending
+func (l *Logger) drain(pending []json.RawMessage) []json.RawMessage {
+	for {
+		select {
+		case event := <-l.events:
+			pending = append(pending, event)
+		default:
+			return pending
+		}
+	}
+}
+
+// flush writes the pending events in batches and returns the events left unwritten, and whether a
+// write failed
+func (l *Logger) flush(ctx context.Context, pending []json.RawMessage) ([]json.RawMessage, bool) {
+	if dropped := l.dropped.Swap(0); dropped > 0 {
+		slog.Warn("Decision log buffer full, events dropped", "count", dropped)
+	}
+	for len(pending) > 0 {
+		batch := pending[:min(l.batchSize, len(pending))]
+		if err := l.sink.Write(ctx, batch); err != nil {
+			slog.Warn("Failed to write decision logs", "count", len(batch), "error", err)
+			if excess := len(pending) - l.bufferSize; excess > 0 {
+				slog.Warn("Decision log buffer full, events dropped", "count", excess)
+				pending = pending[excess:]
+			}
+			return pending, true
+		}
+		pending = pending[len(batch):]
+	}
+	return nil, false
+}
@@ -0,0 +1,190 @@
+package decisionlog_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/decisionlog"
+	"github.com/go-chi/chi/v5/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// recordingSink records the written batches, failing while err is set
+type recordingSink struct {
+	mu      sync.Mutex
+	err     error
+	batches [][]json.RawMessage
+}
+
+func (s *recordingSink) Write(_ context.Context, events []json.RawMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.batches = append(s.batches, append([]json.RawMessage(nil), events...))
+	return nil
+}
+
+func (s *recordingSink) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// events returns the decoded events written so far
+func (s *recordingSink) events() []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []map[string]any
+	for _, batch := range s.batches {
+		for _, raw := range batch {
+			var event map[string]any
+			Expect(json.Unmarshal(raw, &event)).To(Succeed())
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func (s *recordingSink) batchSizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sizes []int
+	for _, batch := range s.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+var _ = Describe("Logger", func() {
+	var (
+		sink *recordingSink
+		opts decisionlog.Options
+	)
+
+	BeforeEach(func() {
+		sink = &recordingSink{}
+		opts = decisionlog.Options{BatchSize: 10, BufferSize: 100, FlushInterval: 20 * time.Millisecond}
+	})
+
+	// run runs the logger until the returned function is called
+	run := func(logger *decisionlog.Logger) (stop func()) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			Expect(logger.Run(ctx)).To(Succeed())
+		}()
+		return func() {
+			cancel()
+			<-done
+		}
+	}
+
+	event := func(id string, input map[string]any) decisionlog.Event {
+		return decisionlog.Event{
+			DecisionID: id,
+			Path:       "policies/region/main",
+			Input:      input,
+			Result:     map[string]any{"rejected": false},
+			Timestamp:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+	}
+
+	It("writes events in OPA decision log format with labels and request ID", func() {
+		opts.Labels = map[string]string{"id": "instance-1"}
+		logger, err := decisionlog.NewLogger(sink, opts)
+		Expect(err).NotTo(HaveOccurred())
+		stop := run(logger)
+		defer stop()
+
+		ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "host/abc-000001")
+		logger.Log(ctx, event("d1", map[string]any{"spec": map[string]any{"region": "eu"}}))
+
+		Eventually(sink.events).Should(HaveLen(1))
+		Expect(sink.events()[0]).To(Equal(map[string]any{
+			"labels":      map[string]any{"id": "instance-1"},
+			"decision_id": "d1",
+			"path":        "policies/region/main",
+			"input":       map[string]any{"spec": map[string]any{"region": "eu"}},
+			"result":      map[string]any{"rejected": false},
+			"request_id":  "host/abc-000001",
+			"timestamp":   "2026-01-02T03:04:05Z",
+		}))
+	})
+
+	It("erases masked paths without modifying the evaluation input", func() {
+		opts.MaskedPaths = []string{"/input/spec/credentials", "/input/spec/missing", "/result/rejected"}
+		logger, err := decisionlog.NewLogger(sink, opts)
+		Expect(err).NotTo(HaveOccurred())
+		stop := run(logger)
+		defer stop()
+
+		input := map[string]any{"spec": map[string]any{"region": "eu", "credentials": "secret"}}
+		logger.Log(context.Background(), event("d1", input))
+
+		Eventually(sink.events).Should(HaveLen(1))
+		logged := sink.events()[0]
+		Expect(logged["input"]).To(Equal(map[string]any{"spec": map[string]any{"region": "eu"}}))
+		Expect(logged["result"]).To(BeEmpty())
+		Expect(logged["erased"]).To(Equal([]any{"/input/spec/credentials", "/result/rejected"}))
+		Expect(input["spec"]).To(HaveKeyWithValue("credentials", "secret"))
+	})
+
+	It("rejects mask paths outside the input and result", func() {
+		for _, path := range []string{"/labels/id", "input/spec", "/input//spec"} {
+			opts.MaskedPaths = []string{path}
+			_, err := decisionlog.NewLogger(sink, opts)
+			Expect(err).To(HaveOccurred(), path)
+		}
+	})
+
+	It("writes full batches without waiting for the flush interval", func() {
+		opts.FlushInterval = time.Hour
+		logger, err := decisionlog.NewLogger(sink, opts)
+		Expect(err).NotTo(HaveOccurred())
+		stop := run(logger)
+		defer stop()
+
+		for range 25 {
+			logger.Log(context.Background(), event("d", nil))
+		}
+		Eventually(sink.batchSizes).Should(Equal([]int{10, 10}))
+
+		stop()
+		Expect(sink.batchSizes()).To(Equal([]int{10, 10, 5}))
+	})
+
+	It("retries failed writes on the next flush", func() {
+		sink.setErr(errors.New("endpoint down"))
+		logger, err := decisionlog.NewLogger(sink, opts)
+		Expect(err).NotTo(HaveOccurred())
+		stop := run(logger)
+		defer stop()
+
+		logger.Log(context.Background(), event("d1", nil))
+		Consistently(sink.events, 60*time.Millisecond).Should(BeEmpty())
+
+		sink.setErr(nil)
+		Eventually(sink.events).Should(HaveLen(1))
+	})
+
+	It("drops events beyond the buffer size instead of blocking", func() {
+		opts.BufferSize = 5
+		logger, err := decisionlog.NewLogger(sink, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Not running: nothing is written until Run starts
+		for range 8 {
+			logger.Log(context.Background(), event("d", nil))
+		}
+		stop := run(logger)
+		stop()
+		Expect(sink.events()).To(HaveLen(5))
+	})
+})
@@ -0,0 +1,73 @@
+package decisionlog
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// maskPath is a path erased from decision log events, e.g. /input/spec/credentials
+type maskPath struct {
+	path     string
+	segments []string
+}
+
+// parseMaskPath parses a slash-separated path below /input or /result
+func parseMaskPath(path string) (maskPath, error) {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if !strings.HasPrefix(path, "/") || (segments[0] != "input" && segments[0] != "result") {
+		return maskPath{}, fmt.Errorf("invalid decision log mask path %q: must start with /input or /result", path)
+	}
+	if slices.Contains(segments, "") {
+		return maskPath{}, fmt.Errorf("invalid decision log mask path %q: empty segment", path)
+	}
+	return maskPath{path: path, segments: segments}, nil
+}
+
+// mask erases the mask paths from the input and result of an event, recording the erased paths.
+// Objects along an erased path are copied rather than modified, since the evaluation still uses them.
+func mask(event *Event, paths []maskPath) {
+	for _, p := range paths {
+		var erased bool
+		switch p.segments[0] {
+		case "input":
+			event.Input, erased = erase(event.Input, p.segments[1:])
+		case "result":
+			event.Result, erased = erase(event.Result, p.segments[1:])
+		}
+		if erased {
+			event.Erased = append(event.Erased, p.path)
+		}
+	}
+}
+
+// erase returns value without the document at path, and whether it was present
+func erase(value any, path []string) (any, bool) {
+	if value == nil {
+		return nil, false
+	}
+	if len(path) == 0 {
+		return nil, true
+	}
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return value, false
+	}
+	child, ok := obj[path[0]]
+	if !ok {
+		return value, false
+	}
+
+	masked := maps.Clone(obj)
+	if len(path) == 1 {
+		delete(masked, path[0])
+		return masked, true
+	}
+	child, erased := erase(child, path[1:])
+	if !erased {
+		return value, false
+	}
+	masked[path[0]] = child
+	return masked, true
+}
@@ -0,0 +1,96 @@
+package decisionlog_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/dcm-project/policy-manager/internal/decisionlog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sinks", func() {
+	ctx := context.Background()
+	events := []json.RawMessage{json.RawMessage(`{"decision_id":"d1"}`), json.RawMessage(`{"decision_id":"d2"}`)}
+
+	Describe("WriterSink", func() {
+		It("writes one event per line", func() {
+			var buf bytes.Buffer
+			Expect(decisionlog.NewWriterSink(&buf).Write(ctx, events)).To(Succeed())
+			Expect(buf.String()).To(Equal("{\"decision_id\":\"d1\"}\n{\"decision_id\":\"d2\"}\n"))
+		})
+	})
+
+	Describe("FileSink", func() {
+		It("rotates the file when it would exceed the maximum size", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "decisions.log")
+			// Each batch of two events is 42 bytes, so a file holds two batches
+			sink, err := decisionlog.NewFileSink(path, 100, 2)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = sink.Close() }()
+
+			for range 7 {
+				Expect(sink.Write(ctx, events)).To(Succeed())
+			}
+
+			for file, size := range map[string]int64{path: 42, path + ".1": 84, path + ".2": 84} {
+				info, err := os.Stat(file)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Size()).To(Equal(size), file)
+			}
+			Expect(path + ".3").NotTo(BeAnExistingFile())
+		})
+
+		It("appends to an existing file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "decisions.log")
+			Expect(os.WriteFile(path, []byte("{\"decision_id\":\"d0\"}\n"), 0o600)).To(Succeed())
+
+			sink, err := decisionlog.NewFileSink(path, 1000, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sink.Write(ctx, events)).To(Succeed())
+			Expect(sink.Close()).To(Succeed())
+
+			content, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bytes.Count(content, []byte("\n"))).To(Equal(3))
+		})
+	})
+
+	Describe("HTTPSink", func() {
+		It("posts batches as a gzip-compressed JSON array", func() {
+			var received []map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.Header.Get("Content-Encoding")).To(Equal("gzip"))
+				gz, err := gzip.NewReader(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				body, err := io.ReadAll(gz)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(json.Unmarshal(body, &received)).To(Succeed())
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			Expect(decisionlog.NewHTTPSink(server.URL, server.Client()).Write(ctx, events)).To(Succeed())
+			Expect(received).To(Equal([]map[string]any{{"decision_id": "d1"}, {"decision_id": "d2"}}))
+		})
+
+		It("fails on an error response", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			err := decisionlog.NewHTTPSink(server.URL, server.Client()).Write(ctx, events)
+			Expect(err).To(MatchError(ContainSubstring("503")))
+		})
+	})
+})
@@ -14,6 +14,7 @@ import (
 	engineserver "github.com/dcm-project/policy-manager/internal/api/engine"
 	"github.com/dcm-project/policy-manager/internal/config"
 	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/metrics"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
@@ -41,6 +42,7 @@ func (s *Server) Run(ctx context.Context) error {
 	router := chi.NewRouter()
 	router.Use(middleware.RequestID)
 	router.Use(logging.RequestLogger)
+	router.Use(metrics.HTTPMiddleware(metrics.ServerEngine))
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
 
@@ -55,7 +57,7 @@ func (s *Server) Run(ctx context.Context) error {
 	}
 
 	engineserver.HandlerFromMuxWithBaseURL(
-		engineserver.NewStrictHandler(s.handler, nil),
+		engineserver.NewStrictHandler(s.handler, []engineserver.StrictMiddlewareFunc{metrics.OperationMiddleware}),
 		router,
 		baseURL,
 	)
@@ -0,0 +1,13 @@
+package enginesync_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEngineSync(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Engine Sync Suite")
+}
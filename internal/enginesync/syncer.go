@@ -0,0 +1,104 @@
+// Package enginesync keeps the engine of every replica compiled from the current stored policy set.
+package enginesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/store"
+)
+
+// Synchronizer recompiles the engine when the stored policy set is at a newer generation than the
+// compiled one
+type Synchronizer interface {
+	SyncEngine(ctx context.Context) error
+}
+
+// Listener announces new policy set generations, such as store.Generation
+type Listener interface {
+	Listen(ctx context.Context, notify func(generation int64)) error
+}
+
+// Syncer synchronizes the engine with the stored policy set after changes made through any replica.
+// Changes are announced through PostgreSQL LISTEN/NOTIFY. The stored generation is also polled, which
+// catches announcements missed while the listening connection was down and is the only mechanism on
+// databases without notifications, such as SQLite.
+type Syncer struct {
+	listener     Listener
+	synchronizer Synchronizer
+	interval     time.Duration
+}
+
+// NewSyncer creates a Syncer that synchronizes on every announcement of listener and every interval
+func NewSyncer(listener Listener, synchronizer Synchronizer, interval time.Duration) *Syncer {
+	return &Syncer{
+		listener:     listener,
+		synchronizer: synchronizer,
+		interval:     interval,
+	}
+}
+
+// Run synchronizes the engine until ctx is cancelled. Failed synchronizations are logged and retried on
+// the next announcement or tick; the previously compiled policies stay in effect.
+func (s *Syncer) Run(ctx context.Context) error {
+	if s.interval <= 0 {
+		return fmt.Errorf("engine sync poll interval must be positive, got %s", s.interval)
+	}
+	slog.Info("Synchronizing engine with the stored policy set", "interval", s.interval)
+
+	// Announcements arriving during a synchronization are coalesced into one more synchronization
+	announced := make(chan struct{}, 1)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	wg.Go(func() { s.listen(ctx, announced) })
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Engine synchronization stopped")
+			return nil
+		case <-ticker.C:
+		case <-announced:
+		}
+		if err := s.synchronizer.SyncEngine(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("Failed to synchronize engine with the stored policy set", "error", err)
+		}
+	}
+}
+
+// listen forwards announcements until ctx is cancelled, reconnecting after every interval when the
+// listening connection fails
+func (s *Syncer) listen(ctx context.Context, announced chan<- struct{}) {
+	notify := func(int64) {
+		select {
+		case announced <- struct{}{}:
+		default:
+		}
+	}
+	for {
+		err := s.listener.Listen(ctx, notify)
+		if errors.Is(err, store.ErrListenUnsupported) {
+			slog.Info("Database has no change notifications, polling for policy set changes only")
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("Listening for policy set changes failed, retrying", "error", err, "retry_in", s.interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.interval):
+		}
+		// Changes announced while the connection was down are caught up once it is back
+		notify(0)
+	}
+}
@@ -0,0 +1,130 @@
+package enginesync_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/enginesync"
+	"github.com/dcm-project/policy-manager/internal/store"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// countingSynchronizer counts the synchronizations
+type countingSynchronizer struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *countingSynchronizer) SyncEngine(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	return nil
+}
+
+func (s *countingSynchronizer) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+// fakeListener hands out the notify function of the current listen, after failing with errs in turn
+type fakeListener struct {
+	mu      sync.Mutex
+	errs    []error
+	listens int
+	notify  func(generation int64)
+}
+
+func (l *fakeListener) Listen(ctx context.Context, notify func(generation int64)) error {
+	l.mu.Lock()
+	l.listens++
+	if len(l.errs) > 0 {
+		err := l.errs[0]
+		l.errs = l.errs[1:]
+		l.mu.Unlock()
+		return err
+	}
+	l.notify = notify
+	l.mu.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
+
+func (l *fakeListener) listenCount() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.listens
+}
+
+func (l *fakeListener) announce(generation int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.notify == nil {
+		return false
+	}
+	l.notify(generation)
+	return true
+}
+
+var _ = Describe("Syncer", func() {
+	var (
+		listener     *fakeListener
+		synchronizer *countingSynchronizer
+		ctx          context.Context
+		cancel       context.CancelFunc
+		done         chan error
+	)
+
+	BeforeEach(func() {
+		listener = &fakeListener{}
+		synchronizer = &countingSynchronizer{}
+		ctx, cancel = context.WithCancel(context.Background())
+		done = make(chan error, 1)
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	start := func(interval time.Duration) {
+		s := enginesync.NewSyncer(listener, synchronizer, interval)
+		go func() { done <- s.Run(ctx) }()
+	}
+
+	It("synchronizes on every announced generation", func() {
+		start(time.Hour)
+
+		Eventually(func() bool { return listener.announce(1) }).Should(BeTrue())
+		Eventually(synchronizer.callCount).Should(Equal(1))
+
+		listener.announce(2)
+		Eventually(synchronizer.callCount).Should(Equal(2))
+	})
+
+	It("polls when the database has no change notifications", func() {
+		listener.errs = []error{store.ErrListenUnsupported}
+		start(10 * time.Millisecond)
+
+		Eventually(synchronizer.callCount).Should(BeNumerically(">=", 2))
+		Expect(listener.listenCount()).To(Equal(1))
+	})
+
+	It("listens again and catches up after the connection fails", func() {
+		listener.errs = []error{errors.New("connection reset")}
+		start(20 * time.Millisecond)
+
+		Eventually(listener.listenCount).Should(Equal(2))
+		Eventually(synchronizer.callCount).Should(BeNumerically(">=", 1))
+	})
+
+	It("rejects a non-positive interval", func() {
+		Expect(enginesync.NewSyncer(listener, synchronizer, 0).Run(ctx)).To(MatchError(ContainSubstring("must be positive")))
+		done <- nil
+	})
+})
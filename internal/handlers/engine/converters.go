@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	engineserver "github.com/dcm-project/policy-manager/internal/api/engine"
+	"github.com/dcm-project/policy-manager/internal/opa"
 	"github.com/dcm-project/policy-manager/internal/service"
 )
 
@@ -12,20 +13,99 @@ func toServiceEvaluationRequest(request engineserver.EvaluateRequestRequestObjec
 	if err != nil {
 		return nil, err
 	}
+	explain := opa.ExplainOff
+	if request.Params.Explain != nil {
+		if explain, err = opa.ParseExplainMode(string(*request.Params.Explain)); err != nil {
+			return nil, err
+		}
+	}
 	return &service.EvaluationRequest{
 		ServiceInstance: request.Body.ServiceInstance.Spec,
 		RequestLabels:   requestLabels,
+		Explain:         explain,
 	}, nil
 }
 
 func toEngineEvaluationResponse(response *service.EvaluationResponse) engineserver.EvaluateResponse {
-	return engineserver.EvaluateResponse{
+	result := engineserver.EvaluateResponse{
 		EvaluatedServiceInstance: engineserver.ServiceInstance{
 			Spec: response.EvaluatedServiceInstance,
 		},
 		SelectedProvider: response.SelectedProvider,
 		Status:           engineserver.EvaluateResponseStatus(response.Status),
+		Generation:       response.Generation,
+	}
+	result.CanaryPolicies = toEngineCanaryPolicies(response.CanaryPolicies)
+	result.Explanations = toEngineExplanations(response.Explanations)
+	return result
+}
+
+// toEngineExplanations converts policy explanations, returning nil when none were requested so they are
+// omitted
+func toEngineExplanations(explanations []service.PolicyExplanation) *[]engineserver.PolicyExplanation {
+	if explanations == nil {
+		return nil
+	}
+	result := make([]engineserver.PolicyExplanation, len(explanations))
+	for i, e := range explanations {
+		result[i] = engineserver.PolicyExplanation{
+			PolicyId:    e.PolicyID,
+			PrintOutput: nonNil(e.PrintOutput),
+			Trace:       nonNil(e.Trace),
+		}
+	}
+	return &result
+}
+
+func toServicePreviewRequest(request engineserver.PreviewConstraintsRequestObject) (*service.ConstraintPreviewRequest, error) {
+	requestLabels, err := extractRequestLabels(request.Body.ServiceInstance.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return &service.ConstraintPreviewRequest{
+		ServiceInstance: request.Body.ServiceInstance.Spec,
+		RequestLabels:   requestLabels,
+	}, nil
+}
+
+func toEngineConstraintsPreview(preview *service.ConstraintPreview) engineserver.ConstraintsPreview {
+	result := engineserver.ConstraintsPreview{
+		Schema:     preview.Schema,
+		Rejections: make([]engineserver.PolicyRejection, len(preview.Rejections)),
+		Generation: preview.Generation,
+	}
+	for i, r := range preview.Rejections {
+		result.Rejections[i] = engineserver.PolicyRejection{PolicyId: r.PolicyID}
+		if r.Reason != "" {
+			result.Rejections[i].Reason = &r.Reason
+		}
+	}
+	result.CanaryPolicies = toEngineCanaryPolicies(preview.CanaryPolicies)
+	return result
+}
+
+// toEngineCanaryPolicies converts canary policies, returning nil when there are none so they are omitted
+func toEngineCanaryPolicies(canaryPolicies []service.CanaryPolicy) *[]engineserver.CanaryPolicy {
+	if len(canaryPolicies) == 0 {
+		return nil
+	}
+	canaries := make([]engineserver.CanaryPolicy, len(canaryPolicies))
+	for i, c := range canaryPolicies {
+		canaries[i] = engineserver.CanaryPolicy{
+			PolicyId:          c.PolicyID,
+			RolloutPercentage: c.RolloutPercentage,
+			Applied:           c.Applied,
+		}
+	}
+	return &canaries
+}
+
+// nonNil returns lines, or an empty slice if nil, so that it is encoded as an empty JSON array
+func nonNil(lines []string) []string {
+	if lines == nil {
+		return []string{}
 	}
+	return lines
 }
 
 // extractRequestLabels extracts labels from spec.metadata.labels
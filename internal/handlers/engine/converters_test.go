@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	engineserver "github.com/dcm-project/policy-manager/internal/api/engine"
+	"github.com/dcm-project/policy-manager/internal/opa"
 	"github.com/dcm-project/policy-manager/internal/service"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -133,6 +134,31 @@ var _ = Describe("toServiceEvaluationRequest", func() {
 		Expect(err).To(MatchError("service type is required"))
 		Expect(got).To(BeNil())
 	})
+
+	It("passes the explain mode", func() {
+		explain := engineserver.Fails
+		req := engineserver.EvaluateRequestRequestObject{
+			Params: engineserver.EvaluateRequestParams{Explain: &explain},
+			Body: &engineserver.EvaluateRequest{
+				ServiceInstance: engineserver.ServiceInstance{Spec: map[string]any{"service_type": "compute"}},
+			},
+		}
+		got, err := toServiceEvaluationRequest(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Explain).To(Equal(opa.ExplainFails))
+	})
+
+	It("returns error for an unknown explain mode", func() {
+		explain := engineserver.EvaluateRequestParamsExplain("debug")
+		req := engineserver.EvaluateRequestRequestObject{
+			Params: engineserver.EvaluateRequestParams{Explain: &explain},
+			Body: &engineserver.EvaluateRequest{
+				ServiceInstance: engineserver.ServiceInstance{Spec: map[string]any{"service_type": "compute"}},
+			},
+		}
+		_, err := toServiceEvaluationRequest(req)
+		Expect(err).To(MatchError(ContainSubstring("unknown explain mode")))
+	})
 })
 
 var _ = Describe("toEngineEvaluationResponse", func() {
@@ -159,4 +185,97 @@ var _ = Describe("toEngineEvaluationResponse", func() {
 		Expect(got.Status).To(Equal(engineserver.MODIFIED))
 		Expect(got.SelectedProvider).To(Equal("other"))
 	})
+
+	It("lists canary policies only when present", func() {
+		resp := &service.EvaluationResponse{
+			EvaluatedServiceInstance: map[string]any{"service_type": "storage"},
+			Status:                   service.EvaluationStatusApproved,
+		}
+		Expect(toEngineEvaluationResponse(resp).CanaryPolicies).To(BeNil())
+
+		resp.CanaryPolicies = []service.CanaryPolicy{{PolicyID: "canary", RolloutPercentage: 10, Applied: true}}
+		got := toEngineEvaluationResponse(resp)
+		Expect(*got.CanaryPolicies).To(Equal([]engineserver.CanaryPolicy{{PolicyId: "canary", RolloutPercentage: 10, Applied: true}}))
+	})
+
+	It("reports the compiled policy set generation", func() {
+		resp := &service.EvaluationResponse{
+			EvaluatedServiceInstance: map[string]any{"service_type": "storage"},
+			Status:                   service.EvaluationStatusApproved,
+			Generation:               7,
+		}
+		Expect(toEngineEvaluationResponse(resp).Generation).To(Equal(int64(7)))
+	})
+
+	It("lists explanations only when requested, with empty arrays for no output", func() {
+		resp := &service.EvaluationResponse{
+			EvaluatedServiceInstance: map[string]any{"service_type": "storage"},
+			Status:                   service.EvaluationStatusApproved,
+		}
+		Expect(toEngineEvaluationResponse(resp).Explanations).To(BeNil())
+
+		resp.Explanations = []service.PolicyExplanation{{PolicyID: "region", PrintOutput: []string{"region: eu"}}}
+		got := toEngineEvaluationResponse(resp)
+		Expect(*got.Explanations).To(Equal([]engineserver.PolicyExplanation{
+			{PolicyId: "region", PrintOutput: []string{"region: eu"}, Trace: []string{}},
+		}))
+	})
+})
+
+var _ = Describe("toEngineConstraintsPreview", func() {
+	It("converts the schema and rejections, omitting empty reasons", func() {
+		schema := map[string]any{"type": "object"}
+		got := toEngineConstraintsPreview(&service.ConstraintPreview{
+			Schema: schema,
+			Rejections: []service.PolicyRejection{
+				{PolicyID: "quota", Reason: "quota exceeded"},
+				{PolicyID: "freeze"},
+			},
+			Generation: 4,
+		})
+		Expect(got.Schema).To(Equal(schema))
+		Expect(got.Generation).To(Equal(int64(4)))
+		Expect(got.Rejections).To(HaveLen(2))
+		Expect(got.Rejections[0].PolicyId).To(Equal("quota"))
+		Expect(*got.Rejections[0].Reason).To(Equal("quota exceeded"))
+		Expect(got.Rejections[1].Reason).To(BeNil())
+		Expect(got.CanaryPolicies).To(BeNil())
+	})
+
+	It("returns an empty rejection list when no policy rejected", func() {
+		got := toEngineConstraintsPreview(&service.ConstraintPreview{Schema: map[string]any{}})
+		Expect(got.Rejections).NotTo(BeNil())
+		Expect(got.Rejections).To(BeEmpty())
+	})
+})
+
+var _ = Describe("handleError", func() {
+	h := &Handler{}
+
+	It("returns the explanations of the evaluated policies with a rejection", func() {
+		err := service.NewPolicyRejectedError("deny", "no")
+		err.Explanations = []service.PolicyExplanation{{PolicyID: "deny", PrintOutput: []string{"denying"}}}
+
+		resp, ok := h.handleError(err).(engineserver.EvaluateRequest406JSONResponse)
+		Expect(ok).To(BeTrue())
+		Expect(*resp.Explanations).To(Equal([]engineserver.PolicyExplanation{
+			{PolicyId: "deny", PrintOutput: []string{"denying"}, Trace: []string{}},
+		}))
+	})
+
+	It("returns the explanations with an internal error without exposing it", func() {
+		err := service.NewInternalError("Failed to evaluate policy 'broken'", "conflicting rules", nil)
+		err.Explanations = []service.PolicyExplanation{{PolicyID: "broken", Trace: []string{"Enter data.broken.main"}}}
+
+		resp, ok := h.handleError(err).(engineserver.EvaluateRequest500JSONResponse)
+		Expect(ok).To(BeTrue())
+		Expect(resp.Title).To(Equal("Internal server error"))
+		Expect(*resp.Explanations).To(HaveLen(1))
+	})
+
+	It("omits explanations unless requested", func() {
+		resp, ok := h.handleError(service.NewPolicyRejectedError("deny", "no")).(engineserver.EvaluateRequest406JSONResponse)
+		Expect(ok).To(BeTrue())
+		Expect(resp.Explanations).To(BeNil())
+	})
 })
@@ -34,21 +34,26 @@ func isClientError(err error) bool {
 	}
 }
 
-// handleError maps service errors to HTTP responses
+// handleError maps service errors to HTTP responses, with the explanations of the evaluated policies
+// when the evaluation was explained
 func (h *Handler) handleError(err error) engineserver.EvaluateRequestResponseObject {
 	if serviceErr, ok := err.(*service.ServiceError); ok {
+		explanations := toEngineExplanations(serviceErr.Explanations)
 		switch serviceErr.Type {
 		case service.ErrorTypeRejected:
-			return h.rejected(serviceErr.Message, serviceErr.Detail)
+			return h.rejected(serviceErr.Message, serviceErr.Detail, explanations)
 		case service.ErrorTypePolicyConflict:
-			return h.conflict(serviceErr.Message, serviceErr.Detail)
+			return h.conflict(serviceErr.Message, serviceErr.Detail, explanations)
 		case service.ErrorTypeInvalidArgument:
 			return h.badRequest(serviceErr.Message)
+		case service.ErrorTypeTimeout:
+			return engineserver.EvaluateRequest504JSONResponse{EvaluationTimeoutJSONResponse: timeout(serviceErr)}
 		}
+		return h.internalError("Internal server error", "An unexpected error occurred", explanations)
 	}
 
 	// Default to internal server error
-	return h.internalError("Internal server error", "An unexpected error occurred")
+	return h.internalError("Internal server error", "An unexpected error occurred", nil)
 }
 
 // badRequest creates a 400 Bad Request response
@@ -64,37 +69,94 @@ func (h *Handler) badRequest(message string) engineserver.EvaluateRequestRespons
 }
 
 // rejected creates a 406 Not Acceptable response
-func (h *Handler) rejected(title, detail string) engineserver.EvaluateRequestResponseObject {
+func (h *Handler) rejected(title, detail string, explanations *[]engineserver.PolicyExplanation) engineserver.EvaluateRequestResponseObject {
 	return engineserver.EvaluateRequest406JSONResponse{
 		RejectedJSONResponse: engineserver.RejectedJSONResponse{
-			Type:   "about:blank",
-			Status: 406,
-			Title:  title,
-			Detail: &detail,
+			Type:         "about:blank",
+			Status:       406,
+			Title:        title,
+			Detail:       &detail,
+			Explanations: explanations,
 		},
 	}
 }
 
 // conflict creates a 409 Conflict response
-func (h *Handler) conflict(title, detail string) engineserver.EvaluateRequestResponseObject {
+func (h *Handler) conflict(title, detail string, explanations *[]engineserver.PolicyExplanation) engineserver.EvaluateRequestResponseObject {
 	return engineserver.EvaluateRequest409JSONResponse{
 		PolicyConflictJSONResponse: engineserver.PolicyConflictJSONResponse{
-			Type:   "about:blank",
-			Status: 409,
-			Title:  title,
-			Detail: &detail,
+			Type:         "about:blank",
+			Status:       409,
+			Title:        title,
+			Detail:       &detail,
+			Explanations: explanations,
 		},
 	}
 }
 
 // internalError creates a 500 Internal Server Error response
-func (h *Handler) internalError(title, detail string) engineserver.EvaluateRequestResponseObject {
+func (h *Handler) internalError(title, detail string, explanations *[]engineserver.PolicyExplanation) engineserver.EvaluateRequestResponseObject {
 	return engineserver.EvaluateRequest500JSONResponse{
+		InternalServerErrorJSONResponse: engineserver.InternalServerErrorJSONResponse{
+			Type:         "about:blank",
+			Status:       500,
+			Title:        title,
+			Detail:       &detail,
+			Explanations: explanations,
+		},
+	}
+}
+
+// timeout creates the body of a 504 Gateway Timeout response
+func timeout(serviceErr *service.ServiceError) engineserver.EvaluationTimeoutJSONResponse {
+	return engineserver.EvaluationTimeoutJSONResponse{
+		Type:         "about:blank",
+		Status:       504,
+		Title:        serviceErr.Message,
+		Detail:       &serviceErr.Detail,
+		Explanations: toEngineExplanations(serviceErr.Explanations),
+	}
+}
+
+// handlePreviewConstraintsError maps service errors to PreviewConstraints HTTP responses
+func (h *Handler) handlePreviewConstraintsError(err error) engineserver.PreviewConstraintsResponseObject {
+	if serviceErr, ok := err.(*service.ServiceError); ok {
+		switch serviceErr.Type {
+		case service.ErrorTypePolicyConflict:
+			return engineserver.PreviewConstraints409JSONResponse{
+				PolicyConflictJSONResponse: engineserver.PolicyConflictJSONResponse{
+					Type:   "about:blank",
+					Status: 409,
+					Title:  serviceErr.Message,
+					Detail: &serviceErr.Detail,
+				},
+			}
+		case service.ErrorTypeInvalidArgument:
+			return h.previewBadRequest(serviceErr.Message)
+		case service.ErrorTypeTimeout:
+			return engineserver.PreviewConstraints504JSONResponse{EvaluationTimeoutJSONResponse: timeout(serviceErr)}
+		}
+	}
+
+	detail := "An unexpected error occurred"
+	return engineserver.PreviewConstraints500JSONResponse{
 		InternalServerErrorJSONResponse: engineserver.InternalServerErrorJSONResponse{
 			Type:   "about:blank",
 			Status: 500,
-			Title:  title,
+			Title:  "Internal server error",
 			Detail: &detail,
 		},
 	}
 }
+
+// previewBadRequest creates a 400 Bad Request response for PreviewConstraints
+func (h *Handler) previewBadRequest(message string) engineserver.PreviewConstraintsResponseObject {
+	return engineserver.PreviewConstraints400JSONResponse{
+		BadRequestJSONResponse: engineserver.BadRequestJSONResponse{
+			Type:   "about:blank",
+			Status: 400,
+			Title:  "Bad Request",
+			Detail: &message,
+		},
+	}
+}
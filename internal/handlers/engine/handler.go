@@ -50,3 +50,24 @@ func (h *Handler) EvaluateRequest(ctx context.Context, request engineserver.Eval
 	// Map service response to API response
 	return engineserver.EvaluateRequest200JSONResponse(toEngineEvaluationResponse(response)), nil
 }
+
+// PreviewConstraints returns the constraints the applicable policies set for a partial request
+func (h *Handler) PreviewConstraints(ctx context.Context, request engineserver.PreviewConstraintsRequestObject) (engineserver.PreviewConstraintsResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("PreviewConstraints received")
+
+	previewRequest, err := toServicePreviewRequest(request)
+	if err != nil {
+		log.Warn("PreviewConstraints invalid input", "error", err)
+		return h.previewBadRequest(err.Error()), nil
+	}
+
+	preview, err := h.evaluationService.PreviewConstraints(ctx, previewRequest)
+	if err != nil {
+		logServiceError(ctx, "PreviewConstraints failed", err)
+		return h.handlePreviewConstraintsError(err), nil
+	}
+
+	log.Info("PreviewConstraints completed", "rejections", len(preview.Rejections))
+	return engineserver.PreviewConstraints200JSONResponse(toEngineConstraintsPreview(preview)), nil
+}
@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/service"
+)
+
+// BatchCreatePolicies handles creating several policies in one transaction.
+func (h *PolicyHandler) BatchCreatePolicies(ctx context.Context, request server.BatchCreatePoliciesRequestObject) (server.BatchCreatePoliciesResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	if request.Body == nil {
+		log.Warn("BatchCreatePolicies called with nil body")
+		return server.BatchCreatePolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				"Invalid request body",
+				strPtr("Request body is required"),
+			)),
+		}, nil
+	}
+
+	log.Debug("BatchCreatePolicies request received", "request_count", len(request.Body.Requests))
+
+	requests := make([]v1alpha1.PolicyCreateRequest, len(request.Body.Requests))
+	for i, r := range request.Body.Requests {
+		requests[i] = v1alpha1.PolicyCreateRequest{Id: r.Id, Policy: policyServerToV1Alpha1(r.Policy)}
+	}
+	opts := service.PolicyWriteOptions{
+		RequirePassingTests: request.Params.RequirePassingTests != nil && *request.Params.RequirePassingTests,
+		ValidateOnly:        request.Params.ValidateOnly != nil && *request.Params.ValidateOnly,
+	}
+	created, err := h.service.BatchCreatePolicies(ctx, requests, opts)
+	if err != nil {
+		logServiceError(ctx, "BatchCreatePolicies failed", err)
+		return h.handleBatchCreatePoliciesError(err), nil
+	}
+
+	log.Info("Policies batch created", "policy_count", len(created), "validate_only", opts.ValidateOnly)
+	return server.BatchCreatePolicies200JSONResponse{Policies: policiesV1Alpha1ToServer(created)}, nil
+}
+
+// BatchUpdatePolicies handles updating several policies in one transaction.
+func (h *PolicyHandler) BatchUpdatePolicies(ctx context.Context, request server.BatchUpdatePoliciesRequestObject) (server.BatchUpdatePoliciesResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	if request.Body == nil {
+		log.Warn("BatchUpdatePolicies called with nil body")
+		return server.BatchUpdatePolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				"Invalid request body",
+				strPtr("Request body is required"),
+			)),
+		}, nil
+	}
+
+	log.Debug("BatchUpdatePolicies request received", "request_count", len(request.Body.Requests))
+
+	requests := make([]v1alpha1.PolicyUpdateRequest, len(request.Body.Requests))
+	for i, r := range request.Body.Requests {
+		requests[i] = v1alpha1.PolicyUpdateRequest{PolicyId: r.PolicyId, Policy: policyServerToV1Alpha1(r.Policy), UpdateMask: r.UpdateMask}
+	}
+	opts := service.PolicyWriteOptions{
+		RequirePassingTests: request.Params.RequirePassingTests != nil && *request.Params.RequirePassingTests,
+		ValidateOnly:        request.Params.ValidateOnly != nil && *request.Params.ValidateOnly,
+	}
+	updated, err := h.service.BatchUpdatePolicies(ctx, requests, opts)
+	if err != nil {
+		logServiceError(ctx, "BatchUpdatePolicies failed", err)
+		return h.handleBatchUpdatePoliciesError(err), nil
+	}
+
+	log.Info("Policies batch updated", "policy_count", len(updated), "validate_only", opts.ValidateOnly)
+	return server.BatchUpdatePolicies200JSONResponse{Policies: policiesV1Alpha1ToServer(updated)}, nil
+}
+
+// BatchDeletePolicies handles deleting several policies in one transaction.
+func (h *PolicyHandler) BatchDeletePolicies(ctx context.Context, request server.BatchDeletePoliciesRequestObject) (server.BatchDeletePoliciesResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	if request.Body == nil {
+		log.Warn("BatchDeletePolicies called with nil body")
+		return server.BatchDeletePolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				"Invalid request body",
+				strPtr("Request body is required"),
+			)),
+		}, nil
+	}
+
+	log.Debug("BatchDeletePolicies request received", "policy_ids", request.Body.PolicyIds)
+
+	if err := h.service.BatchDeletePolicies(ctx, request.Body.PolicyIds); err != nil {
+		logServiceError(ctx, "BatchDeletePolicies failed", err)
+		return h.handleBatchDeletePoliciesError(err), nil
+	}
+
+	log.Info("Policies batch deleted", "policy_count", len(request.Body.PolicyIds))
+	return server.BatchDeletePolicies204Response{}, nil
+}
@@ -0,0 +1,148 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/service"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Batch handlers", func() {
+	var (
+		handler     *PolicyHandler
+		mockService *MockPolicyService
+		ctx         context.Context
+	)
+
+	BeforeEach(func() {
+		mockService = &MockPolicyService{}
+		handler = NewPolicyHandler(mockService)
+		ctx = context.Background()
+	})
+
+	Describe("BatchCreatePolicies", func() {
+		It("should return 200 with the created policies", func() {
+			var received []v1alpha1.PolicyCreateRequest
+			var receivedOpts service.PolicyWriteOptions
+			mockService.BatchCreatePoliciesFn = func(_ context.Context, requests []v1alpha1.PolicyCreateRequest, opts service.PolicyWriteOptions) ([]v1alpha1.Policy, error) {
+				received, receivedOpts = requests, opts
+				return []v1alpha1.Policy{{Id: requests[0].Id, DisplayName: requests[0].Policy.DisplayName}}, nil
+			}
+
+			validateOnly := true
+			response, err := handler.BatchCreatePolicies(ctx, server.BatchCreatePoliciesRequestObject{
+				Params: server.BatchCreatePoliciesParams{ValidateOnly: &validateOnly},
+				Body: &server.BatchCreatePoliciesJSONRequestBody{Requests: []server.PolicyCreateRequest{
+					{Id: strPtr("new"), Policy: server.Policy{DisplayName: strPtr("New")}},
+				}},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(received).To(HaveLen(1))
+			Expect(*received[0].Policy.DisplayName).To(Equal("New"))
+			Expect(receivedOpts.ValidateOnly).To(BeTrue())
+			batchResponse, ok := response.(server.BatchCreatePolicies200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be BatchCreatePolicies200JSONResponse")
+			Expect(*batchResponse.Policies[0].Id).To(Equal("new"))
+		})
+
+		It("should return 400 for nil body", func() {
+			response, err := handler.BatchCreatePolicies(ctx, server.BatchCreatePoliciesRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.BatchCreatePolicies400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be BatchCreatePolicies400JSONResponse")
+		})
+
+		It("should return 409 when a policy conflicts", func() {
+			mockService.BatchCreatePoliciesFn = func(_ context.Context, _ []v1alpha1.PolicyCreateRequest, _ service.PolicyWriteOptions) ([]v1alpha1.Policy, error) {
+				return nil, service.NewPolicyAlreadyExistsError("new")
+			}
+
+			response, err := handler.BatchCreatePolicies(ctx, server.BatchCreatePoliciesRequestObject{
+				Body: &server.BatchCreatePoliciesJSONRequestBody{Requests: []server.PolicyCreateRequest{{Id: strPtr("new")}}},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.BatchCreatePolicies409JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be BatchCreatePolicies409JSONResponse")
+		})
+	})
+
+	Describe("BatchUpdatePolicies", func() {
+		It("should return 200 with the updated policies", func() {
+			var received []v1alpha1.PolicyUpdateRequest
+			mockService.BatchUpdatePoliciesFn = func(_ context.Context, requests []v1alpha1.PolicyUpdateRequest, _ service.PolicyWriteOptions) ([]v1alpha1.Policy, error) {
+				received = requests
+				return []v1alpha1.Policy{{Id: &requests[0].PolicyId, Enabled: requests[0].Policy.Enabled}}, nil
+			}
+
+			enabled := false
+			response, err := handler.BatchUpdatePolicies(ctx, server.BatchUpdatePoliciesRequestObject{
+				Body: &server.BatchUpdatePoliciesJSONRequestBody{Requests: []server.PolicyUpdateRequest{
+					{PolicyId: "old", Policy: server.Policy{Enabled: &enabled}},
+				}},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(received[0].PolicyId).To(Equal("old"))
+			batchResponse, ok := response.(server.BatchUpdatePolicies200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be BatchUpdatePolicies200JSONResponse")
+			Expect(*batchResponse.Policies[0].Enabled).To(BeFalse())
+		})
+
+		It("should return 404 for an unknown policy", func() {
+			mockService.BatchUpdatePoliciesFn = func(_ context.Context, _ []v1alpha1.PolicyUpdateRequest, _ service.PolicyWriteOptions) ([]v1alpha1.Policy, error) {
+				return nil, service.NewPolicyNotFoundError("missing")
+			}
+
+			response, err := handler.BatchUpdatePolicies(ctx, server.BatchUpdatePoliciesRequestObject{
+				Body: &server.BatchUpdatePoliciesJSONRequestBody{Requests: []server.PolicyUpdateRequest{{PolicyId: "missing"}}},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.BatchUpdatePolicies404JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be BatchUpdatePolicies404JSONResponse")
+		})
+	})
+
+	Describe("BatchDeletePolicies", func() {
+		It("should return 204 when the policies are deleted", func() {
+			var received []string
+			mockService.BatchDeletePoliciesFn = func(_ context.Context, ids []string) error {
+				received = ids
+				return nil
+			}
+
+			response, err := handler.BatchDeletePolicies(ctx, server.BatchDeletePoliciesRequestObject{
+				Body: &server.BatchDeletePoliciesJSONRequestBody{PolicyIds: []string{"a", "b"}},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(received).To(Equal([]string{"a", "b"}))
+			_, ok := response.(server.BatchDeletePolicies204Response)
+			Expect(ok).To(BeTrue(), "response should be BatchDeletePolicies204Response")
+		})
+
+		It("should return the compile diagnostics with 400 INVALID_ARGUMENT", func() {
+			mockService.BatchDeletePoliciesFn = func(_ context.Context, _ []string) error {
+				serviceErr := service.NewInvalidArgumentError("Invalid Rego code", "The policy set does not compile: 1 problem(s) found")
+				serviceErr.Diagnostics = []v1alpha1.RegoDiagnostic{{PolicyId: strPtr("dependent"), Message: "undefined function"}}
+				return serviceErr
+			}
+
+			response, err := handler.BatchDeletePolicies(ctx, server.BatchDeletePoliciesRequestObject{
+				Body: &server.BatchDeletePoliciesJSONRequestBody{PolicyIds: []string{"helper"}},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.BatchDeletePolicies400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be BatchDeletePolicies400JSONResponse")
+			Expect(badRequest.Diagnostics).NotTo(BeNil())
+			Expect(*(*badRequest.Diagnostics)[0].PolicyId).To(Equal("dependent"))
+		})
+	})
+})
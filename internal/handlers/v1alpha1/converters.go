@@ -9,16 +9,23 @@ import (
 
 func policyServerToV1Alpha1(p server.Policy) v1alpha1.Policy {
 	out := v1alpha1.Policy{
-		CreateTime:    p.CreateTime,
-		Description:   p.Description,
-		DisplayName:   p.DisplayName,
-		Enabled:       p.Enabled,
-		Id:            p.Id,
-		LabelSelector: p.LabelSelector,
-		Path:          p.Path,
-		Priority:      p.Priority,
-		RegoCode:      p.RegoCode,
-		UpdateTime:    p.UpdateTime,
+		CreateTime:        p.CreateTime,
+		Description:       p.Description,
+		DisplayName:       p.DisplayName,
+		EffectiveFrom:     p.EffectiveFrom,
+		EffectiveUntil:    p.EffectiveUntil,
+		Enabled:           p.Enabled,
+		Entrypoint:        p.Entrypoint,
+		Id:                p.Id,
+		LabelSelector:     p.LabelSelector,
+		Managed:           p.Managed,
+		Path:              p.Path,
+		Priority:          p.Priority,
+		RegoCode:          p.RegoCode,
+		RolloutKey:        p.RolloutKey,
+		RolloutPercentage: p.RolloutPercentage,
+		TestCode:          p.TestCode,
+		UpdateTime:        p.UpdateTime,
 	}
 	if p.PolicyType != nil {
 		t := v1alpha1.PolicyPolicyType(*p.PolicyType)
@@ -29,16 +36,25 @@ func policyServerToV1Alpha1(p server.Policy) v1alpha1.Policy {
 
 func policyV1Alpha1ToServer(p v1alpha1.Policy) server.Policy {
 	out := server.Policy{
-		CreateTime:    p.CreateTime,
-		Description:   p.Description,
-		DisplayName:   p.DisplayName,
-		Enabled:       p.Enabled,
-		Id:            p.Id,
-		LabelSelector: p.LabelSelector,
-		Path:          p.Path,
-		Priority:      p.Priority,
-		RegoCode:      p.RegoCode,
-		UpdateTime:    p.UpdateTime,
+		Active:            p.Active,
+		CreateTime:        p.CreateTime,
+		Description:       p.Description,
+		DisplayName:       p.DisplayName,
+		EffectiveFrom:     p.EffectiveFrom,
+		EffectiveUntil:    p.EffectiveUntil,
+		Enabled:           p.Enabled,
+		Entrypoint:        p.Entrypoint,
+		Id:                p.Id,
+		LabelSelector:     p.LabelSelector,
+		Managed:           p.Managed,
+		Path:              p.Path,
+		Priority:          p.Priority,
+		RegoCode:          p.RegoCode,
+		RolloutKey:        p.RolloutKey,
+		RolloutPercentage: p.RolloutPercentage,
+		TestCode:          p.TestCode,
+		UpdateTime:        p.UpdateTime,
+		Warnings:          diagnosticsV1Alpha1ToServer(p.Warnings),
 	}
 	if p.PolicyType != nil {
 		t := server.PolicyPolicyType(*p.PolicyType)
@@ -47,13 +63,203 @@ func policyV1Alpha1ToServer(p v1alpha1.Policy) server.Policy {
 	return out
 }
 
-func listResponseV1Alpha1ToServer(r v1alpha1.PolicyList) server.PolicyList {
-	policies := make([]server.Policy, len(r.Policies))
-	for i, p := range r.Policies {
-		policies[i] = policyV1Alpha1ToServer(p)
+func policiesV1Alpha1ToServer(policies []v1alpha1.Policy) []server.Policy {
+	out := make([]server.Policy, len(policies))
+	for i, p := range policies {
+		out[i] = policyV1Alpha1ToServer(p)
 	}
+	return out
+}
+
+func listResponseV1Alpha1ToServer(r v1alpha1.PolicyList) server.PolicyList {
 	return server.PolicyList{
 		NextPageToken: r.NextPageToken,
-		Policies:      policies,
+		Policies:      policiesV1Alpha1ToServer(r.Policies),
+		TotalSize:     r.TotalSize,
+	}
+}
+
+func testReportV1Alpha1ToServer(r v1alpha1.PolicyTestReport) server.PolicyTestReport {
+	results := make([]server.PolicyTestResult, len(r.Results))
+	for i, t := range r.Results {
+		results[i] = server.PolicyTestResult{
+			Duration: t.Duration,
+			Message:  t.Message,
+			Name:     t.Name,
+			Output:   t.Output,
+			Status:   server.PolicyTestResultStatus(t.Status),
+		}
+	}
+	return server.PolicyTestReport{
+		Passed:  r.Passed,
+		Results: results,
+	}
+}
+
+func diagnosticsV1Alpha1ToServer(diagnostics *[]v1alpha1.RegoDiagnostic) *[]server.RegoDiagnostic {
+	if diagnostics == nil {
+		return nil
+	}
+	result := make([]server.RegoDiagnostic, len(*diagnostics))
+	for i, d := range *diagnostics {
+		result[i] = server.RegoDiagnostic{
+			Code:      d.Code,
+			Column:    d.Column,
+			Line:      d.Line,
+			Message:   d.Message,
+			PolicyId:  d.PolicyId,
+			LibraryId: d.LibraryId,
+			Rule:      d.Rule,
+			Severity:  server.RegoDiagnosticSeverity(d.Severity),
+		}
+	}
+	return &result
+}
+
+func libraryServerToV1Alpha1(l server.Library) v1alpha1.Library {
+	return v1alpha1.Library{
+		CreateTime:  l.CreateTime,
+		Description: l.Description,
+		DisplayName: l.DisplayName,
+		Id:          l.Id,
+		Package:     l.Package,
+		Path:        l.Path,
+		RegoCode:    l.RegoCode,
+		UpdateTime:  l.UpdateTime,
+	}
+}
+
+func libraryV1Alpha1ToServer(l v1alpha1.Library) server.Library {
+	return server.Library{
+		CreateTime:  l.CreateTime,
+		Description: l.Description,
+		DisplayName: l.DisplayName,
+		Id:          l.Id,
+		Package:     l.Package,
+		Path:        l.Path,
+		RegoCode:    l.RegoCode,
+		UpdateTime:  l.UpdateTime,
+	}
+}
+
+func webhookServerToV1Alpha1(w server.Webhook) v1alpha1.Webhook {
+	out := v1alpha1.Webhook{
+		CreateTime:  w.CreateTime,
+		DisplayName: w.DisplayName,
+		Id:          w.Id,
+		Path:        w.Path,
+		Secret:      w.Secret,
+		UpdateTime:  w.UpdateTime,
+		Url:         w.Url,
+	}
+	if w.EventTypes != nil {
+		eventTypes := make([]v1alpha1.WebhookEventType, len(*w.EventTypes))
+		for i, t := range *w.EventTypes {
+			eventTypes[i] = v1alpha1.WebhookEventType(t)
+		}
+		out.EventTypes = &eventTypes
+	}
+	return out
+}
+
+func webhookV1Alpha1ToServer(w v1alpha1.Webhook) server.Webhook {
+	out := server.Webhook{
+		CreateTime:  w.CreateTime,
+		DisplayName: w.DisplayName,
+		Id:          w.Id,
+		Path:        w.Path,
+		UpdateTime:  w.UpdateTime,
+		Url:         w.Url,
+	}
+	if w.EventTypes != nil {
+		eventTypes := make([]server.WebhookEventType, len(*w.EventTypes))
+		for i, t := range *w.EventTypes {
+			eventTypes[i] = server.WebhookEventType(t)
+		}
+		out.EventTypes = &eventTypes
+	}
+	return out
+}
+
+func webhookDeliveryV1Alpha1ToServer(d v1alpha1.WebhookDelivery) server.WebhookDelivery {
+	return server.WebhookDelivery{
+		Attempts:        d.Attempts,
+		CreateTime:      d.CreateTime,
+		EventId:         d.EventId,
+		EventType:       (*server.WebhookEventType)(d.EventType),
+		Id:              d.Id,
+		LastError:       d.LastError,
+		NextAttemptTime: d.NextAttemptTime,
+		PolicyId:        d.PolicyId,
+		ResponseCode:    d.ResponseCode,
+		Status:          (*server.WebhookDeliveryStatus)(d.Status),
+		UpdateTime:      d.UpdateTime,
+	}
+}
+
+func proposalServerToV1Alpha1(p server.PolicyProposal) v1alpha1.PolicyProposal {
+	out := v1alpha1.PolicyProposal{
+		Description: p.Description,
+		PolicyId:    p.PolicyId,
+		LibraryId:   p.LibraryId,
+	}
+	if p.Operation != nil {
+		op := v1alpha1.PolicyProposalOperation(*p.Operation)
+		out.Operation = &op
 	}
+	if p.Policy != nil {
+		policy := policyServerToV1Alpha1(*p.Policy)
+		out.Policy = &policy
+	}
+	if p.Library != nil {
+		library := libraryServerToV1Alpha1(*p.Library)
+		out.Library = &library
+	}
+	return out
+}
+
+func proposalV1Alpha1ToServer(p v1alpha1.PolicyProposal) server.PolicyProposal {
+	out := server.PolicyProposal{
+		BaseUpdateTime: p.BaseUpdateTime,
+		ChangedFields:  p.ChangedFields,
+		CreateTime:     p.CreateTime,
+		Description:    p.Description,
+		Id:             p.Id,
+		LibraryId:      p.LibraryId,
+		Operation:      (*server.PolicyProposalOperation)(p.Operation),
+		Path:           p.Path,
+		PolicyId:       p.PolicyId,
+		Proposer:       p.Proposer,
+		ReviewComment:  p.ReviewComment,
+		ReviewTime:     p.ReviewTime,
+		Reviewer:       p.Reviewer,
+		State:          (*server.ProposalState)(p.State),
+		UpdateTime:     p.UpdateTime,
+	}
+	if p.Policy != nil {
+		policy := policyV1Alpha1ToServer(*p.Policy)
+		out.Policy = &policy
+	}
+	if p.Current != nil {
+		current := policyV1Alpha1ToServer(*p.Current)
+		out.Current = &current
+	}
+	if p.Library != nil {
+		library := libraryV1Alpha1ToServer(*p.Library)
+		out.Library = &library
+	}
+	if p.CurrentLibrary != nil {
+		current := libraryV1Alpha1ToServer(*p.CurrentLibrary)
+		out.CurrentLibrary = &current
+	}
+	if p.Validation != nil {
+		out.Validation = &server.ProposalValidation{
+			Detail:      p.Validation.Detail,
+			Diagnostics: diagnosticsV1Alpha1ToServer(p.Validation.Diagnostics),
+			Message:     p.Validation.Message,
+			Valid:       p.Validation.Valid,
+			Warnings:    diagnosticsV1Alpha1ToServer(p.Validation.Warnings),
+		}
+	}
+	return out
 }
@@ -28,7 +28,8 @@ func isClientError(err error) bool {
 	}
 	switch serviceErr.Type {
 	case service.ErrorTypeInvalidArgument, service.ErrorTypeNotFound,
-		service.ErrorTypeAlreadyExists, service.ErrorTypeFailedPrecondition:
+		service.ErrorTypeAlreadyExists, service.ErrorTypeFailedPrecondition,
+		service.ErrorTypeUnauthenticated, service.ErrorTypePermissionDenied:
 		return true
 	default:
 		return false
@@ -53,6 +54,812 @@ func (h *PolicyHandler) handleCreatePolicyError(err error, _ server.CreatePolicy
 	switch serviceErr.Type {
 	case service.ErrorTypeInvalidArgument:
 		return server.CreatePolicy400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.CreatePolicy400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeAlreadyExists:
+		return server.CreatePolicy409JSONResponse{
+			AlreadyExistsJSONResponse: alreadyExistsResponse(buildErrorResponse(
+				409,
+				v1alpha1.ALREADYEXISTS,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.CreatePolicy500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleGetPolicyError(err error, _ server.GetPolicyRequestObject) server.GetPolicyResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.GetPolicy500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeNotFound:
+		return server.GetPolicy404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.GetPolicy500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleListPoliciesError(err error, _ server.ListPoliciesRequestObject) server.ListPoliciesResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.ListPolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.ListPolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.ListPolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleUpdatePolicyError(err error, _ server.UpdatePolicyRequestObject) server.UpdatePolicyResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.UpdatePolicy500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.UpdatePolicy400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.UpdatePolicy400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeNotFound:
+		return server.UpdatePolicy404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeAlreadyExists:
+		return server.UpdatePolicy409JSONResponse{
+			AlreadyExistsJSONResponse: alreadyExistsResponse(buildErrorResponse(
+				409,
+				v1alpha1.ALREADYEXISTS,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.UpdatePolicy500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleDeletePolicyError(err error, _ server.DeletePolicyRequestObject) server.DeletePolicyResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.DeletePolicy500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeFailedPrecondition:
+		return server.DeletePolicy400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeNotFound:
+		return server.DeletePolicy404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.DeletePolicy500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleTestPolicyError(err error, _ server.TestPolicyRequestObject) server.TestPolicyResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.TestPolicy500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeFailedPrecondition:
+		return server.TestPolicy400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeNotFound:
+		return server.TestPolicy404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.TestPolicy500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleReorderPoliciesError(err error, _ server.ReorderPoliciesRequestObject) server.ReorderPoliciesResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.ReorderPolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.ReorderPolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.ReorderPolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeNotFound:
+		return server.ReorderPolicies404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.ReorderPolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleBatchCreatePoliciesError(err error) server.BatchCreatePoliciesResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.BatchCreatePolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.BatchCreatePolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.BatchCreatePolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeAlreadyExists:
+		return server.BatchCreatePolicies409JSONResponse{
+			AlreadyExistsJSONResponse: alreadyExistsResponse(buildErrorResponse(
+				409,
+				v1alpha1.ALREADYEXISTS,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.BatchCreatePolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleBatchUpdatePoliciesError(err error) server.BatchUpdatePoliciesResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.BatchUpdatePolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.BatchUpdatePolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.BatchUpdatePolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeNotFound:
+		return server.BatchUpdatePolicies404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeAlreadyExists:
+		return server.BatchUpdatePolicies409JSONResponse{
+			AlreadyExistsJSONResponse: alreadyExistsResponse(buildErrorResponse(
+				409,
+				v1alpha1.ALREADYEXISTS,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.BatchUpdatePolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleBatchDeletePoliciesError(err error) server.BatchDeletePoliciesResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.BatchDeletePolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.BatchDeletePolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.BatchDeletePolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeNotFound:
+		return server.BatchDeletePolicies404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.BatchDeletePolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleImportPolicyBundleError(err error, _ server.ImportPolicyBundleRequestObject) server.ImportPolicyBundleResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.ImportPolicyBundle500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.ImportPolicyBundle400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.ImportPolicyBundle400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeAlreadyExists:
+		return server.ImportPolicyBundle409JSONResponse{
+			AlreadyExistsJSONResponse: alreadyExistsResponse(buildErrorResponse(
+				409,
+				v1alpha1.ALREADYEXISTS,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.ImportPolicyBundle500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleExportPolicyBundleError(err error, _ server.ExportPolicyBundleRequestObject) server.ExportPolicyBundleResponseObject {
+	detail := err.Error()
+	if serviceErr, ok := err.(*service.ServiceError); ok {
+		detail = serviceErr.Detail
+	}
+	return server.ExportPolicyBundle500JSONResponse{
+		InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+			500,
+			v1alpha1.INTERNAL,
+			"Internal server error",
+			strPtr(detail),
+		)),
+	}
+}
+
+func (h *PolicyHandler) handleWatchPoliciesError(err error) server.WatchPoliciesResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.WatchPolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.WatchPolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.WatchPolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.WatchPolicies500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleCreateLibraryError(err error) server.CreateLibraryResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.CreateLibrary500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.CreateLibrary400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.CreateLibrary400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeAlreadyExists:
+		return server.CreateLibrary409JSONResponse{
+			AlreadyExistsJSONResponse: alreadyExistsResponse(buildErrorResponse(
+				409,
+				v1alpha1.ALREADYEXISTS,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.CreateLibrary500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleGetLibraryError(err error) server.GetLibraryResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.GetLibrary500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeNotFound:
+		return server.GetLibrary404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.GetLibrary500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleListLibrariesError(err error) server.ListLibrariesResponseObject {
+	detail := err.Error()
+	if serviceErr, ok := err.(*service.ServiceError); ok {
+		detail = serviceErr.Detail
+	}
+	return server.ListLibraries500JSONResponse{
+		InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+			500,
+			v1alpha1.INTERNAL,
+			"Internal server error",
+			strPtr(detail),
+		)),
+	}
+}
+
+func (h *PolicyHandler) handleUpdateLibraryError(err error) server.UpdateLibraryResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.UpdateLibrary500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.UpdateLibrary400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.UpdateLibrary400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeNotFound:
+		return server.UpdateLibrary404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.UpdateLibrary500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleDeleteLibraryError(err error) server.DeleteLibraryResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.DeleteLibrary500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeFailedPrecondition:
+		return server.DeleteLibrary400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeNotFound:
+		return server.DeleteLibrary404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.DeleteLibrary500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleCreateWebhookError(err error) server.CreateWebhookResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.CreateWebhook500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.CreateWebhook400JSONResponse{
 			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
 				400,
 				v1alpha1.INVALIDARGUMENT,
@@ -61,7 +868,7 @@ func (h *PolicyHandler) handleCreatePolicyError(err error, _ server.CreatePolicy
 			)),
 		}
 	case service.ErrorTypeAlreadyExists:
-		return server.CreatePolicy409JSONResponse{
+		return server.CreateWebhook409JSONResponse{
 			AlreadyExistsJSONResponse: alreadyExistsResponse(buildErrorResponse(
 				409,
 				v1alpha1.ALREADYEXISTS,
@@ -70,7 +877,7 @@ func (h *PolicyHandler) handleCreatePolicyError(err error, _ server.CreatePolicy
 			)),
 		}
 	default:
-		return server.CreatePolicy500JSONResponse{
+		return server.CreateWebhook500JSONResponse{
 			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
 				500,
 				v1alpha1.INTERNAL,
@@ -81,10 +888,10 @@ func (h *PolicyHandler) handleCreatePolicyError(err error, _ server.CreatePolicy
 	}
 }
 
-func (h *PolicyHandler) handleGetPolicyError(err error, _ server.GetPolicyRequestObject) server.GetPolicyResponseObject {
+func (h *PolicyHandler) handleGetWebhookError(err error) server.GetWebhookResponseObject {
 	serviceErr, ok := err.(*service.ServiceError)
 	if !ok {
-		return server.GetPolicy500JSONResponse{
+		return server.GetWebhook500JSONResponse{
 			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
 				500,
 				v1alpha1.INTERNAL,
@@ -96,7 +903,7 @@ func (h *PolicyHandler) handleGetPolicyError(err error, _ server.GetPolicyReques
 
 	switch serviceErr.Type {
 	case service.ErrorTypeNotFound:
-		return server.GetPolicy404JSONResponse{
+		return server.GetWebhook404JSONResponse{
 			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
 				404,
 				v1alpha1.NOTFOUND,
@@ -105,7 +912,7 @@ func (h *PolicyHandler) handleGetPolicyError(err error, _ server.GetPolicyReques
 			)),
 		}
 	default:
-		return server.GetPolicy500JSONResponse{
+		return server.GetWebhook500JSONResponse{
 			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
 				500,
 				v1alpha1.INTERNAL,
@@ -116,10 +923,25 @@ func (h *PolicyHandler) handleGetPolicyError(err error, _ server.GetPolicyReques
 	}
 }
 
-func (h *PolicyHandler) handleListPoliciesError(err error, _ server.ListPoliciesRequestObject) server.ListPoliciesResponseObject {
+func (h *PolicyHandler) handleListWebhooksError(err error) server.ListWebhooksResponseObject {
+	detail := err.Error()
+	if serviceErr, ok := err.(*service.ServiceError); ok {
+		detail = serviceErr.Detail
+	}
+	return server.ListWebhooks500JSONResponse{
+		InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+			500,
+			v1alpha1.INTERNAL,
+			"Internal server error",
+			strPtr(detail),
+		)),
+	}
+}
+
+func (h *PolicyHandler) handleUpdateWebhookError(err error) server.UpdateWebhookResponseObject {
 	serviceErr, ok := err.(*service.ServiceError)
 	if !ok {
-		return server.ListPolicies500JSONResponse{
+		return server.UpdateWebhook500JSONResponse{
 			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
 				500,
 				v1alpha1.INTERNAL,
@@ -131,7 +953,7 @@ func (h *PolicyHandler) handleListPoliciesError(err error, _ server.ListPolicies
 
 	switch serviceErr.Type {
 	case service.ErrorTypeInvalidArgument:
-		return server.ListPolicies400JSONResponse{
+		return server.UpdateWebhook400JSONResponse{
 			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
 				400,
 				v1alpha1.INVALIDARGUMENT,
@@ -139,8 +961,17 @@ func (h *PolicyHandler) handleListPoliciesError(err error, _ server.ListPolicies
 				strPtr(serviceErr.Detail),
 			)),
 		}
+	case service.ErrorTypeNotFound:
+		return server.UpdateWebhook404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
 	default:
-		return server.ListPolicies500JSONResponse{
+		return server.UpdateWebhook500JSONResponse{
 			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
 				500,
 				v1alpha1.INTERNAL,
@@ -151,10 +982,10 @@ func (h *PolicyHandler) handleListPoliciesError(err error, _ server.ListPolicies
 	}
 }
 
-func (h *PolicyHandler) handleUpdatePolicyError(err error, _ server.UpdatePolicyRequestObject) server.UpdatePolicyResponseObject {
+func (h *PolicyHandler) handleDeleteWebhookError(err error) server.DeleteWebhookResponseObject {
 	serviceErr, ok := err.(*service.ServiceError)
 	if !ok {
-		return server.UpdatePolicy500JSONResponse{
+		return server.DeleteWebhook500JSONResponse{
 			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
 				500,
 				v1alpha1.INTERNAL,
@@ -165,8 +996,184 @@ func (h *PolicyHandler) handleUpdatePolicyError(err error, _ server.UpdatePolicy
 	}
 
 	switch serviceErr.Type {
-	case service.ErrorTypeInvalidArgument, service.ErrorTypeFailedPrecondition:
-		return server.UpdatePolicy400JSONResponse{
+	case service.ErrorTypeNotFound:
+		return server.DeleteWebhook404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.DeleteWebhook500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleListWebhookDeliveriesError(err error) server.ListWebhookDeliveriesResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.ListWebhookDeliveries500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeNotFound:
+		return server.ListWebhookDeliveries404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.ListWebhookDeliveries500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleCreateProposalError(err error) server.CreateProposalResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.CreateProposal500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.CreateProposal400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.CreateProposal400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeUnauthenticated:
+		return server.CreateProposal401JSONResponse{
+			UnauthorizedJSONResponse: unauthorizedResponse(buildErrorResponse(
+				401,
+				v1alpha1.UNAUTHENTICATED,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeNotFound:
+		return server.CreateProposal404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeAlreadyExists:
+		return server.CreateProposal409JSONResponse{
+			AlreadyExistsJSONResponse: alreadyExistsResponse(buildErrorResponse(
+				409,
+				v1alpha1.ALREADYEXISTS,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.CreateProposal500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleGetProposalError(err error) server.GetProposalResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.GetProposal500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeNotFound:
+		return server.GetProposal404JSONResponse{
+			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
+				404,
+				v1alpha1.NOTFOUND,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	default:
+		return server.GetProposal500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleListProposalsError(err error) server.ListProposalsResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.ListProposals500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.ListProposals400JSONResponse{
 			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
 				400,
 				v1alpha1.INVALIDARGUMENT,
@@ -174,8 +1181,70 @@ func (h *PolicyHandler) handleUpdatePolicyError(err error, _ server.UpdatePolicy
 				strPtr(serviceErr.Detail),
 			)),
 		}
+	default:
+		return server.ListProposals500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	}
+}
+
+func (h *PolicyHandler) handleApproveProposalError(err error) server.ApproveProposalResponseObject {
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		return server.ApproveProposal500JSONResponse{
+			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
+				500,
+				v1alpha1.INTERNAL,
+				"Internal server error",
+				strPtr(err.Error()),
+			)),
+		}
+	}
+
+	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.ApproveProposal400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(withDiagnostics(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			), serviceErr.Diagnostics)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.ApproveProposal400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeUnauthenticated:
+		return server.ApproveProposal401JSONResponse{
+			UnauthorizedJSONResponse: unauthorizedResponse(buildErrorResponse(
+				401,
+				v1alpha1.UNAUTHENTICATED,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypePermissionDenied:
+		return server.ApproveProposal403JSONResponse{
+			ForbiddenJSONResponse: forbiddenResponse(buildErrorResponse(
+				403,
+				v1alpha1.PERMISSIONDENIED,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
 	case service.ErrorTypeNotFound:
-		return server.UpdatePolicy404JSONResponse{
+		return server.ApproveProposal404JSONResponse{
 			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
 				404,
 				v1alpha1.NOTFOUND,
@@ -184,7 +1253,7 @@ func (h *PolicyHandler) handleUpdatePolicyError(err error, _ server.UpdatePolicy
 			)),
 		}
 	case service.ErrorTypeAlreadyExists:
-		return server.UpdatePolicy409JSONResponse{
+		return server.ApproveProposal409JSONResponse{
 			AlreadyExistsJSONResponse: alreadyExistsResponse(buildErrorResponse(
 				409,
 				v1alpha1.ALREADYEXISTS,
@@ -193,7 +1262,7 @@ func (h *PolicyHandler) handleUpdatePolicyError(err error, _ server.UpdatePolicy
 			)),
 		}
 	default:
-		return server.UpdatePolicy500JSONResponse{
+		return server.ApproveProposal500JSONResponse{
 			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
 				500,
 				v1alpha1.INTERNAL,
@@ -204,10 +1273,10 @@ func (h *PolicyHandler) handleUpdatePolicyError(err error, _ server.UpdatePolicy
 	}
 }
 
-func (h *PolicyHandler) handleDeletePolicyError(err error, _ server.DeletePolicyRequestObject) server.DeletePolicyResponseObject {
+func (h *PolicyHandler) handleRejectProposalError(err error) server.RejectProposalResponseObject {
 	serviceErr, ok := err.(*service.ServiceError)
 	if !ok {
-		return server.DeletePolicy500JSONResponse{
+		return server.RejectProposal500JSONResponse{
 			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
 				500,
 				v1alpha1.INTERNAL,
@@ -218,8 +1287,44 @@ func (h *PolicyHandler) handleDeletePolicyError(err error, _ server.DeletePolicy
 	}
 
 	switch serviceErr.Type {
+	case service.ErrorTypeInvalidArgument:
+		return server.RejectProposal400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeFailedPrecondition:
+		return server.RejectProposal400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.FAILEDPRECONDITION,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypeUnauthenticated:
+		return server.RejectProposal401JSONResponse{
+			UnauthorizedJSONResponse: unauthorizedResponse(buildErrorResponse(
+				401,
+				v1alpha1.UNAUTHENTICATED,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
+	case service.ErrorTypePermissionDenied:
+		return server.RejectProposal403JSONResponse{
+			ForbiddenJSONResponse: forbiddenResponse(buildErrorResponse(
+				403,
+				v1alpha1.PERMISSIONDENIED,
+				serviceErr.Message,
+				strPtr(serviceErr.Detail),
+			)),
+		}
 	case service.ErrorTypeNotFound:
-		return server.DeletePolicy404JSONResponse{
+		return server.RejectProposal404JSONResponse{
 			NotFoundJSONResponse: notFoundResponse(buildErrorResponse(
 				404,
 				v1alpha1.NOTFOUND,
@@ -228,7 +1333,7 @@ func (h *PolicyHandler) handleDeletePolicyError(err error, _ server.DeletePolicy
 			)),
 		}
 	default:
-		return server.DeletePolicy500JSONResponse{
+		return server.RejectProposal500JSONResponse{
 			InternalServerErrorJSONResponse: internalErrorResponse(buildErrorResponse(
 				500,
 				v1alpha1.INTERNAL,
@@ -249,6 +1354,14 @@ func buildErrorResponse(status int32, errorType v1alpha1.ErrorType, title string
 	}
 }
 
+// withDiagnostics adds the compile diagnostics of a service error to an error response
+func withDiagnostics(e v1alpha1.Error, diagnostics []v1alpha1.RegoDiagnostic) v1alpha1.Error {
+	if len(diagnostics) > 0 {
+		e.Diagnostics = &diagnostics
+	}
+	return e
+}
+
 // strPtr returns a pointer to a string
 func strPtr(s string) *string {
 	return &s
@@ -256,11 +1369,12 @@ func strPtr(s string) *string {
 
 func serverErrorFromV1Alpha1(e v1alpha1.Error) server.Error {
 	return server.Error{
-		Detail:   e.Detail,
-		Instance: e.Instance,
-		Status:   e.Status,
-		Title:    e.Title,
-		Type:     server.ErrorType(e.Type),
+		Detail:      e.Detail,
+		Instance:    e.Instance,
+		Status:      e.Status,
+		Title:       e.Title,
+		Type:        server.ErrorType(e.Type),
+		Diagnostics: diagnosticsV1Alpha1ToServer(e.Diagnostics),
 	}
 }
 
@@ -277,6 +1391,14 @@ func alreadyExistsResponse(e v1alpha1.Error) server.AlreadyExistsJSONResponse {
 	return server.AlreadyExistsJSONResponse(serverErrorFromV1Alpha1(e))
 }
 
+func unauthorizedResponse(e v1alpha1.Error) server.UnauthorizedJSONResponse {
+	return server.UnauthorizedJSONResponse(serverErrorFromV1Alpha1(e))
+}
+
+func forbiddenResponse(e v1alpha1.Error) server.ForbiddenJSONResponse {
+	return server.ForbiddenJSONResponse(serverErrorFromV1Alpha1(e))
+}
+
 func internalErrorResponse(e v1alpha1.Error) server.InternalServerErrorJSONResponse {
 	return server.InternalServerErrorJSONResponse(serverErrorFromV1Alpha1(e))
 }
@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/logging"
+)
+
+// CreateLibrary handles creating a new library resource.
+func (h *PolicyHandler) CreateLibrary(ctx context.Context, request server.CreateLibraryRequestObject) (server.CreateLibraryResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	if request.Body == nil {
+		log.Warn("CreateLibrary called with nil body")
+		return server.CreateLibrary400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				"Invalid request body",
+				strPtr("Request body is required"),
+			)),
+		}, nil
+	}
+
+	log.Debug("CreateLibrary request received", "client_id", request.Params.Id)
+
+	created, err := h.service.CreateLibrary(ctx, libraryServerToV1Alpha1(*request.Body), request.Params.Id)
+	if err != nil {
+		logServiceError(ctx, "CreateLibrary failed", err)
+		return h.handleCreateLibraryError(err), nil
+	}
+
+	log.Info("Library created", "library_id", *created.Id)
+	return server.CreateLibrary201JSONResponse(libraryV1Alpha1ToServer(*created)), nil
+}
+
+// GetLibrary handles retrieving a single library by ID.
+func (h *PolicyHandler) GetLibrary(ctx context.Context, request server.GetLibraryRequestObject) (server.GetLibraryResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("GetLibrary request received", "library_id", request.LibraryId)
+
+	library, err := h.service.GetLibrary(ctx, request.LibraryId)
+	if err != nil {
+		logServiceError(ctx, "GetLibrary failed", err, "library_id", request.LibraryId)
+		return h.handleGetLibraryError(err), nil
+	}
+
+	return server.GetLibrary200JSONResponse(libraryV1Alpha1ToServer(*library)), nil
+}
+
+// ListLibraries handles listing all libraries.
+func (h *PolicyHandler) ListLibraries(ctx context.Context, _ server.ListLibrariesRequestObject) (server.ListLibrariesResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("ListLibraries request received")
+
+	result, err := h.service.ListLibraries(ctx)
+	if err != nil {
+		logServiceError(ctx, "ListLibraries failed", err)
+		return h.handleListLibrariesError(err), nil
+	}
+
+	libraries := make([]server.Library, len(result.Libraries))
+	for i, l := range result.Libraries {
+		libraries[i] = libraryV1Alpha1ToServer(l)
+	}
+
+	log.Debug("ListLibraries completed", "count", len(libraries))
+	return server.ListLibraries200JSONResponse{Libraries: libraries}, nil
+}
+
+// UpdateLibrary handles updating an existing library resource.
+func (h *PolicyHandler) UpdateLibrary(ctx context.Context, request server.UpdateLibraryRequestObject) (server.UpdateLibraryResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	if request.Body == nil {
+		log.Warn("UpdateLibrary called with nil body", "library_id", request.LibraryId)
+		return server.UpdateLibrary400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				"Invalid request body",
+				strPtr("Request body is required"),
+			)),
+		}, nil
+	}
+
+	log.Debug("UpdateLibrary request received", "library_id", request.LibraryId)
+
+	patch := libraryServerToV1Alpha1(*request.Body)
+	updated, err := h.service.UpdateLibrary(ctx, request.LibraryId, &patch)
+	if err != nil {
+		logServiceError(ctx, "UpdateLibrary failed", err, "library_id", request.LibraryId)
+		return h.handleUpdateLibraryError(err), nil
+	}
+
+	log.Info("Library updated", "library_id", request.LibraryId)
+	return server.UpdateLibrary200JSONResponse(libraryV1Alpha1ToServer(*updated)), nil
+}
+
+// DeleteLibrary handles deleting a library by ID.
+func (h *PolicyHandler) DeleteLibrary(ctx context.Context, request server.DeleteLibraryRequestObject) (server.DeleteLibraryResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("DeleteLibrary request received", "library_id", request.LibraryId)
+
+	if err := h.service.DeleteLibrary(ctx, request.LibraryId); err != nil {
+		logServiceError(ctx, "DeleteLibrary failed", err, "library_id", request.LibraryId)
+		return h.handleDeleteLibraryError(err), nil
+	}
+
+	log.Info("Library deleted", "library_id", request.LibraryId)
+	return server.DeleteLibrary204Response{}, nil
+}
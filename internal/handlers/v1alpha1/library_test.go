@@ -0,0 +1,136 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Library handlers", func() {
+	var (
+		handler     *PolicyHandler
+		mockService *MockPolicyService
+		ctx         context.Context
+	)
+
+	BeforeEach(func() {
+		mockService = &MockPolicyService{}
+		handler = NewPolicyHandler(mockService)
+		ctx = context.Background()
+	})
+
+	Describe("CreateLibrary", func() {
+		It("should return 201 with the created library", func() {
+			var receivedID *string
+			mockService.CreateLibraryFn = func(_ context.Context, library v1alpha1.Library, clientID *string) (*v1alpha1.Library, error) {
+				receivedID = clientID
+				return &v1alpha1.Library{Id: clientID, Package: strPtr("lib.regions"), RegoCode: library.RegoCode}, nil
+			}
+
+			response, err := handler.CreateLibrary(ctx, server.CreateLibraryRequestObject{
+				Params: server.CreateLibraryParams{Id: strPtr("regions")},
+				Body:   &server.Library{RegoCode: strPtr("package lib.regions")},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*receivedID).To(Equal("regions"))
+			created, ok := response.(server.CreateLibrary201JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreateLibrary201JSONResponse")
+			Expect(*created.Package).To(Equal("lib.regions"))
+		})
+
+		It("should return 409 when the library exists", func() {
+			mockService.CreateLibraryFn = func(_ context.Context, _ v1alpha1.Library, _ *string) (*v1alpha1.Library, error) {
+				return nil, service.NewLibraryAlreadyExistsError("regions")
+			}
+
+			response, err := handler.CreateLibrary(ctx, server.CreateLibraryRequestObject{
+				Body: &server.Library{RegoCode: strPtr("package lib.regions")},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.CreateLibrary409JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreateLibrary409JSONResponse")
+		})
+
+		It("should return 400 FAILED_PRECONDITION when changes require approval", func() {
+			mockService.CreateLibraryFn = func(_ context.Context, _ v1alpha1.Library, _ *string) (*v1alpha1.Library, error) {
+				return nil, service.NewLibraryApprovalRequiredError()
+			}
+
+			response, err := handler.CreateLibrary(ctx, server.CreateLibraryRequestObject{
+				Body: &server.Library{RegoCode: strPtr("package lib.regions")},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.CreateLibrary400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreateLibrary400JSONResponse")
+			Expect(badRequest.Type).To(Equal(server.FAILEDPRECONDITION))
+		})
+	})
+
+	Describe("GetLibrary", func() {
+		It("should return 404 for an unknown library", func() {
+			mockService.GetLibraryFn = func(_ context.Context, id string) (*v1alpha1.Library, error) {
+				return nil, service.NewLibraryNotFoundError(id)
+			}
+
+			response, err := handler.GetLibrary(ctx, server.GetLibraryRequestObject{LibraryId: "missing"})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.GetLibrary404JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be GetLibrary404JSONResponse")
+		})
+	})
+
+	Describe("ListLibraries", func() {
+		It("should return every library", func() {
+			mockService.ListLibrariesFn = func(_ context.Context) (*v1alpha1.LibraryList, error) {
+				return &v1alpha1.LibraryList{Libraries: []v1alpha1.Library{{Id: strPtr("a")}, {Id: strPtr("b")}}}, nil
+			}
+
+			response, err := handler.ListLibraries(ctx, server.ListLibrariesRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			list, ok := response.(server.ListLibraries200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ListLibraries200JSONResponse")
+			Expect(list.Libraries).To(HaveLen(2))
+		})
+	})
+
+	Describe("DeleteLibrary", func() {
+		It("should return 400 FAILED_PRECONDITION with the broken policies when the library is in use", func() {
+			mockService.DeleteLibraryFn = func(_ context.Context, id string) error {
+				return service.NewLibraryInUseError(id, &opa.CompileError{Diagnostics: []opa.Diagnostic{{
+					PolicyID: "region-enforcement",
+					Line:     7,
+					Column:   16,
+					Severity: opa.SeverityError,
+					Code:     "rego_type_error",
+					Message:  "undefined function data.lib.regions.allowed",
+				}}})
+			}
+
+			response, err := handler.DeleteLibrary(ctx, server.DeleteLibraryRequestObject{LibraryId: "regions"})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.DeleteLibrary400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be DeleteLibrary400JSONResponse")
+			Expect(badRequest.Type).To(Equal(server.FAILEDPRECONDITION))
+			Expect(badRequest.Diagnostics).NotTo(BeNil())
+			Expect(*badRequest.Diagnostics).To(ConsistOf(HaveField("PolicyId", HaveValue(Equal("region-enforcement")))))
+		})
+
+		It("should return 204 on success", func() {
+			response, err := handler.DeleteLibrary(ctx, server.DeleteLibraryRequestObject{LibraryId: "regions"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(server.DeleteLibrary204Response{}))
+		})
+	})
+})
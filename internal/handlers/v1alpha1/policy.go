@@ -2,6 +2,7 @@
 package v1alpha1
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/dcm-project/policy-manager/api/v1alpha1"
@@ -28,8 +29,9 @@ func (h *PolicyHandler) GetHealth(_ context.Context, _ server.GetHealthRequestOb
 	status := "ok"
 	path := "health"
 	return server.GetHealth200JSONResponse{
-		Status: status,
-		Path:   &path,
+		Status:     status,
+		Generation: h.service.CompiledGeneration(),
+		Path:       &path,
 	}, nil
 }
 
@@ -55,12 +57,24 @@ func (h *PolicyHandler) CreatePolicy(ctx context.Context, request server.CreateP
 	v1Alpha1Policy := policyServerToV1Alpha1(*request.Body)
 
 	// Call service to create policy
-	created, err := h.service.CreatePolicy(ctx, v1Alpha1Policy, request.Params.Id)
+	opts := service.PolicyWriteOptions{
+		RequirePassingTests: request.Params.RequirePassingTests != nil && *request.Params.RequirePassingTests,
+		ValidateOnly:        request.Params.ValidateOnly != nil && *request.Params.ValidateOnly,
+	}
+	if request.Params.Placement != nil {
+		opts.Placement = *request.Params.Placement
+	}
+	created, err := h.service.CreatePolicy(ctx, v1Alpha1Policy, request.Params.Id, opts)
 	if err != nil {
 		logServiceError(ctx, "CreatePolicy failed", err)
 		return h.handleCreatePolicyError(err, request), nil
 	}
 
+	if opts.ValidateOnly {
+		log.Debug("Policy creation validated", "policy_id", *created.Id)
+		return server.CreatePolicy200JSONResponse(policyV1Alpha1ToServer(*created)), nil
+	}
+
 	log.Info("Policy created", "policy_id", *created.Id)
 	// Convert back to server.Policy
 	return server.CreatePolicy201JSONResponse{
@@ -133,12 +147,24 @@ func (h *PolicyHandler) UpdatePolicy(ctx context.Context, request server.UpdateP
 	patch := policyServerToV1Alpha1(*request.Body)
 
 	// Call service to update policy (merge patch onto existing)
-	updated, err := h.service.UpdatePolicy(ctx, request.PolicyId, &patch)
+	opts := service.PolicyWriteOptions{
+		RequirePassingTests: request.Params.RequirePassingTests != nil && *request.Params.RequirePassingTests,
+		ValidateOnly:        request.Params.ValidateOnly != nil && *request.Params.ValidateOnly,
+	}
+	if request.Params.UpdateMask != nil {
+		opts.UpdateMask = *request.Params.UpdateMask
+	}
+	updated, err := h.service.UpdatePolicy(ctx, request.PolicyId, &patch, opts)
 	if err != nil {
 		logServiceError(ctx, "UpdatePolicy failed", err, "policy_id", request.PolicyId)
 		return h.handleUpdatePolicyError(err, request), nil
 	}
 
+	if opts.ValidateOnly {
+		log.Debug("Policy update validated", "policy_id", request.PolicyId)
+		return server.UpdatePolicy200JSONResponse(policyV1Alpha1ToServer(*updated)), nil
+	}
+
 	log.Info("Policy updated", "policy_id", request.PolicyId)
 	return server.UpdatePolicy200JSONResponse(policyV1Alpha1ToServer(*updated)), nil
 }
@@ -158,3 +184,92 @@ func (h *PolicyHandler) DeletePolicy(ctx context.Context, request server.DeleteP
 	log.Info("Policy deleted", "policy_id", request.PolicyId)
 	return server.DeletePolicy204Response{}, nil
 }
+
+// TestPolicy handles running the Rego tests of a policy.
+func (h *PolicyHandler) TestPolicy(ctx context.Context, request server.TestPolicyRequestObject) (server.TestPolicyResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("TestPolicy request received", "policy_id", request.PolicyId)
+
+	report, err := h.service.TestPolicy(ctx, request.PolicyId)
+	if err != nil {
+		logServiceError(ctx, "TestPolicy failed", err, "policy_id", request.PolicyId)
+		return h.handleTestPolicyError(err, request), nil
+	}
+
+	log.Info("Policy tests run", "policy_id", request.PolicyId, "test_count", len(report.Results), "passed", report.Passed)
+	return server.TestPolicy200JSONResponse(testReportV1Alpha1ToServer(*report)), nil
+}
+
+// ReorderPolicies handles setting the evaluation order of the policies of a policy type.
+func (h *PolicyHandler) ReorderPolicies(ctx context.Context, request server.ReorderPoliciesRequestObject) (server.ReorderPoliciesResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	if request.Body == nil {
+		log.Warn("ReorderPolicies called with nil body")
+		return server.ReorderPolicies400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				"Invalid request body",
+				strPtr("Request body is required"),
+			)),
+		}, nil
+	}
+
+	log.Debug("ReorderPolicies request received", "policy_type", request.Body.PolicyType, "policy_count", len(request.Body.PolicyIds))
+
+	reordered, err := h.service.ReorderPolicies(ctx, request.Body.PolicyType, request.Body.PolicyIds)
+	if err != nil {
+		logServiceError(ctx, "ReorderPolicies failed", err, "policy_type", request.Body.PolicyType)
+		return h.handleReorderPoliciesError(err, request), nil
+	}
+
+	log.Info("Policies reordered", "policy_type", request.Body.PolicyType, "policy_count", len(reordered))
+	return server.ReorderPolicies200JSONResponse{Policies: policiesV1Alpha1ToServer(reordered)}, nil
+}
+
+// ImportPolicyBundle handles importing policies from an OPA bundle.
+func (h *PolicyHandler) ImportPolicyBundle(ctx context.Context, request server.ImportPolicyBundleRequestObject) (server.ImportPolicyBundleResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	if request.Body == nil {
+		log.Warn("ImportPolicyBundle called with nil body")
+		return server.ImportPolicyBundle400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				"Invalid request body",
+				strPtr("Request body is required"),
+			)),
+		}, nil
+	}
+
+	log.Debug("ImportPolicyBundle request received")
+
+	imported, err := h.service.ImportBundle(ctx, request.Body)
+	if err != nil {
+		logServiceError(ctx, "ImportPolicyBundle failed", err)
+		return h.handleImportPolicyBundleError(err, request), nil
+	}
+
+	log.Info("Policy bundle imported", "policy_count", len(imported))
+	return server.ImportPolicyBundle200JSONResponse{Policies: policiesV1Alpha1ToServer(imported)}, nil
+}
+
+// ExportPolicyBundle handles exporting all policies as an OPA bundle.
+func (h *PolicyHandler) ExportPolicyBundle(ctx context.Context, _ server.ExportPolicyBundleRequestObject) (server.ExportPolicyBundleResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("ExportPolicyBundle request received")
+
+	var buf bytes.Buffer
+	if err := h.service.ExportBundle(ctx, &buf); err != nil {
+		logServiceError(ctx, "ExportPolicyBundle failed", err)
+		return h.handleExportPolicyBundleError(err, server.ExportPolicyBundleRequestObject{}), nil
+	}
+
+	log.Debug("ExportPolicyBundle completed", "size", buf.Len())
+	return server.ExportPolicyBundle200ApplicationgzipResponse{
+		Body:          &buf,
+		ContentLength: int64(buf.Len()),
+	}, nil
+}
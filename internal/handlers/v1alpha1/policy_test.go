@@ -2,9 +2,12 @@ package v1alpha1
 
 import (
 	"context"
+	"io"
+	"strings"
 
 	"github.com/dcm-project/policy-manager/api/v1alpha1"
 	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/opa"
 	"github.com/dcm-project/policy-manager/internal/service"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -12,20 +15,61 @@ import (
 
 // MockPolicyService is a mock implementation of PolicyService for testing
 type MockPolicyService struct {
-	CreatePolicyFn func(ctx context.Context, policy v1alpha1.Policy, clientID *string) (*v1alpha1.Policy, error)
+	CompiledGenerationFn func() int64
+
+	CreatePolicyFn func(ctx context.Context, policy v1alpha1.Policy, clientID *string, opts service.PolicyWriteOptions) (*v1alpha1.Policy, error)
 	GetPolicyFn    func(ctx context.Context, id string) (*v1alpha1.Policy, error)
 	ListPoliciesFn func(ctx context.Context, filter *string, orderBy *string, pageToken *string, pageSize *int32) (*v1alpha1.PolicyList, error)
-	UpdatePolicyFn func(ctx context.Context, id string, patch *v1alpha1.Policy) (*v1alpha1.Policy, error)
+	UpdatePolicyFn func(ctx context.Context, id string, patch *v1alpha1.Policy, opts service.PolicyWriteOptions) (*v1alpha1.Policy, error)
 	DeletePolicyFn func(ctx context.Context, id string) error
+	TestPolicyFn   func(ctx context.Context, id string) (*v1alpha1.PolicyTestReport, error)
+	ImportBundleFn func(ctx context.Context, r io.Reader) ([]v1alpha1.Policy, error)
+	ExportBundleFn func(ctx context.Context, w io.Writer) error
+
+	CreateLibraryFn func(ctx context.Context, library v1alpha1.Library, clientID *string) (*v1alpha1.Library, error)
+	GetLibraryFn    func(ctx context.Context, id string) (*v1alpha1.Library, error)
+	ListLibrariesFn func(ctx context.Context) (*v1alpha1.LibraryList, error)
+	UpdateLibraryFn func(ctx context.Context, id string, patch *v1alpha1.Library) (*v1alpha1.Library, error)
+	DeleteLibraryFn func(ctx context.Context, id string) error
+
+	ReorderPoliciesFn func(ctx context.Context, policyType string, ids []string) ([]v1alpha1.Policy, error)
+
+	BatchCreatePoliciesFn func(ctx context.Context, requests []v1alpha1.PolicyCreateRequest, opts service.PolicyWriteOptions) ([]v1alpha1.Policy, error)
+	BatchUpdatePoliciesFn func(ctx context.Context, requests []v1alpha1.PolicyUpdateRequest, opts service.PolicyWriteOptions) ([]v1alpha1.Policy, error)
+	BatchDeletePoliciesFn func(ctx context.Context, ids []string) error
+	WatchPoliciesFn       func(ctx context.Context, lastEventID string) (*service.PolicyWatch, error)
+
+	CreateWebhookFn         func(ctx context.Context, webhook v1alpha1.Webhook, clientID *string) (*v1alpha1.Webhook, error)
+	GetWebhookFn            func(ctx context.Context, id string) (*v1alpha1.Webhook, error)
+	ListWebhooksFn          func(ctx context.Context) (*v1alpha1.WebhookList, error)
+	UpdateWebhookFn         func(ctx context.Context, id string, patch *v1alpha1.Webhook) (*v1alpha1.Webhook, error)
+	DeleteWebhookFn         func(ctx context.Context, id string) error
+	ListWebhookDeliveriesFn func(ctx context.Context, id string) (*v1alpha1.WebhookDeliveryList, error)
+	CreateProposalFn        func(ctx context.Context, proposal v1alpha1.PolicyProposal) (*v1alpha1.PolicyProposal, error)
+	GetProposalFn           func(ctx context.Context, id string) (*v1alpha1.PolicyProposal, error)
+	ListProposalsFn         func(ctx context.Context, state string) (*v1alpha1.PolicyProposalList, error)
+	ApproveProposalFn       func(ctx context.Context, id string, comment string) (*v1alpha1.PolicyProposal, error)
+	RejectProposalFn        func(ctx context.Context, id string, comment string) (*v1alpha1.PolicyProposal, error)
 }
 
 func (m *MockPolicyService) CompileAll(_ context.Context) error {
 	return nil
 }
 
-func (m *MockPolicyService) CreatePolicy(ctx context.Context, policy v1alpha1.Policy, clientID *string) (*v1alpha1.Policy, error) {
+func (m *MockPolicyService) SyncEngine(_ context.Context) error {
+	return nil
+}
+
+func (m *MockPolicyService) CompiledGeneration() int64 {
+	if m.CompiledGenerationFn != nil {
+		return m.CompiledGenerationFn()
+	}
+	return 0
+}
+
+func (m *MockPolicyService) CreatePolicy(ctx context.Context, policy v1alpha1.Policy, clientID *string, opts service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
 	if m.CreatePolicyFn != nil {
-		return m.CreatePolicyFn(ctx, policy, clientID)
+		return m.CreatePolicyFn(ctx, policy, clientID, opts)
 	}
 	return nil, nil
 }
@@ -44,9 +88,9 @@ func (m *MockPolicyService) ListPolicies(ctx context.Context, filter *string, or
 	return nil, nil
 }
 
-func (m *MockPolicyService) UpdatePolicy(ctx context.Context, id string, patch *v1alpha1.Policy) (*v1alpha1.Policy, error) {
+func (m *MockPolicyService) UpdatePolicy(ctx context.Context, id string, patch *v1alpha1.Policy, opts service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
 	if m.UpdatePolicyFn != nil {
-		return m.UpdatePolicyFn(ctx, id, patch)
+		return m.UpdatePolicyFn(ctx, id, patch, opts)
 	}
 	return nil, nil
 }
@@ -58,6 +102,174 @@ func (m *MockPolicyService) DeletePolicy(ctx context.Context, id string) error {
 	return nil
 }
 
+func (m *MockPolicyService) TestPolicy(ctx context.Context, id string) (*v1alpha1.PolicyTestReport, error) {
+	if m.TestPolicyFn != nil {
+		return m.TestPolicyFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) ReorderPolicies(ctx context.Context, policyType string, ids []string) ([]v1alpha1.Policy, error) {
+	if m.ReorderPoliciesFn != nil {
+		return m.ReorderPoliciesFn(ctx, policyType, ids)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) BatchCreatePolicies(ctx context.Context, requests []v1alpha1.PolicyCreateRequest, opts service.PolicyWriteOptions) ([]v1alpha1.Policy, error) {
+	if m.BatchCreatePoliciesFn != nil {
+		return m.BatchCreatePoliciesFn(ctx, requests, opts)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) BatchUpdatePolicies(ctx context.Context, requests []v1alpha1.PolicyUpdateRequest, opts service.PolicyWriteOptions) ([]v1alpha1.Policy, error) {
+	if m.BatchUpdatePoliciesFn != nil {
+		return m.BatchUpdatePoliciesFn(ctx, requests, opts)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) BatchDeletePolicies(ctx context.Context, ids []string) error {
+	if m.BatchDeletePoliciesFn != nil {
+		return m.BatchDeletePoliciesFn(ctx, ids)
+	}
+	return nil
+}
+
+func (m *MockPolicyService) WatchPolicies(ctx context.Context, lastEventID string) (*service.PolicyWatch, error) {
+	if m.WatchPoliciesFn != nil {
+		return m.WatchPoliciesFn(ctx, lastEventID)
+	}
+	return nil, service.NewInternalError("not implemented", "", nil)
+}
+
+func (m *MockPolicyService) ImportBundle(ctx context.Context, r io.Reader) ([]v1alpha1.Policy, error) {
+	if m.ImportBundleFn != nil {
+		return m.ImportBundleFn(ctx, r)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) ExportBundle(ctx context.Context, w io.Writer) error {
+	if m.ExportBundleFn != nil {
+		return m.ExportBundleFn(ctx, w)
+	}
+	return nil
+}
+
+func (m *MockPolicyService) CreateLibrary(ctx context.Context, library v1alpha1.Library, clientID *string) (*v1alpha1.Library, error) {
+	if m.CreateLibraryFn != nil {
+		return m.CreateLibraryFn(ctx, library, clientID)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) GetLibrary(ctx context.Context, id string) (*v1alpha1.Library, error) {
+	if m.GetLibraryFn != nil {
+		return m.GetLibraryFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) ListLibraries(ctx context.Context) (*v1alpha1.LibraryList, error) {
+	if m.ListLibrariesFn != nil {
+		return m.ListLibrariesFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) UpdateLibrary(ctx context.Context, id string, patch *v1alpha1.Library) (*v1alpha1.Library, error) {
+	if m.UpdateLibraryFn != nil {
+		return m.UpdateLibraryFn(ctx, id, patch)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) DeleteLibrary(ctx context.Context, id string) error {
+	if m.DeleteLibraryFn != nil {
+		return m.DeleteLibraryFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockPolicyService) CreateWebhook(ctx context.Context, webhook v1alpha1.Webhook, clientID *string) (*v1alpha1.Webhook, error) {
+	if m.CreateWebhookFn != nil {
+		return m.CreateWebhookFn(ctx, webhook, clientID)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) GetWebhook(ctx context.Context, id string) (*v1alpha1.Webhook, error) {
+	if m.GetWebhookFn != nil {
+		return m.GetWebhookFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) ListWebhooks(ctx context.Context) (*v1alpha1.WebhookList, error) {
+	if m.ListWebhooksFn != nil {
+		return m.ListWebhooksFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) UpdateWebhook(ctx context.Context, id string, patch *v1alpha1.Webhook) (*v1alpha1.Webhook, error) {
+	if m.UpdateWebhookFn != nil {
+		return m.UpdateWebhookFn(ctx, id, patch)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) DeleteWebhook(ctx context.Context, id string) error {
+	if m.DeleteWebhookFn != nil {
+		return m.DeleteWebhookFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockPolicyService) ListWebhookDeliveries(ctx context.Context, id string) (*v1alpha1.WebhookDeliveryList, error) {
+	if m.ListWebhookDeliveriesFn != nil {
+		return m.ListWebhookDeliveriesFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) CreateProposal(ctx context.Context, proposal v1alpha1.PolicyProposal) (*v1alpha1.PolicyProposal, error) {
+	if m.CreateProposalFn != nil {
+		return m.CreateProposalFn(ctx, proposal)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) GetProposal(ctx context.Context, id string) (*v1alpha1.PolicyProposal, error) {
+	if m.GetProposalFn != nil {
+		return m.GetProposalFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) ListProposals(ctx context.Context, state string) (*v1alpha1.PolicyProposalList, error) {
+	if m.ListProposalsFn != nil {
+		return m.ListProposalsFn(ctx, state)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) ApproveProposal(ctx context.Context, id string, comment string) (*v1alpha1.PolicyProposal, error) {
+	if m.ApproveProposalFn != nil {
+		return m.ApproveProposalFn(ctx, id, comment)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyService) RejectProposal(ctx context.Context, id string, comment string) (*v1alpha1.PolicyProposal, error) {
+	if m.RejectProposalFn != nil {
+		return m.RejectProposalFn(ctx, id, comment)
+	}
+	return nil, nil
+}
+
 var _ = Describe("PolicyHandler", func() {
 	var handler *PolicyHandler
 	var mockService *MockPolicyService
@@ -84,6 +296,15 @@ var _ = Describe("PolicyHandler", func() {
 			Expect(healthResponse.Path).NotTo(BeNil())
 			Expect(*healthResponse.Path).To(Equal("health"))
 		})
+
+		It("should report the compiled policy set generation", func() {
+			mockService.CompiledGenerationFn = func() int64 { return 42 }
+
+			response, err := handler.GetHealth(context.Background(), server.GetHealthRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.(server.GetHealth200JSONResponse).Generation).To(Equal(int64(42)))
+		})
 	})
 
 	Describe("CreatePolicy", func() {
@@ -95,7 +316,7 @@ var _ = Describe("PolicyHandler", func() {
 			priority := int32(500)
 
 			regoCode := ""
-			mockService.CreatePolicyFn = func(_ context.Context, policy v1alpha1.Policy, _ *string) (*v1alpha1.Policy, error) {
+			mockService.CreatePolicyFn = func(_ context.Context, policy v1alpha1.Policy, _ *string, _ service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
 				return &v1alpha1.Policy{
 					Id:          &policyID,
 					Path:        &path,
@@ -143,7 +364,7 @@ var _ = Describe("PolicyHandler", func() {
 		It("should return 409 when policy already exists", func() {
 			ctx := context.Background()
 
-			mockService.CreatePolicyFn = func(_ context.Context, _ v1alpha1.Policy, _ *string) (*v1alpha1.Policy, error) {
+			mockService.CreatePolicyFn = func(_ context.Context, _ v1alpha1.Policy, _ *string, _ service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
 				return nil, service.NewAlreadyExistsError("Policy already exists", "Duplicate ID")
 			}
 
@@ -164,6 +385,131 @@ var _ = Describe("PolicyHandler", func() {
 			_, ok := response.(server.CreatePolicy409JSONResponse)
 			Expect(ok).To(BeTrue(), "response should be CreatePolicy409JSONResponse")
 		})
+
+		It("should pass require_passing_tests and return 400 FAILED_PRECONDITION when tests fail", func() {
+			ctx := context.Background()
+
+			var receivedOpts service.PolicyWriteOptions
+			mockService.CreatePolicyFn = func(_ context.Context, _ v1alpha1.Policy, _ *string, opts service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
+				receivedOpts = opts
+				return nil, service.NewFailedPreconditionError("Policy tests failed", "1 test(s) failed")
+			}
+
+			displayName := "Test Policy"
+			regoCodeReq := "package test"
+			pt := server.GLOBAL
+			requirePassingTests := true
+			response, err := handler.CreatePolicy(ctx, server.CreatePolicyRequestObject{
+				Params: server.CreatePolicyParams{RequirePassingTests: &requirePassingTests},
+				Body: &server.Policy{
+					DisplayName: &displayName,
+					PolicyType:  &pt,
+					RegoCode:    &regoCodeReq,
+				},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receivedOpts.RequirePassingTests).To(BeTrue())
+			badRequest, ok := response.(server.CreatePolicy400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreatePolicy400JSONResponse")
+			Expect(badRequest.Type).To(Equal(server.FAILEDPRECONDITION))
+		})
+
+		It("should return 200 without creating when validate_only is set", func() {
+			ctx := context.Background()
+
+			policyID := "validated"
+			displayName := "Test Policy"
+			regoCode := "package test"
+			pt := v1alpha1.GLOBAL
+			var receivedOpts service.PolicyWriteOptions
+			mockService.CreatePolicyFn = func(_ context.Context, _ v1alpha1.Policy, _ *string, opts service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
+				receivedOpts = opts
+				return &v1alpha1.Policy{Id: &policyID, DisplayName: &displayName, PolicyType: &pt, RegoCode: &regoCode}, nil
+			}
+
+			serverPT := server.GLOBAL
+			validateOnly := true
+			response, err := handler.CreatePolicy(ctx, server.CreatePolicyRequestObject{
+				Params: server.CreatePolicyParams{Id: &policyID, ValidateOnly: &validateOnly},
+				Body: &server.Policy{
+					DisplayName: &displayName,
+					PolicyType:  &serverPT,
+					RegoCode:    &regoCode,
+				},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receivedOpts.ValidateOnly).To(BeTrue())
+			validated, ok := response.(server.CreatePolicy200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreatePolicy200JSONResponse")
+			Expect(*validated.Id).To(Equal(policyID))
+		})
+
+		It("should pass the placement to the service", func() {
+			ctx := context.Background()
+
+			policyID := "placed"
+			var receivedOpts service.PolicyWriteOptions
+			mockService.CreatePolicyFn = func(_ context.Context, _ v1alpha1.Policy, _ *string, opts service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
+				receivedOpts = opts
+				return &v1alpha1.Policy{Id: &policyID}, nil
+			}
+
+			placement := "after:global-auth-policy"
+			response, err := handler.CreatePolicy(ctx, server.CreatePolicyRequestObject{
+				Params: server.CreatePolicyParams{Id: &policyID, Placement: &placement},
+				Body:   &server.Policy{},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receivedOpts.Placement).To(Equal(placement))
+			_, ok := response.(server.CreatePolicy201JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreatePolicy201JSONResponse")
+		})
+
+		It("should return the compile diagnostics with 400 INVALID_ARGUMENT", func() {
+			ctx := context.Background()
+
+			rule := "main"
+			mockService.CreatePolicyFn = func(_ context.Context, _ v1alpha1.Policy, _ *string, _ service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
+				serviceErr := service.NewInvalidArgumentError("Invalid Rego code", "The policy set does not compile: 1 problem(s) found")
+				serviceErr.Diagnostics = []v1alpha1.RegoDiagnostic{{
+					PolicyId: strPtr("broken"),
+					Line:     3,
+					Column:   20,
+					Code:     "rego_type_error",
+					Message:  "undefined function data.lib.missing.f",
+					Rule:     &rule,
+				}}
+				return nil, serviceErr
+			}
+
+			displayName := "Test Policy"
+			regoCode := "package test"
+			pt := server.GLOBAL
+			response, err := handler.CreatePolicy(ctx, server.CreatePolicyRequestObject{
+				Body: &server.Policy{
+					DisplayName: &displayName,
+					PolicyType:  &pt,
+					RegoCode:    &regoCode,
+				},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.CreatePolicy400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreatePolicy400JSONResponse")
+			Expect(badRequest.Type).To(Equal(server.INVALIDARGUMENT))
+			Expect(badRequest.Diagnostics).NotTo(BeNil())
+			Expect(*badRequest.Diagnostics).To(Equal([]server.RegoDiagnostic{{
+				PolicyId: strPtr("broken"),
+				Line:     3,
+				Column:   20,
+				Code:     "rego_type_error",
+				Message:  "undefined function data.lib.missing.f",
+				Rule:     &rule,
+			}}))
+		})
 	})
 
 	Describe("GetPolicy", func() {
@@ -340,7 +686,7 @@ var _ = Describe("PolicyHandler", func() {
 			priority := int32(200)
 			regoCodeEmpty := ""
 			pt := v1alpha1.GLOBAL
-			mockService.UpdatePolicyFn = func(_ context.Context, _ string, patch *v1alpha1.Policy) (*v1alpha1.Policy, error) {
+			mockService.UpdatePolicyFn = func(_ context.Context, _ string, patch *v1alpha1.Policy, _ service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
 				displayName := "Updated Policy"
 				if patch != nil && patch.DisplayName != nil {
 					displayName = *patch.DisplayName
@@ -376,6 +722,27 @@ var _ = Describe("PolicyHandler", func() {
 			Expect(*updateResponse.DisplayName).To(Equal("Updated Policy"))
 		})
 
+		It("should pass the update mask to the service", func() {
+			ctx := context.Background()
+			var received service.PolicyWriteOptions
+			mockService.UpdatePolicyFn = func(_ context.Context, id string, _ *v1alpha1.Policy, opts service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
+				received = opts
+				return &v1alpha1.Policy{Id: &id}, nil
+			}
+
+			mask := server.UpdateMask{"effective_from", "effective_until"}
+			response, err := handler.UpdatePolicy(ctx, server.UpdatePolicyRequestObject{
+				PolicyId: "test-policy",
+				Params:   server.UpdatePolicyParams{UpdateMask: &mask},
+				Body:     &server.Policy{},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.UpdatePolicy200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be UpdatePolicy200JSONResponse")
+			Expect(received.UpdateMask).To(Equal([]string{"effective_from", "effective_until"}))
+		})
+
 		It("should return 400 when body is nil", func() {
 			ctx := context.Background()
 
@@ -392,7 +759,7 @@ var _ = Describe("PolicyHandler", func() {
 		It("should return 404 when policy not found", func() {
 			ctx := context.Background()
 
-			mockService.UpdatePolicyFn = func(_ context.Context, _ string, _ *v1alpha1.Policy) (*v1alpha1.Policy, error) {
+			mockService.UpdatePolicyFn = func(_ context.Context, _ string, _ *v1alpha1.Policy, _ service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
 				return nil, service.NewNotFoundError("Policy not found", "Not found")
 			}
 
@@ -412,6 +779,25 @@ var _ = Describe("PolicyHandler", func() {
 			_, ok := response.(server.UpdatePolicy404JSONResponse)
 			Expect(ok).To(BeTrue(), "response should be UpdatePolicy404JSONResponse")
 		})
+
+		It("should return 400 FAILED_PRECONDITION when the policy is managed", func() {
+			ctx := context.Background()
+
+			mockService.UpdatePolicyFn = func(_ context.Context, id string, _ *v1alpha1.Policy, _ service.PolicyWriteOptions) (*v1alpha1.Policy, error) {
+				return nil, service.NewPolicyManagedError(id)
+			}
+
+			displayName := "Updated Policy"
+			response, err := handler.UpdatePolicy(ctx, server.UpdatePolicyRequestObject{
+				PolicyId: "managed-policy",
+				Body:     &server.Policy{DisplayName: &displayName},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.UpdatePolicy400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be UpdatePolicy400JSONResponse")
+			Expect(badRequest.Type).To(Equal(server.FAILEDPRECONDITION))
+		})
 	})
 
 	Describe("DeletePolicy", func() {
@@ -446,5 +832,254 @@ var _ = Describe("PolicyHandler", func() {
 			_, ok := response.(server.DeletePolicy404JSONResponse)
 			Expect(ok).To(BeTrue(), "response should be DeletePolicy404JSONResponse")
 		})
+
+		It("should return 400 FAILED_PRECONDITION when the policy is managed", func() {
+			ctx := context.Background()
+
+			mockService.DeletePolicyFn = func(_ context.Context, id string) error {
+				return service.NewPolicyManagedError(id)
+			}
+
+			response, err := handler.DeletePolicy(ctx, server.DeletePolicyRequestObject{
+				PolicyId: "managed-policy",
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.DeletePolicy400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be DeletePolicy400JSONResponse")
+			Expect(badRequest.Type).To(Equal(server.FAILEDPRECONDITION))
+		})
+	})
+
+	Describe("TestPolicy", func() {
+		It("should return 200 with the test report", func() {
+			ctx := context.Background()
+			message := "failed at line 6: x == 1"
+
+			mockService.TestPolicyFn = func(_ context.Context, _ string) (*v1alpha1.PolicyTestReport, error) {
+				return &v1alpha1.PolicyTestReport{
+					Passed: false,
+					Results: []v1alpha1.PolicyTestResult{
+						{Name: "data.p_test.test_ok", Status: v1alpha1.PASSED, Duration: "0.0001s"},
+						{Name: "data.p_test.test_x", Status: v1alpha1.FAILED, Duration: "0.0002s", Message: &message},
+					},
+				}, nil
+			}
+
+			response, err := handler.TestPolicy(ctx, server.TestPolicyRequestObject{PolicyId: "test-policy"})
+
+			Expect(err).NotTo(HaveOccurred())
+			report, ok := response.(server.TestPolicy200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be TestPolicy200JSONResponse")
+			Expect(report.Passed).To(BeFalse())
+			Expect(report.Results).To(HaveLen(2))
+			Expect(report.Results[1].Status).To(Equal(server.FAILED))
+			Expect(*report.Results[1].Message).To(Equal(message))
+		})
+
+		It("should return 404 when policy not found", func() {
+			ctx := context.Background()
+
+			mockService.TestPolicyFn = func(_ context.Context, id string) (*v1alpha1.PolicyTestReport, error) {
+				return nil, service.NewPolicyNotFoundError(id)
+			}
+
+			response, err := handler.TestPolicy(ctx, server.TestPolicyRequestObject{PolicyId: "non-existent"})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.TestPolicy404JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be TestPolicy404JSONResponse")
+		})
+
+		It("should return 400 FAILED_PRECONDITION when the tests do not compile", func() {
+			ctx := context.Background()
+
+			mockService.TestPolicyFn = func(_ context.Context, _ string) (*v1alpha1.PolicyTestReport, error) {
+				return nil, service.NewFailedPreconditionError("Policy tests do not compile", "undefined function")
+			}
+
+			response, err := handler.TestPolicy(ctx, server.TestPolicyRequestObject{PolicyId: "test-policy"})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.TestPolicy400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be TestPolicy400JSONResponse")
+			Expect(badRequest.Type).To(Equal(server.FAILEDPRECONDITION))
+		})
+	})
+
+	Describe("ReorderPolicies", func() {
+		It("should return 200 with the policies in their new order", func() {
+			first, second := "first", "second"
+			var receivedType string
+			var receivedIDs []string
+			mockService.ReorderPoliciesFn = func(_ context.Context, policyType string, ids []string) ([]v1alpha1.Policy, error) {
+				receivedType, receivedIDs = policyType, ids
+				return []v1alpha1.Policy{{Id: &second}, {Id: &first}}, nil
+			}
+
+			response, err := handler.ReorderPolicies(context.Background(), server.ReorderPoliciesRequestObject{
+				Body: &server.ReorderPoliciesJSONRequestBody{PolicyType: "GLOBAL", PolicyIds: []string{second, first}},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receivedType).To(Equal("GLOBAL"))
+			Expect(receivedIDs).To(Equal([]string{second, first}))
+			reorderResponse, ok := response.(server.ReorderPolicies200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ReorderPolicies200JSONResponse")
+			Expect(reorderResponse.Policies).To(HaveLen(2))
+			Expect(*reorderResponse.Policies[0].Id).To(Equal(second))
+		})
+
+		It("should return 400 for nil body", func() {
+			response, err := handler.ReorderPolicies(context.Background(), server.ReorderPoliciesRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.ReorderPolicies400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ReorderPolicies400JSONResponse")
+		})
+
+		It("should return 400 FAILED_PRECONDITION when a managed policy would move", func() {
+			mockService.ReorderPoliciesFn = func(_ context.Context, _ string, _ []string) ([]v1alpha1.Policy, error) {
+				return nil, service.NewPolicyManagedError("managed")
+			}
+
+			response, err := handler.ReorderPolicies(context.Background(), server.ReorderPoliciesRequestObject{
+				Body: &server.ReorderPoliciesJSONRequestBody{PolicyType: "GLOBAL", PolicyIds: []string{"managed"}},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.ReorderPolicies400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ReorderPolicies400JSONResponse")
+			Expect(badRequest.Type).To(Equal(server.FAILEDPRECONDITION))
+		})
+
+		It("should return 404 for an unknown policy", func() {
+			mockService.ReorderPoliciesFn = func(_ context.Context, _ string, _ []string) ([]v1alpha1.Policy, error) {
+				return nil, service.NewPolicyNotFoundError("missing")
+			}
+
+			response, err := handler.ReorderPolicies(context.Background(), server.ReorderPoliciesRequestObject{
+				Body: &server.ReorderPoliciesJSONRequestBody{PolicyType: "GLOBAL", PolicyIds: []string{"missing"}},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.ReorderPolicies404JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ReorderPolicies404JSONResponse")
+		})
+	})
+
+	Describe("ImportPolicyBundle", func() {
+		It("should return 200 with the imported policies", func() {
+			ctx := context.Background()
+			policyID := "imported-policy"
+
+			mockService.ImportBundleFn = func(_ context.Context, r io.Reader) ([]v1alpha1.Policy, error) {
+				data, err := io.ReadAll(r)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal("bundle"))
+				return []v1alpha1.Policy{{Id: &policyID}}, nil
+			}
+
+			response, err := handler.ImportPolicyBundle(ctx, server.ImportPolicyBundleRequestObject{
+				Body: strings.NewReader("bundle"),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			importResponse, ok := response.(server.ImportPolicyBundle200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ImportPolicyBundle200JSONResponse")
+			Expect(importResponse.Policies).To(HaveLen(1))
+			Expect(*importResponse.Policies[0].Id).To(Equal(policyID))
+		})
+
+		It("should return 400 for nil body", func() {
+			response, err := handler.ImportPolicyBundle(context.Background(), server.ImportPolicyBundleRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.ImportPolicyBundle400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ImportPolicyBundle400JSONResponse")
+		})
+
+		It("should return 400 for an invalid bundle", func() {
+			mockService.ImportBundleFn = func(_ context.Context, _ io.Reader) ([]v1alpha1.Policy, error) {
+				return nil, service.NewInvalidArgumentError("Invalid policy bundle", "missing dcm-metadata.json")
+			}
+
+			response, err := handler.ImportPolicyBundle(context.Background(), server.ImportPolicyBundleRequestObject{
+				Body: strings.NewReader("not a bundle"),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.ImportPolicyBundle400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ImportPolicyBundle400JSONResponse")
+			Expect(*badRequest.Detail).To(ContainSubstring("dcm-metadata.json"))
+		})
+
+		It("should return 400 with the diagnostics when the policy set does not compile", func() {
+			mockService.ImportBundleFn = func(_ context.Context, _ io.Reader) ([]v1alpha1.Policy, error) {
+				return nil, service.NewPolicySetCompileError(&opa.CompileError{Diagnostics: []opa.Diagnostic{{
+					PolicyID: "clash",
+					Line:     2,
+					Column:   1,
+					Severity: opa.SeverityError,
+					Code:     "rego_type_error",
+					Message:  "conflicting rules data.shared.main found",
+				}}})
+			}
+
+			response, err := handler.ImportPolicyBundle(context.Background(), server.ImportPolicyBundleRequestObject{
+				Body: strings.NewReader("bundle"),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.ImportPolicyBundle400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ImportPolicyBundle400JSONResponse")
+			Expect(badRequest.Diagnostics).NotTo(BeNil())
+			Expect(*badRequest.Diagnostics).To(ConsistOf(HaveField("PolicyId", HaveValue(Equal("clash")))))
+		})
+
+		It("should return 409 when a policy conflicts", func() {
+			mockService.ImportBundleFn = func(_ context.Context, _ io.Reader) ([]v1alpha1.Policy, error) {
+				return nil, service.NewAlreadyExistsError("Policy priority and policy type already exists", "conflict")
+			}
+
+			response, err := handler.ImportPolicyBundle(context.Background(), server.ImportPolicyBundleRequestObject{
+				Body: strings.NewReader("bundle"),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.ImportPolicyBundle409JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ImportPolicyBundle409JSONResponse")
+		})
+	})
+
+	Describe("ExportPolicyBundle", func() {
+		It("should return 200 with the bundle content", func() {
+			mockService.ExportBundleFn = func(_ context.Context, w io.Writer) error {
+				_, err := w.Write([]byte("bundle"))
+				return err
+			}
+
+			response, err := handler.ExportPolicyBundle(context.Background(), server.ExportPolicyBundleRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			exportResponse, ok := response.(server.ExportPolicyBundle200ApplicationgzipResponse)
+			Expect(ok).To(BeTrue(), "response should be ExportPolicyBundle200ApplicationgzipResponse")
+			Expect(exportResponse.ContentLength).To(Equal(int64(6)))
+			data, err := io.ReadAll(exportResponse.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("bundle"))
+		})
+
+		It("should return 500 when the export fails", func() {
+			mockService.ExportBundleFn = func(_ context.Context, _ io.Writer) error {
+				return service.NewInternalError("Failed to list policies", "db down", nil)
+			}
+
+			response, err := handler.ExportPolicyBundle(context.Background(), server.ExportPolicyBundleRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.ExportPolicyBundle500JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ExportPolicyBundle500JSONResponse")
+		})
 	})
 })
@@ -0,0 +1,111 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/logging"
+)
+
+// CreateProposal handles proposing a policy or library change.
+func (h *PolicyHandler) CreateProposal(ctx context.Context, request server.CreateProposalRequestObject) (server.CreateProposalResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	if request.Body == nil {
+		log.Warn("CreateProposal called with nil body")
+		return server.CreateProposal400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				"Invalid request body",
+				strPtr("Request body is required"),
+			)),
+		}, nil
+	}
+
+	log.Debug("CreateProposal request received", "policy_id", request.Body.PolicyId, "library_id", request.Body.LibraryId)
+
+	created, err := h.service.CreateProposal(ctx, proposalServerToV1Alpha1(*request.Body))
+	if err != nil {
+		logServiceError(ctx, "CreateProposal failed", err)
+		return h.handleCreateProposalError(err), nil
+	}
+
+	return server.CreateProposal201JSONResponse(proposalV1Alpha1ToServer(*created)), nil
+}
+
+// GetProposal handles retrieving a single proposal by ID.
+func (h *PolicyHandler) GetProposal(ctx context.Context, request server.GetProposalRequestObject) (server.GetProposalResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("GetProposal request received", "proposal_id", request.ProposalId)
+
+	proposal, err := h.service.GetProposal(ctx, request.ProposalId)
+	if err != nil {
+		logServiceError(ctx, "GetProposal failed", err, "proposal_id", request.ProposalId)
+		return h.handleGetProposalError(err), nil
+	}
+
+	return server.GetProposal200JSONResponse(proposalV1Alpha1ToServer(*proposal)), nil
+}
+
+// ListProposals handles listing proposals, optionally in one state.
+func (h *PolicyHandler) ListProposals(ctx context.Context, request server.ListProposalsRequestObject) (server.ListProposalsResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	var state string
+	if request.Params.State != nil {
+		state = string(*request.Params.State)
+	}
+	log.Debug("ListProposals request received", "state", state)
+
+	result, err := h.service.ListProposals(ctx, state)
+	if err != nil {
+		logServiceError(ctx, "ListProposals failed", err)
+		return h.handleListProposalsError(err), nil
+	}
+
+	proposals := make([]server.PolicyProposal, len(result.Proposals))
+	for i, p := range result.Proposals {
+		proposals[i] = proposalV1Alpha1ToServer(p)
+	}
+
+	log.Debug("ListProposals completed", "count", len(proposals))
+	return server.ListProposals200JSONResponse{Proposals: proposals}, nil
+}
+
+// ApproveProposal handles approving a proposal, which applies its change.
+func (h *PolicyHandler) ApproveProposal(ctx context.Context, request server.ApproveProposalRequestObject) (server.ApproveProposalResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("ApproveProposal request received", "proposal_id", request.ProposalId)
+
+	approved, err := h.service.ApproveProposal(ctx, request.ProposalId, reviewComment(request.Body))
+	if err != nil {
+		logServiceError(ctx, "ApproveProposal failed", err, "proposal_id", request.ProposalId)
+		return h.handleApproveProposalError(err), nil
+	}
+
+	return server.ApproveProposal200JSONResponse(proposalV1Alpha1ToServer(*approved)), nil
+}
+
+// RejectProposal handles rejecting a proposal.
+func (h *PolicyHandler) RejectProposal(ctx context.Context, request server.RejectProposalRequestObject) (server.RejectProposalResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("RejectProposal request received", "proposal_id", request.ProposalId)
+
+	rejected, err := h.service.RejectProposal(ctx, request.ProposalId, reviewComment(request.Body))
+	if err != nil {
+		logServiceError(ctx, "RejectProposal failed", err, "proposal_id", request.ProposalId)
+		return h.handleRejectProposalError(err), nil
+	}
+
+	return server.RejectProposal200JSONResponse(proposalV1Alpha1ToServer(*rejected)), nil
+}
+
+// reviewComment returns the comment of an optional review request body
+func reviewComment(review *server.ProposalReview) string {
+	if review == nil || review.Comment == nil {
+		return ""
+	}
+	return *review.Comment
+}
@@ -0,0 +1,192 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/service"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Proposal handlers", func() {
+	var (
+		handler     *PolicyHandler
+		mockService *MockPolicyService
+		ctx         context.Context
+	)
+
+	BeforeEach(func() {
+		mockService = &MockPolicyService{}
+		handler = NewPolicyHandler(mockService)
+		ctx = context.Background()
+	})
+
+	Describe("CreateProposal", func() {
+		It("should return 201 with the created proposal", func() {
+			var received v1alpha1.PolicyProposal
+			mockService.CreateProposalFn = func(_ context.Context, proposal v1alpha1.PolicyProposal) (*v1alpha1.PolicyProposal, error) {
+				received = proposal
+				state := v1alpha1.ProposalPending
+				return &v1alpha1.PolicyProposal{
+					Id:         strPtr("p1"),
+					Operation:  proposal.Operation,
+					PolicyId:   proposal.PolicyId,
+					Policy:     proposal.Policy,
+					State:      &state,
+					Validation: &v1alpha1.ProposalValidation{Valid: true},
+				}, nil
+			}
+
+			op := server.ProposalUpdate
+			response, err := handler.CreateProposal(ctx, server.CreateProposalRequestObject{
+				Body: &server.PolicyProposal{
+					Operation: &op,
+					PolicyId:  strPtr("region"),
+					Policy:    &server.Policy{Description: strPtr("new")},
+				},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*received.Operation).To(Equal(v1alpha1.ProposalUpdate))
+			Expect(*received.Policy.Description).To(Equal("new"))
+			created, ok := response.(server.CreateProposal201JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreateProposal201JSONResponse")
+			Expect(*created.State).To(Equal(server.ProposalPending))
+			Expect(created.Validation.Valid).To(BeTrue())
+		})
+
+		It("should pass a library change through", func() {
+			var received v1alpha1.PolicyProposal
+			mockService.CreateProposalFn = func(_ context.Context, proposal v1alpha1.PolicyProposal) (*v1alpha1.PolicyProposal, error) {
+				received = proposal
+				return &v1alpha1.PolicyProposal{
+					Id:             strPtr("p1"),
+					Operation:      proposal.Operation,
+					LibraryId:      proposal.LibraryId,
+					Library:        proposal.Library,
+					CurrentLibrary: &v1alpha1.Library{DisplayName: strPtr("old")},
+				}, nil
+			}
+
+			op := server.ProposalUpdate
+			response, err := handler.CreateProposal(ctx, server.CreateProposalRequestObject{
+				Body: &server.PolicyProposal{
+					Operation: &op,
+					LibraryId: strPtr("regions"),
+					Library:   &server.Library{DisplayName: strPtr("new")},
+				},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*received.LibraryId).To(Equal("regions"))
+			Expect(*received.Library.DisplayName).To(Equal("new"))
+			created, ok := response.(server.CreateProposal201JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreateProposal201JSONResponse")
+			Expect(*created.LibraryId).To(Equal("regions"))
+			Expect(*created.Library.DisplayName).To(Equal("new"))
+			Expect(*created.CurrentLibrary.DisplayName).To(Equal("old"))
+		})
+
+		It("should return 401 without a principal", func() {
+			mockService.CreateProposalFn = func(_ context.Context, _ v1alpha1.PolicyProposal) (*v1alpha1.PolicyProposal, error) {
+				return nil, service.NewUnauthenticatedError("Principal required", "no principal")
+			}
+
+			response, err := handler.CreateProposal(ctx, server.CreateProposalRequestObject{Body: &server.PolicyProposal{}})
+
+			Expect(err).NotTo(HaveOccurred())
+			unauthorized, ok := response.(server.CreateProposal401JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreateProposal401JSONResponse")
+			Expect(unauthorized.Type).To(Equal(server.UNAUTHENTICATED))
+		})
+	})
+
+	Describe("ListProposals", func() {
+		It("should pass the state filter", func() {
+			var received string
+			mockService.ListProposalsFn = func(_ context.Context, state string) (*v1alpha1.PolicyProposalList, error) {
+				received = state
+				return &v1alpha1.PolicyProposalList{Proposals: []v1alpha1.PolicyProposal{{Id: strPtr("p1")}}}, nil
+			}
+
+			state := server.ProposalPending
+			response, err := handler.ListProposals(ctx, server.ListProposalsRequestObject{
+				Params: server.ListProposalsParams{State: &state},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(received).To(Equal("PENDING"))
+			list, ok := response.(server.ListProposals200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ListProposals200JSONResponse")
+			Expect(list.Proposals).To(HaveLen(1))
+		})
+	})
+
+	Describe("ApproveProposal", func() {
+		It("should pass the review comment", func() {
+			var received string
+			mockService.ApproveProposalFn = func(_ context.Context, id string, comment string) (*v1alpha1.PolicyProposal, error) {
+				received = comment
+				state := v1alpha1.ProposalApproved
+				return &v1alpha1.PolicyProposal{Id: &id, State: &state}, nil
+			}
+
+			response, err := handler.ApproveProposal(ctx, server.ApproveProposalRequestObject{
+				ProposalId: "p1",
+				Body:       &server.ProposalReview{Comment: strPtr("lgtm")},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(received).To(Equal("lgtm"))
+			approved, ok := response.(server.ApproveProposal200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ApproveProposal200JSONResponse")
+			Expect(*approved.State).To(Equal(server.ProposalApproved))
+		})
+
+		It("should return 403 when the proposer approves", func() {
+			mockService.ApproveProposalFn = func(_ context.Context, _ string, _ string) (*v1alpha1.PolicyProposal, error) {
+				return nil, service.NewPermissionDeniedError("Proposal cannot be reviewed by its proposer", "same principal")
+			}
+
+			response, err := handler.ApproveProposal(ctx, server.ApproveProposalRequestObject{ProposalId: "p1"})
+
+			Expect(err).NotTo(HaveOccurred())
+			forbidden, ok := response.(server.ApproveProposal403JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ApproveProposal403JSONResponse")
+			Expect(forbidden.Type).To(Equal(server.PERMISSIONDENIED))
+		})
+
+		It("should return 400 with diagnostics when the change no longer compiles", func() {
+			mockService.ApproveProposalFn = func(_ context.Context, _ string, _ string) (*v1alpha1.PolicyProposal, error) {
+				return nil, &service.ServiceError{
+					Type:        service.ErrorTypeInvalidArgument,
+					Message:     "Invalid Rego code",
+					Diagnostics: []v1alpha1.RegoDiagnostic{{Line: 1, Column: 1, Code: "rego_type_error", Message: "bad"}},
+				}
+			}
+
+			response, err := handler.ApproveProposal(ctx, server.ApproveProposalRequestObject{ProposalId: "p1"})
+
+			Expect(err).NotTo(HaveOccurred())
+			badRequest, ok := response.(server.ApproveProposal400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ApproveProposal400JSONResponse")
+			Expect(*badRequest.Diagnostics).To(HaveLen(1))
+		})
+	})
+
+	Describe("RejectProposal", func() {
+		It("should return 400 for a proposal that is not pending", func() {
+			mockService.RejectProposalFn = func(_ context.Context, _ string, _ string) (*v1alpha1.PolicyProposal, error) {
+				return nil, service.NewFailedPreconditionError("Proposal is not pending", "already approved")
+			}
+
+			response, err := handler.RejectProposal(ctx, server.RejectProposalRequestObject{ProposalId: "p1"})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.RejectProposal400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be RejectProposal400JSONResponse")
+		})
+	})
+})
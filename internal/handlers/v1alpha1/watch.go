@@ -0,0 +1,110 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/service"
+)
+
+// watchKeepaliveInterval is the interval of the comment lines that keep an idle watch connection open
+const watchKeepaliveInterval = 15 * time.Second
+
+// WatchPolicies handles streaming policy changes as server-sent events.
+func (h *PolicyHandler) WatchPolicies(ctx context.Context, request server.WatchPoliciesRequestObject) (server.WatchPoliciesResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	// Browsers resume an event source with the Last-Event-ID header, which takes precedence
+	lastEventID := ""
+	if request.Params.LastEventID != nil {
+		lastEventID = *request.Params.LastEventID
+	} else if request.Params.LastEventId != nil {
+		lastEventID = *request.Params.LastEventId
+	}
+	log.Debug("WatchPolicies request received", "last_event_id", lastEventID)
+
+	watch, err := h.service.WatchPolicies(ctx, lastEventID)
+	if err != nil {
+		logServiceError(ctx, "WatchPolicies failed", err)
+		return h.handleWatchPoliciesError(err), nil
+	}
+	return policyEventStream{ctx: ctx, watch: watch}, nil
+}
+
+// policyEventStream writes the events of a policy watch as a server-sent event stream until the client
+// disconnects or the watch ends
+type policyEventStream struct {
+	ctx   context.Context
+	watch *service.PolicyWatch
+}
+
+func (s policyEventStream) VisitWatchPoliciesResponse(w http.ResponseWriter) error {
+	defer s.watch.Close()
+	log := logging.FromContext(s.ctx)
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Keep reverse proxies from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if s.watch.Resync {
+		if _, err := fmt.Fprint(w, "event: resync\ndata: {}\n\n"); err != nil {
+			return err
+		}
+	}
+	for _, event := range s.watch.Backlog {
+		if err := writePolicyEvent(w, event); err != nil {
+			return err
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return err
+	}
+
+	keepalive := time.NewTicker(watchKeepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			log.Debug("Policy watch closed by client")
+			return nil
+		case event, ok := <-s.watch.Events:
+			if !ok {
+				log.Debug("Policy watch ended by server")
+				return nil
+			}
+			if err := writePolicyEvent(w, event); err != nil {
+				return err
+			}
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return err
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+func writePolicyEvent(w http.ResponseWriter, e service.PolicyEvent) error {
+	changeType := server.PolicyEventChangeType(e.ChangeType)
+	data, err := json.Marshal(server.PolicyEvent{
+		ChangeType: &changeType,
+		Id:         &e.ID,
+		PolicyId:   &e.PolicyID,
+		Revision:   e.Revision,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\ndata: %s\n\n", e.ID, data)
+	return err
+}
@@ -0,0 +1,99 @@
+package v1alpha1
+
+import (
+	"context"
+	"net/http/httptest"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/service"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WatchPolicies handler", func() {
+	var (
+		handler     *PolicyHandler
+		mockService *MockPolicyService
+		ctx         context.Context
+	)
+
+	BeforeEach(func() {
+		mockService = &MockPolicyService{}
+		handler = NewPolicyHandler(mockService)
+		ctx = context.Background()
+	})
+
+	It("should prefer the Last-Event-ID header over the query parameter", func() {
+		var received string
+		mockService.WatchPoliciesFn = func(_ context.Context, lastEventID string) (*service.PolicyWatch, error) {
+			received = lastEventID
+			return nil, service.NewInvalidArgumentError("Invalid last event ID", "bad")
+		}
+
+		_, err := handler.WatchPolicies(ctx, server.WatchPoliciesRequestObject{
+			Params: server.WatchPoliciesParams{LastEventID: strPtr("header-1"), LastEventId: strPtr("query-1")},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(received).To(Equal("header-1"))
+	})
+
+	It("should return 400 for an invalid last event ID", func() {
+		mockService.WatchPoliciesFn = func(_ context.Context, _ string) (*service.PolicyWatch, error) {
+			return nil, service.NewInvalidArgumentError("Invalid last event ID", "bad")
+		}
+
+		response, err := handler.WatchPolicies(ctx, server.WatchPoliciesRequestObject{
+			Params: server.WatchPoliciesParams{LastEventId: strPtr("bad")},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		badRequest, ok := response.(server.WatchPolicies400JSONResponse)
+		Expect(ok).To(BeTrue(), "response should be WatchPolicies400JSONResponse")
+		Expect(badRequest.Type).To(Equal(server.INVALIDARGUMENT))
+	})
+
+	It("should stream the resync, backlog and new events until the watch ends", func() {
+		revision := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		events := make(chan service.PolicyEvent, 1)
+		mockService.WatchPoliciesFn = func(_ context.Context, _ string) (*service.PolicyWatch, error) {
+			return &service.PolicyWatch{
+				Resync: true,
+				Backlog: []service.PolicyEvent{
+					{ID: "e-1", ChangeType: service.PolicyCreated, PolicyID: "a", Revision: &revision},
+				},
+				Events: events,
+			}, nil
+		}
+		events <- service.PolicyEvent{ID: "e-2", ChangeType: service.PolicyDeleted, PolicyID: "a"}
+		close(events)
+
+		response, err := handler.WatchPolicies(ctx, server.WatchPoliciesRequestObject{})
+		Expect(err).NotTo(HaveOccurred())
+
+		recorder := httptest.NewRecorder()
+		Expect(response.VisitWatchPoliciesResponse(recorder)).To(Succeed())
+
+		Expect(recorder.Code).To(Equal(200))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("text/event-stream"))
+		Expect(recorder.Body.String()).To(Equal(
+			"event: resync\ndata: {}\n\n" +
+				"id: e-1\ndata: {\"change_type\":\"CREATED\",\"id\":\"e-1\",\"policy_id\":\"a\",\"revision\":\"2026-01-02T03:04:05Z\"}\n\n" +
+				"id: e-2\ndata: {\"change_type\":\"DELETED\",\"id\":\"e-2\",\"policy_id\":\"a\"}\n\n",
+		))
+	})
+
+	It("should stop streaming when the client disconnects", func() {
+		mockService.WatchPoliciesFn = func(_ context.Context, _ string) (*service.PolicyWatch, error) {
+			return &service.PolicyWatch{Events: make(chan service.PolicyEvent)}, nil
+		}
+		cancelCtx, cancel := context.WithCancel(ctx)
+
+		response, err := handler.WatchPolicies(cancelCtx, server.WatchPoliciesRequestObject{})
+		Expect(err).NotTo(HaveOccurred())
+
+		cancel()
+		Expect(response.VisitWatchPoliciesResponse(httptest.NewRecorder())).To(Succeed())
+	})
+})
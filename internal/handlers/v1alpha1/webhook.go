@@ -0,0 +1,132 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/logging"
+)
+
+// CreateWebhook handles creating a new webhook resource.
+func (h *PolicyHandler) CreateWebhook(ctx context.Context, request server.CreateWebhookRequestObject) (server.CreateWebhookResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	if request.Body == nil {
+		log.Warn("CreateWebhook called with nil body")
+		return server.CreateWebhook400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				"Invalid request body",
+				strPtr("Request body is required"),
+			)),
+		}, nil
+	}
+
+	log.Debug("CreateWebhook request received", "client_id", request.Params.Id)
+
+	created, err := h.service.CreateWebhook(ctx, webhookServerToV1Alpha1(*request.Body), request.Params.Id)
+	if err != nil {
+		logServiceError(ctx, "CreateWebhook failed", err)
+		return h.handleCreateWebhookError(err), nil
+	}
+
+	log.Info("Webhook created", "webhook_id", *created.Id)
+	return server.CreateWebhook201JSONResponse(webhookV1Alpha1ToServer(*created)), nil
+}
+
+// GetWebhook handles retrieving a single webhook by ID.
+func (h *PolicyHandler) GetWebhook(ctx context.Context, request server.GetWebhookRequestObject) (server.GetWebhookResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("GetWebhook request received", "webhook_id", request.WebhookId)
+
+	webhook, err := h.service.GetWebhook(ctx, request.WebhookId)
+	if err != nil {
+		logServiceError(ctx, "GetWebhook failed", err, "webhook_id", request.WebhookId)
+		return h.handleGetWebhookError(err), nil
+	}
+
+	return server.GetWebhook200JSONResponse(webhookV1Alpha1ToServer(*webhook)), nil
+}
+
+// ListWebhooks handles listing all webhooks.
+func (h *PolicyHandler) ListWebhooks(ctx context.Context, _ server.ListWebhooksRequestObject) (server.ListWebhooksResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("ListWebhooks request received")
+
+	result, err := h.service.ListWebhooks(ctx)
+	if err != nil {
+		logServiceError(ctx, "ListWebhooks failed", err)
+		return h.handleListWebhooksError(err), nil
+	}
+
+	webhooks := make([]server.Webhook, len(result.Webhooks))
+	for i, w := range result.Webhooks {
+		webhooks[i] = webhookV1Alpha1ToServer(w)
+	}
+
+	log.Debug("ListWebhooks completed", "count", len(webhooks))
+	return server.ListWebhooks200JSONResponse{Webhooks: webhooks}, nil
+}
+
+// UpdateWebhook handles updating an existing webhook resource.
+func (h *PolicyHandler) UpdateWebhook(ctx context.Context, request server.UpdateWebhookRequestObject) (server.UpdateWebhookResponseObject, error) {
+	log := logging.FromContext(ctx)
+
+	if request.Body == nil {
+		log.Warn("UpdateWebhook called with nil body", "webhook_id", request.WebhookId)
+		return server.UpdateWebhook400JSONResponse{
+			BadRequestJSONResponse: badRequestResponse(buildErrorResponse(
+				400,
+				v1alpha1.INVALIDARGUMENT,
+				"Invalid request body",
+				strPtr("Request body is required"),
+			)),
+		}, nil
+	}
+
+	log.Debug("UpdateWebhook request received", "webhook_id", request.WebhookId)
+
+	patch := webhookServerToV1Alpha1(*request.Body)
+	updated, err := h.service.UpdateWebhook(ctx, request.WebhookId, &patch)
+	if err != nil {
+		logServiceError(ctx, "UpdateWebhook failed", err, "webhook_id", request.WebhookId)
+		return h.handleUpdateWebhookError(err), nil
+	}
+
+	log.Info("Webhook updated", "webhook_id", request.WebhookId)
+	return server.UpdateWebhook200JSONResponse(webhookV1Alpha1ToServer(*updated)), nil
+}
+
+// DeleteWebhook handles deleting a webhook by ID.
+func (h *PolicyHandler) DeleteWebhook(ctx context.Context, request server.DeleteWebhookRequestObject) (server.DeleteWebhookResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("DeleteWebhook request received", "webhook_id", request.WebhookId)
+
+	if err := h.service.DeleteWebhook(ctx, request.WebhookId); err != nil {
+		logServiceError(ctx, "DeleteWebhook failed", err, "webhook_id", request.WebhookId)
+		return h.handleDeleteWebhookError(err), nil
+	}
+
+	log.Info("Webhook deleted", "webhook_id", request.WebhookId)
+	return server.DeleteWebhook204Response{}, nil
+}
+
+// ListWebhookDeliveries handles listing the delivery log of a webhook.
+func (h *PolicyHandler) ListWebhookDeliveries(ctx context.Context, request server.ListWebhookDeliveriesRequestObject) (server.ListWebhookDeliveriesResponseObject, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("ListWebhookDeliveries request received", "webhook_id", request.WebhookId)
+
+	result, err := h.service.ListWebhookDeliveries(ctx, request.WebhookId)
+	if err != nil {
+		logServiceError(ctx, "ListWebhookDeliveries failed", err, "webhook_id", request.WebhookId)
+		return h.handleListWebhookDeliveriesError(err), nil
+	}
+
+	deliveries := make([]server.WebhookDelivery, len(result.Deliveries))
+	for i, d := range result.Deliveries {
+		deliveries[i] = webhookDeliveryV1Alpha1ToServer(d)
+	}
+	return server.ListWebhookDeliveries200JSONResponse{Deliveries: deliveries}, nil
+}
@@ -0,0 +1,98 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/api/server"
+	"github.com/dcm-project/policy-manager/internal/service"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhook handlers", func() {
+	var (
+		handler     *PolicyHandler
+		mockService *MockPolicyService
+		ctx         context.Context
+	)
+
+	BeforeEach(func() {
+		mockService = &MockPolicyService{}
+		handler = NewPolicyHandler(mockService)
+		ctx = context.Background()
+	})
+
+	Describe("CreateWebhook", func() {
+		It("should return 201 with the created webhook", func() {
+			var received v1alpha1.Webhook
+			mockService.CreateWebhookFn = func(_ context.Context, webhook v1alpha1.Webhook, clientID *string) (*v1alpha1.Webhook, error) {
+				received = webhook
+				return &v1alpha1.Webhook{Id: clientID, Url: webhook.Url, EventTypes: webhook.EventTypes}, nil
+			}
+
+			eventTypes := []server.WebhookEventType{server.POLICYCREATED}
+			response, err := handler.CreateWebhook(ctx, server.CreateWebhookRequestObject{
+				Params: server.CreateWebhookParams{Id: strPtr("chat")},
+				Body: &server.Webhook{
+					Url:        strPtr("https://chat.example.com/hook"),
+					EventTypes: &eventTypes,
+					Secret:     strPtr("0123456789abcdef"),
+				},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*received.Secret).To(Equal("0123456789abcdef"))
+			Expect(*received.EventTypes).To(Equal([]v1alpha1.WebhookEventType{v1alpha1.POLICYCREATED}))
+			created, ok := response.(server.CreateWebhook201JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreateWebhook201JSONResponse")
+			Expect(*created.Id).To(Equal("chat"))
+			Expect(created.Secret).To(BeNil())
+		})
+
+		It("should return 400 for an invalid webhook", func() {
+			mockService.CreateWebhookFn = func(_ context.Context, _ v1alpha1.Webhook, _ *string) (*v1alpha1.Webhook, error) {
+				return nil, service.NewInvalidArgumentError("Invalid url", "bad")
+			}
+
+			response, err := handler.CreateWebhook(ctx, server.CreateWebhookRequestObject{Body: &server.Webhook{}})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.CreateWebhook400JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be CreateWebhook400JSONResponse")
+		})
+	})
+
+	Describe("DeleteWebhook", func() {
+		It("should return 404 for an unknown webhook", func() {
+			mockService.DeleteWebhookFn = func(_ context.Context, id string) error {
+				return service.NewWebhookNotFoundError(id)
+			}
+
+			response, err := handler.DeleteWebhook(ctx, server.DeleteWebhookRequestObject{WebhookId: "missing"})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := response.(server.DeleteWebhook404JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be DeleteWebhook404JSONResponse")
+		})
+	})
+
+	Describe("ListWebhookDeliveries", func() {
+		It("should return the delivery log", func() {
+			status := v1alpha1.DeliveryFailed
+			mockService.ListWebhookDeliveriesFn = func(_ context.Context, _ string) (*v1alpha1.WebhookDeliveryList, error) {
+				return &v1alpha1.WebhookDeliveryList{Deliveries: []v1alpha1.WebhookDelivery{
+					{Id: strPtr("d-1"), Status: &status, LastError: strPtr("unexpected response status 500")},
+				}}, nil
+			}
+
+			response, err := handler.ListWebhookDeliveries(ctx, server.ListWebhookDeliveriesRequestObject{WebhookId: "chat"})
+
+			Expect(err).NotTo(HaveOccurred())
+			list, ok := response.(server.ListWebhookDeliveries200JSONResponse)
+			Expect(ok).To(BeTrue(), "response should be ListWebhookDeliveries200JSONResponse")
+			Expect(list.Deliveries).To(HaveLen(1))
+			Expect(*list.Deliveries[0].Status).To(Equal(server.DeliveryFailed))
+		})
+	})
+})
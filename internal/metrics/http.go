@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+// Servers labelling the HTTP metrics
+const (
+	// ServerAPI is the public policy management API server
+	ServerAPI = "api"
+	// ServerEngine is the engine API server
+	ServerEngine = "engine"
+)
+
+// OperationUnknown labels the requests that did not reach an operation, such as requests to unknown
+// paths or with invalid parameters
+const OperationUnknown = "unknown"
+
+type operationKey struct{}
+
+// HTTPMiddleware counts and times the requests of a server by operation. The operation is the
+// OpenAPI operation ID recorded by OperationMiddleware.
+func HTTPMiddleware(server string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			operation := OperationUnknown
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), operationKey{}, &operation)))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			HTTPRequests.WithLabelValues(server, operation, strconv.Itoa(status)).Inc()
+			HTTPRequestDuration.WithLabelValues(server, operation).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// OperationMiddleware is a strict handler middleware recording the operation ID of a request for
+// HTTPMiddleware
+func OperationMiddleware(f strictnethttp.StrictHTTPHandlerFunc, operationID string) strictnethttp.StrictHTTPHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		if operation, ok := ctx.Value(operationKey{}).(*string); ok {
+			*operation = operationID
+		}
+		return f(ctx, w, r, request)
+	}
+}
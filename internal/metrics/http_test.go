@@ -0,0 +1,59 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/dcm-project/policy-manager/internal/metrics"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("HTTPMiddleware", func() {
+	// operation serves a request as the strict handler of the operation would, through OperationMiddleware
+	operation := func(operationID string, status int) http.Handler {
+		handler := metrics.OperationMiddleware(func(_ context.Context, w http.ResponseWriter, _ *http.Request, _ any) (any, error) {
+			w.WriteHeader(status)
+			return nil, nil
+		}, operationID)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = handler(r.Context(), w, r, nil)
+		})
+	}
+
+	serve := func(handler http.Handler) {
+		GinkgoHelper()
+		recorder := httptest.NewRecorder()
+		metrics.HTTPMiddleware("test")(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	It("counts and times requests by operation and status code", func() {
+		counter := metrics.HTTPRequests.WithLabelValues("test", "GetPolicy", "404")
+		before := testutil.ToFloat64(counter)
+
+		serve(operation("GetPolicy", http.StatusNotFound))
+
+		Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+		Expect(testutil.CollectAndCount(metrics.HTTPRequestDuration)).To(BeNumerically(">=", 1))
+	})
+
+	It("labels requests that reach no operation as unknown", func() {
+		counter := metrics.HTTPRequests.WithLabelValues("test", metrics.OperationUnknown, "404")
+		before := testutil.ToFloat64(counter)
+
+		serve(http.NotFoundHandler())
+
+		Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+	})
+
+	It("reports requests writing no status as 200", func() {
+		counter := metrics.HTTPRequests.WithLabelValues("test", metrics.OperationUnknown, "200")
+		before := testutil.ToFloat64(counter)
+
+		serve(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+		Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+	})
+})
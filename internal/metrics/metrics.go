@@ -0,0 +1,127 @@
+// Package metrics defines the Prometheus metrics of the policy manager.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "policy_manager"
+
+// Registry holds every metric of the policy manager, along with the Go runtime and process metrics
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// HTTPRequests counts the HTTP requests served, by server, operation and response status code
+var HTTPRequests = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "http_requests_total",
+	Help:      "HTTP requests served, by server, operation and status code.",
+}, []string{"server", "operation", "code"})
+
+// HTTPRequestDuration observes the time taken to serve HTTP requests, by server and operation
+var HTTPRequestDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "http_request_duration_seconds",
+	Help:      "Time taken to serve HTTP requests, by server and operation.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"server", "operation"})
+
+// EngineCompileDuration observes the time taken to compile the policy set into the engine
+var EngineCompileDuration = promauto.With(Registry).NewHistogram(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "engine_compile_duration_seconds",
+	Help:      "Time taken to compile the policy set into the engine, successful or not.",
+	Buckets:   prometheus.DefBuckets,
+})
+
+// EngineCompileFailures counts the compilations of the policy set that failed
+var EngineCompileFailures = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "engine_compile_failures_total",
+	Help:      "Compilations of the policy set into the engine that failed.",
+})
+
+// EnginePolicies is the number of policies compiled into the engine
+var EnginePolicies = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "engine_policies",
+	Help:      "Policies compiled into the engine.",
+})
+
+// EvaluationDuration observes the time taken to evaluate a request against all applicable policies
+var EvaluationDuration = promauto.With(Registry).NewHistogram(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "evaluation_duration_seconds",
+	Help:      "Time taken to evaluate a request against all applicable policies.",
+	Buckets:   prometheus.DefBuckets,
+})
+
+// PolicyEvaluationDuration observes the time taken to evaluate a single policy, by policy
+var PolicyEvaluationDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "policy_evaluation_duration_seconds",
+	Help:      "Time taken to evaluate a single policy, by policy.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"policy_id", "policy_type"})
+
+// PolicyRejections counts the requests rejected by a policy
+var PolicyRejections = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "policy_rejections_total",
+	Help:      "Requests rejected by a policy.",
+}, []string{"policy_id", "policy_type"})
+
+// PolicyViolations counts the decisions of a policy that violated the constraints of higher-priority
+// policies, by setting a constrained field or selecting a disallowed service provider
+var PolicyViolations = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "policy_constraint_violations_total",
+	Help:      "Policy decisions that violated the constraints of higher-priority policies.",
+}, []string{"policy_id", "policy_type"})
+
+// PolicyConflicts counts the decisions of a policy whose constraints loosened the constraints of
+// higher-priority policies
+var PolicyConflicts = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "policy_constraint_conflicts_total",
+	Help:      "Policy decisions whose constraints conflicted with the constraints of higher-priority policies.",
+}, []string{"policy_id", "policy_type"})
+
+// Timeout scopes of EvaluationTimeouts
+const (
+	// TimeoutPolicy is the per-policy evaluation timeout
+	TimeoutPolicy = "policy"
+	// TimeoutDeadline is the deadline of the whole evaluation of a request
+	TimeoutDeadline = "deadline"
+)
+
+// EvaluationTimeouts counts the policy evaluations stopped by a timeout, by the policy being evaluated
+// and the timeout that expired
+var EvaluationTimeouts = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "policy_evaluation_timeouts_total",
+	Help:      "Policy evaluations stopped because the per-policy timeout or the evaluation deadline expired.",
+}, []string{"policy_id", "policy_type", "timeout"})
+
+// DeletePolicy removes the series of a deleted policy from the per-policy metrics, so that their
+// number stays bounded by the number of policies
+func DeletePolicy(policyID string) {
+	labels := prometheus.Labels{"policy_id": policyID}
+	for _, vec := range []interface{ DeletePartialMatch(prometheus.Labels) int }{
+		PolicyEvaluationDuration,
+		PolicyRejections,
+		PolicyViolations,
+		PolicyConflicts,
+		EvaluationTimeouts,
+	} {
+		vec.DeletePartialMatch(labels)
+	}
+}
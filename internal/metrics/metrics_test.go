@@ -0,0 +1,28 @@
+package metrics_test
+
+import (
+	"github.com/dcm-project/policy-manager/internal/metrics"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("DeletePolicy", func() {
+	It("drops the series of the policy from the per-policy metrics", func() {
+		metrics.PolicyRejections.WithLabelValues("deleted", "GLOBAL").Inc()
+		metrics.PolicyRejections.WithLabelValues("kept", "GLOBAL").Inc()
+		metrics.PolicyEvaluationDuration.WithLabelValues("deleted", "GLOBAL").Observe(0.1)
+		metrics.EvaluationTimeouts.WithLabelValues("deleted", "GLOBAL", metrics.TimeoutPolicy).Inc()
+		metrics.EvaluationTimeouts.WithLabelValues("deleted", "GLOBAL", metrics.TimeoutDeadline).Inc()
+		rejections := testutil.CollectAndCount(metrics.PolicyRejections)
+		durations := testutil.CollectAndCount(metrics.PolicyEvaluationDuration)
+		timeouts := testutil.CollectAndCount(metrics.EvaluationTimeouts)
+
+		metrics.DeletePolicy("deleted")
+
+		Expect(testutil.CollectAndCount(metrics.PolicyRejections)).To(Equal(rejections - 1))
+		Expect(testutil.CollectAndCount(metrics.PolicyEvaluationDuration)).To(Equal(durations - 1))
+		Expect(testutil.CollectAndCount(metrics.EvaluationTimeouts)).To(Equal(timeouts - 2))
+		Expect(testutil.ToFloat64(metrics.PolicyRejections.WithLabelValues("kept", "GLOBAL"))).To(Equal(1.0))
+	})
+})
@@ -0,0 +1,53 @@
+// Package metricsserver provides the HTTP server exposing the Prometheus metrics.
+package metricsserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const gracefulShutdownTimeout = 5 * time.Second
+
+// Server wraps the HTTP server for the metrics endpoint
+type Server struct {
+	listener net.Listener
+}
+
+// New creates a new metrics server instance
+func New(listener net.Listener) *Server {
+	return &Server{listener: listener}
+}
+
+// Run starts the HTTP server and blocks until shutdown
+func (s *Server) Run(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+
+	srv := &http.Server{Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		ctxTimeout, cancel := context.WithTimeout(context.Background(), gracefulShutdownTimeout)
+		defer cancel()
+		slog.Info("Shutting down metrics server")
+		if err := srv.Shutdown(ctxTimeout); err != nil {
+			slog.Error("Error during metrics server shutdown", "error", err)
+		}
+	}()
+
+	slog.Info("Metrics server started", "address", s.listener.Addr().String())
+	if err := srv.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve metrics server: %w", err)
+	}
+
+	slog.Info("Metrics server stopped")
+	return nil
+}
@@ -0,0 +1,260 @@
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/open-policy-agent/opa/v1/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Names of the built-in functions available to policies in addition to those of OPA
+const (
+	BuiltinQuantityParse   = "dcm.quantity.parse"
+	BuiltinQuantityCompare = "dcm.quantity.compare"
+	BuiltinSemverSatisfies = "dcm.semver.satisfies"
+	BuiltinProviderGet     = "dcm.provider.get"
+)
+
+// Built-ins are registered with OPA globally, so that every compilation type-checks their use. A
+// built-in that fails, e.g. on a malformed quantity, is undefined, like OPA's own built-ins.
+func init() {
+	quantity := types.Named("quantity", types.NewAny(types.S, types.N)).
+		Description("quantity such as 4Gi, 500m or 1.5e3, or a number")
+
+	rego.RegisterBuiltin1(&rego.Function{
+		Name:        BuiltinQuantityParse,
+		Description: "Parses a Kubernetes-style resource quantity into a number, e.g. 4Gi to 4294967296 and 500m to 0.5.",
+		Decl: types.NewFunction(
+			types.Args(quantity),
+			types.Named("value", types.N).Description("the value of the quantity"),
+		),
+	}, builtinQuantityParse)
+
+	rego.RegisterBuiltin2(&rego.Function{
+		Name:        BuiltinQuantityCompare,
+		Description: "Compares two resource quantities, returning -1, 0 or 1 when a is less than, equal to or greater than b.",
+		Decl: types.NewFunction(
+			types.Args(
+				types.Named("a", types.NewAny(types.S, types.N)).Description("quantity or number"),
+				types.Named("b", types.NewAny(types.S, types.N)).Description("quantity or number"),
+			),
+			types.Named("result", types.N).Description("-1, 0 or 1"),
+		),
+	}, builtinQuantityCompare)
+
+	rego.RegisterBuiltin2(&rego.Function{
+		Name:        BuiltinSemverSatisfies,
+		Description: "Reports whether a semantic version satisfies a version range such as >=1.2.0 <2.0.0, ^1.4 or ~1.2.3 || 2.x.",
+		Decl: types.NewFunction(
+			types.Args(
+				types.Named("version", types.S).Description("semantic version, e.g. 1.2.3 or v1.2"),
+				types.Named("range", types.S).Description("version range"),
+			),
+			types.Named("result", types.B).Description("true if the version is in the range"),
+		),
+	}, builtinSemverSatisfies)
+
+	rego.RegisterBuiltin1(&rego.Function{
+		Name:        BuiltinProviderGet,
+		Description: "Returns the entry of a service provider in the provider catalog; undefined for an unknown provider.",
+		Decl: types.NewFunction(
+			types.Args(types.Named("name", types.S).Description("provider name")),
+			types.Named("provider", types.A).Description("catalog entry of the provider"),
+		),
+	}, builtinProviderGet)
+}
+
+// quantityPattern matches a number followed by a binary SI, decimal SI or decimal exponent suffix
+var quantityPattern = regexp.MustCompile(`^([+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+))([eE][+-]?[0-9]+|Ki|Mi|Gi|Ti|Pi|Ei|[numkMGTPE])?$`)
+
+// maxQuantityExponent bounds decimal exponents, which are otherwise arbitrarily expensive to apply
+const maxQuantityExponent = 64
+
+var quantitySuffixes = map[string]*big.Rat{
+	"Ki": new(big.Rat).SetInt64(1 << 10),
+	"Mi": new(big.Rat).SetInt64(1 << 20),
+	"Gi": new(big.Rat).SetInt64(1 << 30),
+	"Ti": new(big.Rat).SetInt64(1 << 40),
+	"Pi": new(big.Rat).SetInt64(1 << 50),
+	"Ei": new(big.Rat).SetInt64(1 << 60),
+	"n":  big.NewRat(1, 1e9),
+	"u":  big.NewRat(1, 1e6),
+	"m":  big.NewRat(1, 1e3),
+	"k":  new(big.Rat).SetInt64(1e3),
+	"M":  new(big.Rat).SetInt64(1e6),
+	"G":  new(big.Rat).SetInt64(1e9),
+	"T":  new(big.Rat).SetInt64(1e12),
+	"P":  new(big.Rat).SetInt64(1e15),
+	"E":  new(big.Rat).SetInt64(1e18),
+}
+
+// parseQuantity returns the exact value of a quantity string or number term
+func parseQuantity(term *ast.Term) (*big.Rat, error) {
+	switch v := term.Value.(type) {
+	case ast.Number:
+		r, ok := new(big.Rat).SetString(string(v))
+		if !ok {
+			return nil, fmt.Errorf("invalid number %s", v)
+		}
+		return r, nil
+	case ast.String:
+		return parseQuantityString(strings.TrimSpace(string(v)))
+	default:
+		return nil, fmt.Errorf("quantity must be a string or number, got %s", ast.ValueName(term.Value))
+	}
+}
+
+func parseQuantityString(s string) (*big.Rat, error) {
+	m := quantityPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("invalid quantity %q", s)
+	}
+	value, ok := new(big.Rat).SetString(m[1])
+	if !ok {
+		return nil, fmt.Errorf("invalid quantity %q", s)
+	}
+
+	suffix := m[2]
+	if factor, ok := quantitySuffixes[suffix]; ok {
+		return value.Mul(value, factor), nil
+	}
+	if suffix != "" {
+		var exponent int64
+		if _, err := fmt.Sscan(suffix[1:], &exponent); err != nil || exponent > maxQuantityExponent || exponent < -maxQuantityExponent {
+			return nil, fmt.Errorf("invalid quantity exponent in %q", s)
+		}
+		factor := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(abs(exponent)), nil))
+		if exponent < 0 {
+			factor.Inv(factor)
+		}
+		value.Mul(value, factor)
+	}
+	return value, nil
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// ratTerm returns an exact integer term for integral values and the nearest float term otherwise
+func ratTerm(r *big.Rat) *ast.Term {
+	if r.IsInt() {
+		return ast.NumberTerm(json.Number(r.Num().String()))
+	}
+	f, _ := r.Float64()
+	return ast.FloatNumberTerm(f)
+}
+
+func builtinQuantityParse(_ rego.BuiltinContext, op *ast.Term) (*ast.Term, error) {
+	value, err := parseQuantity(op)
+	if err != nil {
+		return nil, err
+	}
+	return ratTerm(value), nil
+}
+
+func builtinQuantityCompare(_ rego.BuiltinContext, a, b *ast.Term) (*ast.Term, error) {
+	x, err := parseQuantity(a)
+	if err != nil {
+		return nil, err
+	}
+	y, err := parseQuantity(b)
+	if err != nil {
+		return nil, err
+	}
+	return ast.IntNumberTerm(x.Cmp(y)), nil
+}
+
+func builtinSemverSatisfies(_ rego.BuiltinContext, versionTerm, rangeTerm *ast.Term) (*ast.Term, error) {
+	v, ok := versionTerm.Value.(ast.String)
+	if !ok {
+		return nil, fmt.Errorf("version must be a string, got %s", ast.ValueName(versionTerm.Value))
+	}
+	r, ok := rangeTerm.Value.(ast.String)
+	if !ok {
+		return nil, fmt.Errorf("range must be a string, got %s", ast.ValueName(rangeTerm.Value))
+	}
+
+	version, err := semver.NewVersion(string(v))
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q: %w", v, err)
+	}
+	constraint, err := semver.NewConstraint(string(r))
+	if err != nil {
+		return nil, fmt.Errorf("invalid version range %q: %w", r, err)
+	}
+	return ast.BooleanTerm(constraint.Check(version)), nil
+}
+
+func builtinProviderGet(bctx rego.BuiltinContext, op *ast.Term) (*ast.Term, error) {
+	name, ok := op.Value.(ast.String)
+	if !ok {
+		return nil, fmt.Errorf("provider name must be a string, got %s", ast.ValueName(op.Value))
+	}
+	providers := providersFromContext(bctx.Context)
+	provider, ok := providers[string(name)]
+	if !ok {
+		return nil, nil
+	}
+	return provider, nil
+}
+
+// ProviderCatalog holds the entries of the service providers known to dcm.provider.get, by name
+type ProviderCatalog map[string]*ast.Term
+
+// LoadProviderCatalog reads a provider catalog from a YAML or JSON file mapping provider names to
+// their entries, e.g. their regions and capacities
+func LoadProviderCatalog(path string) (ProviderCatalog, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provider catalog: %w", err)
+	}
+	var entries map[string]any
+	if err := yaml.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse provider catalog %s: %w", path, err)
+	}
+	return NewProviderCatalog(entries)
+}
+
+// NewProviderCatalog converts provider entries given by name into a ProviderCatalog
+func NewProviderCatalog(entries map[string]any) (ProviderCatalog, error) {
+	catalog := make(ProviderCatalog, len(entries))
+	for name, entry := range entries {
+		value, err := ast.InterfaceToValue(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider catalog entry %q: %w", name, err)
+		}
+		catalog[name] = ast.NewTerm(value)
+	}
+	return catalog, nil
+}
+
+type providersKey struct{}
+
+// withProviders makes the provider catalog available to dcm.provider.get during an evaluation
+func withProviders(ctx context.Context, providers ProviderCatalog) context.Context {
+	if providers == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, providersKey{}, providers)
+}
+
+func providersFromContext(ctx context.Context) ProviderCatalog {
+	if ctx == nil {
+		return nil
+	}
+	providers, _ := ctx.Value(providersKey{}).(ProviderCatalog)
+	return providers
+}
@@ -0,0 +1,117 @@
+package opa_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/dcm-project/policy-manager/internal/opa"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Built-ins", func() {
+	var (
+		engine opa.Engine
+		ctx    context.Context
+	)
+
+	BeforeEach(func() {
+		providers, err := opa.NewProviderCatalog(map[string]any{
+			"aws": map[string]any{"regions": []any{"us-east-1", "eu-west-1"}, "max_cpu": 64},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		engine = opa.NewEngine(opa.WithProviders(providers))
+		ctx = context.Background()
+	})
+
+	// evaluate compiles a policy whose decision is defined when expr holds
+	evaluate := func(expr string) *opa.EvaluationResult {
+		err := engine.Compile(ctx, []opa.PolicyModule{
+			{ID: "builtins", RegoCode: "package builtins\nmain := {\"rejected\": false} if {\n\t" + expr + "\n}"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		result, err := engine.EvaluatePolicy(ctx, "builtins", map[string]any{})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	DescribeTable("hold",
+		func(expr string) {
+			Expect(evaluate(expr).Defined).To(BeTrue())
+		},
+		Entry("binary SI suffix", `dcm.quantity.parse("4Gi") == 4294967296`),
+		Entry("decimal SI suffix", `dcm.quantity.parse("2k") == 2000`),
+		Entry("milli suffix", `dcm.quantity.parse("500m") == 0.5`),
+		Entry("fractional quantity", `dcm.quantity.parse("1.5Gi") == 1610612736`),
+		Entry("decimal exponent", `dcm.quantity.parse("12e3") == 12000`),
+		Entry("exa suffix", `dcm.quantity.parse("1E") == 1000000000000000000`),
+		Entry("plain number string", `dcm.quantity.parse("2") == 2`),
+		Entry("number", `dcm.quantity.parse(3) == 3`),
+		Entry("compare less", `dcm.quantity.compare("500m", 1) == -1`),
+		Entry("compare equal", `dcm.quantity.compare("1Ki", "1024") == 0`),
+		Entry("compare greater", `dcm.quantity.compare("1Gi", "1G") == 1`),
+		Entry("semver in range", `dcm.semver.satisfies("1.4.2", ">=1.2.0, <2.0.0")`),
+		Entry("semver caret range", `dcm.semver.satisfies("v1.9", "^1.4")`),
+		Entry("semver out of range", `not dcm.semver.satisfies("2.0.0", "~1.2 || 1.x")`),
+		Entry("provider lookup", `dcm.provider.get("aws").regions[_] == "eu-west-1"`),
+	)
+
+	DescribeTable("are undefined on invalid arguments",
+		func(expr string) {
+			Expect(evaluate(expr).Defined).To(BeFalse())
+		},
+		Entry("malformed quantity", `dcm.quantity.parse("4 GiB")`),
+		Entry("unknown suffix", `dcm.quantity.parse("4Xi")`),
+		Entry("huge exponent", `dcm.quantity.parse("1e1000000")`),
+		Entry("malformed version", `dcm.semver.satisfies("latest", ">=1.0.0")`),
+		Entry("malformed range", `dcm.semver.satisfies("1.0.0", ">=>1")`),
+		Entry("unknown provider", `dcm.provider.get("azure")`),
+	)
+
+	It("type-checks their use", func() {
+		for _, expr := range []string{
+			`dcm.quantity.parse("1Gi", "1Mi")`,
+			`dcm.semver.satisfies(1, ">=1.0.0")`,
+			`dcm.provider.get(["aws"])`,
+			`dcm.quantity.parse("1Gi") == "1073741824"`,
+		} {
+			err := engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "builtins", RegoCode: "package builtins\nmain := {\"rejected\": false} if {\n\t" + expr + "\n}"},
+			})
+			Expect(err).To(MatchError(opa.ErrInvalidRego), expr)
+		}
+	})
+
+	It("are available to policy tests", func() {
+		results, err := engine.RunTests(ctx, []opa.PolicyModule{
+			{ID: "cpu", RegoCode: "package cpu\nmain := {\"rejected\": input.cpu > dcm.provider.get(\"aws\").max_cpu}"},
+		}, opa.TestModule{
+			PolicyID: "cpu",
+			RegoCode: "package cpu_test\nimport data.cpu\ntest_rejects_above_provider_max if cpu.main.rejected with input as {\"cpu\": 128}",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Passed).To(BeTrue())
+	})
+
+	Describe("LoadProviderCatalog", func() {
+		It("reads a YAML catalog", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "providers.yaml")
+			Expect(os.WriteFile(path, []byte("aws:\n  max_cpu: 64\ngcp:\n  max_cpu: 32\n"), 0o600)).To(Succeed())
+
+			providers, err := opa.LoadProviderCatalog(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveKey("aws"))
+			Expect(providers).To(HaveKey("gcp"))
+		})
+
+		It("rejects a catalog that is not a map of providers", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "providers.yaml")
+			Expect(os.WriteFile(path, []byte("- aws\n- gcp\n"), 0o600)).To(Succeed())
+
+			_, err := opa.LoadProviderCatalog(path)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package opa
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+)
+
+// CodeDisallowedBuiltin is the code of the diagnostics reporting calls to built-in functions that the
+// capabilities of the engine do not allow
+const CodeDisallowedBuiltin = "dcm_disallowed_builtin"
+
+// RestrictedBuiltins are the OPA built-in functions removed from the capabilities of the engine unless
+// allowed by the operator, because they make network calls or expose the runtime environment
+var RestrictedBuiltins = []string{"http.send", "net.lookup_ip_addr", "opa.runtime"}
+
+// dcmBuiltins are the names of the built-in functions of this package, which are always allowed
+var dcmBuiltins = []string{BuiltinQuantityParse, BuiltinQuantityCompare, BuiltinSemverSatisfies, BuiltinProviderGet}
+
+// LoadCapabilities reads an OPA capabilities JSON file, such as those produced by `opa capabilities`
+func LoadCapabilities(path string) (*ast.Capabilities, error) {
+	caps, err := ast.LoadCapabilitiesFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read capabilities file %s: %w", path, err)
+	}
+	return caps, nil
+}
+
+// NewCapabilities returns the capabilities policies are compiled against: the built-ins of base, or of
+// this OPA version if base is nil, without the RestrictedBuiltins that are not listed in allowed, plus
+// the dcm.* built-ins. Every allowed built-in must be part of base.
+func NewCapabilities(base *ast.Capabilities, allowed []string) (*ast.Capabilities, error) {
+	if base == nil {
+		base = ast.CapabilitiesForThisVersion()
+	}
+	available := make(map[string]bool, len(base.Builtins))
+	for _, b := range base.Builtins {
+		available[b.Name] = true
+	}
+	for _, name := range allowed {
+		if !available[name] {
+			return nil, fmt.Errorf("allowed built-in function %s is not part of the capabilities", name)
+		}
+	}
+
+	caps := *base
+	caps.Builtins = make([]*ast.Builtin, 0, len(base.Builtins)+len(dcmBuiltins))
+	for _, b := range base.Builtins {
+		if slices.Contains(RestrictedBuiltins, b.Name) && !slices.Contains(allowed, b.Name) {
+			continue
+		}
+		caps.Builtins = append(caps.Builtins, b)
+	}
+	for _, name := range dcmBuiltins {
+		if !available[name] {
+			caps.Builtins = append(caps.Builtins, ast.BuiltinMap[name])
+		}
+	}
+	return &caps, nil
+}
+
+// markDisallowedBuiltins reports the calls to OPA built-ins missing from the capabilities, which the
+// type checker reports as calls to undefined functions, as disallowed
+func markDisallowedBuiltins(diagnostics []Diagnostic) {
+	for i, d := range diagnostics {
+		name, ok := strings.CutPrefix(d.Message, "undefined function ")
+		if d.Code != ast.TypeErr || !ok {
+			continue
+		}
+		if _, builtin := ast.BuiltinMap[name]; builtin {
+			diagnostics[i].Code = CodeDisallowedBuiltin
+			diagnostics[i].Message = fmt.Sprintf("built-in function %s is not allowed in policies", name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package opa_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/dcm-project/policy-manager/internal/opa"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-policy-agent/opa/v1/ast"
+)
+
+var _ = Describe("Capabilities", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	builtinNames := func(caps *ast.Capabilities) []string {
+		names := make([]string, len(caps.Builtins))
+		for i, b := range caps.Builtins {
+			names[i] = b.Name
+		}
+		return names
+	}
+
+	Describe("NewCapabilities", func() {
+		It("removes the restricted built-ins by default", func() {
+			caps, err := opa.NewCapabilities(nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			names := builtinNames(caps)
+			Expect(names).NotTo(ContainElements("http.send", "net.lookup_ip_addr", "opa.runtime"))
+			Expect(names).To(ContainElements("count", "time.now_ns", opa.BuiltinQuantityParse))
+		})
+
+		It("keeps the allowed restricted built-ins", func() {
+			caps, err := opa.NewCapabilities(nil, []string{"http.send"})
+			Expect(err).NotTo(HaveOccurred())
+			names := builtinNames(caps)
+			Expect(names).To(ContainElement("http.send"))
+			Expect(names).NotTo(ContainElement("opa.runtime"))
+		})
+
+		It("adds the dcm.* built-ins to capabilities loaded from a file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "capabilities.json")
+			Expect(os.WriteFile(path, []byte(`{"builtins": [{"name": "count", "decl": {"type": "function", "args": [{"type": "any"}], "result": {"type": "number"}}}]}`), 0o600)).To(Succeed())
+			base, err := opa.LoadCapabilities(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			caps, err := opa.NewCapabilities(base, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builtinNames(caps)).To(ConsistOf("count", opa.BuiltinQuantityParse, opa.BuiltinQuantityCompare,
+				opa.BuiltinSemverSatisfies, opa.BuiltinProviderGet))
+		})
+
+		It("rejects allowing a built-in missing from the capabilities", func() {
+			_, err := opa.NewCapabilities(nil, []string{"http.fetch"})
+			Expect(err).To(MatchError(ContainSubstring("http.fetch")))
+		})
+	})
+
+	Describe("engine", func() {
+		const networkPolicy = `package policies.network
+
+main := {"rejected": resp.status_code != 200} if {
+	resp := http.send({"method": "get", "url": "http://inventory.local"})
+}`
+
+		It("reports calls to restricted built-ins as disallowed", func() {
+			engine := opa.NewEngine()
+
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{{ID: "network", RegoCode: networkPolicy}}, []string{"network"})
+
+			var compileErr *opa.CompileError
+			Expect(errors.As(err, &compileErr)).To(BeTrue())
+			Expect(compileErr.Diagnostics).To(ConsistOf(opa.Diagnostic{
+				PolicyID: "network",
+				Line:     4,
+				Column:   10,
+				Severity: opa.SeverityError,
+				Code:     opa.CodeDisallowedBuiltin,
+				Message:  "built-in function http.send is not allowed in policies",
+				Rule:     "main",
+			}))
+			Expect(engine.Compile(ctx, []opa.PolicyModule{{ID: "network", RegoCode: networkPolicy}})).
+				To(MatchError(opa.ErrInvalidRego))
+		})
+
+		It("compiles calls to allowed restricted built-ins", func() {
+			caps, err := opa.NewCapabilities(nil, []string{"http.send"})
+			Expect(err).NotTo(HaveOccurred())
+			engine := opa.NewEngine(opa.WithCapabilities(caps))
+
+			_, err = engine.CheckPolicies(ctx, []opa.PolicyModule{{ID: "network", RegoCode: networkPolicy}}, []string{"network"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(engine.Compile(ctx, []opa.PolicyModule{{ID: "network", RegoCode: networkPolicy}})).To(Succeed())
+		})
+
+		It("restricts the built-ins of policy tests", func() {
+			engine := opa.NewEngine()
+
+			_, err := engine.RunTests(ctx, []opa.PolicyModule{
+				{ID: "simple", RegoCode: "package policies.simple\nmain := {\"rejected\": false}"},
+			}, opa.TestModule{PolicyID: "simple", RegoCode: "package policies.simple_test\ntest_env if opa.runtime().env"})
+
+			Expect(err).To(MatchError(opa.ErrInvalidTests))
+		})
+	})
+})
@@ -0,0 +1,191 @@
+package opa
+
+import (
+	"slices"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+)
+
+// parsedModule is a module kept by the engine between compilations, so that only changed sources
+// are parsed again
+type parsedModule struct {
+	source PolicyModule
+	module *ast.Module
+	// pkg holds every prefix of the package path of the module, the path itself last
+	pkg []string
+	// deps are the data references of the module, including its own package
+	deps []dataRef
+}
+
+// dataRef is the constant prefix of a data reference, kept as strings to look it up in a packageIndex
+type dataRef struct {
+	// prefixes holds the proper prefixes of the reference, shortest first
+	prefixes []string
+	path     string
+}
+
+func newDataRef(ref ast.Ref) dataRef {
+	d := dataRef{path: ref.String()}
+	for i := 1; i < len(ref); i++ {
+		d.prefixes = append(d.prefixes, ref[:i].String())
+	}
+	return d
+}
+
+func newParsedModule(p PolicyModule, mod *ast.Module) *parsedModule {
+	pkg := newDataRef(mod.Package.Path)
+	m := &parsedModule{source: p, module: mod, pkg: append(pkg.prefixes, pkg.path)}
+	seen := map[string]bool{}
+	for _, ref := range dataDependencies(mod) {
+		d := newDataRef(ref)
+		if !seen[d.path] {
+			seen[d.path] = true
+			m.deps = append(m.deps, d)
+		}
+	}
+	return m
+}
+
+// dataDependencies returns the constant prefixes of every data reference in a parsed module. A bare
+// or dynamic reference to data depends on the whole document and yields the data root.
+func dataDependencies(mod *ast.Module) []ast.Ref {
+	deps := []ast.Ref{mod.Package.Path}
+	var vis *ast.GenericVisitor
+	vis = ast.NewGenericVisitor(func(x any) bool {
+		switch v := x.(type) {
+		case ast.Ref:
+			if !v[0].Equal(ast.DefaultRootDocument) {
+				return false
+			}
+			deps = append(deps, v.ConstantPrefix())
+			// Dynamic parts of the reference may contain references of their own
+			for _, t := range v[1:] {
+				vis.Walk(t)
+			}
+			return true
+		case ast.Var:
+			if v.Equal(ast.DefaultRootDocument.Value) {
+				deps = append(deps, ast.DefaultRootRef)
+			}
+		}
+		return false
+	})
+	vis.Walk(mod)
+	return deps
+}
+
+// packageIndex finds the modules whose package overlaps a data reference, i.e. the modules that
+// may define a document the reference reads
+type packageIndex struct {
+	// byPackage maps a package path to the modules declared in it
+	byPackage map[string][]string
+	// byPrefix maps every prefix of a package path, including the path itself, to the modules
+	// declared under it
+	byPrefix map[string][]string
+}
+
+func newPackageIndex(modules map[string]*parsedModule) *packageIndex {
+	idx := &packageIndex{byPackage: map[string][]string{}, byPrefix: map[string][]string{}}
+	for name, m := range modules {
+		path := m.pkg[len(m.pkg)-1]
+		idx.byPackage[path] = append(idx.byPackage[path], name)
+		for _, prefix := range m.pkg {
+			idx.byPrefix[prefix] = append(idx.byPrefix[prefix], name)
+		}
+	}
+	return idx
+}
+
+// overlapping calls fn for every module declared in a package that is a prefix of ref or that has
+// ref as a prefix
+func (idx *packageIndex) overlapping(ref dataRef, fn func(name string)) {
+	for _, prefix := range ref.prefixes {
+		for _, name := range idx.byPackage[prefix] {
+			fn(name)
+		}
+	}
+	for _, name := range idx.byPrefix[ref.path] {
+		fn(name)
+	}
+}
+
+// closure returns the sorted names of the modules that the named module transitively depends on,
+// including itself. Compiling a module together with its closure reports the same errors and
+// evaluates to the same result as compiling it with every module.
+func (idx *packageIndex) closure(modules map[string]*parsedModule, name string) []string {
+	seen := map[string]bool{name: true}
+	queue := []string{name}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, dep := range modules[next].deps {
+			idx.overlapping(dep, func(name string) {
+				if !seen[name] {
+					seen[name] = true
+					queue = append(queue, name)
+				}
+			})
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// compiledState returns the parsed modules and closures of the compiled state. apply replaces them but
+// never modifies them, so they can be read after compileMu is released.
+func (e *embeddedEngine) compiledState() (map[string]*parsedModule, map[string][]string) {
+	e.compileMu.Lock()
+	defer e.compileMu.Unlock()
+	return e.modules, e.closures
+}
+
+// parseCandidates parses the modules of a candidate policy set, reusing the modules of the compiled
+// state whose source is unchanged. It returns the parsed modules by module name, the names of the
+// modules that differ from the compiled state, being new, changed or removed, and the parse errors.
+func parseCandidates(policies []PolicyModule, compiled map[string]*parsedModule) (map[string]*parsedModule, map[string]bool, []error) {
+	parsed := make(map[string]*parsedModule, len(policies))
+	changed := map[string]bool{}
+	var errs []error
+	for _, p := range policies {
+		name := moduleName(p)
+		if m, ok := compiled[name]; ok && m.source == p {
+			parsed[name] = m
+			continue
+		}
+		changed[name] = true
+		mod, err := ast.ParseModuleWithOpts(name, p.RegoCode, ast.ParserOptions{RegoVersion: ast.RegoV1})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		parsed[name] = newParsedModule(p, mod)
+	}
+	for name := range compiled {
+		if _, ok := parsed[name]; !ok {
+			changed[name] = true
+		}
+	}
+	return parsed, changed, errs
+}
+
+// affectedSources returns the modules to compile to check a candidate policy set: the roots and the
+// modules whose closure holds a changed module, before or after the change, together with their
+// closures. Every other module has the same closure as in the compiled state, where it compiled.
+func affectedSources(parsed map[string]*parsedModule, changed map[string]bool, closures map[string][]string, roots []string) map[string]*ast.Module {
+	idx := newPackageIndex(parsed)
+	sources := map[string]*ast.Module{}
+	for name := range parsed {
+		closure := idx.closure(parsed, name)
+		if slices.Contains(roots, name) || dependsOnAny(closure, changed) || dependsOnAny(closures[name], changed) {
+			for _, dep := range closure {
+				sources[dep] = parsed[dep].module
+			}
+		}
+	}
+	return sources
+}
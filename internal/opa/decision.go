@@ -0,0 +1,126 @@
+package opa
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+)
+
+// DefaultEntrypoint is the rule a policy decision is read from unless the policy names another one
+const DefaultEntrypoint = "main"
+
+// Rules of the multi-rule convention, read when a policy does not define its entrypoint rule
+const (
+	RuleDeny                       = "deny"
+	RulePatch                      = "patch"
+	RuleConstraints                = "constraints"
+	RuleProvider                   = "provider"
+	RuleServiceProviderConstraints = "service_provider_constraints"
+)
+
+// decisionRules maps the rules of the multi-rule convention, other than deny, to the decision keys they set
+var decisionRules = map[string]string{
+	RulePatch:                      "patch",
+	RuleConstraints:                "constraints",
+	RuleProvider:                   "selected_provider",
+	RuleServiceProviderConstraints: "service_provider_constraints",
+}
+
+// conventionRules lists the rules of the multi-rule convention in query order
+var conventionRules = []string{RuleDeny, RulePatch, RuleConstraints, RuleProvider, RuleServiceProviderConstraints}
+
+// entrypointOf returns the entrypoint rule of a policy module
+func entrypointOf(p PolicyModule) string {
+	if p.Entrypoint == "" {
+		return DefaultEntrypoint
+	}
+	return p.Entrypoint
+}
+
+// moduleRule returns the first definition of a rule of the package of a module in the module itself,
+// or nil if the module does not define the rule
+func moduleRule(compiler *ast.Compiler, mod *ast.Module, name string) *ast.Rule {
+	for _, rule := range compiler.GetRulesExact(mod.Package.Path.Append(ast.StringTerm(name))) {
+		if rule.Location != nil && rule.Location.File == mod.Package.Location.File {
+			return rule
+		}
+	}
+	return nil
+}
+
+// definedRules returns the names of the given rules the module of a policy defines
+func definedRules(compiler *ast.Compiler, mod *ast.Module, names ...string) []string {
+	var defined []string
+	for _, name := range names {
+		if moduleRule(compiler, mod, name) != nil {
+			defined = append(defined, name)
+		}
+	}
+	return defined
+}
+
+// decisionQuery returns the query reading the decision of a policy module and, for a policy following
+// the multi-rule convention, the convention rules the query reads. A policy that defines its
+// entrypoint rule is queried for that rule; otherwise each convention rule it defines is collected,
+// in an array holding the value of the rule or nothing when it is undefined.
+func decisionQuery(compiler *ast.Compiler, mod *ast.Module, entrypoint string) (string, []string) {
+	pkg := mod.Package.Path.String()
+	rules := definedRules(compiler, mod, conventionRules...)
+	if len(rules) == 0 || moduleRule(compiler, mod, entrypoint) != nil {
+		return fmt.Sprintf("%s.%s", pkg, entrypoint), nil
+	}
+
+	exprs := make([]string, len(rules))
+	for i, rule := range rules {
+		if rule == RuleDeny {
+			exprs[i] = fmt.Sprintf("%s := [m | some m in %s.%s]", rule, pkg, rule)
+		} else {
+			exprs[i] = fmt.Sprintf("%s := [x | x := %s.%s]", rule, pkg, rule)
+		}
+	}
+	return strings.Join(exprs, "; "), rules
+}
+
+// assembleDecision builds the decision of a policy following the multi-rule convention from the
+// bindings of its query. The request is rejected when deny holds any message, with the sorted
+// messages as the rejection reason; the other rules set their decision key when defined.
+func assembleDecision(bindings rego.Vars) map[string]any {
+	decision := map[string]any{"rejected": false}
+	if deny, ok := bindings[RuleDeny].([]any); ok && len(deny) > 0 {
+		messages := make([]string, len(deny))
+		for i, m := range deny {
+			messages[i] = denyMessage(m)
+		}
+		slices.Sort(messages)
+		decision["rejected"] = true
+		decision["rejection_reason"] = strings.Join(messages, "; ")
+	}
+	for rule, key := range decisionRules {
+		if values, ok := bindings[rule].([]any); ok && len(values) > 0 {
+			decision[key] = values[0]
+		}
+	}
+	return decision
+}
+
+// denyMessage returns the message of a deny rule value: a string, or an object with a string msg
+// as in Gatekeeper. Other values are rendered as JSON.
+func denyMessage(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if msg, ok := v["msg"].(string); ok {
+			return msg
+		}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
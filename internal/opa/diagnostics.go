@@ -0,0 +1,63 @@
+package opa
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+)
+
+// toDiagnostics converts parse or compile errors to diagnostics sorted by module and position.
+// modules is used to find the rule each error is located in and may be nil.
+func toDiagnostics(err error, modules map[string]*ast.Module) []Diagnostic {
+	var astErrs ast.Errors
+	if !errors.As(err, &astErrs) {
+		return []Diagnostic{{Severity: SeverityError, Code: ast.CompileErr, Message: err.Error()}}
+	}
+
+	diagnostics := make([]Diagnostic, len(astErrs))
+	for i, e := range astErrs {
+		d := Diagnostic{Severity: SeverityError, Code: e.Code, Message: e.Message}
+		if e.Location != nil {
+			d.PolicyID, d.LibraryID = moduleOwner(e.Location.File)
+			d.Line = e.Location.Row
+			d.Column = e.Location.Col
+			d.Rule = enclosingRule(modules[e.Location.File], e.Location.Row)
+		}
+		diagnostics[i] = d
+	}
+	sortDiagnostics(diagnostics)
+	return diagnostics
+}
+
+// sortDiagnostics sorts diagnostics by module and position
+func sortDiagnostics(diagnostics []Diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i], diagnostics[j]
+		if a.module() != b.module() {
+			return a.module() < b.module()
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// enclosingRule returns the name of the rule of mod spanning the given line, or "" if there is none
+func enclosingRule(mod *ast.Module, line int) string {
+	if mod == nil {
+		return ""
+	}
+	for _, rule := range mod.Rules {
+		loc := rule.Location
+		if loc == nil {
+			continue
+		}
+		if line >= loc.Row && line <= loc.Row+strings.Count(string(loc.Text), "\n") {
+			return rule.Head.Ref().String()
+		}
+	}
+	return ""
+}
@@ -3,12 +3,21 @@ package opa
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/dcm-project/policy-manager/internal/decisionlog"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/metrics"
+	"github.com/google/uuid"
 	"github.com/open-policy-agent/opa/v1/ast"
+	opametrics "github.com/open-policy-agent/opa/v1/metrics"
 	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/open-policy-agent/opa/v1/topdown"
 )
 
 // Engine defines the interface for the embedded OPA engine
@@ -16,73 +25,247 @@ type Engine interface {
 	// Compile loads and compiles all Rego modules, replacing any previously compiled state.
 	Compile(ctx context.Context, policies []PolicyModule) error
 
-	// EvaluatePolicy evaluates a policy by ID against the given input.
-	EvaluatePolicy(ctx context.Context, policyID string, input map[string]any) (*EvaluationResult, error)
+	// Update adds or replaces the upsert modules and removes the remove modules, keeping all other
+	// compiled modules. Only the affected policies are prepared again.
+	Update(ctx context.Context, upsert []PolicyModule, remove []PolicyModule) error
+
+	// EvaluatePolicy evaluates a policy by ID against the given input, capturing its print() output.
+	// A failed evaluation returns its print() output and trace with a *EvaluationError.
+	EvaluatePolicy(ctx context.Context, policyID string, input map[string]any, opts ...EvalOption) (*EvaluationResult, error)
 
 	// ValidateRego checks Rego syntax without persisting.
 	ValidateRego(ctx context.Context, regoCode string) error
+
+	// CheckPolicies compiles the policy modules as Compile would, without affecting the compiled state,
+	// and lints the output contract of the policies listed in lint. Warnings are returned; compile and
+	// lint errors are returned as a *CompileError.
+	CheckPolicies(ctx context.Context, policies []PolicyModule, lint []string) ([]Diagnostic, error)
+
+	// RunTests runs the test rules of a test module against the given policy modules without
+	// affecting the compiled state.
+	RunTests(ctx context.Context, policies []PolicyModule, tests TestModule) ([]TestResult, error)
 }
 
 // PolicyModule represents a Rego module to compile
 type PolicyModule struct {
 	ID       string
 	RegoCode string
+	// Library marks a shared module declared under data.lib. Libraries are compiled with the
+	// policies that import them but have no main rule and are never evaluated.
+	Library bool
+	// Entrypoint is the rule the decision of the policy is read from; DefaultEntrypoint when empty
+	Entrypoint string
 }
 
 // embeddedEngine implements Engine using OPA's Go library
 type embeddedEngine struct {
 	mu        sync.RWMutex // protects reads/writes of queries
-	compileMu sync.Mutex   // serializes Compile calls
-	queries   map[string]*rego.PreparedEvalQuery
+	compileMu sync.Mutex   // serializes Compile and Update calls, and protects modules and closures
+	queries   map[string]*preparedPolicy
+	// modules holds the parsed modules of the compiled state by module name
+	modules map[string]*parsedModule
+	// closures holds the sorted names of the modules each module was compiled with
+	closures map[string][]string
+	// providers is the catalog read by dcm.provider.get
+	providers ProviderCatalog
+	// decisionLogger receives an event for every policy evaluation, if set
+	decisionLogger DecisionLogger
+	// capabilities restricts the built-ins policies and their tests may call
+	capabilities *ast.Capabilities
+}
+
+// preparedPolicy is the prepared query of a policy's decision
+type preparedPolicy struct {
+	query rego.PreparedEvalQuery
+	// path is the path of the entrypoint rule, or of the package of a multi-rule policy, in the data
+	// document, as in decision logs
+	path string
+	// rules lists the convention rules the query reads, if the policy follows the multi-rule convention
+	rules []string
+}
+
+// DecisionLogger receives a decision log event for every policy evaluation, such as decisionlog.Logger
+type DecisionLogger interface {
+	Log(ctx context.Context, event decisionlog.Event)
+}
+
+// EngineOption configures an embedded OPA engine
+type EngineOption func(*embeddedEngine)
+
+// WithProviders sets the provider catalog that policies read with dcm.provider.get
+func WithProviders(providers ProviderCatalog) EngineOption {
+	return func(e *embeddedEngine) {
+		e.providers = providers
+	}
+}
+
+// WithDecisionLogger emits a decision log event to logger for every policy evaluation
+func WithDecisionLogger(logger DecisionLogger) EngineOption {
+	return func(e *embeddedEngine) {
+		e.decisionLogger = logger
+	}
+}
+
+// WithCapabilities compiles policies against capabilities, e.g. built by NewCapabilities, instead of
+// the default capabilities without the RestrictedBuiltins
+func WithCapabilities(capabilities *ast.Capabilities) EngineOption {
+	return func(e *embeddedEngine) {
+		e.capabilities = capabilities
+	}
 }
 
 // NewEngine creates a new embedded OPA engine
-func NewEngine() Engine {
-	return &embeddedEngine{}
+func NewEngine(opts ...EngineOption) Engine {
+	e := &embeddedEngine{}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.capabilities == nil {
+		e.capabilities, _ = NewCapabilities(nil, nil) // cannot fail without allowed built-ins
+	}
+	return e
 }
 
 // Compile compiles all provided policy modules. On success, replaces the previous compiled state.
 // On failure, the previous state is preserved (atomic). Concurrent Compile calls are serialized.
+// Only the modules that changed since the previous compilation, and the modules depending on them,
+// are compiled and prepared again.
 func (e *embeddedEngine) Compile(ctx context.Context, policies []PolicyModule) error {
 	e.compileMu.Lock()
 	defer e.compileMu.Unlock()
 
-	if len(policies) == 0 {
-		e.mu.Lock()
-		e.queries = nil
-		e.mu.Unlock()
-		return nil
+	next := make(map[string]PolicyModule, len(policies))
+	for _, p := range policies {
+		next[moduleName(p)] = p
 	}
+	return e.compile(ctx, next)
+}
 
-	// Build source map for compilation
-	sources := make(map[string]string, len(policies))
-	for _, p := range policies {
-		sources[p.ID] = p.RegoCode
+// Update adds or replaces the upsert modules and removes the remove modules from the compiled state,
+// with the same guarantees as Compile.
+func (e *embeddedEngine) Update(ctx context.Context, upsert []PolicyModule, remove []PolicyModule) error {
+	e.compileMu.Lock()
+	defer e.compileMu.Unlock()
+
+	next := make(map[string]PolicyModule, len(e.modules)+len(upsert))
+	for name, m := range e.modules {
+		next[name] = m.source
+	}
+	for _, p := range remove {
+		delete(next, moduleName(p))
 	}
+	for _, p := range upsert {
+		next[moduleName(p)] = p
+	}
+	return e.compile(ctx, next)
+}
 
-	// Compile all modules together to catch cross-module errors
-	compiler, err := ast.CompileModulesWithOpt(sources, ast.CompileOpts{ParserOptions: ast.ParserOptions{RegoVersion: ast.RegoV1}})
+// compile applies the next modules and records the duration and outcome of the compilation and the
+// number of compiled policies. Must be called with compileMu held.
+func (e *embeddedEngine) compile(ctx context.Context, next map[string]PolicyModule) error {
+	start := time.Now()
+	err := e.apply(ctx, next)
+	metrics.EngineCompileDuration.Observe(time.Since(start).Seconds())
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrInvalidRego, err)
+		metrics.EngineCompileFailures.Inc()
+		return err
 	}
+	metrics.EnginePolicies.Set(float64(len(e.queries)))
+	return nil
+}
 
-	// Build one PreparedEvalQuery per policy, keyed by policy ID
-	newQueries := make(map[string]*rego.PreparedEvalQuery, len(policies))
-	for _, p := range policies {
-		mod := compiler.Modules[p.ID]
-		// mod.Package.Path is like "data.policies.my_policy", we need the part after "data."
-		pkgName := strings.TrimPrefix(mod.Package.Path.String(), "data.")
-		query := fmt.Sprintf("data.%s.main", pkgName)
+// apply replaces the compiled state with the next modules, keyed by module name. Only the modules
+// that changed and the modules depending on them are compiled, together with their dependencies, and
+// only their queries are prepared again; the other queries keep the compiler they were prepared with.
+// Must be called with compileMu held.
+func (e *embeddedEngine) apply(ctx context.Context, next map[string]PolicyModule) error {
+	names := make([]string, 0, len(next))
+	for name := range next {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	// Parse the modules that are new or changed, reusing the others
+	modules := make(map[string]*parsedModule, len(next))
+	changed := map[string]bool{}
+	for _, name := range names {
+		p := next[name]
+		if m, ok := e.modules[name]; ok && m.source == p {
+			modules[name] = m
+			continue
+		}
+		mod, err := ast.ParseModuleWithOpts(name, p.RegoCode, ast.ParserOptions{RegoVersion: ast.RegoV1})
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRego, err)
+		}
+		modules[name] = newParsedModule(p, mod)
+		changed[name] = true
+	}
+	for name := range e.modules {
+		if _, ok := next[name]; !ok {
+			changed[name] = true
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	// A module is affected when a changed module is among its dependencies, before or after the change
+	idx := newPackageIndex(modules)
+	closures := make(map[string][]string, len(modules))
+	newQueries := make(map[string]*preparedPolicy, len(modules))
+	var affected []string
+	for _, name := range names {
+		closure := idx.closure(modules, name)
+		closures[name] = closure
+		if dependsOnAny(closure, changed) || dependsOnAny(e.closures[name], changed) {
+			affected = append(affected, name)
+		} else if pq, ok := e.queries[name]; ok {
+			newQueries[name] = pq
+		}
+	}
+
+	// Compile the affected modules with their dependencies to catch cross-module errors
+	sources := map[string]*ast.Module{}
+	for _, name := range affected {
+		for _, dep := range closures[name] {
+			sources[dep] = modules[dep].module
+		}
+	}
+	// Keep print() calls, which the compiler removes by default, so that their output is captured
+	compiler := ast.NewCompiler().
+		WithDefaultRegoVersion(ast.RegoV1).
+		WithCapabilities(e.capabilities).
+		WithEnablePrintStatements(true)
+	compiler.Compile(sources)
+	if compiler.Failed() {
+		return fmt.Errorf("%w: %v", ErrInvalidRego, compiler.Errors)
+	}
+
+	// Build one PreparedEvalQuery per affected policy, keyed by policy ID
+	for _, name := range affected {
+		p := modules[name].source
+		if p.Library {
+			continue
+		}
+		mod := compiler.Modules[name]
+		entrypoint := entrypointOf(p)
+		query, rules := decisionQuery(compiler, mod, entrypoint)
+		path := rulePath(mod.Package.Path, entrypoint)
+		if rules != nil {
+			path = rulePath(mod.Package.Path, "")
+		}
 
 		r := rego.New(
 			rego.Query(query),
 			rego.Compiler(compiler),
+			rego.EnablePrintStatements(true),
 		)
 		pq, err := r.PrepareForEval(ctx)
 		if err != nil {
 			return fmt.Errorf("%w: failed to prepare query for policy '%s': %v", ErrInvalidRego, p.ID, err)
 		}
-		newQueries[p.ID] = &pq
+		newQueries[p.ID] = &preparedPolicy{query: pq, path: path, rules: rules}
 	}
 
 	// Atomically swap the query map
@@ -90,20 +273,91 @@ func (e *embeddedEngine) Compile(ctx context.Context, policies []PolicyModule) e
 	e.queries = newQueries
 	e.mu.Unlock()
 
+	e.modules = modules
+	e.closures = closures
 	return nil
 }
 
-// EvaluatePolicy evaluates a policy by ID. Safe for concurrent use.
-func (e *embeddedEngine) EvaluatePolicy(ctx context.Context, policyID string, input map[string]any) (*EvaluationResult, error) {
+// dependsOnAny reports whether any of the module names is in the set
+func dependsOnAny(names []string, set map[string]bool) bool {
+	return slices.ContainsFunc(names, func(name string) bool { return set[name] })
+}
+
+// EvaluatePolicy evaluates a policy by ID. Safe for concurrent use. The print() output and explain
+// trace of the evaluation are also logged at debug level.
+func (e *embeddedEngine) EvaluatePolicy(ctx context.Context, policyID string, input map[string]any, opts ...EvalOption) (*EvaluationResult, error) {
+	var o evalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	e.mu.RLock()
-	pq, ok := e.queries[policyID]
+	pp, ok := e.queries[policyID]
 	e.mu.RUnlock()
 
 	if !ok {
 		return &EvaluationResult{Defined: false}, nil
 	}
 
-	rs, err := pq.Eval(ctx, rego.EvalInput(input))
+	prints := &printCollector{}
+	evalOpts := []rego.EvalOption{rego.EvalInput(input), rego.EvalPrintHook(prints)}
+	var tracer *topdown.BufferTracer
+	if o.explain != ExplainOff {
+		tracer = topdown.NewBufferTracer()
+		evalOpts = append(evalOpts, rego.EvalQueryTracer(tracer))
+	}
+	var m opametrics.Metrics
+	start := time.Now()
+	if e.decisionLogger != nil {
+		m = opametrics.New()
+		evalOpts = append(evalOpts, rego.EvalMetrics(m))
+	}
+
+	result, err := evaluate(withProviders(ctx, e.providers), pp, policyID, evalOpts...)
+
+	log := logging.FromContext(ctx)
+	printOutput := prints.output()
+	if len(printOutput) > 0 {
+		log.Debug("Policy print output", "policy_id", policyID, "output", printOutput)
+	}
+	var trace []string
+	if tracer != nil {
+		trace = formatTrace(o.explain, *tracer)
+		log.Debug("Policy explain trace", "policy_id", policyID, "explain", o.explain, "trace", trace)
+	}
+	if result != nil {
+		result.PrintOutput = printOutput
+		result.Trace = trace
+	}
+
+	if e.decisionLogger != nil {
+		event := decisionlog.Event{
+			DecisionID: uuid.NewString(),
+			Path:       pp.path,
+			Input:      input,
+			Metrics:    m.All(),
+			Timestamp:  start,
+		}
+		if err != nil {
+			event.Error = eventError(err)
+		} else if result.Defined {
+			event.Result = result.Result
+		}
+		e.decisionLogger.Log(ctx, event)
+	}
+	if err != nil {
+		return nil, &EvaluationError{Err: err, PrintOutput: printOutput, Trace: trace}
+	}
+	return result, nil
+}
+
+// evaluate evaluates the prepared query of a policy
+func evaluate(ctx context.Context, pp *preparedPolicy, policyID string, opts ...rego.EvalOption) (*EvaluationResult, error) {
+	rs, err := pp.query.Eval(ctx, opts...)
+	if err != nil && topdown.IsCancel(err) && ctx.Err() != nil {
+		// OPA stops the evaluation when the context is done; report why
+		return nil, fmt.Errorf("%w for policy '%s': %w: %w", ErrEvaluationCanceled, policyID, context.Cause(ctx), err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("evaluation error for policy '%s': %w", policyID, err)
 	}
@@ -111,6 +365,9 @@ func (e *embeddedEngine) EvaluatePolicy(ctx context.Context, policyID string, in
 	if len(rs) == 0 || len(rs[0].Expressions) == 0 {
 		return &EvaluationResult{Defined: false}, nil
 	}
+	if pp.rules != nil {
+		return &EvaluationResult{Result: assembleDecision(rs[0].Bindings), Defined: true}, nil
+	}
 
 	val := rs[0].Expressions[0].Value
 	if val == nil {
@@ -119,7 +376,7 @@ func (e *embeddedEngine) EvaluatePolicy(ctx context.Context, policyID string, in
 
 	resultMap, ok := val.(map[string]any)
 	if !ok {
-		return nil, fmt.Errorf("%w: policy main rule must return an object; got %T", ErrEngineInternal, val)
+		return nil, fmt.Errorf("%w: policy entrypoint rule must return an object; got %T", ErrEngineInternal, val)
 	}
 
 	return &EvaluationResult{
@@ -128,6 +385,35 @@ func (e *embeddedEngine) EvaluatePolicy(ctx context.Context, policyID string, in
 	}, nil
 }
 
+// rulePath returns the path of a rule of a package in the data document, e.g. policies/region/main
+// for the main rule of data.policies.region, or the path of the package itself when rule is empty
+func rulePath(pkg ast.Ref, rule string) string {
+	parts := make([]string, 0, len(pkg))
+	for _, t := range pkg[1:] {
+		if s, ok := t.Value.(ast.String); ok {
+			parts = append(parts, string(s))
+		} else {
+			parts = append(parts, t.String())
+		}
+	}
+	if rule != "" {
+		parts = append(parts, rule)
+	}
+	return strings.Join(parts, "/")
+}
+
+// eventError returns the decision log error of a failed evaluation, with the code of OPA errors
+func eventError(err error) *decisionlog.EventError {
+	var topdownErr *topdown.Error
+	if errors.As(err, &topdownErr) {
+		return &decisionlog.EventError{Code: topdownErr.Code, Message: topdownErr.Message}
+	}
+	if errors.Is(err, ErrEngineInternal) {
+		return &decisionlog.EventError{Code: "internal_error", Message: err.Error()}
+	}
+	return &decisionlog.EventError{Code: "eval_error", Message: err.Error()}
+}
+
 // ValidateRego checks that the given Rego code compiles without errors.
 func (e *embeddedEngine) ValidateRego(_ context.Context, regoCode string) error {
 	if strings.TrimSpace(regoCode) == "" {
@@ -141,3 +427,47 @@ func (e *embeddedEngine) ValidateRego(_ context.Context, regoCode string) error
 
 	return nil
 }
+
+// CheckPolicies compiles the provided policy modules together and reports every problem found.
+// Unlike Compile, it does not stop at the first module that fails to parse or at the compiler's error limit.
+// Only the modules that differ from the compiled state, the modules depending on them and the policies
+// listed in lint are compiled, with their dependencies: the other modules compiled in the compiled state
+// and compile the same way. The policies listed in lint and the libraries compiled with them are then
+// checked by the linter, which only runs on a policy set that compiles.
+func (e *embeddedEngine) CheckPolicies(_ context.Context, policies []PolicyModule, lint []string) ([]Diagnostic, error) {
+	compiled, closures := e.compiledState()
+	parsed, changed, parseErrs := parseCandidates(policies, compiled)
+	if len(parseErrs) > 0 {
+		var diagnostics []Diagnostic
+		for _, err := range parseErrs {
+			diagnostics = append(diagnostics, toDiagnostics(err, nil)...)
+		}
+		return nil, &CompileError{Diagnostics: diagnostics}
+	}
+	modules := affectedSources(parsed, changed, closures, lint)
+
+	compiler := ast.NewCompiler().
+		WithDefaultRegoVersion(ast.RegoV1).
+		WithCapabilities(e.capabilities).
+		SetErrorLimit(0)
+	compiler.Compile(modules)
+	if compiler.Failed() {
+		diagnostics := toDiagnostics(compiler.Errors, modules)
+		markDisallowedBuiltins(diagnostics)
+		return nil, &CompileError{Diagnostics: diagnostics}
+	}
+
+	entrypoints := make(map[string]string, len(lint))
+	for _, p := range policies {
+		if !p.Library && slices.Contains(lint, p.ID) {
+			entrypoints[p.ID] = p.Entrypoint
+		}
+	}
+	diagnostics := lintPolicies(compiler, modules, lint, entrypoints)
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			return nil, &CompileError{Diagnostics: diagnostics}
+		}
+	}
+	return diagnostics, nil
+}
@@ -0,0 +1,101 @@
+package opa_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dcm-project/policy-manager/internal/opa"
+)
+
+func benchPolicy(i int, version int) opa.PolicyModule {
+	return opa.PolicyModule{ID: fmt.Sprintf("policy-%d", i), RegoCode: fmt.Sprintf(`package policies.p%d
+
+import data.lib.regions
+
+main := {
+	"rejected": rejected,
+	"rejection_reason": "region not allowed",
+	"patch": {"metadata": {"version": %d}},
+}
+
+default rejected := false
+
+rejected if {
+	not regions.allowed(input.spec.region)
+	input.spec.labels.team == "team-%d"
+}
+`, i, version, i)}
+}
+
+func regionsLibrary(regions string) opa.PolicyModule {
+	return opa.PolicyModule{ID: "regions", Library: true, RegoCode: fmt.Sprintf(`package lib.regions
+
+allowed(region) if region in {%s}
+`, regions)}
+}
+
+func benchSet(n int) []opa.PolicyModule {
+	modules := []opa.PolicyModule{regionsLibrary(`"us-east-1", "eu-west-1"`)}
+	for i := range n {
+		modules = append(modules, benchPolicy(i, 0))
+	}
+	return modules
+}
+
+// BenchmarkCompileFull1000 compiles a set of 1000 policies into an empty engine, as on startup
+func BenchmarkCompileFull1000(b *testing.B) {
+	ctx := context.Background()
+	modules := benchSet(1000)
+	for b.Loop() {
+		if err := opa.NewEngine().Compile(ctx, modules); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCompileOneChanged1000 recompiles a set of 1000 policies of which one changed
+func BenchmarkCompileOneChanged1000(b *testing.B) {
+	ctx := context.Background()
+	engine := compiledEngine(b, 1000)
+	modules := benchSet(1000)
+	for i := 1; b.Loop(); i++ {
+		modules[1] = benchPolicy(0, i)
+		if err := engine.Compile(ctx, modules); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkUpdatePolicy1000 replaces one policy of a compiled set of 1000 policies
+func BenchmarkUpdatePolicy1000(b *testing.B) {
+	ctx := context.Background()
+	engine := compiledEngine(b, 1000)
+	for i := 1; b.Loop(); i++ {
+		if err := engine.Update(ctx, []opa.PolicyModule{benchPolicy(0, i)}, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkUpdateLibrary1000 replaces the library imported by every policy of a compiled set of
+// 1000 policies, which prepares all of them again
+func BenchmarkUpdateLibrary1000(b *testing.B) {
+	ctx := context.Background()
+	engine := compiledEngine(b, 1000)
+	for i := 1; b.Loop(); i++ {
+		library := regionsLibrary(fmt.Sprintf(`"us-east-1", "eu-west-%d"`, i))
+		if err := engine.Update(ctx, []opa.PolicyModule{library}, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func compiledEngine(b *testing.B, n int) opa.Engine {
+	b.Helper()
+	engine := opa.NewEngine()
+	if err := engine.Compile(context.Background(), benchSet(n)); err != nil {
+		b.Fatal(err)
+	}
+	return engine
+}
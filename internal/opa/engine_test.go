@@ -2,14 +2,28 @@ package opa_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"sync"
+	"time"
 
+	"github.com/dcm-project/policy-manager/internal/decisionlog"
+	"github.com/dcm-project/policy-manager/internal/metrics"
 	"github.com/dcm-project/policy-manager/internal/opa"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
+// recordingDecisionLogger records the logged decision events
+type recordingDecisionLogger struct {
+	events []decisionlog.Event
+}
+
+func (l *recordingDecisionLogger) Log(_ context.Context, event decisionlog.Event) {
+	l.events = append(l.events, event)
+}
+
 var _ = Describe("Engine", func() {
 	var (
 		engine opa.Engine
@@ -91,6 +105,107 @@ var _ = Describe("Engine", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result.Defined).To(BeTrue())
 		})
+
+		It("records the compiled policy count and compile failures", func() {
+			failures := testutil.ToFloat64(metrics.EngineCompileFailures)
+
+			Expect(engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "lib", RegoCode: "package lib.common\nallowed := true", Library: true},
+				{ID: "p1", RegoCode: "package policy_a\nmain = {\"rejected\": false}"},
+				{ID: "p2", RegoCode: "package policy_b\nmain = {\"rejected\": false}"},
+			})).To(Succeed())
+			Expect(testutil.ToFloat64(metrics.EnginePolicies)).To(Equal(2.0))
+
+			Expect(engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "bad", RegoCode: "package bad\n{invalid"},
+			})).NotTo(Succeed())
+			Expect(testutil.ToFloat64(metrics.EngineCompileFailures)).To(Equal(failures + 1))
+			Expect(testutil.ToFloat64(metrics.EnginePolicies)).To(Equal(2.0))
+		})
+	})
+
+	Describe("Update", func() {
+		const library = "package lib.regions\n\nallowed(region) if region in {\"us-east-1\"}\n"
+		const policy = "package policies.regions\n\nimport data.lib.regions\n\nmain := {\"rejected\": not_allowed}\n\nnot_allowed if not regions.allowed(input.spec.region)\ndefault not_allowed := false\n"
+		euInput := map[string]any{"spec": map[string]any{"region": "eu-west-1"}}
+
+		evaluate := func(id string, input map[string]any) *opa.EvaluationResult {
+			GinkgoHelper()
+			result, err := engine.EvaluatePolicy(ctx, id, input)
+			Expect(err).NotTo(HaveOccurred())
+			return result
+		}
+
+		BeforeEach(func() {
+			Expect(engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "regions", RegoCode: library, Library: true},
+				{ID: "regions", RegoCode: policy},
+				{ID: "other", RegoCode: "package policies.other\nmain := {\"rejected\": false}"},
+			})).To(Succeed())
+		})
+
+		It("adds, replaces and removes policies, keeping the others", func() {
+			Expect(engine.Update(ctx, []opa.PolicyModule{
+				{ID: "added", RegoCode: "package policies.added\nmain := {\"rejected\": true}"},
+				{ID: "other", RegoCode: "package policies.other\nmain := {\"rejected\": true}"},
+			}, nil)).To(Succeed())
+			Expect(evaluate("added", nil).Result).To(HaveKeyWithValue("rejected", true))
+			Expect(evaluate("other", nil).Result).To(HaveKeyWithValue("rejected", true))
+			Expect(evaluate("regions", euInput).Result).To(HaveKeyWithValue("rejected", true))
+
+			Expect(engine.Update(ctx, nil, []opa.PolicyModule{{ID: "added"}})).To(Succeed())
+			Expect(evaluate("added", nil).Defined).To(BeFalse())
+			Expect(evaluate("other", nil).Defined).To(BeTrue())
+		})
+
+		It("prepares the policies importing a changed library again", func() {
+			Expect(engine.Update(ctx, []opa.PolicyModule{
+				{ID: "regions", RegoCode: "package lib.regions\n\nallowed(region) if region in {\"us-east-1\", \"eu-west-1\"}\n", Library: true},
+			}, nil)).To(Succeed())
+
+			Expect(evaluate("regions", euInput).Result).To(HaveKeyWithValue("rejected", false))
+		})
+
+		It("rejects removing a library that policies still import", func() {
+			err := engine.Update(ctx, nil, []opa.PolicyModule{{ID: "regions", Library: true}})
+			Expect(errors.Is(err, opa.ErrInvalidRego)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("undefined function data.lib.regions.allowed"))
+		})
+
+		It("reports conflicts with unchanged modules", func() {
+			err := engine.Update(ctx, []opa.PolicyModule{
+				{ID: "conflict", RegoCode: "package policies.other\nmain(x) := {\"rejected\": x}"},
+			}, nil)
+			Expect(errors.Is(err, opa.ErrInvalidRego)).To(BeTrue())
+		})
+
+		It("is atomic on failure", func() {
+			err := engine.Update(ctx, []opa.PolicyModule{
+				{ID: "other", RegoCode: "package policies.other\nmain := {\"rejected\": true}"},
+				{ID: "bad", RegoCode: "package bad\n{invalid"},
+			}, nil)
+			Expect(errors.Is(err, opa.ErrInvalidRego)).To(BeTrue())
+
+			Expect(evaluate("other", nil).Result).To(HaveKeyWithValue("rejected", false))
+			Expect(evaluate("bad", nil).Defined).To(BeFalse())
+
+			// The failed update leaves nothing behind for the next one
+			Expect(engine.Update(ctx, nil, []opa.PolicyModule{{ID: "regions"}})).To(Succeed())
+			Expect(evaluate("other", nil).Result).To(HaveKeyWithValue("rejected", false))
+			Expect(evaluate("regions", nil).Defined).To(BeFalse())
+		})
+
+		It("agrees with Compile on the resulting policy set", func() {
+			Expect(engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "regions", RegoCode: library, Library: true},
+				{ID: "other", RegoCode: "package policies.other\nmain := {\"rejected\": data.policies.regions.main.rejected}"},
+			})).To(Succeed())
+			Expect(evaluate("regions", nil).Defined).To(BeFalse())
+
+			// The policy referring to a removed package is prepared again once it is back
+			Expect(engine.Update(ctx, []opa.PolicyModule{{ID: "regions", RegoCode: policy}}, nil)).To(Succeed())
+			Expect(evaluate("other", euInput).Result).To(HaveKeyWithValue("rejected", true))
+		})
 	})
 
 	Describe("EvaluatePolicy", func() {
@@ -219,6 +334,255 @@ main = {"rejected": false, "patch": {"cpu_limit": input.spec.cpu}} if {
 
 			wg.Wait()
 		})
+
+		It("stops an evaluation when the context is done and reports the cause", func() {
+			err := engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "slow", RegoCode: `package slow
+main := {"rejected": count({x | some i in numbers.range(1, 5000); some j in numbers.range(1, 5000); x := i * j}) < 0}`},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			timeout := errors.New("test timeout")
+			evalCtx, cancel := context.WithTimeoutCause(ctx, 20*time.Millisecond, timeout)
+			defer cancel()
+
+			_, err = engine.EvaluatePolicy(evalCtx, "slow", map[string]any{})
+			Expect(err).To(MatchError(opa.ErrEvaluationCanceled))
+			Expect(err).To(MatchError(timeout))
+		})
+	})
+
+	Describe("decision entrypoints", func() {
+		evaluate := func(p opa.PolicyModule, input map[string]any) *opa.EvaluationResult {
+			Expect(engine.Compile(ctx, []opa.PolicyModule{p})).To(Succeed())
+			result, err := engine.EvaluatePolicy(ctx, p.ID, input)
+			Expect(err).NotTo(HaveOccurred())
+			return result
+		}
+
+		It("assembles the decision of a policy defining separate rules", func() {
+			result := evaluate(opa.PolicyModule{ID: "rules", RegoCode: `package policies.rules
+
+deny contains "region is required" if not input.spec.region
+
+patch := {"tier": "standard"} if input.spec.region == "eu-west-1"
+
+constraints := {"tier": {"enum": ["standard", "premium"]}}
+
+provider := "aws" if input.spec.region
+
+service_provider_constraints := {"allow_list": ["aws"]}`}, map[string]any{"spec": map[string]any{"region": "us-east-1"}})
+
+			Expect(result.Defined).To(BeTrue())
+			Expect(result.Result).To(Equal(map[string]any{
+				"rejected":                     false,
+				"constraints":                  map[string]any{"tier": map[string]any{"enum": []any{"standard", "premium"}}},
+				"selected_provider":            "aws",
+				"service_provider_constraints": map[string]any{"allow_list": []any{"aws"}},
+			}))
+		})
+
+		It("rejects with the sorted deny messages", func() {
+			result := evaluate(opa.PolicyModule{ID: "rules", RegoCode: `package policies.rules
+
+deny contains "region is required" if not input.spec.region
+
+deny contains {"msg": "cpu exceeds 8", "details": {"max": 8}} if input.spec.cpu > 8`}, map[string]any{"spec": map[string]any{"cpu": 16}})
+
+			decision := opa.ParsePolicyDecision(result.Result)
+			Expect(decision.Rejected).To(BeTrue())
+			Expect(decision.RejectionReason).To(Equal("cpu exceeds 8; region is required"))
+		})
+
+		It("approves when no deny message holds", func() {
+			result := evaluate(opa.PolicyModule{ID: "rules", RegoCode: `package policies.rules
+
+deny contains "region is required" if not input.spec.region`}, map[string]any{"spec": map[string]any{"region": "eu"}})
+
+			Expect(result.Result).To(Equal(map[string]any{"rejected": false}))
+		})
+
+		It("reads main when a policy defines it along with convention rules", func() {
+			result := evaluate(opa.PolicyModule{ID: "main", RegoCode: `package policies.main
+
+deny contains "ignored"
+
+main := {"rejected": false, "patch": {"checked": count(deny)}}`}, map[string]any{})
+
+			Expect(result.Result).To(Equal(map[string]any{"rejected": false, "patch": map[string]any{"checked": json.Number("1")}}))
+		})
+
+		It("reads the custom entrypoint of a policy", func() {
+			result := evaluate(opa.PolicyModule{ID: "custom", Entrypoint: "decision", RegoCode: `package policies.custom
+
+main := {"rejected": true}
+
+decision := {"rejected": false, "selected_provider": "gcp"}`}, map[string]any{})
+
+			Expect(result.Result).To(Equal(map[string]any{"rejected": false, "selected_provider": "gcp"}))
+		})
+
+		It("lints the rules of a multi-rule policy", func() {
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{{ID: "rules", RegoCode: `package policies.rules
+
+deny contains "always"
+
+provider := 42`}}, []string{"rules"})
+
+			var compileErr *opa.CompileError
+			Expect(errors.As(err, &compileErr)).To(BeTrue())
+			Expect(compileErr.Diagnostics).To(ConsistOf(opa.Diagnostic{
+				PolicyID: "rules",
+				Line:     5,
+				Column:   1,
+				Severity: opa.SeverityError,
+				Code:     opa.CodeInvalidDecision,
+				Message:  "rule provider must be of type string, got number",
+				Rule:     "provider",
+			}))
+		})
+
+		It("reports a missing custom entrypoint", func() {
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{
+				{ID: "custom", Entrypoint: "decision", RegoCode: "package policies.custom\nmain := {\"rejected\": false}"},
+			}, []string{"custom"})
+
+			var compileErr *opa.CompileError
+			Expect(errors.As(err, &compileErr)).To(BeTrue())
+			Expect(compileErr.Diagnostics).To(ConsistOf(HaveField("Message", "policy does not define data.policies.custom.decision")))
+		})
+
+		It("records the package of a multi-rule policy as the decision path", func() {
+			logger := &recordingDecisionLogger{}
+			engine = opa.NewEngine(opa.WithDecisionLogger(logger))
+
+			evaluate(opa.PolicyModule{ID: "rules", RegoCode: "package policies.rules\ndeny contains \"no\""}, map[string]any{})
+
+			Expect(logger.events).To(HaveLen(1))
+			Expect(logger.events[0].Path).To(Equal("policies/rules"))
+		})
+	})
+
+	Describe("print output and explain traces", func() {
+		BeforeEach(func() {
+			Expect(engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "region", RegoCode: `package policies.region
+
+main := {"rejected": false} if {
+	print("region:", input.region)
+	trace("checking region")
+	input.region == "eu"
+}`},
+				{ID: "conflict", RegoCode: `package policies.conflict
+
+main := {"rejected": false} if {
+	print("allowing")
+	input.allow
+}
+
+main := {"rejected": true} if input.reject`},
+			})).To(Succeed())
+		})
+
+		It("captures print output without a trace by default", func() {
+			result, err := engine.EvaluatePolicy(ctx, "region", map[string]any{"region": "eu"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Defined).To(BeTrue())
+			Expect(result.PrintOutput).To(Equal([]string{"region: eu"}))
+			Expect(result.Trace).To(BeNil())
+		})
+
+		It("captures print output of undefined decisions", func() {
+			result, err := engine.EvaluatePolicy(ctx, "region", map[string]any{"region": "us"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Defined).To(BeFalse())
+			Expect(result.PrintOutput).To(Equal([]string{"region: us"}))
+		})
+
+		It("records only trace notes in notes mode", func() {
+			result, err := engine.EvaluatePolicy(ctx, "region", map[string]any{"region": "eu"}, opa.WithExplain(opa.ExplainNotes))
+			Expect(err).NotTo(HaveOccurred())
+			// Notes come with the rules they were made in
+			Expect(result.Trace).To(ContainElement(ContainSubstring(`Note "checking region"`)))
+			Expect(result.Trace).NotTo(ContainElement(ContainSubstring("Eval")))
+		})
+
+		It("records the failed expressions in fails mode", func() {
+			result, err := engine.EvaluatePolicy(ctx, "region", map[string]any{"region": "us"}, opa.WithExplain(opa.ExplainFails))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Trace).To(ContainElement(ContainSubstring(`Fail input.region = "eu"`)))
+		})
+
+		It("records every step in full mode", func() {
+			fails, err := engine.EvaluatePolicy(ctx, "region", map[string]any{"region": "us"}, opa.WithExplain(opa.ExplainFails))
+			Expect(err).NotTo(HaveOccurred())
+			full, err := engine.EvaluatePolicy(ctx, "region", map[string]any{"region": "us"}, opa.WithExplain(opa.ExplainFull))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(full.Trace)).To(BeNumerically(">", len(fails.Trace)))
+			Expect(full.Trace).To(ContainElement(ContainSubstring("Enter data.policies.region.main")))
+		})
+
+		It("returns the print output and trace of a failed evaluation with the error", func() {
+			result, err := engine.EvaluatePolicy(ctx, "conflict", map[string]any{"allow": true, "reject": true}, opa.WithExplain(opa.ExplainFull))
+			Expect(result).To(BeNil())
+
+			var evalErr *opa.EvaluationError
+			Expect(errors.As(err, &evalErr)).To(BeTrue())
+			Expect(evalErr.Error()).To(ContainSubstring("evaluation error for policy 'conflict'"))
+			Expect(evalErr.PrintOutput).To(Equal([]string{"allowing"}))
+			Expect(evalErr.Trace).To(ContainElement(ContainSubstring("Enter data.policies.conflict.main")))
+		})
+	})
+
+	Describe("decision logs", func() {
+		var logger *recordingDecisionLogger
+
+		BeforeEach(func() {
+			logger = &recordingDecisionLogger{}
+			engine = opa.NewEngine(opa.WithDecisionLogger(logger))
+			Expect(engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "region", RegoCode: "package policies.region\nmain := {\"rejected\": false} if input.spec.region == \"eu\""},
+				{ID: "conflict", RegoCode: "package policies.conflict\nmain := {\"rejected\": false} if input.a\nmain := {\"rejected\": true} if input.b"},
+			})).To(Succeed())
+		})
+
+		It("logs the path, input, result and metrics of each evaluation", func() {
+			input := map[string]any{"spec": map[string]any{"region": "eu"}}
+			_, err := engine.EvaluatePolicy(ctx, "region", input)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.events).To(HaveLen(1))
+			event := logger.events[0]
+			Expect(event.DecisionID).NotTo(BeEmpty())
+			Expect(event.Path).To(Equal("policies/region/main"))
+			Expect(event.Input).To(Equal(input))
+			Expect(event.Result).To(Equal(map[string]any{"rejected": false}))
+			Expect(event.Metrics).To(HaveKey("timer_rego_query_eval_ns"))
+			Expect(event.Timestamp).NotTo(BeZero())
+		})
+
+		It("logs undefined decisions without a result", func() {
+			_, err := engine.EvaluatePolicy(ctx, "region", map[string]any{"spec": map[string]any{"region": "us"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.events).To(HaveLen(1))
+			Expect(logger.events[0].Result).To(BeNil())
+			Expect(logger.events[0].Error).To(BeNil())
+		})
+
+		It("logs evaluation errors with their OPA code", func() {
+			_, err := engine.EvaluatePolicy(ctx, "conflict", map[string]any{"a": true, "b": true})
+			Expect(err).To(HaveOccurred())
+
+			Expect(logger.events).To(HaveLen(1))
+			Expect(logger.events[0].Error).NotTo(BeNil())
+			Expect(logger.events[0].Error.Code).To(Equal("eval_conflict_error"))
+		})
+
+		It("does not log unknown policies", func() {
+			_, err := engine.EvaluatePolicy(ctx, "unknown", map[string]any{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logger.events).To(BeEmpty())
+		})
 	})
 
 	Describe("ValidateRego", func() {
@@ -239,4 +603,445 @@ main = {"rejected": false, "patch": {"cpu_limit": input.spec.cpu}} if {
 			Expect(errors.Is(err, opa.ErrInvalidRego)).To(BeTrue())
 		})
 	})
+
+	Describe("CheckPolicies", func() {
+		It("accepts a valid policy set", func() {
+			warnings, err := engine.CheckPolicies(ctx, []opa.PolicyModule{
+				{ID: "a", RegoCode: "package policies.a\nmain := {\"rejected\": false}"},
+				{ID: "b", RegoCode: "package policies.b\nimport data.policies.a\nmain := a.main"},
+			}, []string{"a", "b"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("reports errors with their position and rule", func() {
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{
+				{ID: "a", RegoCode: "package policies.a\n\nmain := {\"rejected\": x}\n\nx := data.policies.missing.f(1)"},
+			}, nil)
+
+			Expect(errors.Is(err, opa.ErrInvalidRego)).To(BeTrue())
+			var compileErr *opa.CompileError
+			Expect(errors.As(err, &compileErr)).To(BeTrue())
+			Expect(compileErr.Diagnostics).To(ConsistOf(opa.Diagnostic{
+				PolicyID: "a",
+				Line:     5,
+				Column:   6,
+				Severity: opa.SeverityError,
+				Code:     "rego_type_error",
+				Message:  "undefined function data.policies.missing.f",
+				Rule:     "x",
+			}))
+		})
+
+		It("reports conflicts between modules", func() {
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{
+				{ID: "a", RegoCode: "package shared\nmain := {\"rejected\": false}"},
+				{ID: "b", RegoCode: "package shared\n\nmain(x) := x"},
+			}, nil)
+
+			var compileErr *opa.CompileError
+			Expect(errors.As(err, &compileErr)).To(BeTrue())
+			Expect(compileErr.Diagnostics).To(ContainElement(HaveField("Message", "conflicting rules data.shared.main found")))
+		})
+
+		It("reports parse errors of every module", func() {
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{
+				{ID: "a", RegoCode: "package policies.a\n{invalid"},
+				{ID: "b", RegoCode: "package policies.b\nmain := {"},
+			}, nil)
+
+			var compileErr *opa.CompileError
+			Expect(errors.As(err, &compileErr)).To(BeTrue())
+			Expect(compileErr.Diagnostics).To(ContainElement(SatisfyAll(
+				HaveField("PolicyID", "a"),
+				HaveField("Line", 2),
+				HaveField("Code", "rego_parse_error"),
+			)))
+			Expect(compileErr.Diagnostics).To(ContainElement(HaveField("PolicyID", "b")))
+		})
+
+		It("does not change the compiled policies", func() {
+			Expect(engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "a", RegoCode: "package policies.a\nmain := {\"rejected\": false}"},
+			})).To(Succeed())
+
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{
+				{ID: "b", RegoCode: "package policies.b\nmain := {\"rejected\": true}"},
+			}, []string{"b"})
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := engine.EvaluatePolicy(ctx, "a", map[string]any{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Defined).To(BeTrue())
+			result, err = engine.EvaluatePolicy(ctx, "b", map[string]any{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Defined).To(BeFalse())
+		})
+
+		Context("against a compiled policy set", func() {
+			const library = "package lib.regions\n\nallowed(region) if region in {\"us-east-1\"}"
+			const importer = "package policies.importer\n\nimport data.lib.regions\n\nmain := {\"rejected\": regions.allowed(input.spec.region)}"
+			var compiled []opa.PolicyModule
+
+			BeforeEach(func() {
+				compiled = []opa.PolicyModule{
+					{ID: "regions", RegoCode: library, Library: true},
+					{ID: "importer", RegoCode: importer},
+					{ID: "other", RegoCode: "package policies.other\nmain := {\"rejected\": false}"},
+				}
+				Expect(engine.Compile(ctx, compiled)).To(Succeed())
+			})
+
+			It("reports errors of unchanged policies depending on a changed library", func() {
+				changed := append([]opa.PolicyModule{{ID: "regions", RegoCode: "package lib.regions\n\nzones := {\"us\"}", Library: true}}, compiled[1:]...)
+
+				_, err := engine.CheckPolicies(ctx, changed, nil)
+
+				var compileErr *opa.CompileError
+				Expect(errors.As(err, &compileErr)).To(BeTrue())
+				Expect(compileErr.Diagnostics).To(ContainElement(HaveField("PolicyID", "importer")))
+			})
+
+			It("reports errors of unchanged policies depending on a removed library", func() {
+				_, err := engine.CheckPolicies(ctx, compiled[1:], nil)
+
+				var compileErr *opa.CompileError
+				Expect(errors.As(err, &compileErr)).To(BeTrue())
+				Expect(compileErr.Diagnostics).To(ContainElement(HaveField("PolicyID", "importer")))
+			})
+
+			It("reports conflicts of a new policy with an unchanged one", func() {
+				added := append(compiled, opa.PolicyModule{ID: "clash", RegoCode: "package policies.other\n\nmain(x) := x"})
+
+				_, err := engine.CheckPolicies(ctx, added, []string{"clash"})
+
+				var compileErr *opa.CompileError
+				Expect(errors.As(err, &compileErr)).To(BeTrue())
+				Expect(compileErr.Diagnostics).To(ContainElement(HaveField("Message", "conflicting rules data.policies.other.main found")))
+			})
+		})
+
+		Describe("linting", func() {
+			lint := func(regoCode string) ([]opa.Diagnostic, error) {
+				return engine.CheckPolicies(ctx, []opa.PolicyModule{
+					{ID: "unlinted", RegoCode: "package policies.unlinted\nx := input.unknown"},
+					{ID: "p", RegoCode: regoCode},
+				}, []string{"p"})
+			}
+
+			expectLintError := func(regoCode string, expected opa.Diagnostic) {
+				GinkgoHelper()
+				_, err := lint(regoCode)
+				var compileErr *opa.CompileError
+				Expect(errors.As(err, &compileErr)).To(BeTrue())
+				Expect(compileErr.Diagnostics).To(ContainElement(expected))
+			}
+
+			It("accepts decisions built from input", func() {
+				warnings, err := lint(`package policies.p
+
+main := {
+	"rejected": input.spec.encrypted == false,
+	"rejection_reason": sprintf("provider %s", [input.provider]),
+	"patch": {"region": input.spec.region},
+	"selected_provider": input.service_provider_constraints.allow_list[0],
+}`)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+			})
+
+			It("rejects a policy without main", func() {
+				expectLintError("package policies.p\n\nallow := true", opa.Diagnostic{
+					PolicyID: "p",
+					Line:     1,
+					Column:   1,
+					Severity: opa.SeverityError,
+					Code:     opa.CodeMissingMain,
+					Message:  "policy does not define data.policies.p.main",
+				})
+			})
+
+			It("rejects a main that is not an object", func() {
+				expectLintError("package policies.p\n\nmain := true", opa.Diagnostic{
+					PolicyID: "p",
+					Line:     3,
+					Column:   1,
+					Severity: opa.SeverityError,
+					Code:     opa.CodeInvalidDecision,
+					Message:  "main must be an object, got boolean",
+					Rule:     "main",
+				})
+			})
+
+			It("rejects a main function", func() {
+				expectLintError("package policies.p\n\nmain(x) := {\"rejected\": x}", opa.Diagnostic{
+					PolicyID: "p",
+					Line:     3,
+					Column:   1,
+					Severity: opa.SeverityError,
+					Code:     opa.CodeInvalidDecision,
+					Message:  "main must be a rule, not a function",
+					Rule:     "main",
+				})
+			})
+
+			It("rejects decision keys of the wrong type in any definition of main", func() {
+				expectLintError(`package policies.p
+
+main := {"rejected": false} if input.provider == "aws"
+
+main := {"rejected": "yes"} if input.provider != "aws"`, opa.Diagnostic{
+					PolicyID: "p",
+					Line:     3,
+					Column:   1,
+					Severity: opa.SeverityError,
+					Code:     opa.CodeInvalidDecision,
+					Message:  `decision key "rejected" must be of type boolean, got string`,
+					Rule:     "main",
+				})
+			})
+
+			It("rejects input used against its declared type", func() {
+				expectLintError("package policies.p\n\nmain := {\"rejected\": input.provider > 1 + input.provider}", opa.Diagnostic{
+					PolicyID: "p",
+					Line:     3,
+					Column:   39,
+					Severity: opa.SeverityError,
+					Code:     "rego_type_error",
+					Message:  "plus: invalid argument(s)",
+					Rule:     "main",
+				})
+			})
+
+			It("warns about unknown keys, missing keys and unknown input fields", func() {
+				warnings, err := lint(`package policies.p
+
+main := {"reject": input.regoin == "eu"}`)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(ConsistOf(
+					SatisfyAll(
+						HaveField("Code", opa.CodeUnknownInputReference),
+						HaveField("Severity", opa.SeverityWarning),
+						HaveField("Message", "undefined ref: input.regoin"),
+					),
+					SatisfyAll(
+						HaveField("Code", opa.CodeUnknownDecisionKey),
+						HaveField("Message", `unknown decision key "reject" is ignored`),
+					),
+					SatisfyAll(
+						HaveField("Code", opa.CodeMissingDecisionKey),
+						HaveField("Message", `decision does not set "rejected"`),
+					),
+				))
+			})
+
+			It("accepts values only known at evaluation time", func() {
+				warnings, err := lint("package policies.p\n\nmain := data.decisions[input.provider]")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+
+				warnings, err = lint("package policies.p\n\nmain[key] := value if {\n\tsome key, value in input.spec.decision\n}")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("libraries", func() {
+		const library = "package lib.regions\n\nallowed(region) if region in {\"us-east-1\", \"eu-west-1\"}\n"
+		const policy = "package policies.regions\n\nimport data.lib.regions\n\nmain := {\"rejected\": not_allowed}\n\nnot_allowed if not regions.allowed(input.spec.region)\ndefault not_allowed := false\n"
+
+		It("compiles libraries with the policies that import them", func() {
+			Expect(engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "regions", RegoCode: library, Library: true},
+				{ID: "regions", RegoCode: policy},
+			})).To(Succeed())
+
+			result, err := engine.EvaluatePolicy(ctx, "regions", map[string]any{"spec": map[string]any{"region": "ap-south-1"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Result).To(HaveKeyWithValue("rejected", true))
+		})
+
+		It("does not evaluate libraries", func() {
+			Expect(engine.Compile(ctx, []opa.PolicyModule{
+				{ID: "regions", RegoCode: library, Library: true},
+			})).To(Succeed())
+
+			result, err := engine.EvaluatePolicy(ctx, "regions", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Defined).To(BeFalse())
+		})
+
+		It("reports errors located in a library by library ID", func() {
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{
+				{ID: "regions", RegoCode: "package lib.regions\n\nallowed(region) if undefined_fn(region)\n", Library: true},
+			}, nil)
+
+			var compileErr *opa.CompileError
+			Expect(errors.As(err, &compileErr)).To(BeTrue())
+			Expect(compileErr.Diagnostics).To(ConsistOf(SatisfyAll(
+				HaveField("LibraryID", "regions"),
+				HaveField("PolicyID", ""),
+				HaveField("Line", 3),
+				HaveField("Rule", "allowed"),
+			)))
+		})
+
+		It("reports the policies broken by a missing library", func() {
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{
+				{ID: "regions", RegoCode: policy},
+			}, nil)
+
+			var compileErr *opa.CompileError
+			Expect(errors.As(err, &compileErr)).To(BeTrue())
+			Expect(compileErr.Diagnostics).To(ContainElement(SatisfyAll(
+				HaveField("PolicyID", "regions"),
+				HaveField("Message", "undefined function data.lib.regions.allowed"),
+			)))
+		})
+
+		It("rejects libraries outside data.lib or defining main", func() {
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{
+				{ID: "outside", RegoCode: "package helpers\n\ndouble(x) := x * 2\n", Library: true},
+				{ID: "main", RegoCode: "package lib.main\n\nmain := {\"rejected\": false}\n", Library: true},
+			}, nil)
+
+			var compileErr *opa.CompileError
+			Expect(errors.As(err, &compileErr)).To(BeTrue())
+			Expect(compileErr.Diagnostics).To(ConsistOf(
+				opa.Diagnostic{
+					LibraryID: "main",
+					Line:      3,
+					Column:    1,
+					Severity:  opa.SeverityError,
+					Code:      opa.CodeLibraryMain,
+					Message:   "libraries are never evaluated and must not define main",
+					Rule:      "main",
+				},
+				opa.Diagnostic{
+					LibraryID: "outside",
+					Line:      1,
+					Column:    1,
+					Severity:  opa.SeverityError,
+					Code:      opa.CodeLibraryPackage,
+					Message:   "library package must be data.lib or a package under it, got data.helpers",
+				},
+			))
+		})
+
+		It("rejects policies declared under data.lib", func() {
+			_, err := engine.CheckPolicies(ctx, []opa.PolicyModule{
+				{ID: "p", RegoCode: "package lib.p\n\nmain := {\"rejected\": false}\n"},
+			}, []string{"p"})
+
+			var compileErr *opa.CompileError
+			Expect(errors.As(err, &compileErr)).To(BeTrue())
+			Expect(compileErr.Diagnostics).To(ConsistOf(HaveField("Code", opa.CodeReservedPackage)))
+		})
+
+		It("runs tests against libraries", func() {
+			results, err := engine.RunTests(ctx, []opa.PolicyModule{
+				{ID: "regions", RegoCode: library, Library: true},
+				{ID: "regions", RegoCode: policy},
+			}, opa.TestModule{PolicyID: "regions", RegoCode: "package policies.regions_test\n\nimport data.lib.regions\n\ntest_allowed if regions.allowed(\"us-east-1\")\n"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(ConsistOf(HaveField("Passed", true)))
+		})
+	})
+
+	Describe("RunTests", func() {
+		policies := []opa.PolicyModule{
+			{ID: "region", RegoCode: "package policies.region\nmain := {\"rejected\": input.region != \"us-east-1\"}"},
+			{ID: "other", RegoCode: "package policies.other\nmain := {\"rejected\": false}\ntest_other if false"},
+		}
+
+		It("reports passing, failing and skipped tests of the test module only", func() {
+			tests := opa.TestModule{
+				PolicyID: "region",
+				RegoCode: `package policies.region_test
+
+import data.policies.region
+
+test_allows_us_east if {
+	not region.main.rejected with input as {"region": "us-east-1"}
+}
+
+test_allows_eu if {
+	print("evaluating eu")
+	not region.main.rejected with input as {"region": "eu-west-1"}
+}
+
+todo_test_later if false
+`,
+			}
+
+			results, err := engine.RunTests(ctx, policies, tests)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(3))
+			byName := map[string]opa.TestResult{}
+			for _, r := range results {
+				byName[r.Name] = r
+			}
+
+			passed := byName["data.policies.region_test.test_allows_us_east"]
+			Expect(passed.Passed).To(BeTrue())
+			Expect(passed.Message).To(BeEmpty())
+
+			failed := byName["data.policies.region_test.test_allows_eu"]
+			Expect(failed.Passed).To(BeFalse())
+			Expect(failed.Package).To(Equal("data.policies.region_test"))
+			Expect(failed.Message).To(ContainSubstring("line 11"))
+			Expect(failed.Output).To(ContainSubstring("evaluating eu"))
+
+			skipped := byName["data.policies.region_test.todo_test_later"]
+			Expect(skipped.Skipped).To(BeTrue())
+			Expect(skipped.Passed).To(BeFalse())
+		})
+
+		It("returns ErrInvalidTests when the test module does not compile", func() {
+			tests := opa.TestModule{
+				PolicyID: "region",
+				RegoCode: "package policies.region_test\ntest_x if data.policies.region.main.rejected == undefined_var",
+			}
+
+			_, err := engine.RunTests(ctx, policies, tests)
+
+			Expect(errors.Is(err, opa.ErrInvalidTests)).To(BeTrue())
+		})
+
+		It("returns ErrInvalidTests when the test module does not parse", func() {
+			_, err := engine.RunTests(ctx, policies, opa.TestModule{PolicyID: "region", RegoCode: "package x\n{invalid"})
+
+			Expect(errors.Is(err, opa.ErrInvalidTests)).To(BeTrue())
+		})
+
+		It("returns ErrInvalidRego when a policy module does not compile", func() {
+			broken := []opa.PolicyModule{
+				{ID: "region", RegoCode: "package policies.region\nmain := {\"rejected\": undefined_var}"},
+			}
+
+			_, err := engine.RunTests(ctx, broken, opa.TestModule{PolicyID: "region", RegoCode: "package policies.region_test\ntest_x if true"})
+
+			Expect(errors.Is(err, opa.ErrInvalidRego)).To(BeTrue())
+			Expect(errors.Is(err, opa.ErrInvalidTests)).To(BeFalse())
+		})
+
+		It("does not change the compiled policies", func() {
+			Expect(engine.Compile(ctx, policies[:1])).To(Succeed())
+
+			_, err := engine.RunTests(ctx, policies, opa.TestModule{
+				PolicyID: "region",
+				RegoCode: "package policies.region_test\ntest_x if true",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := engine.EvaluatePolicy(ctx, "other", map[string]any{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Defined).To(BeFalse())
+		})
+	})
 })
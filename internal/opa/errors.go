@@ -2,6 +2,8 @@ package opa
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 // Sentinel errors for policy engine operations
@@ -9,6 +11,81 @@ var (
 	// ErrInvalidRego indicates that the Rego code is syntactically invalid
 	ErrInvalidRego = errors.New("invalid Rego code")
 
+	// ErrInvalidTests indicates that a Rego test module does not parse or compile
+	ErrInvalidTests = errors.New("invalid Rego test module")
+
 	// ErrEngineInternal indicates an unexpected error within the policy engine
 	ErrEngineInternal = errors.New("policy engine internal error")
+
+	// ErrEvaluationCanceled indicates that an evaluation was canceled because its context was done. It
+	// is returned together with the cause of the context, e.g. context.DeadlineExceeded.
+	ErrEvaluationCanceled = errors.New("policy evaluation canceled")
 )
+
+// Severity of a diagnostic
+type Severity string
+
+const (
+	// SeverityError is a problem that prevents the policy set from being used
+	SeverityError Severity = "ERROR"
+	// SeverityWarning is a likely mistake that does not prevent the policy set from being used
+	SeverityWarning Severity = "WARNING"
+)
+
+// Diagnostic is a single problem found while compiling or linting a policy set
+type Diagnostic struct {
+	PolicyID  string // policy the problem is located in
+	LibraryID string // library the problem is located in, set instead of PolicyID
+	Line      int
+	Column    int
+	Severity  Severity
+	Code      string // OPA error code, e.g. rego_type_error, or one of the linter codes
+	Message   string
+	Rule      string // rule the problem is located in, if any
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", d.module(), d.Line, d.Column, d.Code, d.Message)
+}
+
+// module returns the name of the module the problem is located in
+func (d Diagnostic) module() string {
+	if d.LibraryID != "" {
+		return libraryModuleName(d.LibraryID)
+	}
+	return d.PolicyID
+}
+
+// CompileError reports every problem found while compiling or linting a policy set, of which at
+// least one is an error. It wraps ErrInvalidRego.
+type CompileError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *CompileError) Error() string {
+	parts := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		parts[i] = d.String()
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidRego, strings.Join(parts, "; "))
+}
+
+func (e *CompileError) Unwrap() error {
+	return ErrInvalidRego
+}
+
+// EvaluationError reports a failed policy evaluation together with the print() output and explain
+// trace recorded up to the failure. It wraps the cause of the failure.
+type EvaluationError struct {
+	Err         error
+	PrintOutput []string // Lines printed by print() calls before the failure
+	Trace       []string // Explain trace up to the failure, if requested with WithExplain
+}
+
+func (e *EvaluationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *EvaluationError) Unwrap() error {
+	return e.Err
+}
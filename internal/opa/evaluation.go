@@ -2,8 +2,10 @@ package opa
 
 // EvaluationResult represents the result from OPA evaluation
 type EvaluationResult struct {
-	Result  map[string]any // The policy decision
-	Defined bool           // Whether the policy made a decision
+	Result      map[string]any // The policy decision
+	Defined     bool           // Whether the policy made a decision
+	PrintOutput []string       // Lines printed by print() calls
+	Trace       []string       // Explain trace, if requested with WithExplain
 }
 
 // ServiceProviderConstraints represents constraints on which service providers are allowed
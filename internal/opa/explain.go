@@ -0,0 +1,90 @@
+package opa
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/open-policy-agent/opa/v1/topdown"
+	"github.com/open-policy-agent/opa/v1/topdown/lineage"
+	"github.com/open-policy-agent/opa/v1/topdown/print"
+)
+
+// ExplainMode selects the events of the explain trace recorded for a policy evaluation
+type ExplainMode string
+
+const (
+	// ExplainOff records no trace
+	ExplainOff ExplainMode = ""
+	// ExplainNotes keeps the notes of trace() calls
+	ExplainNotes ExplainMode = "notes"
+	// ExplainFails keeps the expressions that failed
+	ExplainFails ExplainMode = "fails"
+	// ExplainFull keeps every evaluation step
+	ExplainFull ExplainMode = "full"
+)
+
+// ParseExplainMode returns the explain mode named s, where the empty string is ExplainOff
+func ParseExplainMode(s string) (ExplainMode, error) {
+	switch mode := ExplainMode(s); mode {
+	case ExplainOff, ExplainNotes, ExplainFails, ExplainFull:
+		return mode, nil
+	default:
+		return ExplainOff, fmt.Errorf("unknown explain mode %q, expected notes, fails or full", s)
+	}
+}
+
+// EvalOption configures a single policy evaluation
+type EvalOption func(*evalOptions)
+
+type evalOptions struct {
+	explain ExplainMode
+}
+
+// WithExplain records an explain trace of the evaluation in EvaluationResult.Trace
+func WithExplain(mode ExplainMode) EvalOption {
+	return func(o *evalOptions) {
+		o.explain = mode
+	}
+}
+
+// printCollector collects the output of print() calls during an evaluation
+type printCollector struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (c *printCollector) Print(_ print.Context, msg string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lines = append(c.lines, msg)
+	return nil
+}
+
+func (c *printCollector) output() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lines
+}
+
+// formatTrace filters the events of a trace for the explain mode and formats them like opa eval
+// --explain, one line per event
+func formatTrace(mode ExplainMode, events []*topdown.Event) []string {
+	switch mode {
+	case ExplainNotes:
+		events = lineage.Notes(events)
+	case ExplainFails:
+		events = lineage.Fails(events)
+	default:
+		events = lineage.Full(events)
+	}
+
+	var buf bytes.Buffer
+	topdown.PrettyTraceWithLocation(&buf, events)
+	trimmed := strings.TrimRight(buf.String(), "\n")
+	if trimmed == "" {
+		return []string{}
+	}
+	return strings.Split(trimmed, "\n")
+}
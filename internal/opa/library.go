@@ -0,0 +1,98 @@
+package opa
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+)
+
+// Codes of the diagnostics reported for library modules and the packages they reserve
+const (
+	CodeLibraryPackage  = "dcm_library_package"
+	CodeLibraryMain     = "dcm_library_main"
+	CodeReservedPackage = "dcm_reserved_package"
+)
+
+// libraryModulePrefix prefixes the module names of libraries. Policy IDs cannot contain a slash, so
+// a library never collides with a policy of the same ID.
+const libraryModulePrefix = "lib/"
+
+// libraryRoot is the package that library modules are declared under, e.g. data.lib.strings
+var libraryRoot = ast.MustParseRef("data.lib")
+
+// moduleName returns the name a module is compiled under
+func moduleName(p PolicyModule) string {
+	if p.Library {
+		return libraryModuleName(p.ID)
+	}
+	return p.ID
+}
+
+func libraryModuleName(id string) string {
+	return libraryModulePrefix + id
+}
+
+// moduleOwner returns the policy or library ID of a compiled module name
+func moduleOwner(name string) (policyID, libraryID string) {
+	if id, ok := strings.CutPrefix(name, libraryModulePrefix); ok {
+		return "", id
+	}
+	return name, ""
+}
+
+// lintLibrary checks that a library module is declared under data.lib and does not define main,
+// so it can only be used through the policies that import it
+func lintLibrary(mod *ast.Module, id string) []Diagnostic {
+	pkg := mod.Package.Path
+	if !pkg.HasPrefix(libraryRoot) {
+		return []Diagnostic{{
+			LibraryID: id,
+			Line:      mod.Package.Location.Row,
+			Column:    mod.Package.Location.Col,
+			Severity:  SeverityError,
+			Code:      CodeLibraryPackage,
+			Message:   fmt.Sprintf("library package must be %s or a package under it, got %s", libraryRoot, pkg),
+		}}
+	}
+
+	var diagnostics []Diagnostic
+	for _, rule := range mod.Rules {
+		if rule.Head.Ref().String() == "main" {
+			diagnostics = append(diagnostics, Diagnostic{
+				LibraryID: id,
+				Line:      rule.Location.Row,
+				Column:    rule.Location.Col,
+				Severity:  SeverityError,
+				Code:      CodeLibraryMain,
+				Message:   "libraries are never evaluated and must not define main",
+				Rule:      "main",
+			})
+		}
+	}
+	return diagnostics
+}
+
+// lintPolicyPackage checks that a policy is not declared in the package reserved for libraries
+func lintPolicyPackage(mod *ast.Module, id string) []Diagnostic {
+	if !mod.Package.Path.HasPrefix(libraryRoot) {
+		return nil
+	}
+	return []Diagnostic{{
+		PolicyID: id,
+		Line:     mod.Package.Location.Row,
+		Column:   mod.Package.Location.Col,
+		Severity: SeverityError,
+		Code:     CodeReservedPackage,
+		Message:  fmt.Sprintf("package %s is reserved for libraries", libraryRoot),
+	}}
+}
+
+// ModulePackage returns the package a Rego module is declared in, without the data prefix, e.g. lib.regions
+func ModulePackage(regoCode string) (string, error) {
+	mod, err := ast.ParseModuleWithOpts("module", regoCode, ast.ParserOptions{RegoVersion: ast.RegoV1})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidRego, err)
+	}
+	return strings.TrimPrefix(mod.Package.Path.String(), "data."), nil
+}
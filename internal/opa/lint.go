@@ -0,0 +1,262 @@
+package opa
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/types"
+)
+
+// Codes of the diagnostics reported by the policy linter
+const (
+	CodeMissingMain           = "dcm_missing_main"
+	CodeInvalidDecision       = "dcm_invalid_decision"
+	CodeUnknownDecisionKey    = "dcm_unknown_decision_key"
+	CodeMissingDecisionKey    = "dcm_missing_decision_key"
+	CodeUnknownInputReference = "dcm_unknown_input_reference"
+)
+
+// jsonSchema is the subset of JSON Schema used to declare the decision shape
+type jsonSchema struct {
+	Type       string                `json:"type"`
+	Properties map[string]jsonSchema `json:"properties"`
+	Required   []string              `json:"required"`
+}
+
+var (
+	//go:embed schemas/input.json
+	inputSchemaJSON []byte
+
+	//go:embed schemas/decision.json
+	decisionSchemaJSON []byte
+
+	inputSchema    = mustUnmarshal[any](inputSchemaJSON)
+	decisionSchema = mustUnmarshal[jsonSchema](decisionSchemaJSON)
+)
+
+func mustUnmarshal[T any](data []byte) T {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		panic(fmt.Sprintf("invalid embedded schema: %v", err))
+	}
+	return v
+}
+
+// lintPolicies checks the output contract of the given policies of a compiled policy set:
+//   - the policy defines its entrypoint rule, main unless entrypoints names another one, whose value
+//     is an object of the shape of schemas/decision.json, or else rules of the multi-rule convention
+//     whose values have the types of the decision keys they set
+//   - input is only used as declared by schemas/input.json, using OPA's schema type checking
+//   - the policy is not declared in the package reserved for libraries
+//
+// Every library of the set is checked to be declared under data.lib and to not define main.
+//
+// Problems that make evaluation fail are errors; keys or input fields that are unknown, and so
+// always ignored or undefined, are warnings.
+func lintPolicies(compiler *ast.Compiler, modules map[string]*ast.Module, ids []string, entrypoints map[string]string) []Diagnostic {
+	schemas := ast.NewSchemaSet()
+	schemas.Put(ast.SchemaRootRef, inputSchema)
+	typed := ast.NewCompiler().
+		WithDefaultRegoVersion(ast.RegoV1).
+		WithSchemas(schemas).
+		SetErrorLimit(0)
+	typed.Compile(modules)
+
+	var diagnostics []Diagnostic
+	for _, d := range toDiagnostics(typed.Errors, modules) {
+		if !slices.Contains(ids, d.PolicyID) {
+			continue
+		}
+		if strings.HasPrefix(d.Message, "undefined ref: input.") {
+			d.Severity = SeverityWarning
+			d.Code = CodeUnknownInputReference
+		}
+		diagnostics = append(diagnostics, d)
+	}
+
+	for _, id := range ids {
+		if reserved := lintPolicyPackage(compiler.Modules[id], id); reserved != nil {
+			diagnostics = append(diagnostics, reserved...)
+			continue
+		}
+		entrypoint := entrypoints[id]
+		if entrypoint == "" {
+			entrypoint = DefaultEntrypoint
+		}
+		diagnostics = append(diagnostics, lintDecision(compiler, id, entrypoint)...)
+	}
+	for name, mod := range compiler.Modules {
+		if _, libraryID := moduleOwner(name); libraryID != "" {
+			diagnostics = append(diagnostics, lintLibrary(mod, libraryID)...)
+		}
+	}
+	sortDiagnostics(diagnostics)
+	return diagnostics
+}
+
+// lintDecision checks that the policy defines its entrypoint rule and that the type inferred for it
+// matches the decision schema, or that the rules of the multi-rule convention it defines instead have
+// the types of the decision keys they set
+func lintDecision(compiler *ast.Compiler, id, entrypoint string) []Diagnostic {
+	mod := compiler.Modules[id]
+	ref := mod.Package.Path.Append(ast.StringTerm(entrypoint))
+
+	main := moduleRule(compiler, mod, entrypoint)
+	if main == nil {
+		if rules := definedRules(compiler, mod, conventionRules...); len(rules) > 0 {
+			return lintDecisionRules(compiler, id, rules)
+		}
+		return []Diagnostic{{
+			PolicyID: id,
+			Line:     mod.Package.Location.Row,
+			Column:   mod.Package.Location.Col,
+			Severity: SeverityError,
+			Code:     CodeMissingMain,
+			Message:  fmt.Sprintf("policy does not define %s", ref),
+		}}
+	}
+
+	findings := checkDecisionType(compiler.TypeEnv.Get(ref), entrypoint)
+	diagnostics := make([]Diagnostic, 0, len(findings))
+	for _, f := range findings {
+		f.PolicyID = id
+		f.Line = main.Location.Row
+		f.Column = main.Location.Col
+		f.Rule = entrypoint
+		if !slices.Contains(diagnostics, f) {
+			diagnostics = append(diagnostics, f)
+		}
+	}
+	return diagnostics
+}
+
+// lintDecisionRules checks that the rules of the multi-rule convention a policy defines have the types
+// of the decision keys they set: deny is a set or array of messages
+func lintDecisionRules(compiler *ast.Compiler, id string, rules []string) []Diagnostic {
+	mod := compiler.Modules[id]
+	var diagnostics []Diagnostic
+	for _, name := range rules {
+		jsonType := "array"
+		if key, ok := decisionRules[name]; ok {
+			jsonType = decisionSchema.Properties[key].Type
+		}
+		ref := mod.Package.Path.Append(ast.StringTerm(name))
+		t := compiler.TypeEnv.Get(ref)
+		if hasJSONType(t, jsonType) {
+			continue
+		}
+		rule := moduleRule(compiler, mod, name)
+		diagnostics = append(diagnostics, Diagnostic{
+			PolicyID: id,
+			Line:     rule.Location.Row,
+			Column:   rule.Location.Col,
+			Severity: SeverityError,
+			Code:     CodeInvalidDecision,
+			Message:  fmt.Sprintf("rule %s must be of type %s, got %s", name, jsonType, types.Sprint(t)),
+			Rule:     name,
+		})
+	}
+	return diagnostics
+}
+
+// checkDecisionType checks the type of the entrypoint rule against the decision schema. The position
+// of the findings is left unset.
+func checkDecisionType(t types.Type, entrypoint string) []Diagnostic {
+	switch t := t.(type) {
+	case types.Any:
+		// One type per definition of the rule; the empty union is a value only known at evaluation
+		var findings []Diagnostic
+		for _, member := range t {
+			findings = append(findings, checkDecisionType(member, entrypoint)...)
+		}
+		return findings
+	case *types.Object:
+		return checkDecisionObject(t)
+	case *types.Function:
+		return []Diagnostic{{
+			Severity: SeverityError,
+			Code:     CodeInvalidDecision,
+			Message:  fmt.Sprintf("%s must be a rule, not a function", entrypoint),
+		}}
+	default:
+		return []Diagnostic{{
+			Severity: SeverityError,
+			Code:     CodeInvalidDecision,
+			Message:  fmt.Sprintf("%s must be an object, got %s", entrypoint, types.Sprint(t)),
+		}}
+	}
+}
+
+func checkDecisionObject(obj *types.Object) []Diagnostic {
+	var findings []Diagnostic
+	keys := map[string]bool{}
+	for _, prop := range obj.StaticProperties() {
+		key, ok := prop.Key.(string)
+		if !ok {
+			continue
+		}
+		keys[key] = true
+
+		field, known := decisionSchema.Properties[key]
+		switch {
+		case !known:
+			findings = append(findings, Diagnostic{
+				Severity: SeverityWarning,
+				Code:     CodeUnknownDecisionKey,
+				Message:  fmt.Sprintf("unknown decision key %q is ignored", key),
+			})
+		case !hasJSONType(prop.Value, field.Type):
+			findings = append(findings, Diagnostic{
+				Severity: SeverityError,
+				Code:     CodeInvalidDecision,
+				Message:  fmt.Sprintf("decision key %q must be of type %s, got %s", key, field.Type, types.Sprint(prop.Value)),
+			})
+		}
+	}
+
+	// Objects with dynamic keys may set any key at evaluation time
+	if obj.DynamicProperties() == nil {
+		for _, key := range decisionSchema.Required {
+			if !keys[key] {
+				findings = append(findings, Diagnostic{
+					Severity: SeverityWarning,
+					Code:     CodeMissingDecisionKey,
+					Message:  fmt.Sprintf("decision does not set %q", key),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// hasJSONType reports whether every value of type t is of the given JSON Schema type.
+// Values of unknown type are assumed to match.
+func hasJSONType(t types.Type, jsonType string) bool {
+	switch t := t.(type) {
+	case types.Any:
+		for _, member := range t {
+			if !hasJSONType(member, jsonType) {
+				return false
+			}
+		}
+		return true
+	case types.Boolean:
+		return jsonType == "boolean"
+	case types.String:
+		return jsonType == "string"
+	case types.Number:
+		return jsonType == "number" || jsonType == "integer"
+	case types.Null:
+		return jsonType == "null"
+	case *types.Object:
+		return jsonType == "object"
+	case *types.Array, *types.Set:
+		return jsonType == "array"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,141 @@
+package opa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/tester"
+	"github.com/open-policy-agent/opa/v1/topdown"
+)
+
+// TestModule is a Rego module holding the test rules (test_*) of a policy
+type TestModule struct {
+	PolicyID string
+	RegoCode string
+}
+
+// TestResult is the outcome of a single test rule
+type TestResult struct {
+	Name     string // fully qualified rule name, e.g. data.policies.region.test_allows_us
+	Package  string
+	Passed   bool
+	Skipped  bool // todo_test_* rules are reported but not run
+	Errored  bool // evaluation failed, as opposed to the test being false or undefined
+	Duration time.Duration
+	Message  string // why the test failed or errored
+	Output   string // output of print() calls
+}
+
+// testModuleName returns the module name of the test module of a policy.
+// Policy IDs cannot contain underscores, so it never collides with a policy module.
+func testModuleName(policyID string) string {
+	return policyID + "_test"
+}
+
+// RunTests compiles the test module and the policy under test together with the modules they depend on
+// and runs its test rules. Test rules of other modules are not run. The compiled state of the engine is
+// not affected, and its parsed modules are reused for the policy modules that did not change. Compile
+// errors located in the test module are reported as ErrInvalidTests, others as ErrInvalidRego.
+func (e *embeddedEngine) RunTests(ctx context.Context, policies []PolicyModule, tests TestModule) ([]TestResult, error) {
+	testFile := testModuleName(tests.PolicyID)
+
+	compiled, _ := e.compiledState()
+	parsed, _, parseErrs := parseCandidates(policies, compiled)
+	if len(parseErrs) > 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRego, parseErrs[0])
+	}
+	testMod, err := ast.ParseModuleWithOpts(testFile, tests.RegoCode, ast.ParserOptions{RegoVersion: ast.RegoV1})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTests, err)
+	}
+	parsed[testFile] = newParsedModule(PolicyModule{ID: tests.PolicyID, RegoCode: tests.RegoCode}, testMod)
+
+	// Compile the test module and the policy under test with their dependencies
+	idx := newPackageIndex(parsed)
+	modules := map[string]*ast.Module{}
+	for _, root := range []string{testFile, tests.PolicyID} {
+		if _, ok := parsed[root]; !ok {
+			continue
+		}
+		for _, name := range idx.closure(parsed, root) {
+			modules[name] = parsed[name].module
+		}
+	}
+
+	compiler := ast.NewCompiler().
+		WithDefaultRegoVersion(ast.RegoV1).
+		WithCapabilities(e.capabilities).
+		WithEnablePrintStatements(true)
+	ch, err := tester.NewRunner().
+		SetCompiler(compiler).
+		SetModules(modules).
+		CapturePrintOutput(true).
+		EnableTracing(true).
+		RunTests(withProviders(ctx, e.providers), nil)
+	if err != nil {
+		if inModule(err, testFile) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTests, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRego, err)
+	}
+
+	results := []TestResult{}
+	for r := range ch {
+		if r.Location == nil || r.Location.File != testFile {
+			continue
+		}
+		results = append(results, TestResult{
+			Name:     r.Package + "." + r.Name,
+			Package:  r.Package,
+			Passed:   r.Pass(),
+			Skipped:  r.Skip,
+			Errored:  r.Error != nil,
+			Duration: r.Duration,
+			Message:  testFailureMessage(r, testFile),
+			Output:   string(r.Output),
+		})
+	}
+	return results, nil
+}
+
+// inModule reports whether any of the compile errors in err is located in the given module
+func inModule(err error, module string) bool {
+	var astErrs ast.Errors
+	if !errors.As(err, &astErrs) {
+		return false
+	}
+	for _, e := range astErrs {
+		if e.Location != nil && e.Location.File == module {
+			return true
+		}
+	}
+	return false
+}
+
+// testFailureMessage describes why a test did not pass. For failed tests it reports the last
+// expression of the test module that evaluated to false.
+func testFailureMessage(r *tester.Result, testFile string) string {
+	switch {
+	case r.Error != nil:
+		return r.Error.Error()
+	case !r.Fail:
+		return ""
+	}
+
+	var failed *topdown.Event
+	for _, event := range r.Trace {
+		if event.Op != topdown.FailOp || event.Location == nil || event.Location.File != testFile {
+			continue
+		}
+		if _, ok := event.Node.(*ast.Expr); ok {
+			failed = event
+		}
+	}
+	if failed == nil {
+		return "test is undefined or false"
+	}
+	return fmt.Sprintf("failed at line %d: %s", failed.Location.Row, failed.Node)
+}
@@ -0,0 +1,180 @@
+// Package policydir loads DCM policies from a directory of Rego files and keeps them in sync with it.
+package policydir
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dcm-project/policy-manager/internal/bundle"
+	"github.com/open-policy-agent/opa/v1/ast"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	regoExt     = ".rego"
+	testRegoExt = "_test.rego"
+)
+
+// sidecarExts are the extensions of the metadata files that may accompany a Rego module
+var sidecarExts = []string{".yaml", ".yml"}
+
+// ErrInvalidPolicyDir indicates that a file in the policy directory or its metadata is malformed
+var ErrInvalidPolicyDir = errors.New("invalid policy directory")
+
+// Load reads every non-test Rego module below dir. Hidden files and directories (such as .git) are skipped.
+//
+// The metadata of a module is read from a YAML sidecar with the same base name (region.rego and
+// region.yaml) using the field names of the bundle metadata. Without a sidecar, it is read from the
+// package-scoped METADATA annotation of the module: title and description map to display_name and
+// description, and the remaining fields are read from custom. If no ID is given, the module file name
+// (without extension) is used. A test module next to a module (region_test.rego) is read as its TestCode.
+func Load(dir string) ([]bundle.Policy, error) {
+	var policies []bundle.Policy
+	err := walkFiles(dir, func(path, relPath string) error {
+		if !strings.HasSuffix(relPath, regoExt) || strings.HasSuffix(relPath, testRegoExt) {
+			return nil
+		}
+		policy, err := loadPolicy(path, relPath)
+		if err != nil {
+			return err
+		}
+		policies = append(policies, *policy)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Path < policies[j].Path
+	})
+	return policies, nil
+}
+
+// walkFiles calls fn in lexical order for every file below dir that is not hidden and not in a hidden
+// directory. relPath is slash-separated and relative to dir. A symlinked dir is resolved first.
+func walkFiles(dir string, fn func(path, relPath string) error) error {
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		return fn(path, filepath.ToSlash(relPath))
+	})
+}
+
+func loadPolicy(path, relPath string) (*bundle.Policy, error) {
+	regoCode, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, found, err := readSidecar(strings.TrimSuffix(path, regoExt))
+	if err != nil {
+		return nil, fmt.Errorf("%w: module '%s': %v", ErrInvalidPolicyDir, relPath, err)
+	}
+	if !found {
+		meta, err = readAnnotations(relPath, regoCode)
+		if err != nil {
+			return nil, fmt.Errorf("%w: module '%s': %v", ErrInvalidPolicyDir, relPath, err)
+		}
+	}
+	if meta.ID == "" {
+		meta.ID = strings.TrimSuffix(filepath.Base(path), regoExt)
+	}
+
+	testCode, err := os.ReadFile(bundle.TestModulePath(path))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return &bundle.Policy{
+		Path:     relPath,
+		RegoCode: string(regoCode),
+		TestCode: string(testCode),
+		Metadata: meta,
+	}, nil
+}
+
+// readSidecar reads the YAML metadata file next to a module. basePath is the module path without extension.
+func readSidecar(basePath string) (bundle.PolicyMetadata, bool, error) {
+	var meta bundle.PolicyMetadata
+	for _, ext := range sidecarExts {
+		data, err := os.ReadFile(basePath + ext)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return meta, false, err
+		}
+		if err := yaml.UnmarshalStrict(data, &meta); err != nil {
+			return meta, false, fmt.Errorf("failed to decode %s: %v", filepath.Base(basePath+ext), err)
+		}
+		return meta, true, nil
+	}
+	return meta, false, nil
+}
+
+// readAnnotations reads the metadata from the package-scoped METADATA annotation of a module
+func readAnnotations(relPath string, regoCode []byte) (bundle.PolicyMetadata, error) {
+	var meta bundle.PolicyMetadata
+	module, err := ast.ParseModuleWithOpts(relPath, string(regoCode), ast.ParserOptions{
+		RegoVersion:       ast.RegoV1,
+		ProcessAnnotation: true,
+	})
+	if err != nil {
+		return meta, err
+	}
+
+	for _, a := range module.Annotations {
+		if a.Scope != "package" {
+			continue
+		}
+		if len(a.Custom) > 0 {
+			data, err := json.Marshal(a.Custom)
+			if err != nil {
+				return meta, err
+			}
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&meta); err != nil {
+				return meta, fmt.Errorf("invalid METADATA custom fields: %v", err)
+			}
+		}
+		if meta.DisplayName == "" {
+			meta.DisplayName = a.Title
+		}
+		if meta.Description == "" {
+			meta.Description = a.Description
+		}
+		return meta, nil
+	}
+
+	return meta, errors.New("no metadata: add a YAML sidecar or a package METADATA annotation")
+}
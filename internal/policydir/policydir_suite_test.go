@@ -0,0 +1,13 @@
+package policydir_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPolicyDir(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Policy Directory Suite")
+}
@@ -0,0 +1,195 @@
+package policydir_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/bundle"
+	"github.com/dcm-project/policy-manager/internal/policydir"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const annotatedRego = `# METADATA
+# title: Region Enforcement
+# description: Restrict regions
+# custom:
+#   policy_type: GLOBAL
+#   priority: 100
+#   label_selector:
+#     env: prod
+package policies.region
+
+main := {"rejected": false}
+`
+
+func writeFile(dir, name, content string) {
+	path := filepath.Join(dir, name)
+	Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+	Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+}
+
+// recordingReconciler records every reconciliation and optionally fails it
+type recordingReconciler struct {
+	mu    sync.Mutex
+	calls [][]bundle.Policy
+	err   error
+}
+
+func (r *recordingReconciler) ReconcileManagedPolicies(_ context.Context, policies []bundle.Policy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, policies)
+	return r.err
+}
+
+func (r *recordingReconciler) callCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.calls)
+}
+
+func (r *recordingReconciler) last() []bundle.Policy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls[len(r.calls)-1]
+}
+
+var _ = Describe("Load", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("reads metadata from the package METADATA annotation", func() {
+		writeFile(dir, "region.rego", annotatedRego)
+
+		policies, err := policydir.Load(dir)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveLen(1))
+		Expect(policies[0].Path).To(Equal("region.rego"))
+		Expect(policies[0].RegoCode).To(Equal(annotatedRego))
+		meta := policies[0].Metadata
+		Expect(meta.ID).To(Equal("region"))
+		Expect(meta.DisplayName).To(Equal("Region Enforcement"))
+		Expect(meta.Description).To(Equal("Restrict regions"))
+		Expect(meta.PolicyType).To(Equal("GLOBAL"))
+		Expect(*meta.Priority).To(Equal(int32(100)))
+		Expect(meta.LabelSelector).To(Equal(map[string]string{"env": "prod"}))
+	})
+
+	It("prefers a YAML sidecar over annotations", func() {
+		writeFile(dir, "team/quota.rego", "package policies.quota\nmain := {\"rejected\": false}\n")
+		writeFile(dir, "team/quota.yaml", "id: team-quota\ndisplay_name: Quota\npolicy_type: USER\nenabled: false\neffective_until: 2027-01-05T00:00:00Z\n")
+
+		policies, err := policydir.Load(dir)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveLen(1))
+		Expect(policies[0].Path).To(Equal("team/quota.rego"))
+		meta := policies[0].Metadata
+		Expect(meta.ID).To(Equal("team-quota"))
+		Expect(meta.DisplayName).To(Equal("Quota"))
+		Expect(meta.PolicyType).To(Equal("USER"))
+		Expect(*meta.Enabled).To(BeFalse())
+		Expect(*meta.EffectiveUntil).To(Equal(time.Date(2027, 1, 5, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("reads test modules as the test code of their policy and skips hidden directories", func() {
+		writeFile(dir, "region.rego", annotatedRego)
+		writeFile(dir, "region_test.rego", "package policies.region_test\n")
+		writeFile(dir, "orphan_test.rego", "package orphan_test\n")
+		writeFile(dir, ".git/hooks/ignored.rego", "not rego")
+
+		policies, err := policydir.Load(dir)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveLen(1))
+		Expect(policies[0].TestCode).To(Equal("package policies.region_test\n"))
+	})
+
+	It("follows a symlinked directory", func() {
+		writeFile(dir, "rev-1/region.rego", annotatedRego)
+		link := filepath.Join(GinkgoT().TempDir(), "current")
+		Expect(os.Symlink(filepath.Join(dir, "rev-1"), link)).To(Succeed())
+
+		policies, err := policydir.Load(link)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveLen(1))
+		Expect(policies[0].Path).To(Equal("region.rego"))
+	})
+
+	It("fails for a module without metadata", func() {
+		writeFile(dir, "bare.rego", "package policies.bare\nmain := {}\n")
+
+		_, err := policydir.Load(dir)
+
+		Expect(errors.Is(err, policydir.ErrInvalidPolicyDir)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("bare.rego"))
+	})
+
+	It("fails for unknown sidecar fields", func() {
+		writeFile(dir, "region.rego", "package policies.region\n")
+		writeFile(dir, "region.yml", "display_name: Region\npriorty: 5\n")
+
+		_, err := policydir.Load(dir)
+
+		Expect(errors.Is(err, policydir.ErrInvalidPolicyDir)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Watcher", func() {
+	var (
+		dir        string
+		reconciler *recordingReconciler
+		ctx        context.Context
+		cancel     context.CancelFunc
+		done       chan error
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		reconciler = &recordingReconciler{}
+		ctx, cancel = context.WithCancel(context.Background())
+		done = make(chan error, 1)
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	start := func() {
+		w := policydir.NewWatcher(dir, 10*time.Millisecond, reconciler)
+		go func() { done <- w.Run(ctx) }()
+	}
+
+	It("reconciles on start and only again after a change", func() {
+		writeFile(dir, "region.rego", annotatedRego)
+		start()
+
+		Eventually(reconciler.callCount).Should(Equal(1))
+		Consistently(reconciler.callCount, 50*time.Millisecond).Should(Equal(1))
+
+		writeFile(dir, "quota.rego", "package policies.quota\n")
+		writeFile(dir, "quota.yaml", "display_name: Quota\npolicy_type: GLOBAL\n")
+
+		Eventually(reconciler.callCount).Should(Equal(2))
+		Expect(reconciler.last()).To(HaveLen(2))
+	})
+
+	It("retries a failed reconciliation", func() {
+		reconciler.err = errors.New("boom")
+		writeFile(dir, "region.rego", annotatedRego)
+		start()
+
+		Eventually(reconciler.callCount).Should(BeNumerically(">=", 2))
+	})
+})
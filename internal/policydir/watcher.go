@@ -0,0 +1,110 @@
+package policydir
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/bundle"
+)
+
+// Reconciler applies the policies loaded from the directory
+type Reconciler interface {
+	ReconcileManagedPolicies(ctx context.Context, policies []bundle.Policy) error
+}
+
+// Watcher polls a policy directory and reconciles its policies whenever its content changes.
+// Polling is used rather than file system notifications so that directories updated through
+// symlink swaps (git-sync, Kubernetes ConfigMap volumes) are handled reliably.
+type Watcher struct {
+	dir        string
+	interval   time.Duration
+	reconciler Reconciler
+}
+
+// NewWatcher creates a Watcher for dir that checks for changes every interval
+func NewWatcher(dir string, interval time.Duration, reconciler Reconciler) *Watcher {
+	return &Watcher{
+		dir:        dir,
+		interval:   interval,
+		reconciler: reconciler,
+	}
+}
+
+// Run reconciles the directory immediately and then on every change until ctx is cancelled.
+// Failed reconciliations are logged and retried on the next tick; the previously applied policies stay in effect.
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.interval <= 0 {
+		return fmt.Errorf("policy directory poll interval must be positive, got %s", w.interval)
+	}
+	slog.Info("Watching policy directory", "dir", w.dir, "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var applied string
+	for {
+		applied = w.sync(ctx, applied)
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Policy directory watcher stopped", "dir", w.dir)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync reconciles the directory if its fingerprint differs from the last applied one and returns
+// the fingerprint that is now applied
+func (w *Watcher) sync(ctx context.Context, applied string) string {
+	fingerprint, err := Fingerprint(w.dir)
+	if err != nil {
+		slog.Error("Failed to read policy directory", "dir", w.dir, "error", err)
+		return applied
+	}
+	if fingerprint == applied {
+		return applied
+	}
+
+	policies, err := Load(w.dir)
+	if err != nil {
+		slog.Error("Failed to load policy directory", "dir", w.dir, "error", err)
+		return applied
+	}
+	if err := w.reconciler.ReconcileManagedPolicies(ctx, policies); err != nil {
+		slog.Error("Failed to reconcile policy directory", "dir", w.dir, "error", err)
+		return applied
+	}
+
+	slog.Debug("Policy directory synced", "dir", w.dir, "policy_count", len(policies))
+	return fingerprint
+}
+
+// Fingerprint returns a digest of the names and content of every non-hidden file below dir
+func Fingerprint(dir string) (string, error) {
+	h := sha256.New()
+	err := walkFiles(dir, func(path, relPath string) error {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+
+		_, _ = io.WriteString(h, relPath+"\x00")
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+		_, _ = h.Write([]byte{0})
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
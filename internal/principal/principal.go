@@ -0,0 +1,35 @@
+// Package principal carries the identity of the API caller, as asserted by the gateway in front of the
+// service.
+package principal
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type contextKey struct{}
+
+// NewContext returns a new context with the given principal stored in it.
+func NewContext(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, contextKey{}, name)
+}
+
+// FromContext returns the principal stored in ctx, or "" if the caller is unknown.
+func FromContext(ctx context.Context) string {
+	name, _ := ctx.Value(contextKey{}).(string)
+	return name
+}
+
+// Middleware is a Chi middleware that stores the principal named by the given request header in the
+// request context. The header must be set by a trusted gateway that authenticates the caller.
+func Middleware(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if name := strings.TrimSpace(r.Header.Get(header)); name != "" {
+				r = r.WithContext(NewContext(r.Context(), name))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
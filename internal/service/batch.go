@@ -0,0 +1,402 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+)
+
+// MaxBatchSize is the maximum number of requests of a batch method
+const MaxBatchSize = 100
+
+// errValidatedBatch rolls back the transaction of a validate-only batch once it has been written
+var errValidatedBatch = errors.New("validated batch")
+
+// batchChange is a single validated change of a batch. policy is the new state and is nil for a delete;
+// previous is the stored state and is nil for a create.
+type batchChange struct {
+	id       string
+	policy   *model.Policy
+	previous *model.Policy
+	// lint is set when the Rego code of the policy is new and its output contract has to be linted
+	lint bool
+	// module is the bundle module the change was read from, which errors are reported for instead of
+	// the index of the batch request
+	module string
+}
+
+// libraryChange is a validated create or update of a library by a bundle import. previous is the
+// stored state and is nil for a create; module is the bundle module the library was read from.
+type libraryChange struct {
+	library  model.Library
+	previous *model.Library
+	module   string
+}
+
+// requestError prefixes a ServiceError detail with the bundle module or batch request of the change
+func (c batchChange) requestError(index int, err error) error {
+	if c.module != "" {
+		return bundleModuleError(c.module, err)
+	}
+	return batchRequestError(index, err)
+}
+
+// batchRequestError prefixes a ServiceError detail with the index of the failing batch request
+func batchRequestError(index int, err error) error {
+	return withDetailPrefix(fmt.Sprintf("Request %d", index), err)
+}
+
+// withDetailPrefix prefixes the detail of a ServiceError, e.g. with the batch request or bundle module it
+// was raised for. Other errors are returned unchanged.
+func withDetailPrefix(prefix string, err error) error {
+	var serviceErr *ServiceError
+	if !errors.As(err, &serviceErr) {
+		return err
+	}
+	return &ServiceError{
+		Type:        serviceErr.Type,
+		Message:     serviceErr.Message,
+		Detail:      fmt.Sprintf("%s: %s", prefix, serviceErr.Detail),
+		Diagnostics: serviceErr.Diagnostics,
+		Err:         serviceErr.Err,
+	}
+}
+
+func validateBatchSize(n int) error {
+	if n == 0 {
+		return NewInvalidArgumentError("Empty batch", "At least one request is required")
+	}
+	if n > MaxBatchSize {
+		return NewInvalidArgumentError("Batch too large", fmt.Sprintf("At most %d requests can be sent at once, got %d", MaxBatchSize, n))
+	}
+	return nil
+}
+
+// BatchCreatePolicies creates several policies in one transaction with a single recompile (AEP-233).
+func (s *PolicyServiceImpl) BatchCreatePolicies(ctx context.Context, requests []v1alpha1.PolicyCreateRequest, opts PolicyWriteOptions) ([]v1alpha1.Policy, error) {
+	if err := s.checkDirectWrite(opts); err != nil {
+		return nil, err
+	}
+	if err := validateBatchSize(len(requests)); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Debug("Batch creating policies", "request_count", len(requests))
+
+	changes := make([]batchChange, len(requests))
+	for i, r := range requests {
+		if err := validatePostInput(r.Policy); err != nil {
+			return nil, batchRequestError(i, err)
+		}
+		policyID, err := getPolicyID(r.Id)
+		if err != nil {
+			return nil, batchRequestError(i, err)
+		}
+		if err := s.engine.ValidateRego(ctx, *r.Policy.RegoCode); err != nil {
+			return nil, batchRequestError(i, handleEngineError(err, "create"))
+		}
+		dbPolicy := APIToDBModel(r.Policy, *policyID)
+		changes[i] = batchChange{id: *policyID, policy: &dbPolicy, lint: true}
+	}
+
+	return s.applyBatch(ctx, changes, nil, opts)
+}
+
+// BatchUpdatePolicies updates several policies using partial merge (PATCH) in one transaction with a
+// single recompile (AEP-234).
+func (s *PolicyServiceImpl) BatchUpdatePolicies(ctx context.Context, requests []v1alpha1.PolicyUpdateRequest, opts PolicyWriteOptions) ([]v1alpha1.Policy, error) {
+	if err := s.checkDirectWrite(opts); err != nil {
+		return nil, err
+	}
+	if err := validateBatchSize(len(requests)); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Debug("Batch updating policies", "request_count", len(requests))
+
+	changes := make([]batchChange, len(requests))
+	for i, r := range requests {
+		patch := r.Policy
+		if err := validatePatchInput(&patch); err != nil {
+			return nil, batchRequestError(i, err)
+		}
+		var mask []string
+		if r.UpdateMask != nil {
+			mask = *r.UpdateMask
+		}
+		if err := validateUpdateMask(mask, &patch); err != nil {
+			return nil, batchRequestError(i, err)
+		}
+		existingDB, err := s.existingPolicy(ctx, r.PolicyId, "update")
+		if err != nil {
+			return nil, batchRequestError(i, err)
+		}
+		existing := DBToAPIModel(existingDB)
+		if err := validatePatchImmutableFields(&patch, existing); err != nil {
+			return nil, batchRequestError(i, err)
+		}
+		patch = *maskPolicyPatch(&patch, mask)
+		regoChanged := patch.RegoCode != nil
+		if regoChanged {
+			if err := s.engine.ValidateRego(ctx, *patch.RegoCode); err != nil {
+				return nil, batchRequestError(i, handleEngineError(err, "update"))
+			}
+		}
+		merged := mergePolicyOntoPolicy(&patch, existing, mask)
+		if err := validateEffectiveWindow(merged.EffectiveFrom, merged.EffectiveUntil); err != nil {
+			return nil, batchRequestError(i, err)
+		}
+		dbPolicy := APIToDBModel(merged, r.PolicyId)
+		// The decision of the policy depends on its Rego code and entrypoint
+		lint := regoChanged || patch.Entrypoint != nil || slices.Contains(mask, "entrypoint")
+		changes[i] = batchChange{id: r.PolicyId, policy: &dbPolicy, previous: existingDB, lint: lint}
+	}
+
+	return s.applyBatch(ctx, changes, nil, opts)
+}
+
+// BatchDeletePolicies deletes several policies in one transaction with a single recompile (AEP-235).
+func (s *PolicyServiceImpl) BatchDeletePolicies(ctx context.Context, ids []string) error {
+	if err := s.checkDirectWrite(PolicyWriteOptions{}); err != nil {
+		return err
+	}
+	if err := validateBatchSize(len(ids)); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Debug("Batch deleting policies", "request_count", len(ids))
+
+	changes := make([]batchChange, len(ids))
+	for i, id := range ids {
+		existingDB, err := s.existingPolicy(ctx, id, "delete")
+		if err != nil {
+			return batchRequestError(i, err)
+		}
+		changes[i] = batchChange{id: id, previous: existingDB}
+	}
+
+	_, err := s.applyBatch(ctx, changes, nil, PolicyWriteOptions{})
+	return err
+}
+
+// existingPolicy returns a stored policy that the API may change
+func (s *PolicyServiceImpl) existingPolicy(ctx context.Context, id string, operation string) (*model.Policy, error) {
+	existing, err := s.store.Policy().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrPolicyNotFound) {
+			return nil, NewPolicyNotFoundError(id)
+		}
+		logging.FromContext(ctx).Error("Failed to get existing policy for "+operation, "policy_id", id, "error", err)
+		return nil, NewInternalError("Failed to get existing policy", err.Error(), err)
+	}
+	if existing.Managed {
+		return nil, NewPolicyManagedError(id)
+	}
+	return existing, nil
+}
+
+// applyBatch compiles the policy set with all changes applied and runs the tests of the changed policies,
+// then writes the changes in one transaction. The engine is recompiled from within the transaction, so a
+// compile failure rolls back every change. The created or updated policies are returned in order.
+// libraries are the library changes of a bundle import, which are applied and written with the policies.
+func (s *PolicyServiceImpl) applyBatch(ctx context.Context, changes []batchChange, libraries []libraryChange, opts PolicyWriteOptions) ([]v1alpha1.Policy, error) {
+	log := logging.FromContext(ctx)
+
+	seen := make(map[string]int, len(changes))
+	for i, c := range changes {
+		if first, ok := seen[c.id]; ok {
+			return nil, NewInvalidArgumentError(
+				"Invalid batch",
+				fmt.Sprintf("Requests %d and %d both change policy '%s'", first, i, c.id),
+			)
+		}
+		seen[c.id] = i
+	}
+
+	// Compile the policy set with every change applied before writing anything
+	modules, err := s.storedModules(ctx)
+	if err != nil {
+		return nil, NewInternalError("Failed to list policies", err.Error(), err)
+	}
+	for _, l := range libraries {
+		modules = append(withoutModule(modules, l.library.ID, true), opa.PolicyModule{ID: l.library.ID, RegoCode: l.library.RegoCode, Library: true})
+	}
+	var lint []string
+	for _, c := range changes {
+		modules = withoutModule(modules, c.id, false)
+		if c.policy != nil {
+			modules = append(modules, policyModule(*c.policy))
+		}
+		if c.lint {
+			lint = append(lint, c.id)
+		}
+	}
+	diagnostics, err := s.engine.CheckPolicies(ctx, modules, lint)
+	if err != nil {
+		var compileErr *opa.CompileError
+		if errors.As(err, &compileErr) {
+			return nil, NewPolicySetCompileError(compileErr)
+		}
+		return nil, handleEngineError(err, "validate")
+	}
+	warnings := diagnosticsToAPI(diagnostics)
+
+	for i, c := range changes {
+		if c.policy == nil {
+			continue
+		}
+		if err := s.checkPolicyTestsIn(ctx, modules, *c.policy, opts); err != nil {
+			return nil, c.requestError(i, err)
+		}
+	}
+
+	// Write the changes and recompile the engine in one transaction. A validate-only batch is written
+	// to check the store constraints and then rolled back. The generation lock is taken before the
+	// transaction starts and held until the generation is advanced after the commit.
+	unlock := s.lockGeneration()
+	defer unlock()
+	written := make([]*model.Policy, len(changes))
+	compiled := false
+	var compileErr error
+	err = s.store.Transaction(ctx, func(tx store.Store) error {
+		if err := writeLibraries(ctx, tx, libraries); err != nil {
+			return err
+		}
+		if err := writeBatch(ctx, tx, changes, written); err != nil {
+			return err
+		}
+		if opts.ValidateOnly {
+			return errValidatedBatch
+		}
+		txService := &PolicyServiceImpl{store: tx, engine: s.engine}
+		if err := txService.recompileEngine(ctx, nil); err != nil {
+			log.Error("Failed to recompile engine after batch, rolling back DB", "error", err)
+			compileErr = err
+			return NewInternalError("Failed to compile policies after batch", err.Error(), err)
+		}
+		compiled = true
+		return nil
+	})
+	if err != nil && !errors.Is(err, errValidatedBatch) {
+		if compileErr != nil {
+			s.publishCompileFailure(ctx, compileErr)
+		}
+		if compiled {
+			// The commit failed after the engine was compiled with the batch; restore the stored set
+			if recompileErr := s.recompileEngineLocked(ctx, nil); recompileErr != nil {
+				log.Error("Failed to recompile engine after batch rollback", "error", recompileErr)
+			}
+		}
+		var serviceErr *ServiceError
+		if errors.As(err, &serviceErr) {
+			return nil, err
+		}
+		log.Error("Failed to write policy batch", "error", err)
+		return nil, NewInternalError("Failed to write policy batch", err.Error(), err)
+	}
+
+	if !opts.ValidateOnly {
+		// The engine was recompiled within the transaction, before the changes were visible outside of it
+		changed := make([]string, len(changes))
+		for i, c := range changes {
+			changed[i] = c.id
+		}
+		s.publishChangesLocked(ctx, changed)
+	}
+
+	result := make([]v1alpha1.Policy, 0, len(changes))
+	for _, p := range written {
+		if p != nil {
+			result = append(result, batchPolicyToAPI(p, warnings))
+		}
+	}
+	log.Debug("Policy batch applied", "request_count", len(changes), "validate_only", opts.ValidateOnly)
+	return result, nil
+}
+
+// writeBatch writes the changes of a batch through tx, storing the written policies in written.
+// Deletes are written first and changed priorities are assigned through temporary priorities, so that
+// policies can take over the priority or display name of another policy of the same batch.
+func writeBatch(ctx context.Context, tx store.Store, changes []batchChange, written []*model.Policy) error {
+	for i, c := range changes {
+		if c.policy != nil {
+			continue
+		}
+		if err := tx.Policy().Delete(ctx, c.id); err != nil {
+			if errors.Is(err, store.ErrPolicyNotFound) {
+				return c.requestError(i, NewPolicyNotFoundError(c.id))
+			}
+			return err
+		}
+	}
+
+	priorities := make(map[string]int32)
+	for _, c := range changes {
+		if c.policy != nil && c.previous != nil && c.policy.Priority != c.previous.Priority {
+			priorities[c.id] = c.policy.Priority
+		}
+	}
+	if len(priorities) > 0 {
+		if err := tx.Policy().SetPriorities(ctx, priorities); err != nil {
+			if errors.Is(err, store.ErrPriorityPolicyTypeTaken) {
+				return NewAlreadyExistsError("Policy priority and policy type already exists", "A priority assigned by the batch is held by another policy of the same type")
+			}
+			return err
+		}
+	}
+
+	for i, c := range changes {
+		if c.policy == nil {
+			continue
+		}
+		var err error
+		if c.previous == nil {
+			written[i], err = tx.Policy().Create(ctx, *c.policy)
+		} else {
+			written[i], err = tx.Policy().Update(ctx, *c.policy)
+		}
+		if err != nil {
+			operation := "update"
+			if c.previous == nil {
+				operation = "create"
+			}
+			return c.requestError(i, processPolicyStoreError(err, *c.policy, operation))
+		}
+	}
+	return nil
+}
+
+// writeLibraries writes the library changes of a bundle import through tx
+func writeLibraries(ctx context.Context, tx store.Store, libraries []libraryChange) error {
+	for _, l := range libraries {
+		var err error
+		operation := "update"
+		if l.previous == nil {
+			operation = "create"
+			_, err = tx.Library().Create(ctx, l.library)
+		} else {
+			_, err = tx.Library().Update(ctx, l.library)
+		}
+		if err != nil {
+			return bundleModuleError(l.module, processLibraryStoreError(err, l.library.ID, operation))
+		}
+	}
+	return nil
+}
+
+// batchPolicyToAPI converts a policy written by a batch and attaches its lint warnings
+func batchPolicyToAPI(p *model.Policy, warnings []v1alpha1.RegoDiagnostic) v1alpha1.Policy {
+	apiPolicy := DBToAPIModel(p)
+	var own []v1alpha1.RegoDiagnostic
+	for _, w := range warnings {
+		if w.PolicyId != nil && *w.PolicyId == p.ID {
+			own = append(own, w)
+		}
+	}
+	apiPolicy.Warnings = warningsPtr(own)
+	return apiPolicy
+}
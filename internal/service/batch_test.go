@@ -0,0 +1,202 @@
+package service_test
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("PolicyService batches", func() {
+	var (
+		db            *gorm.DB
+		policyService *service.PolicyServiceImpl
+		ctx           context.Context
+	)
+
+	expectServiceError := func(err error, errorType service.ErrorType, message string) *service.ServiceError {
+		GinkgoHelper()
+		Expect(err).To(HaveOccurred())
+		serviceErr, ok := err.(*service.ServiceError)
+		Expect(ok).To(BeTrue())
+		Expect(serviceErr.Type).To(Equal(errorType))
+		Expect(serviceErr.Message).To(Equal(message))
+		return serviceErr
+	}
+
+	newPolicy := func(id string, priority int32) v1alpha1.Policy {
+		return v1alpha1.Policy{
+			DisplayName: strPtr(id),
+			PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+			Priority:    &priority,
+			RegoCode:    strPtr("package policies." + id + "\n\nmain := {\"rejected\": false}\n"),
+		}
+	}
+
+	expectMissing := func(id string) {
+		GinkgoHelper()
+		_, err := policyService.GetPolicy(ctx, id)
+		expectServiceError(err, service.ErrorTypeNotFound, "Policy not found")
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
+
+		policyService = service.NewPolicyService(store.NewStore(db), opa.NewEngine())
+		ctx = context.Background()
+
+		_, err = policyService.CreatePolicy(ctx, newPolicy("old", 10), strPtr("old"), service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	Describe("BatchCreatePolicies", func() {
+		It("creates every policy", func() {
+			created, err := policyService.BatchCreatePolicies(ctx, []v1alpha1.PolicyCreateRequest{
+				{Id: strPtr("first"), Policy: newPolicy("first", 20)},
+				{Id: strPtr("second"), Policy: newPolicy("second", 30)},
+			}, service.PolicyWriteOptions{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(HaveLen(2))
+			Expect(*created[1].Id).To(Equal("second"))
+			_, err = policyService.GetPolicy(ctx, "first")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates nothing when one policy conflicts", func() {
+			_, err := policyService.BatchCreatePolicies(ctx, []v1alpha1.PolicyCreateRequest{
+				{Id: strPtr("first"), Policy: newPolicy("first", 20)},
+				{Id: strPtr("second"), Policy: newPolicy("second", 10)},
+			}, service.PolicyWriteOptions{})
+
+			serviceErr := expectServiceError(err, service.ErrorTypeAlreadyExists, "Policy priority and policy type already exists")
+			Expect(serviceErr.Detail).To(HavePrefix("Request 1: "))
+			expectMissing("first")
+		})
+
+		It("creates nothing when the policy set does not compile", func() {
+			broken := newPolicy("second", 30)
+			broken.RegoCode = strPtr("package policies.second\n\nmain := {\"rejected\": data.policies.missing.allowed(1)}\n")
+
+			_, err := policyService.BatchCreatePolicies(ctx, []v1alpha1.PolicyCreateRequest{
+				{Id: strPtr("first"), Policy: newPolicy("first", 20)},
+				{Id: strPtr("second"), Policy: broken},
+			}, service.PolicyWriteOptions{})
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid Rego code")
+			expectMissing("first")
+		})
+
+		It("writes nothing when validating only", func() {
+			validated, err := policyService.BatchCreatePolicies(ctx, []v1alpha1.PolicyCreateRequest{
+				{Id: strPtr("first"), Policy: newPolicy("first", 20)},
+			}, service.PolicyWriteOptions{ValidateOnly: true})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(validated).To(HaveLen(1))
+			expectMissing("first")
+		})
+
+		It("rejects the same ID twice", func() {
+			_, err := policyService.BatchCreatePolicies(ctx, []v1alpha1.PolicyCreateRequest{
+				{Id: strPtr("first"), Policy: newPolicy("first", 20)},
+				{Id: strPtr("first"), Policy: newPolicy("other", 30)},
+			}, service.PolicyWriteOptions{})
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid batch")
+		})
+	})
+
+	Describe("BatchUpdatePolicies", func() {
+		BeforeEach(func() {
+			replacement := newPolicy("replacement", 11)
+			replacement.Enabled = boolPtr(false)
+			_, err := policyService.CreatePolicy(ctx, replacement, strPtr("replacement"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("switches from one policy to another and swaps their priorities", func() {
+			updated, err := policyService.BatchUpdatePolicies(ctx, []v1alpha1.PolicyUpdateRequest{
+				{PolicyId: "old", Policy: v1alpha1.Policy{Enabled: boolPtr(false), Priority: int32Ptr(11)}},
+				{PolicyId: "replacement", Policy: v1alpha1.Policy{Enabled: boolPtr(true), Priority: int32Ptr(10)}},
+			}, service.PolicyWriteOptions{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*updated[0].Enabled).To(BeFalse())
+			Expect(*updated[0].Priority).To(Equal(int32(11)))
+			Expect(*updated[1].Enabled).To(BeTrue())
+			Expect(*updated[1].Priority).To(Equal(int32(10)))
+		})
+
+		It("resets the fields listed in the update mask of a request and absent from its policy", func() {
+			updated, err := policyService.BatchUpdatePolicies(ctx, []v1alpha1.PolicyUpdateRequest{
+				{PolicyId: "replacement", Policy: v1alpha1.Policy{}, UpdateMask: &[]string{"enabled"}},
+			}, service.PolicyWriteOptions{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*updated[0].Enabled).To(BeTrue())
+		})
+
+		It("changes nothing when one request fails", func() {
+			_, err := policyService.BatchUpdatePolicies(ctx, []v1alpha1.PolicyUpdateRequest{
+				{PolicyId: "replacement", Policy: v1alpha1.Policy{Enabled: boolPtr(true)}},
+				{PolicyId: "missing", Policy: v1alpha1.Policy{Enabled: boolPtr(false)}},
+			}, service.PolicyWriteOptions{})
+
+			serviceErr := expectServiceError(err, service.ErrorTypeNotFound, "Policy not found")
+			Expect(serviceErr.Detail).To(HavePrefix("Request 1: "))
+			policy, err := policyService.GetPolicy(ctx, "replacement")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*policy.Enabled).To(BeFalse())
+		})
+	})
+
+	Describe("BatchDeletePolicies", func() {
+		It("deletes every policy", func() {
+			_, err := policyService.CreatePolicy(ctx, newPolicy("other", 20), strPtr("other"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(policyService.BatchDeletePolicies(ctx, []string{"old", "other"})).To(Succeed())
+
+			expectMissing("old")
+			expectMissing("other")
+		})
+
+		It("deletes nothing when a remaining policy depends on a deleted one", func() {
+			helper := newPolicy("helper", 20)
+			helper.RegoCode = strPtr("package policies.helper\n\nmain := {\"rejected\": false}\n\ndouble(x) := x * 2\n")
+			dependent := newPolicy("dependent", 30)
+			dependent.RegoCode = strPtr("package policies.dependent\n\nmain := {\"rejected\": data.policies.helper.double(1) != 2}\n")
+			_, err := policyService.BatchCreatePolicies(ctx, []v1alpha1.PolicyCreateRequest{
+				{Id: strPtr("helper"), Policy: helper},
+				{Id: strPtr("dependent"), Policy: dependent},
+			}, service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = policyService.BatchDeletePolicies(ctx, []string{"old", "helper"})
+
+			serviceErr := expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid Rego code")
+			Expect(serviceErr.Diagnostics).To(ContainElement(HaveField("PolicyId", HaveValue(Equal("dependent")))))
+			_, err = policyService.GetPolicy(ctx, "old")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/bundle"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	"github.com/google/uuid"
+)
+
+// bundlePolicyToAPI converts a bundled module and its sidecar metadata to an API Policy
+func bundlePolicyToAPI(p bundle.Policy) v1alpha1.Policy {
+	regoCode := p.RegoCode
+	testCode := p.TestCode
+	policy := v1alpha1.Policy{
+		Priority:          p.Metadata.Priority,
+		Enabled:           p.Metadata.Enabled,
+		EffectiveFrom:     p.Metadata.EffectiveFrom,
+		EffectiveUntil:    p.Metadata.EffectiveUntil,
+		RolloutPercentage: p.Metadata.RolloutPercentage,
+		RegoCode:          &regoCode,
+		TestCode:          &testCode,
+	}
+	if p.Metadata.DisplayName != "" {
+		policy.DisplayName = &p.Metadata.DisplayName
+	}
+	if p.Metadata.Description != "" {
+		policy.Description = &p.Metadata.Description
+	}
+	if p.Metadata.PolicyType != "" {
+		policyType := v1alpha1.PolicyPolicyType(p.Metadata.PolicyType)
+		policy.PolicyType = &policyType
+	}
+	if p.Metadata.LabelSelector != nil {
+		policy.LabelSelector = &p.Metadata.LabelSelector
+	}
+	if p.Metadata.RolloutKey != "" {
+		policy.RolloutKey = &p.Metadata.RolloutKey
+	}
+	if p.Metadata.Entrypoint != "" {
+		policy.Entrypoint = &p.Metadata.Entrypoint
+	}
+	return policy
+}
+
+// dbPolicyToBundle converts a database Policy to a bundled module with sidecar metadata
+func dbPolicyToBundle(p *model.Policy) bundle.Policy {
+	priority := p.Priority
+	enabled := p.Enabled
+	meta := bundle.PolicyMetadata{
+		ID:                p.ID,
+		DisplayName:       p.DisplayName,
+		Description:       p.Description,
+		PolicyType:        p.PolicyType,
+		Priority:          &priority,
+		Enabled:           &enabled,
+		EffectiveFrom:     p.EffectiveFrom,
+		EffectiveUntil:    p.EffectiveUntil,
+		RolloutPercentage: p.RolloutPercentage,
+		RolloutKey:        p.RolloutKey,
+		Entrypoint:        p.Entrypoint,
+	}
+	if len(p.LabelSelector) > 0 {
+		meta.LabelSelector = p.LabelSelector
+	}
+	return bundle.Policy{
+		RegoCode: p.RegoCode,
+		TestCode: p.TestCode,
+		Metadata: meta,
+	}
+}
+
+func validateBundlePolicy(p bundle.Policy, policy v1alpha1.Policy, title string) error {
+	if err := validatePostInput(policy); err != nil {
+		return bundleModuleError(p.Path, err)
+	}
+	if *policy.PolicyType != v1alpha1.GLOBAL && *policy.PolicyType != v1alpha1.USER {
+		return NewInvalidArgumentError(
+			title,
+			fmt.Sprintf("Module '%s': policy_type must be GLOBAL or USER", p.Path),
+		)
+	}
+	return nil
+}
+
+// bundleIDFormatValid reports whether the ID of a bundled module is of the ID format or a server-generated
+// UUID, as in an exported bundle
+func bundleIDFormatValid(id string) bool {
+	if idPattern.MatchString(id) {
+		return true
+	}
+	parsed, err := uuid.Parse(id)
+	return err == nil && parsed.String() == id
+}
+
+// validateBundlePolicyID validates the policy ID of a bundled module. The ID format only applies to new
+// client-chosen IDs: the ID of an existing policy and a server-generated UUID, as in an exported bundle,
+// are accepted as they are.
+func (s *PolicyServiceImpl) validateBundlePolicyID(ctx context.Context, id string) error {
+	if bundleIDFormatValid(id) {
+		return nil
+	}
+	_, err := s.store.Policy().Get(ctx, id)
+	switch {
+	case err == nil:
+		return nil
+	case !errors.Is(err, store.ErrPolicyNotFound):
+		return NewInternalError("Failed to get existing policy", err.Error(), err)
+	}
+	_, err = getPolicyID(&id)
+	return err
+}
+
+// validateBundlePolicies validates every module and its metadata and converts them to API policies.
+// title is used as the error message for errors that span several modules.
+func (s *PolicyServiceImpl) validateBundlePolicies(ctx context.Context, bundlePolicies []bundle.Policy, title string) ([]v1alpha1.Policy, error) {
+	policies := make([]v1alpha1.Policy, len(bundlePolicies))
+	seen := make(map[string]string, len(bundlePolicies))
+	for i, p := range bundlePolicies {
+		policy := bundlePolicyToAPI(p)
+		if err := validateBundlePolicy(p, policy, title); err != nil {
+			return nil, err
+		}
+		if err := s.validateBundlePolicyID(ctx, p.Metadata.ID); err != nil {
+			return nil, bundleModuleError(p.Path, err)
+		}
+		if other, ok := seen[p.Metadata.ID]; ok {
+			return nil, NewInvalidArgumentError(
+				title,
+				fmt.Sprintf("Modules '%s' and '%s' both declare policy ID '%s'", other, p.Path, p.Metadata.ID),
+			)
+		}
+		seen[p.Metadata.ID] = p.Path
+		if err := s.engine.ValidateRego(ctx, p.RegoCode); err != nil {
+			return nil, bundleModuleError(p.Path, handleEngineError(err, "import"))
+		}
+		if p.TestCode != "" {
+			if err := s.engine.ValidateRego(ctx, p.TestCode); err != nil {
+				return nil, bundleModuleError(bundle.TestModulePath(p.Path), NewInvalidArgumentError("Invalid test code", err.Error()))
+			}
+		}
+		policies[i] = policy
+	}
+	return policies, nil
+}
+
+// bundleLibraryChanges validates the library modules of a bundle and returns the creates and updates
+// they make. As for policies, the ID format only applies to new client-chosen IDs.
+func (s *PolicyServiceImpl) bundleLibraryChanges(ctx context.Context, bundleLibraries []bundle.Library) ([]libraryChange, error) {
+	changes := make([]libraryChange, len(bundleLibraries))
+	seen := make(map[string]string, len(bundleLibraries))
+	for i, l := range bundleLibraries {
+		id := l.Metadata.ID
+		if other, ok := seen[id]; ok {
+			return nil, NewInvalidArgumentError(
+				"Invalid policy bundle",
+				fmt.Sprintf("Modules '%s' and '%s' both declare library ID '%s'", other, l.Path, id),
+			)
+		}
+		seen[id] = l.Path
+
+		existing, err := s.store.Library().Get(ctx, id)
+		switch {
+		case errors.Is(err, store.ErrLibraryNotFound):
+			if !bundleIDFormatValid(id) {
+				if _, err := getResourceID(&id, "library", "Library"); err != nil {
+					return nil, bundleModuleError(l.Path, err)
+				}
+			}
+		case err != nil:
+			return nil, NewInternalError("Failed to get existing library", err.Error(), err)
+		}
+
+		library := model.Library{ID: id, DisplayName: l.Metadata.DisplayName, Description: l.Metadata.Description, RegoCode: l.RegoCode}
+		if existing != nil {
+			if library.DisplayName == "" {
+				library.DisplayName = existing.DisplayName
+			}
+			if library.Description == "" {
+				library.Description = existing.Description
+			}
+		}
+		library.Package, err = s.libraryPackage(ctx, l.RegoCode, "import")
+		if err != nil {
+			return nil, bundleModuleError(l.Path, err)
+		}
+		changes[i] = libraryChange{library: library, previous: existing, module: l.Path}
+	}
+	return changes, nil
+}
+
+// bundleModuleError prefixes a ServiceError detail with the bundle module path
+func bundleModuleError(modulePath string, err error) error {
+	return withDetailPrefix(fmt.Sprintf("Module '%s'", modulePath), err)
+}
+
+// ImportBundle creates or updates the policies and libraries contained in an OPA bundle.
+// All modules are validated and the policy set with the bundle applied is compiled and linted before
+// any change is made. The policies are then written in one transaction, as for a batch, from which the
+// engine is recompiled.
+func (s *PolicyServiceImpl) ImportBundle(ctx context.Context, r io.Reader) ([]v1alpha1.Policy, error) {
+	log := logging.FromContext(ctx)
+
+	if err := s.checkDirectWrite(PolicyWriteOptions{}); err != nil {
+		return nil, err
+	}
+
+	b, err := bundle.Read(r)
+	if err != nil {
+		return nil, NewInvalidArgumentError("Invalid policy bundle", err.Error())
+	}
+	if len(b.Policies) == 0 && len(b.Libraries) == 0 {
+		return nil, NewInvalidArgumentError("Invalid policy bundle", "The bundle does not contain any policy or library modules")
+	}
+
+	log.Debug("Importing policy bundle", "revision", b.Revision, "module_count", len(b.Policies)+len(b.Libraries))
+
+	libraries, err := s.bundleLibraryChanges(ctx, b.Libraries)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := s.validateBundlePolicies(ctx, b.Policies, "Invalid policy bundle")
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]batchChange, len(b.Policies))
+	for i, p := range b.Policies {
+		id := p.Metadata.ID
+		existingDB, err := s.store.Policy().Get(ctx, id)
+		switch {
+		case errors.Is(err, store.ErrPolicyNotFound):
+			dbPolicy := APIToDBModel(policies[i], id)
+			changes[i] = batchChange{id: id, policy: &dbPolicy, lint: true, module: p.Path}
+		case err != nil:
+			return nil, NewInternalError("Failed to get existing policy", err.Error(), err)
+		default:
+			if existingDB.Managed {
+				return nil, bundleModuleError(p.Path, NewPolicyManagedError(id))
+			}
+			existing := DBToAPIModel(existingDB)
+			if *existing.PolicyType != *policies[i].PolicyType {
+				return nil, bundleModuleError(p.Path, NewInvalidArgumentError(
+					"policy_type is immutable",
+					"The policy_type field cannot be changed after creation",
+				))
+			}
+			merged := mergePolicyOntoPolicy(&policies[i], existing, nil)
+			if err := validateEffectiveWindow(merged.EffectiveFrom, merged.EffectiveUntil); err != nil {
+				return nil, bundleModuleError(p.Path, err)
+			}
+			dbPolicy := APIToDBModel(merged, id)
+			// The decision of the policy depends on its Rego code and entrypoint
+			lint := dbPolicy.RegoCode != existingDB.RegoCode || dbPolicy.Entrypoint != existingDB.Entrypoint
+			changes[i] = batchChange{id: id, policy: &dbPolicy, previous: existingDB, lint: lint, module: p.Path}
+		}
+	}
+
+	result, err := s.applyBatch(ctx, changes, libraries, PolicyWriteOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Policy bundle imported", "revision", b.Revision, "policy_count", len(result), "library_count", len(libraries))
+	return result, nil
+}
+
+// ExportBundle writes all policies and libraries to w as a gzipped OPA bundle.
+// The bundle revision is the latest update time across all policies and libraries.
+func (s *PolicyServiceImpl) ExportBundle(ctx context.Context, w io.Writer) error {
+	log := logging.FromContext(ctx)
+
+	allPolicies, err := s.store.Policy().ListAll(ctx)
+	if err != nil {
+		log.Error("Failed to list policies for export", "error", err)
+		return NewInternalError("Failed to list policies", err.Error(), err)
+	}
+	allLibraries, err := s.store.Library().ListAll(ctx)
+	if err != nil {
+		log.Error("Failed to list libraries for export", "error", err)
+		return NewInternalError("Failed to list libraries", err.Error(), err)
+	}
+
+	b := &bundle.Bundle{
+		Policies:  make([]bundle.Policy, len(allPolicies)),
+		Libraries: make([]bundle.Library, len(allLibraries)),
+	}
+	var revision time.Time
+	for i := range allPolicies {
+		b.Policies[i] = dbPolicyToBundle(&allPolicies[i])
+		if allPolicies[i].UpdateTime.After(revision) {
+			revision = allPolicies[i].UpdateTime
+		}
+	}
+	for i, l := range allLibraries {
+		b.Libraries[i] = bundle.Library{
+			RegoCode: l.RegoCode,
+			Metadata: bundle.LibraryMetadata{ID: l.ID, DisplayName: l.DisplayName, Description: l.Description},
+		}
+		if l.UpdateTime.After(revision) {
+			revision = l.UpdateTime
+		}
+	}
+	if !revision.IsZero() {
+		b.Revision = revision.UTC().Format(time.RFC3339Nano)
+	}
+
+	if err := bundle.Write(w, b); err != nil {
+		log.Error("Failed to write policy bundle", "error", err)
+		return NewInternalError("Failed to export policies", err.Error(), err)
+	}
+
+	log.Debug("Policy bundle exported", "policy_count", len(allPolicies), "library_count", len(allLibraries))
+	return nil
+}
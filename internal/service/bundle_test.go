@@ -0,0 +1,342 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/bundle"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+func boolPtr(b bool) *bool    { return &b }
+
+func writeBundle(policies ...bundle.Policy) *bytes.Buffer {
+	var buf bytes.Buffer
+	Expect(bundle.Write(&buf, &bundle.Bundle{Revision: "test", Policies: policies})).To(Succeed())
+	return &buf
+}
+
+func bundlePolicy(id, displayName, regoCode string, priority int32) bundle.Policy {
+	return bundle.Policy{
+		RegoCode: regoCode,
+		Metadata: bundle.PolicyMetadata{
+			ID:          id,
+			DisplayName: displayName,
+			PolicyType:  "GLOBAL",
+			Priority:    int32Ptr(priority),
+		},
+	}
+}
+
+var _ = Describe("PolicyService bundles", func() {
+	var (
+		db            *gorm.DB
+		dataStore     store.Store
+		engine        opa.Engine
+		policyService service.PolicyService
+		ctx           context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
+
+		dataStore = store.NewStore(db)
+		engine = opa.NewEngine()
+		policyService = service.NewPolicyService(dataStore, engine)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	Describe("ImportBundle", func() {
+		It("creates policies and compiles them into the engine", func() {
+			imported, err := policyService.ImportBundle(ctx, writeBundle(
+				bundlePolicy("region", "Region", "package policies.region\nmain := {\"rejected\": false}", 10),
+				bundlePolicy("quota", "Quota", "package policies.quota\nmain := {\"rejected\": false}", 20),
+			))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imported).To(HaveLen(2))
+
+			got, err := policyService.GetPolicy(ctx, "region")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*got.Priority).To(Equal(int32(10)))
+			Expect(*got.Enabled).To(BeTrue())
+
+			result, err := engine.EvaluatePolicy(ctx, "quota", map[string]any{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Defined).To(BeTrue())
+		})
+
+		It("updates existing policies matched by ID", func() {
+			_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Region"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.region\nmain := {\"rejected\": false}"),
+				Description: strPtr("kept"),
+				Priority:    int32Ptr(10),
+			}, strPtr("region"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = policyService.ImportBundle(ctx, writeBundle(
+				bundlePolicy("region", "Region v2", "package policies.region\nmain := {\"rejected\": true}", 15),
+			))
+			Expect(err).NotTo(HaveOccurred())
+
+			got, err := policyService.GetPolicy(ctx, "region")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*got.DisplayName).To(Equal("Region v2"))
+			Expect(*got.Priority).To(Equal(int32(15)))
+			Expect(*got.Description).To(Equal("kept"))
+
+			result, err := engine.EvaluatePolicy(ctx, "region", map[string]any{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Result["rejected"]).To(BeTrue())
+		})
+
+		It("rejects changing the policy type of an existing policy", func() {
+			_, err := policyService.ImportBundle(ctx, writeBundle(
+				bundlePolicy("region", "Region", "package policies.region\nmain := {\"rejected\": false}", 10),
+			))
+			Expect(err).NotTo(HaveOccurred())
+
+			userPolicy := bundlePolicy("region", "Region", "package policies.region\nmain := {\"rejected\": false}", 10)
+			userPolicy.Metadata.PolicyType = "USER"
+			_, err = policyService.ImportBundle(ctx, writeBundle(userPolicy))
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(serviceErr.Message).To(Equal("policy_type is immutable"))
+		})
+
+		It("rejects modules with missing required metadata", func() {
+			p := bundlePolicy("region", "", "package policies.region\nmain := {\"rejected\": false}", 10)
+
+			_, err := policyService.ImportBundle(ctx, writeBundle(p))
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(serviceErr.Detail).To(ContainSubstring("region.rego"))
+		})
+
+		It("rejects a new policy ID that is neither of the ID format nor a UUID", func() {
+			_, err := policyService.ImportBundle(ctx, writeBundle(
+				bundlePolicy("1region", "Region", "package policies.region\nmain := {\"rejected\": false}", 10),
+			))
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Message).To(Equal("Invalid policy ID format"))
+		})
+
+		It("rejects a malformed bundle", func() {
+			_, err := policyService.ImportBundle(ctx, bytes.NewBufferString("not a bundle"))
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+		})
+
+		It("rejects a bundle that does not compile with the policy set before writing anything", func() {
+			_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Existing"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package shared\nmain := {\"rejected\": false}"),
+				Priority:    int32Ptr(1),
+			}, strPtr("existing"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = policyService.ImportBundle(ctx, writeBundle(
+				bundlePolicy("fine", "Fine", "package policies.fine\nmain := {\"rejected\": false}", 10),
+				bundlePolicy("clash", "Clash", "package shared\nmain(x) := x", 20),
+			))
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(serviceErr.Diagnostics).To(ContainElement(HaveField("PolicyId", strPtr("clash"))))
+
+			_, err = policyService.GetPolicy(ctx, "fine")
+			Expect(err).To(HaveOccurred())
+			_, err = policyService.GetPolicy(ctx, "clash")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects a library that breaks the policies importing it", func() {
+			_, err := policyService.CreateLibrary(ctx, v1alpha1.Library{
+				RegoCode: strPtr("package lib.regions\n\nallowed(region) if region == \"eu-west-1\"\n"),
+			}, strPtr("regions"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Region"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.region\n\nimport data.lib.regions\n\nmain := {\"rejected\": false} if regions.allowed(input.region)\n"),
+			}, strPtr("region"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var buf bytes.Buffer
+			Expect(bundle.Write(&buf, &bundle.Bundle{Libraries: []bundle.Library{{
+				RegoCode: "package lib.regions\n\ndenied(region) if region == \"us-east-1\"\n",
+				Metadata: bundle.LibraryMetadata{ID: "regions"},
+			}}})).To(Succeed())
+			_, err = policyService.ImportBundle(ctx, &buf)
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(serviceErr.Diagnostics).To(ContainElement(HaveField("PolicyId", strPtr("region"))))
+			library, err := policyService.GetLibrary(ctx, "regions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*library.RegoCode).To(ContainSubstring("allowed"))
+		})
+
+		It("returns the lint warnings of the imported policies", func() {
+			imported, err := policyService.ImportBundle(ctx, writeBundle(
+				bundlePolicy("typo", "Typo", "package policies.typo\nmain := {\"rejected\": false, \"selected_providers\": \"aws\"}", 10),
+			))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imported).To(HaveLen(1))
+			Expect(imported[0].Warnings).NotTo(BeNil())
+			Expect(*imported[0].Warnings).To(ConsistOf(HaveField("Code", opa.CodeUnknownDecisionKey)))
+		})
+	})
+
+	Describe("ExportBundle", func() {
+		It("exports all policies with their metadata", func() {
+			_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName:   strPtr("Region"),
+				PolicyType:    policyTypePtr(v1alpha1.USER),
+				RegoCode:      strPtr("package policies.region\nmain := {\"rejected\": false}"),
+				Priority:      int32Ptr(42),
+				LabelSelector: &map[string]string{"env": "prod"},
+			}, strPtr("region"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var buf bytes.Buffer
+			Expect(policyService.ExportBundle(ctx, &buf)).To(Succeed())
+
+			b, err := bundle.Read(&buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Revision).NotTo(BeEmpty())
+			Expect(b.Policies).To(HaveLen(1))
+			Expect(b.Policies[0].Path).To(Equal("region.rego"))
+			Expect(b.Policies[0].Metadata.PolicyType).To(Equal("USER"))
+			Expect(*b.Policies[0].Metadata.Priority).To(Equal(int32(42)))
+			Expect(b.Policies[0].Metadata.LabelSelector).To(Equal(map[string]string{"env": "prod"}))
+		})
+
+		It("re-imports an exported bundle unchanged", func() {
+			_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Region"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.region\nmain := {\"rejected\": false}"),
+			}, strPtr("region"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var buf bytes.Buffer
+			Expect(policyService.ExportBundle(ctx, &buf)).To(Succeed())
+
+			imported, err := policyService.ImportBundle(ctx, &buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imported).To(HaveLen(1))
+			Expect(*imported[0].Id).To(Equal("region"))
+			Expect(*imported[0].Priority).To(Equal(int32(service.DefaultPriority)))
+		})
+
+		It("re-imports a policy together with the library it imports", func() {
+			_, err := policyService.CreateLibrary(ctx, v1alpha1.Library{
+				DisplayName: strPtr("Regions"),
+				RegoCode:    strPtr("package lib.regions\n\nallowed(region) if region in {\"eu-west-1\"}\n"),
+			}, strPtr("regions"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Region"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.region\n\nimport data.lib.regions\n\nmain := {\"rejected\": false} if regions.allowed(input.region)\n"),
+			}, strPtr("region"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var buf bytes.Buffer
+			Expect(policyService.ExportBundle(ctx, &buf)).To(Succeed())
+			exported := buf.Bytes()
+			b, err := bundle.Read(bytes.NewReader(exported))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Libraries).To(HaveLen(1))
+			Expect(b.Libraries[0].Path).To(Equal("lib/regions.rego"))
+			Expect(b.Libraries[0].Metadata.DisplayName).To(Equal("Regions"))
+
+			// Into a policy set without either
+			Expect(policyService.DeletePolicy(ctx, "region")).To(Succeed())
+			Expect(policyService.DeleteLibrary(ctx, "regions")).To(Succeed())
+			imported, err := policyService.ImportBundle(ctx, bytes.NewReader(exported))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imported).To(HaveLen(1))
+
+			library, err := policyService.GetLibrary(ctx, "regions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*library.DisplayName).To(Equal("Regions"))
+			Expect(*library.Package).To(Equal("lib.regions"))
+			result, err := engine.EvaluatePolicy(ctx, "region", map[string]any{"region": "eu-west-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Defined).To(BeTrue())
+
+			// Onto the existing policy and library
+			_, err = policyService.ImportBundle(ctx, bytes.NewReader(exported))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("re-imports a policy with a server-generated ID", func() {
+			created, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Region"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.region\nmain := {\"rejected\": false}"),
+			}, nil, service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			id := *created.Id
+
+			var buf bytes.Buffer
+			Expect(policyService.ExportBundle(ctx, &buf)).To(Succeed())
+			exported := buf.Bytes()
+
+			// Onto the existing policy
+			imported, err := policyService.ImportBundle(ctx, bytes.NewReader(exported))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imported).To(HaveLen(1))
+			Expect(*imported[0].Id).To(Equal(id))
+
+			// Into a policy set without it
+			Expect(policyService.DeletePolicy(ctx, id)).To(Succeed())
+			imported, err = policyService.ImportBundle(ctx, bytes.NewReader(exported))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imported).To(HaveLen(1))
+			Expect(*imported[0].Id).To(Equal(id))
+		})
+	})
+})
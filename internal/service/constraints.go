@@ -3,6 +3,7 @@ package service
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
 	"regexp"
 	"slices"
@@ -224,6 +225,77 @@ func (c *ConstraintContext) GetSPConstraintsMap() map[string]any {
 	return result
 }
 
+// JSONSchema returns the accumulated constraints as a JSON Schema document of the policy input: field
+// constraints nested along their field paths under the spec property, and service provider constraints
+// under the provider property
+func (c *ConstraintContext) JSONSchema() map[string]any {
+	spec := map[string]any{"type": "object"}
+	fieldPaths := slices.Sorted(maps.Keys(c.constrainedFieldsByFieldPath))
+	for _, fieldPath := range fieldPaths {
+		node := spec
+		for _, key := range strings.Split(fieldPath, ".") {
+			properties, ok := node["properties"].(map[string]any)
+			if !ok {
+				properties = map[string]any{}
+				node["properties"] = properties
+			}
+			child, ok := properties[key].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				properties[key] = child
+			}
+			node = child
+		}
+		maps.Copy(node, deepCopySchemaMap(c.constrainedFieldsByFieldPath[fieldPath]))
+	}
+	// Objects holding constrained fields, unless constrained to another type themselves
+	markObjects(spec)
+
+	provider := map[string]any{"type": "string"}
+	if sp := c.serviceProviderConstraints; sp != nil {
+		if len(sp.AllowList) > 0 {
+			provider["enum"] = slices.Clone(sp.AllowList)
+		}
+		switch len(sp.Patterns) {
+		case 0:
+		case 1:
+			provider["pattern"] = sp.Patterns[0]
+		default:
+			// A schema has a single pattern keyword; all patterns must match
+			allOf := make([]any, len(sp.Patterns))
+			for i, pattern := range sp.Patterns {
+				allOf[i] = map[string]any{"pattern": pattern}
+			}
+			provider["allOf"] = allOf
+		}
+	}
+
+	return map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type":    "object",
+		"properties": map[string]any{
+			"spec":     spec,
+			"provider": provider,
+		},
+	}
+}
+
+// markObjects sets the type of the schemas with properties to object, unless they already have a type
+func markObjects(schema map[string]any) {
+	properties, ok := schema["properties"].(map[string]any)
+	if !ok {
+		return
+	}
+	if _, ok := schema["type"]; !ok {
+		schema["type"] = "object"
+	}
+	for _, child := range properties {
+		if childSchema, ok := child.(map[string]any); ok {
+			markObjects(childSchema)
+		}
+	}
+}
+
 // mergeSchemaKeywords merges JSON Schema keywords, enforcing tightening-only.
 func mergeSchemaKeywords(existing, new map[string]any, fieldPath, existingPolicyID string) (map[string]any, error) {
 	merged := deepCopySchemaMap(existing)
@@ -446,6 +446,57 @@ var _ = Describe("ConstraintContext", func() {
 		})
 	})
 
+	Describe("JSONSchema", func() {
+		It("returns an input schema without constraints when none were set", func() {
+			Expect(constraintCtx.JSONSchema()).To(Equal(map[string]any{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type":    "object",
+				"properties": map[string]any{
+					"spec":     map[string]any{"type": "object"},
+					"provider": map[string]any{"type": "string"},
+				},
+			}))
+		})
+
+		It("nests field constraints along their field paths", func() {
+			Expect(constraintCtx.MergeConstraints(map[string]any{
+				"region":        map[string]any{"enum": []any{"eu-west-1", "eu-central-1"}},
+				"resources.cpu": map[string]any{"maximum": 8.0},
+				"resources":     map[string]any{"required": []any{"cpu"}},
+			}, "policy-1")).To(Succeed())
+
+			spec := constraintCtx.JSONSchema()["properties"].(map[string]any)["spec"]
+			Expect(spec).To(Equal(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"region": map[string]any{"enum": []any{"eu-west-1", "eu-central-1"}},
+					"resources": map[string]any{
+						"type":       "object",
+						"required":   []any{"cpu"},
+						"properties": map[string]any{"cpu": map[string]any{"maximum": 8.0}},
+					},
+				},
+			}))
+		})
+
+		It("returns service provider constraints as the provider schema", func() {
+			Expect(constraintCtx.MergeSPConstraints(&opa.ServiceProviderConstraints{
+				AllowList: []string{"aws", "gcp"},
+				Patterns:  []string{"^a"},
+			}, "policy-1")).To(Succeed())
+			provider := constraintCtx.JSONSchema()["properties"].(map[string]any)["provider"]
+			Expect(provider).To(Equal(map[string]any{"type": "string", "enum": []string{"aws", "gcp"}, "pattern": "^a"}))
+
+			Expect(constraintCtx.MergeSPConstraints(&opa.ServiceProviderConstraints{Patterns: []string{"s$"}}, "policy-2")).To(Succeed())
+			provider = constraintCtx.JSONSchema()["properties"].(map[string]any)["provider"]
+			Expect(provider).To(HaveKeyWithValue("allOf", []any{
+				map[string]any{"pattern": "^a"},
+				map[string]any{"pattern": "s$"},
+			}))
+			Expect(provider).NotTo(HaveKey("pattern"))
+		})
+	})
+
 	Describe("GetConstraintsMap", func() {
 		It("returns nil when no constraints exist", func() {
 			Expect(constraintCtx.GetConstraintsMap()).To(BeNil())
@@ -2,13 +2,16 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
 	"github.com/dcm-project/policy-manager/internal/store/model"
 )
 
 const (
-	DefaultPriority = 500
+	DefaultPriority          = 500
+	DefaultRolloutPercentage = 100
 )
 
 // APIToDBModel converts an API Policy model to a database Policy model.
@@ -36,31 +39,72 @@ func APIToDBModel(api v1alpha1.Policy, id string) model.Policy {
 	} else {
 		db.Priority = DefaultPriority
 	}
+	db.EffectiveFrom = utcTimePtr(api.EffectiveFrom)
+	db.EffectiveUntil = utcTimePtr(api.EffectiveUntil)
+	// A full rollout is stored as no rollout
+	if api.RolloutPercentage != nil && *api.RolloutPercentage != DefaultRolloutPercentage {
+		percentage := *api.RolloutPercentage
+		db.RolloutPercentage = &percentage
+	}
+	if api.RolloutKey != nil {
+		db.RolloutKey = *api.RolloutKey
+	}
+	// The default entrypoint is stored as no entrypoint
+	if api.Entrypoint != nil && *api.Entrypoint != opa.DefaultEntrypoint {
+		db.Entrypoint = *api.Entrypoint
+	}
 	if api.LabelSelector != nil {
 		db.LabelSelector = *api.LabelSelector
 	}
 	if api.RegoCode != nil {
 		db.RegoCode = *api.RegoCode
 	}
+	if api.TestCode != nil {
+		db.TestCode = *api.TestCode
+	}
 
 	return db
 }
 
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
 // DBToAPIModel converts a database Policy model to an API Policy model.
+// The active field is computed at the current time.
 func DBToAPIModel(db *model.Policy) v1alpha1.Policy {
 	path := fmt.Sprintf("policies/%s", db.ID)
 	displayName := db.DisplayName
 	policyType := v1alpha1.PolicyPolicyType(db.PolicyType)
+	active := db.ActiveAt(time.Now())
+	rolloutPercentage := int32(DefaultRolloutPercentage)
+	if db.RolloutPercentage != nil {
+		rolloutPercentage = *db.RolloutPercentage
+	}
+	entrypoint := opa.DefaultEntrypoint
+	if db.Entrypoint != "" {
+		entrypoint = db.Entrypoint
+	}
 	api := v1alpha1.Policy{
-		Id:          &db.ID,
-		Path:        &path,
-		DisplayName: &displayName,
-		PolicyType:  &policyType,
-		Priority:    &db.Priority,
-		Enabled:     &db.Enabled,
-		CreateTime:  &db.CreateTime,
-		UpdateTime:  &db.UpdateTime,
-		RegoCode:    &db.RegoCode,
+		Id:                &db.ID,
+		Path:              &path,
+		DisplayName:       &displayName,
+		PolicyType:        &policyType,
+		Priority:          &db.Priority,
+		Enabled:           &db.Enabled,
+		EffectiveFrom:     db.EffectiveFrom,
+		EffectiveUntil:    db.EffectiveUntil,
+		Active:            &active,
+		RolloutPercentage: &rolloutPercentage,
+		Entrypoint:        &entrypoint,
+		Managed:           &db.Managed,
+		CreateTime:        &db.CreateTime,
+		UpdateTime:        &db.UpdateTime,
+		RegoCode:          &db.RegoCode,
 	}
 	if db.Description != "" {
 		api.Description = &db.Description
@@ -68,5 +112,189 @@ func DBToAPIModel(db *model.Policy) v1alpha1.Policy {
 	if len(db.LabelSelector) > 0 {
 		api.LabelSelector = &db.LabelSelector
 	}
+	if db.TestCode != "" {
+		api.TestCode = &db.TestCode
+	}
+	if db.RolloutKey != "" {
+		api.RolloutKey = &db.RolloutKey
+	}
+	return api
+}
+
+// LibraryAPIToDBModel converts an API Library model to a database Library model.
+func LibraryAPIToDBModel(api v1alpha1.Library, id string) model.Library {
+	db := model.Library{ID: id}
+	if api.DisplayName != nil {
+		db.DisplayName = *api.DisplayName
+	}
+	if api.Description != nil {
+		db.Description = *api.Description
+	}
+	if api.RegoCode != nil {
+		db.RegoCode = *api.RegoCode
+	}
+	return db
+}
+
+// LibraryDBToAPIModel converts a database Library model to an API Library model.
+func LibraryDBToAPIModel(db *model.Library) v1alpha1.Library {
+	path := fmt.Sprintf("libraries/%s", db.ID)
+	api := v1alpha1.Library{
+		Id:         &db.ID,
+		Path:       &path,
+		RegoCode:   &db.RegoCode,
+		CreateTime: &db.CreateTime,
+		UpdateTime: &db.UpdateTime,
+	}
+	if db.DisplayName != "" {
+		api.DisplayName = &db.DisplayName
+	}
+	if db.Description != "" {
+		api.Description = &db.Description
+	}
+	if db.Package != "" {
+		api.Package = &db.Package
+	}
+	return api
+}
+
+// diagnosticsToAPI converts engine diagnostics to API diagnostics
+func diagnosticsToAPI(diagnostics []opa.Diagnostic) []v1alpha1.RegoDiagnostic {
+	result := make([]v1alpha1.RegoDiagnostic, len(diagnostics))
+	for i, d := range diagnostics {
+		result[i] = v1alpha1.RegoDiagnostic{
+			Line:     int32(d.Line),
+			Column:   int32(d.Column),
+			Severity: v1alpha1.RegoDiagnosticSeverity(d.Severity),
+			Code:     d.Code,
+			Message:  d.Message,
+		}
+		if d.PolicyID != "" {
+			result[i].PolicyId = &d.PolicyID
+		}
+		if d.LibraryID != "" {
+			result[i].LibraryId = &d.LibraryID
+		}
+		if d.Rule != "" {
+			result[i].Rule = &d.Rule
+		}
+	}
+	return result
+}
+
+// warningsPtr returns the warnings field of a policy, omitted when there are no warnings
+func warningsPtr(warnings []v1alpha1.RegoDiagnostic) *[]v1alpha1.RegoDiagnostic {
+	if len(warnings) == 0 {
+		return nil
+	}
+	return &warnings
+}
+
+// WebhookAPIToDBModel converts an API Webhook model to a database Webhook model.
+func WebhookAPIToDBModel(api v1alpha1.Webhook, id string) model.Webhook {
+	db := model.Webhook{ID: id}
+	if api.DisplayName != nil {
+		db.DisplayName = *api.DisplayName
+	}
+	if api.Url != nil {
+		db.URL = *api.Url
+	}
+	if api.EventTypes != nil {
+		db.EventTypes = webhookEventTypesToDB(*api.EventTypes)
+	}
+	if api.Secret != nil {
+		db.Secret = *api.Secret
+	}
+	return db
+}
+
+// WebhookDBToAPIModel converts a database Webhook model to an API Webhook model. The secret is not
+// returned.
+func WebhookDBToAPIModel(db *model.Webhook) v1alpha1.Webhook {
+	path := fmt.Sprintf("webhooks/%s", db.ID)
+	eventTypes := make([]v1alpha1.WebhookEventType, len(db.EventTypes))
+	for i, t := range db.EventTypes {
+		eventTypes[i] = v1alpha1.WebhookEventType(t)
+	}
+	api := v1alpha1.Webhook{
+		Id:         &db.ID,
+		Path:       &path,
+		Url:        &db.URL,
+		EventTypes: &eventTypes,
+		CreateTime: &db.CreateTime,
+		UpdateTime: &db.UpdateTime,
+	}
+	if db.DisplayName != "" {
+		api.DisplayName = &db.DisplayName
+	}
+	return api
+}
+
+func webhookEventTypesToDB(eventTypes []v1alpha1.WebhookEventType) []string {
+	result := make([]string, len(eventTypes))
+	for i, t := range eventTypes {
+		result[i] = string(t)
+	}
+	return result
+}
+
+// WebhookDeliveryDBToAPIModel converts a database WebhookDelivery model to an API WebhookDelivery model.
+func WebhookDeliveryDBToAPIModel(db *model.WebhookDelivery) v1alpha1.WebhookDelivery {
+	eventType := v1alpha1.WebhookEventType(db.EventType)
+	status := v1alpha1.WebhookDeliveryStatus(db.Status)
+	api := v1alpha1.WebhookDelivery{
+		Id:              &db.ID,
+		EventId:         &db.EventID,
+		EventType:       &eventType,
+		Status:          &status,
+		Attempts:        &db.Attempts,
+		NextAttemptTime: db.NextAttemptTime,
+		CreateTime:      &db.CreateTime,
+		UpdateTime:      &db.UpdateTime,
+	}
+	if db.PolicyID != "" {
+		api.PolicyId = &db.PolicyID
+	}
+	if db.ResponseCode != 0 {
+		api.ResponseCode = &db.ResponseCode
+	}
+	if db.LastError != "" {
+		api.LastError = &db.LastError
+	}
+	return api
+}
+
+// ProposalDBToAPIModel converts a database PolicyProposal model to an API PolicyProposal model. The
+// proposed policy or library, the current version and the validation are added by the service.
+func ProposalDBToAPIModel(db *model.PolicyProposal) v1alpha1.PolicyProposal {
+	path := fmt.Sprintf("proposals/%s", db.ID)
+	operation := v1alpha1.PolicyProposalOperation(db.Operation)
+	state := v1alpha1.ProposalState(db.State)
+	api := v1alpha1.PolicyProposal{
+		Id:             &db.ID,
+		Path:           &path,
+		Operation:      &operation,
+		State:          &state,
+		Proposer:       &db.Proposer,
+		BaseUpdateTime: utcTimePtr(db.BaseUpdateTime),
+		ReviewTime:     utcTimePtr(db.ReviewTime),
+		CreateTime:     &db.CreateTime,
+		UpdateTime:     &db.UpdateTime,
+	}
+	if db.PolicyID != "" {
+		api.PolicyId = &db.PolicyID
+	}
+	if db.LibraryID != "" {
+		api.LibraryId = &db.LibraryID
+	}
+	if db.Description != "" {
+		api.Description = &db.Description
+	}
+	if db.Reviewer != "" {
+		api.Reviewer = &db.Reviewer
+	}
+	if db.ReviewComment != "" {
+		api.ReviewComment = &db.ReviewComment
+	}
 	return api
 }
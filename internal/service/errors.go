@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
 	"github.com/dcm-project/policy-manager/internal/store"
 	"github.com/dcm-project/policy-manager/internal/store/model"
 	"gorm.io/gorm"
@@ -20,8 +22,11 @@ const (
 	ErrorTypeAlreadyExists      ErrorType = "ALREADY_EXISTS"
 	ErrorTypeInternal           ErrorType = "INTERNAL"
 	ErrorTypeFailedPrecondition ErrorType = "FAILED_PRECONDITION"
+	ErrorTypeUnauthenticated    ErrorType = "UNAUTHENTICATED"
+	ErrorTypePermissionDenied   ErrorType = "PERMISSION_DENIED"
 	ErrorTypeRejected           ErrorType = "REJECTED"        // Policy evaluation rejected
 	ErrorTypePolicyConflict     ErrorType = "POLICY_CONFLICT" // Policy constraint conflict
+	ErrorTypeTimeout            ErrorType = "TIMEOUT"         // Policy evaluation timed out
 )
 
 // ServiceError represents a structured error from the service layer
@@ -30,6 +35,11 @@ type ServiceError struct {
 	Message string
 	Detail  string
 	Err     error
+	// Diagnostics lists the compile problems of the policy set, if the error is caused by them
+	Diagnostics []v1alpha1.RegoDiagnostic
+	// Explanations holds the explanations of the policies evaluated for a request that failed, when
+	// requested with EvaluationRequest.Explain
+	Explanations []PolicyExplanation
 }
 
 func (e *ServiceError) Error() string {
@@ -60,6 +70,26 @@ func processPolicyStoreError(err error, dbPolicy model.Policy, operation string)
 	return NewInternalError(fmt.Sprintf("Failed to %s policy", operation), err.Error(), err)
 }
 
+func processLibraryStoreError(err error, libraryID string, operation string) *ServiceError {
+	if errors.Is(err, store.ErrLibraryIDTaken) {
+		return NewLibraryAlreadyExistsError(libraryID)
+	}
+	if errors.Is(err, store.ErrLibraryNotFound) {
+		return NewLibraryNotFoundError(libraryID)
+	}
+	return NewInternalError(fmt.Sprintf("Failed to %s library", operation), err.Error(), err)
+}
+
+func processWebhookStoreError(err error, webhookID string, operation string) *ServiceError {
+	if errors.Is(err, store.ErrWebhookIDTaken) {
+		return NewWebhookAlreadyExistsError(webhookID)
+	}
+	if errors.Is(err, store.ErrWebhookNotFound) {
+		return NewWebhookNotFoundError(webhookID)
+	}
+	return NewInternalError(fmt.Sprintf("Failed to %s webhook", operation), err.Error(), err)
+}
+
 // NewInvalidArgumentError creates a new invalid argument error
 func NewInvalidArgumentError(message, detail string) *ServiceError {
 	return &ServiceError{
@@ -128,6 +158,105 @@ func NewFailedPreconditionError(message, detail string) *ServiceError {
 	}
 }
 
+// NewUnauthenticatedError creates a new unauthenticated error
+func NewUnauthenticatedError(message, detail string) *ServiceError {
+	return &ServiceError{
+		Type:    ErrorTypeUnauthenticated,
+		Message: message,
+		Detail:  detail,
+	}
+}
+
+// NewPermissionDeniedError creates a new permission denied error
+func NewPermissionDeniedError(message, detail string) *ServiceError {
+	return &ServiceError{
+		Type:    ErrorTypePermissionDenied,
+		Message: message,
+		Detail:  detail,
+	}
+}
+
+// NewApprovalRequiredError creates a failed precondition error for a direct change of the policy set
+// while changes require approval
+func NewApprovalRequiredError() *ServiceError {
+	return NewFailedPreconditionError(
+		"Approval required",
+		"Policy changes require approval: propose the change with POST /proposals",
+	)
+}
+
+// NewLibraryApprovalRequiredError creates a failed precondition error for a direct change of a library
+// while changes require approval
+func NewLibraryApprovalRequiredError() *ServiceError {
+	return NewFailedPreconditionError(
+		"Approval required",
+		"Library changes require approval: propose the change with POST /proposals",
+	)
+}
+
+func NewProposalNotFoundError(proposalID string) *ServiceError {
+	return NewNotFoundError("Proposal not found", fmt.Sprintf("Proposal with ID '%s' does not exist", proposalID))
+}
+
+// NewPolicyManagedError creates a failed precondition error for a policy owned by the policy directory
+func NewPolicyManagedError(policyID string) *ServiceError {
+	return NewFailedPreconditionError(
+		"Policy is managed",
+		fmt.Sprintf("Policy '%s' is managed by the policy directory and cannot be modified through the API", policyID),
+	)
+}
+
+// NewPolicyTestsFailedError creates a failed precondition error for a policy whose tests fail
+func NewPolicyTestsFailedError(policyID string, failures []opa.TestResult) *ServiceError {
+	parts := make([]string, len(failures))
+	for i, f := range failures {
+		parts[i] = fmt.Sprintf("%s: %s", f.Name, f.Message)
+	}
+	return NewFailedPreconditionError(
+		"Policy tests failed",
+		fmt.Sprintf("%d test(s) of policy '%s' failed: %s", len(failures), policyID, strings.Join(parts, "; ")),
+	)
+}
+
+// NewPolicySetCompileError creates an invalid argument error listing every problem that prevents the
+// policy set from compiling or the policy from passing the linter
+func NewPolicySetCompileError(compileErr *opa.CompileError) *ServiceError {
+	diagnostics := diagnosticsToAPI(compileErr.Diagnostics)
+	return &ServiceError{
+		Type:        ErrorTypeInvalidArgument,
+		Message:     "Invalid Rego code",
+		Detail:      fmt.Sprintf("The policy set does not compile: %d problem(s) found", len(diagnostics)),
+		Diagnostics: diagnostics,
+	}
+}
+
+// NewLibraryInUseError creates a failed precondition error for a library that policies still depend on
+func NewLibraryInUseError(libraryID string, compileErr *opa.CompileError) *ServiceError {
+	diagnostics := diagnosticsToAPI(compileErr.Diagnostics)
+	return &ServiceError{
+		Type:        ErrorTypeFailedPrecondition,
+		Message:     "Library is in use",
+		Detail:      fmt.Sprintf("Deleting library '%s' breaks the policy set: %d problem(s) found", libraryID, len(diagnostics)),
+		Diagnostics: diagnostics,
+	}
+}
+
+func NewLibraryNotFoundError(libraryID string) *ServiceError {
+	return NewNotFoundError("Library not found", fmt.Sprintf("Library with ID '%s' does not exist", libraryID))
+}
+
+func NewLibraryAlreadyExistsError(libraryID string) *ServiceError {
+	return NewAlreadyExistsError("Library already exists", fmt.Sprintf("A library with ID '%s' already exists", libraryID))
+}
+
+func NewWebhookNotFoundError(webhookID string) *ServiceError {
+	return NewNotFoundError("Webhook not found", fmt.Sprintf("Webhook with ID '%s' does not exist", webhookID))
+}
+
+func NewWebhookAlreadyExistsError(webhookID string) *ServiceError {
+	return NewAlreadyExistsError("Webhook already exists", fmt.Sprintf("A webhook with ID '%s' already exists", webhookID))
+}
+
 // NewPolicyRejectedError creates a new policy rejected error (406 Not Acceptable)
 func NewPolicyRejectedError(policyID, reason string) *ServiceError {
 	return &ServiceError{
@@ -137,6 +266,26 @@ func NewPolicyRejectedError(policyID, reason string) *ServiceError {
 	}
 }
 
+// NewPolicyTimeoutError creates a new policy timeout error (504 Gateway Timeout), returned when a policy
+// does not finish evaluating within the per-policy timeout
+func NewPolicyTimeoutError(policyID string, timeout time.Duration) *ServiceError {
+	return &ServiceError{
+		Type:    ErrorTypeTimeout,
+		Message: fmt.Sprintf("Evaluation of policy '%s' timed out", policyID),
+		Detail:  fmt.Sprintf("The policy did not finish evaluating within %s", timeout),
+	}
+}
+
+// NewEvaluationDeadlineError creates a new evaluation deadline error (504 Gateway Timeout), returned when
+// the evaluation of a request exceeds its deadline while evaluating a policy
+func NewEvaluationDeadlineError(policyID string, timeout time.Duration) *ServiceError {
+	return &ServiceError{
+		Type:    ErrorTypeTimeout,
+		Message: fmt.Sprintf("Evaluation deadline exceeded while evaluating policy '%s'", policyID),
+		Detail:  fmt.Sprintf("The evaluation of the request did not finish within %s", timeout),
+	}
+}
+
 // NewPolicyConflictError creates a new policy conflict error (409 Conflict)
 func NewPolicyConflictError(lowerPolicyID, field, higherPolicyID string) *ServiceError {
 	return &ServiceError{
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/brunoga/deep/v4"
 	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/metrics"
 	"github.com/dcm-project/policy-manager/internal/opa"
 	"github.com/dcm-project/policy-manager/internal/store"
 	"github.com/dcm-project/policy-manager/internal/store/model"
@@ -23,12 +25,15 @@ const (
 // EvaluationService defines the interface for policy evaluation
 type EvaluationService interface {
 	EvaluateRequest(ctx context.Context, req *EvaluationRequest) (*EvaluationResponse, error)
+	PreviewConstraints(ctx context.Context, req *ConstraintPreviewRequest) (*ConstraintPreview, error)
 }
 
 // EvaluationRequest represents a request for policy evaluation
 type EvaluationRequest struct {
 	ServiceInstance map[string]any
 	RequestLabels   map[string]string
+	// Explain requests the print() output and an explain trace of every evaluated policy
+	Explain opa.ExplainMode
 }
 
 // EvaluationResponse represents the response from policy evaluation
@@ -36,26 +41,104 @@ type EvaluationResponse struct {
 	EvaluatedServiceInstance map[string]any
 	SelectedProvider         string
 	Status                   EvaluationStatus
+	// CanaryPolicies lists the matching policies rolled out to a share of requests
+	CanaryPolicies []CanaryPolicy
+	// Generation is the policy set generation the engine was compiled from when the evaluation started
+	Generation int64
+	// Explanations holds the print() output and explain trace of every evaluated policy, in evaluation
+	// order, when requested with EvaluationRequest.Explain
+	Explanations []PolicyExplanation
+}
+
+// PolicyExplanation is the print() output and explain trace of a policy evaluation
+type PolicyExplanation struct {
+	PolicyID    string
+	PrintOutput []string
+	Trace       []string
+}
+
+// explainer collects the explanations of the policies evaluated for a request
+type explainer struct {
+	mode         opa.ExplainMode
+	explanations []PolicyExplanation
+}
+
+// record adds the explanation of the evaluation of a policy, which the engine returns with the result
+// or, when the evaluation fails, with the error
+func (e *explainer) record(policyID string, result *opa.EvaluationResult, err error) {
+	explanation := PolicyExplanation{PolicyID: policyID}
+	var evalErr *opa.EvaluationError
+	switch {
+	case err == nil:
+		explanation.PrintOutput = result.PrintOutput
+		explanation.Trace = result.Trace
+	case errors.As(err, &evalErr):
+		explanation.PrintOutput = evalErr.PrintOutput
+		explanation.Trace = evalErr.Trace
+	}
+	e.explanations = append(e.explanations, explanation)
 }
 
 // evaluationService implements EvaluationService
 type evaluationService struct {
 	policyStore store.Policy
 	engine      opa.Engine
+	generation  func() int64
+	// policyTimeout bounds the evaluation of a single policy; zero means no timeout
+	policyTimeout time.Duration
+	// timeout bounds the evaluation of a whole request; zero means no deadline
+	timeout time.Duration
+}
+
+// Causes of the cancellation of an evaluation context
+var (
+	errPolicyTimeout      = errors.New("policy evaluation timeout expired")
+	errEvaluationDeadline = errors.New("evaluation deadline exceeded")
+)
+
+// EvaluationServiceOption configures an evaluation service
+type EvaluationServiceOption func(*evaluationService)
+
+// WithCompiledGeneration reports the policy set generation returned by generation in every evaluation
+// response, e.g. PolicyServiceImpl.CompiledGeneration of the service compiling the engine
+func WithCompiledGeneration(generation func() int64) EvaluationServiceOption {
+	return func(s *evaluationService) {
+		s.generation = generation
+	}
+}
+
+// WithEvaluationTimeouts bounds the evaluation of every policy by policyTimeout and the evaluation of a
+// whole request by timeout. Evaluations exceeding either are canceled and fail with a timeout error
+// naming the policy being evaluated. A zero duration disables the corresponding timeout.
+func WithEvaluationTimeouts(policyTimeout, timeout time.Duration) EvaluationServiceOption {
+	return func(s *evaluationService) {
+		s.policyTimeout = policyTimeout
+		s.timeout = timeout
+	}
 }
 
 // NewEvaluationService creates a new evaluation service
-func NewEvaluationService(policyStore store.Policy, engine opa.Engine) EvaluationService {
-	return &evaluationService{
+func NewEvaluationService(policyStore store.Policy, engine opa.Engine, opts ...EvaluationServiceOption) EvaluationService {
+	s := &evaluationService{
 		policyStore: policyStore,
 		engine:      engine,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // EvaluateRequest evaluates a service instance request against all applicable policies
 func (s *evaluationService) EvaluateRequest(ctx context.Context, req *EvaluationRequest) (*EvaluationResponse, error) {
 	log := logging.FromContext(ctx)
 	log.Debug("Starting policy evaluation", "label_count", len(req.RequestLabels))
+	defer func(start time.Time) {
+		metrics.EvaluationDuration.Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	ctx, cancel := s.withDeadline(ctx)
+	defer cancel()
 
 	// Initialize the current service instance spec (we'll modify this as we evaluate policies)
 	currentSpec, err := deep.Copy(req.ServiceInstance)
@@ -63,23 +146,100 @@ func (s *evaluationService) EvaluateRequest(ctx context.Context, req *Evaluation
 		return nil, NewInternalError("Failed to make a deep copy of the service instance spec", err.Error(), err)
 	}
 
+	var generation int64
+	if s.generation != nil {
+		generation = s.generation()
+	}
+
 	// Initialize constraint context
 	constraintCtx := NewConstraintContext()
 
 	// Track selected provider across policies (starts unknown)
 	selectedProvider := ""
 
-	// Paginate over all enabled policies, ordered by policy_type ASC, priority ASC
-	var pageToken *string
+	var explain *explainer
+	if req.Explain != opa.ExplainOff {
+		explain = &explainer{mode: req.Explain, explanations: []PolicyExplanation{}}
+	}
 	policiesEvaluated := 0
-	policiesSkipped := 0
+	selection, err := s.forEachApplicablePolicy(ctx, req.ServiceInstance, req.RequestLabels, func(policy *model.Policy) error {
+		log.Debug("Evaluating policy", "policy_id", policy.ID, "policy_type", policy.PolicyType, "priority", policy.Priority)
+
+		var err error
+		currentSpec, selectedProvider, err = s.evaluatePolicy(ctx, policy, currentSpec, selectedProvider, constraintCtx, explain)
+		if err != nil {
+			log.Warn("Policy evaluation failed", "policy_id", policy.ID, "error", err)
+			return err
+		}
+		policiesEvaluated++
+		return nil
+	})
+	if err != nil {
+		// The explanations show how the policies up to the failing one reached their decisions
+		var serviceErr *ServiceError
+		if explain != nil && errors.As(err, &serviceErr) {
+			serviceErr.Explanations = explain.explanations
+		}
+		return nil, err
+	}
+
+	// Determine status
+	status := EvaluationStatusApproved
+	if !deep.Equal(req.ServiceInstance, currentSpec) {
+		status = EvaluationStatusModified
+	}
+
+	log.Info("Policy evaluation completed",
+		"status", status,
+		"policies_evaluated", policiesEvaluated,
+		"policies_skipped", selection.skipped,
+		"selected_provider", selectedProvider,
+	)
+
+	response := &EvaluationResponse{
+		EvaluatedServiceInstance: currentSpec,
+		SelectedProvider:         selectedProvider,
+		Status:                   status,
+		CanaryPolicies:           selection.canaries,
+		Generation:               generation,
+	}
+	if explain != nil {
+		response.Explanations = explain.explanations
+	}
+	return response, nil
+}
+
+// policySelection describes the policies skipped while selecting the policies applicable to a request
+type policySelection struct {
+	// canaries lists the matching policies rolled out to a share of requests
+	canaries []CanaryPolicy
+	// skipped counts the active policies not applicable to the request
+	skipped int
+}
+
+// forEachApplicablePolicy calls fn for every policy applicable to a request, in evaluation order: the
+// policies active at request time whose label selector matches the request labels and whose rollout
+// includes the request. It stops at the first error returned by fn.
+func (s *evaluationService) forEachApplicablePolicy(
+	ctx context.Context,
+	serviceInstance map[string]any,
+	requestLabels map[string]string,
+	fn func(policy *model.Policy) error,
+) (*policySelection, error) {
+	log := logging.FromContext(ctx)
+	selection := &policySelection{}
+
+	// Paginate over all policies active at request time, ordered by policy_type ASC, priority ASC
+	now := time.Now()
+	var after *model.Policy
+	requestKey := ""
 	for {
 		policyListResult, err := s.policyStore.List(ctx, &store.PolicyListOptions{
 			Filter: &store.PolicyFilter{
-				Enabled: boolPtr(true),
+				ActiveAt: &now,
 			},
-			PageSize:  1000,
-			PageToken: pageToken,
+			PageSize: 1000,
+			After:    after,
 		})
 		if err != nil {
 			log.Error("Failed to retrieve policies for evaluation", "error", err)
@@ -89,45 +249,39 @@ func (s *evaluationService) EvaluateRequest(ctx context.Context, req *Evaluation
 		// Evaluate each policy on this page sequentially
 		for _, policy := range policyListResult.Policies {
 			// Filter by label selector
-			if !MatchesLabelSelector(policy.LabelSelector, req.RequestLabels) {
-				policiesSkipped++
+			if !MatchesLabelSelector(policy.LabelSelector, requestLabels) {
+				selection.skipped++
 				continue
 			}
 
-			log.Debug("Evaluating policy", "policy_id", policy.ID, "policy_type", policy.PolicyType, "priority", policy.Priority)
+			if policy.RolloutPercentage != nil {
+				if requestKey == "" {
+					requestKey = rolloutRequestKey(serviceInstance)
+				}
+				applied := inRollout(&policy, requestLabels, requestKey)
+				selection.canaries = append(selection.canaries, CanaryPolicy{
+					PolicyID:          policy.ID,
+					RolloutPercentage: *policy.RolloutPercentage,
+					Applied:           applied,
+				})
+				log.Info("Canary policy", "policy_id", policy.ID, "rollout_percentage", *policy.RolloutPercentage, "applied", applied)
+				if !applied {
+					selection.skipped++
+					continue
+				}
+			}
 
-			currentSpec, selectedProvider, err = s.evaluatePolicy(ctx, &policy, currentSpec, selectedProvider, constraintCtx)
-			if err != nil {
-				log.Warn("Policy evaluation failed", "policy_id", policy.ID, "error", err)
+			if err := fn(&policy); err != nil {
 				return nil, err
 			}
-			policiesEvaluated++
 		}
 
-		if policyListResult.NextPageToken == "" {
+		if !policyListResult.HasMore {
 			break
 		}
-		pageToken = &policyListResult.NextPageToken
+		after = &policyListResult.Policies[len(policyListResult.Policies)-1]
 	}
-
-	// Determine status
-	status := EvaluationStatusApproved
-	if !deep.Equal(req.ServiceInstance, currentSpec) {
-		status = EvaluationStatusModified
-	}
-
-	log.Info("Policy evaluation completed",
-		"status", status,
-		"policies_evaluated", policiesEvaluated,
-		"policies_skipped", policiesSkipped,
-		"selected_provider", selectedProvider,
-	)
-
-	return &EvaluationResponse{
-		EvaluatedServiceInstance: currentSpec,
-		SelectedProvider:         selectedProvider,
-		Status:                   status,
-	}, nil
+	return selection, nil
 }
 
 func (s *evaluationService) evaluatePolicy(
@@ -136,28 +290,16 @@ func (s *evaluationService) evaluatePolicy(
 	currentSpec map[string]any,
 	selectedProvider string,
 	constraintCtx *ConstraintContext,
+	explain *explainer, // nil unless explaining
 ) (map[string]any, string, error) {
 	log := logging.FromContext(ctx)
 	// 1. Build OPA input with constraints and SP constraints
-	opaInput := map[string]any{
-		"spec":     currentSpec,
-		"provider": selectedProvider,
-	}
-	if constraints := constraintCtx.GetConstraintsMap(); constraints != nil {
-		opaInput["constraints"] = constraints
-	}
-	if spConstraints := constraintCtx.GetSPConstraintsMap(); spConstraints != nil {
-		opaInput["service_provider_constraints"] = spConstraints
-	}
+	opaInput := policyInput(currentSpec, selectedProvider, constraintCtx)
 
 	// 2. Evaluate the policy using the embedded engine
-	evalResult, err := s.engine.EvaluatePolicy(ctx, policy.ID, opaInput)
+	evalResult, err := s.evaluate(ctx, policy, opaInput, explain)
 	if err != nil {
-		return nil, "", NewInternalError(
-			fmt.Sprintf("Failed to evaluate policy '%s'", policy.ID),
-			err.Error(),
-			err,
-		)
+		return nil, "", err
 	}
 
 	// Skip if policy is undefined
@@ -172,31 +314,22 @@ func (s *evaluationService) evaluatePolicy(
 	// 3. Check for rejection
 	if decision.Rejected {
 		log.Info("Policy rejected request", "policy_id", policy.ID, "reason", decision.RejectionReason)
+		metrics.PolicyRejections.WithLabelValues(policy.ID, policy.PolicyType).Inc()
 		return nil, "", NewPolicyRejectedError(policy.ID, decision.RejectionReason)
 	}
 
 	// 4. Validate and merge constraints — new constraints must not loosen existing ones
-	if decision.Constraints != nil {
-		if err := constraintCtx.MergeConstraints(decision.Constraints, policy.ID); err != nil {
-			var conflictErr *ConstraintConflictError
-			if errors.As(err, &conflictErr) {
-				return nil, "", NewConstraintConflictError(
-					policy.ID, conflictErr.FieldPath, conflictErr.SetByPolicy, conflictErr.Reason,
-				)
-			}
-			return nil, "", NewConstraintConflictError(policy.ID, "", "", err.Error())
-		}
-	}
-
 	// 5. Merge service provider constraints
-	if err := constraintCtx.MergeSPConstraints(decision.ServiceProviderConstraints, policy.ID); err != nil {
-		return nil, "", NewServiceProviderConstraintError(policy.ID, err.Error())
+	if err := mergeDecisionConstraints(constraintCtx, decision, policy.ID); err != nil {
+		metrics.PolicyConflicts.WithLabelValues(policy.ID, policy.PolicyType).Inc()
+		return nil, "", err
 	}
 
 	// 6. Validate patch against accumulated constraints
 	if decision.Patch != nil {
 		violations := constraintCtx.ValidatePatch(decision.Patch)
 		if len(violations) > 0 {
+			metrics.PolicyViolations.WithLabelValues(policy.ID, policy.PolicyType).Inc()
 			return nil, "", NewConstraintViolationError(policy.ID, violations)
 		}
 
@@ -211,6 +344,7 @@ func (s *evaluationService) evaluatePolicy(
 	// 8. Validate service provider against SP constraints
 	if decision.SelectedProvider != "" {
 		if err := constraintCtx.ValidateServiceProvider(decision.SelectedProvider); err != nil {
+			metrics.PolicyViolations.WithLabelValues(policy.ID, policy.PolicyType).Inc()
 			return nil, "", NewServiceProviderConstraintError(policy.ID, err.Error())
 		}
 		log.Debug("Policy selected provider", "policy_id", policy.ID, "provider", decision.SelectedProvider)
@@ -220,6 +354,85 @@ func (s *evaluationService) evaluatePolicy(
 	return currentSpec, selectedProvider, nil
 }
 
+// withDeadline returns a context bounded by the evaluation deadline of a request, if any
+func (s *evaluationService) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, s.timeout, errEvaluationDeadline)
+}
+
+// evaluate evaluates a policy with the engine, within the per-policy timeout, recording its explanation
+// with explain, if not nil, whether the evaluation succeeds or fails. A policy stopped by the per-policy
+// timeout or the evaluation deadline fails with a timeout error naming it.
+func (s *evaluationService) evaluate(ctx context.Context, policy *model.Policy, input map[string]any, explain *explainer) (*opa.EvaluationResult, error) {
+	if s.policyTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, s.policyTimeout, errPolicyTimeout)
+		defer cancel()
+	}
+
+	var opts []opa.EvalOption
+	if explain != nil {
+		opts = append(opts, opa.WithExplain(explain.mode))
+	}
+	start := time.Now()
+	evalResult, err := s.engine.EvaluatePolicy(ctx, policy.ID, input, opts...)
+	metrics.PolicyEvaluationDuration.WithLabelValues(policy.ID, policy.PolicyType).Observe(time.Since(start).Seconds())
+	if explain != nil {
+		explain.record(policy.ID, evalResult, err)
+	}
+	if err == nil {
+		return evalResult, nil
+	}
+	switch {
+	case errors.Is(err, errPolicyTimeout):
+		metrics.EvaluationTimeouts.WithLabelValues(policy.ID, policy.PolicyType, metrics.TimeoutPolicy).Inc()
+		return nil, NewPolicyTimeoutError(policy.ID, s.policyTimeout)
+	case errors.Is(err, errEvaluationDeadline):
+		metrics.EvaluationTimeouts.WithLabelValues(policy.ID, policy.PolicyType, metrics.TimeoutDeadline).Inc()
+		return nil, NewEvaluationDeadlineError(policy.ID, s.timeout)
+	}
+	return nil, NewInternalError(fmt.Sprintf("Failed to evaluate policy '%s'", policy.ID), err.Error(), err)
+}
+
+// policyInput builds the OPA input of a policy from the current spec, the selected provider and the
+// constraints set by higher-priority policies
+func policyInput(spec map[string]any, selectedProvider string, constraintCtx *ConstraintContext) map[string]any {
+	input := map[string]any{
+		"spec":     spec,
+		"provider": selectedProvider,
+	}
+	if constraints := constraintCtx.GetConstraintsMap(); constraints != nil {
+		input["constraints"] = constraints
+	}
+	if spConstraints := constraintCtx.GetSPConstraintsMap(); spConstraints != nil {
+		input["service_provider_constraints"] = spConstraints
+	}
+	return input
+}
+
+// mergeDecisionConstraints merges the constraints and service provider constraints of a policy decision
+// into the constraint context, failing if they loosen the existing ones
+func mergeDecisionConstraints(constraintCtx *ConstraintContext, decision *opa.PolicyDecision, policyID string) error {
+	if decision.Constraints != nil {
+		if err := constraintCtx.MergeConstraints(decision.Constraints, policyID); err != nil {
+			var conflictErr *ConstraintConflictError
+			if errors.As(err, &conflictErr) {
+				return NewConstraintConflictError(
+					policyID, conflictErr.FieldPath, conflictErr.SetByPolicy, conflictErr.Reason,
+				)
+			}
+			return NewConstraintConflictError(policyID, "", "", err.Error())
+		}
+	}
+
+	if err := constraintCtx.MergeSPConstraints(decision.ServiceProviderConstraints, policyID); err != nil {
+		return NewServiceProviderConstraintError(policyID, err.Error())
+	}
+	return nil
+}
+
 // mergePatch performs a recursive JSON Merge Patch (RFC 7396) of patch into base.
 // Fields in patch override fields in base. Null values in patch remove fields from base.
 // Fields not mentioned in patch are preserved from base.
@@ -254,8 +467,3 @@ func mergePatch(base, patch map[string]any) (map[string]any, error) {
 
 	return result, nil
 }
-
-// boolPtr returns a pointer to a bool value
-func boolPtr(b bool) *bool {
-	return &b
-}
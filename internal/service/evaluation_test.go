@@ -3,12 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/dcm-project/policy-manager/internal/metrics"
 	"github.com/dcm-project/policy-manager/internal/opa"
 	"github.com/dcm-project/policy-manager/internal/store"
 	"github.com/dcm-project/policy-manager/internal/store/model"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 // Test suite is registered in other test files - don't register again
@@ -17,6 +21,8 @@ import (
 type mockPolicyStore struct {
 	policies []model.Policy
 	err      error
+	// listOptions records the options of the last List call
+	listOptions *store.PolicyListOptions
 }
 
 func (m *mockPolicyStore) Create(_ context.Context, _ model.Policy) (*model.Policy, error) {
@@ -27,7 +33,8 @@ func (m *mockPolicyStore) Get(_ context.Context, _ string) (*model.Policy, error
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockPolicyStore) List(_ context.Context, _ *store.PolicyListOptions) (*store.PolicyListResult, error) {
+func (m *mockPolicyStore) List(_ context.Context, opts *store.PolicyListOptions) (*store.PolicyListResult, error) {
+	m.listOptions = opts
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -51,6 +58,14 @@ func (m *mockPolicyStore) Delete(_ context.Context, _ string) error {
 	return errors.New("not implemented")
 }
 
+func (m *mockPolicyStore) CheckUnique(_ context.Context, _ model.Policy, _ bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockPolicyStore) SetPriorities(_ context.Context, _ map[string]int32) error {
+	return errors.New("not implemented")
+}
+
 type mockEngine struct {
 	evaluations map[string]*opa.EvaluationResult
 	err         error
@@ -60,11 +75,23 @@ func (m *mockEngine) Compile(_ context.Context, _ []opa.PolicyModule) error {
 	return nil
 }
 
+func (m *mockEngine) Update(_ context.Context, _ []opa.PolicyModule, _ []opa.PolicyModule) error {
+	return nil
+}
+
 func (m *mockEngine) ValidateRego(_ context.Context, _ string) error {
 	return nil
 }
 
-func (m *mockEngine) EvaluatePolicy(_ context.Context, policyID string, _ map[string]any) (*opa.EvaluationResult, error) {
+func (m *mockEngine) CheckPolicies(_ context.Context, _ []opa.PolicyModule, _ []string) ([]opa.Diagnostic, error) {
+	return nil, nil
+}
+
+func (m *mockEngine) RunTests(_ context.Context, _ []opa.PolicyModule, _ opa.TestModule) ([]opa.TestResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockEngine) EvaluatePolicy(_ context.Context, policyID string, _ map[string]any, _ ...opa.EvalOption) (*opa.EvaluationResult, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -110,6 +137,113 @@ var _ = Describe("EvaluationService", func() {
 				Expect(response.EvaluatedServiceInstance).To(Equal(map[string]any{}))
 				Expect(response.SelectedProvider).To(Equal(""))
 			})
+
+			It("only lists the policies active at request time", func() {
+				before := time.Now()
+				_, err := service.EvaluateRequest(ctx, baseRequest)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockStore.listOptions.Filter.ActiveAt).NotTo(BeNil())
+				Expect(*mockStore.listOptions.Filter.ActiveAt).To(BeTemporally(">=", before))
+				Expect(*mockStore.listOptions.Filter.ActiveAt).To(BeTemporally("<=", time.Now()))
+			})
+
+			It("reports the compiled policy set generation", func() {
+				service = NewEvaluationService(mockStore, mockOPA, WithCompiledGeneration(func() int64 { return 5 }))
+
+				response, err := service.EvaluateRequest(ctx, baseRequest)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Generation).To(Equal(int64(5)))
+			})
+		})
+
+		Context("when explaining", func() {
+			BeforeEach(func() {
+				engine := opa.NewEngine()
+				Expect(engine.Compile(ctx, []opa.PolicyModule{
+					{ID: "first", RegoCode: "package first\nmain := {\"rejected\": false} if print(\"first evaluated\")"},
+					{ID: "second", RegoCode: "package second\nmain := {\"rejected\": false} if input.spec.region == \"eu\""},
+				})).To(Succeed())
+				service = NewEvaluationService(mockStore, engine)
+				mockStore.policies = []model.Policy{
+					{ID: "first", Enabled: true, PolicyType: "GLOBAL", Priority: 1},
+					{ID: "second", Enabled: true, PolicyType: "GLOBAL", Priority: 2},
+				}
+			})
+
+			It("returns the print output and trace of every evaluated policy in order", func() {
+				baseRequest.Explain = opa.ExplainFails
+
+				response, err := service.EvaluateRequest(ctx, baseRequest)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Explanations).To(HaveLen(2))
+				Expect(response.Explanations[0].PolicyID).To(Equal("first"))
+				Expect(response.Explanations[0].PrintOutput).To(Equal([]string{"first evaluated"}))
+				Expect(response.Explanations[1].PolicyID).To(Equal("second"))
+				Expect(response.Explanations[1].Trace).To(ContainElement(ContainSubstring("Fail")))
+			})
+
+			It("returns no explanations unless requested", func() {
+				response, err := service.EvaluateRequest(ctx, baseRequest)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Explanations).To(BeNil())
+			})
+
+			It("returns the explanations up to the failing policy with the error", func() {
+				engine := opa.NewEngine()
+				Expect(engine.Compile(ctx, []opa.PolicyModule{
+					{ID: "first", RegoCode: "package first\nmain := {\"rejected\": false} if print(\"first evaluated\")"},
+					{ID: "broken", RegoCode: "package broken\nmain := {\"rejected\": false} if print(\"broken evaluated\")\nmain := {\"rejected\": true}"},
+				})).To(Succeed())
+				service = NewEvaluationService(mockStore, engine)
+				mockStore.policies = []model.Policy{
+					{ID: "first", Enabled: true, PolicyType: "GLOBAL", Priority: 1},
+					{ID: "broken", Enabled: true, PolicyType: "GLOBAL", Priority: 2},
+				}
+				baseRequest.Explain = opa.ExplainFails
+
+				_, err := service.EvaluateRequest(ctx, baseRequest)
+
+				var serviceErr *ServiceError
+				Expect(errors.As(err, &serviceErr)).To(BeTrue())
+				Expect(serviceErr.Type).To(Equal(ErrorTypeInternal))
+				Expect(serviceErr.Explanations).To(HaveLen(2))
+				Expect(serviceErr.Explanations[0].PolicyID).To(Equal("first"))
+				Expect(serviceErr.Explanations[1].PolicyID).To(Equal("broken"))
+				Expect(serviceErr.Explanations[1].PrintOutput).To(Equal([]string{"broken evaluated"}))
+			})
+
+			It("returns the explanations with a rejection", func() {
+				engine := opa.NewEngine()
+				Expect(engine.Compile(ctx, []opa.PolicyModule{
+					{ID: "deny", RegoCode: "package deny\nmain := {\"rejected\": true, \"rejection_reason\": \"no\"} if print(\"denying\")"},
+				})).To(Succeed())
+				service = NewEvaluationService(mockStore, engine)
+				mockStore.policies = []model.Policy{{ID: "deny", Enabled: true, PolicyType: "GLOBAL", Priority: 1}}
+				baseRequest.Explain = opa.ExplainNotes
+
+				_, err := service.EvaluateRequest(ctx, baseRequest)
+
+				var serviceErr *ServiceError
+				Expect(errors.As(err, &serviceErr)).To(BeTrue())
+				Expect(serviceErr.Type).To(Equal(ErrorTypeRejected))
+				Expect(serviceErr.Explanations).To(HaveLen(1))
+				Expect(serviceErr.Explanations[0].PrintOutput).To(Equal([]string{"denying"}))
+			})
+
+			It("returns no explanations with an error unless requested", func() {
+				mockStore.policies = []model.Policy{{ID: "first", Enabled: true, PolicyType: "GLOBAL", Priority: 1}}
+				service = NewEvaluationService(mockStore, &mockEngine{err: errors.New("boom")})
+
+				_, err := service.EvaluateRequest(ctx, baseRequest)
+
+				var serviceErr *ServiceError
+				Expect(errors.As(err, &serviceErr)).To(BeTrue())
+				Expect(serviceErr.Explanations).To(BeNil())
+			})
 		})
 
 		Context("when policies don't match label selectors", func() {
@@ -229,6 +363,9 @@ var _ = Describe("EvaluationService", func() {
 			})
 
 			It("returns policy rejected error", func() {
+				counter := metrics.PolicyRejections.WithLabelValues("policy-1", "GLOBAL")
+				before := testutil.ToFloat64(counter)
+
 				_, err := service.EvaluateRequest(ctx, baseRequest)
 
 				Expect(err).To(HaveOccurred())
@@ -237,6 +374,7 @@ var _ = Describe("EvaluationService", func() {
 				Expect(serviceErr.Type).To(Equal(ErrorTypeRejected))
 				Expect(serviceErr.Message).To(ContainSubstring("policy-1"))
 				Expect(serviceErr.Detail).To(Equal("Security policy violation"))
+				Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
 			})
 		})
 
@@ -286,6 +424,9 @@ var _ = Describe("EvaluationService", func() {
 			})
 
 			It("returns policy conflict error", func() {
+				counter := metrics.PolicyViolations.WithLabelValues("policy-2", "GLOBAL")
+				before := testutil.ToFloat64(counter)
+
 				_, err := service.EvaluateRequest(ctx, baseRequest)
 
 				Expect(err).To(HaveOccurred())
@@ -293,6 +434,7 @@ var _ = Describe("EvaluationService", func() {
 				Expect(ok).To(BeTrue())
 				Expect(serviceErr.Type).To(Equal(ErrorTypePolicyConflict))
 				Expect(serviceErr.Message).To(ContainSubstring("policy-2"))
+				Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
 			})
 		})
 
@@ -342,6 +484,9 @@ var _ = Describe("EvaluationService", func() {
 			})
 
 			It("returns constraint conflict error", func() {
+				counter := metrics.PolicyConflicts.WithLabelValues("policy-2", "GLOBAL")
+				before := testutil.ToFloat64(counter)
+
 				_, err := service.EvaluateRequest(ctx, baseRequest)
 
 				Expect(err).To(HaveOccurred())
@@ -350,6 +495,7 @@ var _ = Describe("EvaluationService", func() {
 				Expect(serviceErr.Type).To(Equal(ErrorTypePolicyConflict))
 				Expect(serviceErr.Message).To(ContainSubstring("policy-2"))
 				Expect(serviceErr.Detail).To(ContainSubstring("loosen"))
+				Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
 			})
 		})
 
@@ -484,6 +630,67 @@ var _ = Describe("EvaluationService", func() {
 			})
 		})
 
+		Context("when a policy evaluation times out", func() {
+			// slowPolicy iterates over 25 million pairs, far longer than the timeouts below
+			const slowPolicy = `package slow
+main := {"rejected": count({x | some i in numbers.range(1, 5000); some j in numbers.range(1, 5000); x := i * j}) < 0}`
+
+			var engine opa.Engine
+
+			BeforeEach(func() {
+				engine = opa.NewEngine()
+				Expect(engine.Compile(ctx, []opa.PolicyModule{
+					{ID: "fast", RegoCode: "package fast\nmain := {\"rejected\": false}"},
+					{ID: "slow", RegoCode: slowPolicy},
+				})).To(Succeed())
+				mockStore.policies = []model.Policy{
+					{ID: "fast", Enabled: true, PolicyType: "GLOBAL", Priority: 1},
+					{ID: "slow", Enabled: true, PolicyType: "GLOBAL", Priority: 2},
+				}
+			})
+
+			It("fails with a timeout error naming the policy when the per-policy timeout expires", func() {
+				counter := metrics.EvaluationTimeouts.WithLabelValues("slow", "GLOBAL", metrics.TimeoutPolicy)
+				before := testutil.ToFloat64(counter)
+				service = NewEvaluationService(mockStore, engine, WithEvaluationTimeouts(20*time.Millisecond, time.Minute))
+
+				_, err := service.EvaluateRequest(ctx, baseRequest)
+
+				var serviceErr *ServiceError
+				Expect(errors.As(err, &serviceErr)).To(BeTrue())
+				Expect(serviceErr.Type).To(Equal(ErrorTypeTimeout))
+				Expect(serviceErr.Message).To(ContainSubstring("'slow'"))
+				Expect(serviceErr.Detail).To(ContainSubstring("20ms"))
+				Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+			})
+
+			It("fails with a deadline error naming the policy when the evaluation deadline expires", func() {
+				counter := metrics.EvaluationTimeouts.WithLabelValues("slow", "GLOBAL", metrics.TimeoutDeadline)
+				before := testutil.ToFloat64(counter)
+				service = NewEvaluationService(mockStore, engine, WithEvaluationTimeouts(0, 50*time.Millisecond))
+
+				_, err := service.EvaluateRequest(ctx, baseRequest)
+
+				var serviceErr *ServiceError
+				Expect(errors.As(err, &serviceErr)).To(BeTrue())
+				Expect(serviceErr.Type).To(Equal(ErrorTypeTimeout))
+				Expect(serviceErr.Message).To(Equal("Evaluation deadline exceeded while evaluating policy 'slow'"))
+				Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+			})
+
+			It("reports a canceled request as an internal error", func() {
+				service = NewEvaluationService(mockStore, engine, WithEvaluationTimeouts(time.Minute, time.Minute))
+				canceledCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+				defer cancel()
+
+				_, err := service.EvaluateRequest(canceledCtx, baseRequest)
+
+				var serviceErr *ServiceError
+				Expect(errors.As(err, &serviceErr)).To(BeTrue())
+				Expect(serviceErr.Type).To(Equal(ErrorTypeInternal))
+			})
+		})
+
 		Context("when label selector matches", func() {
 			BeforeEach(func() {
 				mockStore.policies = []model.Policy{
@@ -534,6 +741,72 @@ var _ = Describe("EvaluationService", func() {
 			})
 		})
 
+		Context("when a canary policy matches", func() {
+			var percentage int32
+
+			BeforeEach(func() {
+				percentage = 50
+				mockStore.policies = []model.Policy{
+					{
+						ID:                "canary",
+						Enabled:           true,
+						PolicyType:        "GLOBAL",
+						Priority:          100,
+						RolloutPercentage: &percentage,
+						RolloutKey:        "user",
+					},
+				}
+
+				mockOPA.evaluations["canary"] = &opa.EvaluationResult{
+					Defined: true,
+					Result: map[string]any{
+						"rejected": false,
+						"patch":    map[string]any{"region": "us-east-1"},
+					},
+				}
+			})
+
+			It("never applies a policy rolled out to no request", func() {
+				percentage = 0
+
+				response, err := service.EvaluateRequest(ctx, baseRequest)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Status).To(Equal(EvaluationStatusApproved))
+				Expect(response.CanaryPolicies).To(Equal([]CanaryPolicy{{PolicyID: "canary", RolloutPercentage: 0, Applied: false}}))
+			})
+
+			It("gives every request with the same rollout key the same result", func() {
+				applied := map[bool]int{}
+				for i := range 40 {
+					baseRequest.RequestLabels = map[string]string{"user": fmt.Sprintf("user-%d", i)}
+					first, err := service.EvaluateRequest(ctx, baseRequest)
+					Expect(err).NotTo(HaveOccurred())
+
+					baseRequest.ServiceInstance = map[string]any{"attempt": i}
+					second, err := service.EvaluateRequest(ctx, baseRequest)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(second.CanaryPolicies).To(Equal(first.CanaryPolicies))
+					applied[first.CanaryPolicies[0].Applied]++
+				}
+				Expect(applied[true]).To(BeNumerically(">", 0))
+				Expect(applied[false]).To(BeNumerically(">", 0))
+			})
+
+			It("hashes the request when it does not carry the rollout key", func() {
+				baseRequest.ServiceInstance = map[string]any{"name": "web"}
+				first, err := service.EvaluateRequest(ctx, baseRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				second, err := service.EvaluateRequest(ctx, baseRequest)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(second.CanaryPolicies).To(Equal(first.CanaryPolicies))
+				Expect(second.Status == EvaluationStatusModified).To(Equal(second.CanaryPolicies[0].Applied))
+			})
+		})
+
 		Context("when OPA input includes accumulated constraints", func() {
 			var capturedInput map[string]any
 
@@ -657,11 +930,23 @@ func (m *mockEngineWithCapture) Compile(_ context.Context, _ []opa.PolicyModule)
 	return nil
 }
 
+func (m *mockEngineWithCapture) Update(_ context.Context, _ []opa.PolicyModule, _ []opa.PolicyModule) error {
+	return nil
+}
+
 func (m *mockEngineWithCapture) ValidateRego(_ context.Context, _ string) error {
 	return nil
 }
 
-func (m *mockEngineWithCapture) EvaluatePolicy(_ context.Context, policyID string, input map[string]any) (*opa.EvaluationResult, error) {
+func (m *mockEngineWithCapture) CheckPolicies(_ context.Context, _ []opa.PolicyModule, _ []string) ([]opa.Diagnostic, error) {
+	return nil, nil
+}
+
+func (m *mockEngineWithCapture) RunTests(_ context.Context, _ []opa.PolicyModule, _ opa.TestModule) ([]opa.TestResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockEngineWithCapture) EvaluatePolicy(_ context.Context, policyID string, input map[string]any, _ ...opa.EvalOption) (*opa.EvaluationResult, error) {
 	if m.captureFunc != nil {
 		m.captureFunc(input)
 	}
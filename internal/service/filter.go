@@ -2,28 +2,91 @@ package service
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	"github.com/google/cel-go/cel"
+	celast "github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/overloads"
+	"github.com/google/cel-go/common/types"
 )
 
-var (
-	// Regex patterns for CEL filter parsing
-	policyTypePattern = regexp.MustCompile(`policy_type\s*=\s*'(GLOBAL|USER)'`)
-	enabledPattern    = regexp.MustCompile(`enabled\s*=\s*(true|false)`)
+const (
+	labelSelectorField = "label_selector"
+	// activeField is computed from enabled and the effective window at the time the filter is applied
+	activeField = "active"
 )
 
-// parseFilter parses a CEL filter expression into a PolicyFilter.
-// Supports filtering by policy_type and enabled fields.
+// filterEnv declares the policy fields that filter expressions may reference
+var filterEnv = mustFilterEnv()
+
+func mustFilterEnv() *cel.Env {
+	env, err := cel.NewEnv(
+		cel.Variable("id", cel.StringType),
+		cel.Variable("display_name", cel.StringType),
+		cel.Variable("description", cel.StringType),
+		cel.Variable("policy_type", cel.StringType),
+		cel.Variable("priority", cel.IntType),
+		cel.Variable("enabled", cel.BoolType),
+		cel.Variable("managed", cel.BoolType),
+		cel.Variable(labelSelectorField, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("create_time", cel.TimestampType),
+		cel.Variable("update_time", cel.TimestampType),
+		cel.Variable("effective_from", cel.TimestampType),
+		cel.Variable("effective_until", cel.TimestampType),
+		cel.Variable(activeField, cel.BoolType),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create filter environment: %v", err))
+	}
+	return env
+}
+
+// comparisonOps maps CEL comparison operators to store operators
+var comparisonOps = map[string]store.CompareOp{
+	operators.Equals:        store.OpEq,
+	operators.NotEquals:     store.OpNe,
+	operators.Less:          store.OpLt,
+	operators.LessEquals:    store.OpLe,
+	operators.Greater:       store.OpGt,
+	operators.GreaterEquals: store.OpGe,
+}
+
+// flippedOps maps an operator to its equivalent when the operands are swapped
+var flippedOps = map[store.CompareOp]store.CompareOp{
+	store.OpEq: store.OpEq,
+	store.OpNe: store.OpNe,
+	store.OpLt: store.OpGt,
+	store.OpLe: store.OpGe,
+	store.OpGt: store.OpLt,
+	store.OpGe: store.OpLe,
+}
+
+var matchFunctions = map[string]store.MatchKind{
+	overloads.Contains:   store.MatchContains,
+	overloads.StartsWith: store.MatchPrefix,
+	overloads.EndsWith:   store.MatchSuffix,
+}
+
+// parseFilter parses an AEP-160 CEL filter expression into a PolicyFilter.
+//
+// The expression may reference id, display_name, description, policy_type, priority, enabled,
+// managed, label_selector, create_time, update_time, effective_from, effective_until and active,
+// for example:
+//   - policy_type == 'GLOBAL' && enabled
+//   - display_name.contains('quota') || description.startsWith('Region')
+//   - priority >= 100 && priority < 200
+//   - label_selector.env == 'prod' || !('team' in label_selector)
+//   - create_time > timestamp('2026-01-01T00:00:00Z')
+//   - enabled && !active
 //
-// Supported expressions:
-//   - policy_type='GLOBAL'
-//   - policy_type='USER'
-//   - enabled=true
-//   - enabled=false
-//   - policy_type='GLOBAL' AND enabled=true
-//   - enabled=true AND policy_type='USER'
+// Policies without an effective_from or effective_until never match a condition on it.
+// The legacy syntax with `=`, AND, OR and NOT is still accepted. Conditions are translated to SQL
+// where possible; the rest of the expression is evaluated in memory.
 //
 // Returns an error for invalid filter expressions.
 func parseFilter(filterExpr string) (*store.PolicyFilter, error) {
@@ -31,40 +94,337 @@ func parseFilter(filterExpr string) (*store.PolicyFilter, error) {
 		return nil, nil
 	}
 
-	filter := &store.PolicyFilter{}
-
-	// Parse policy_type filter
-	if matches := policyTypePattern.FindStringSubmatch(filterExpr); len(matches) > 1 {
-		policyType := matches[1]
-		filter.PolicyType = &policyType
+	checked, issues := filterEnv.Compile(normalizeFilter(filterExpr))
+	if issues != nil && issues.Err() != nil {
+		return nil, NewInvalidArgumentError(
+			"Invalid filter expression",
+			fmt.Sprintf("Filter expression '%s' is invalid: %s", filterExpr, issues.Err()),
+		)
 	}
-
-	// Parse enabled filter
-	if matches := enabledPattern.FindStringSubmatch(filterExpr); len(matches) > 1 {
-		enabledStr := matches[1]
-		enabled := enabledStr == "true"
-		filter.Enabled = &enabled
+	if checked.OutputType() != cel.BoolType {
+		return nil, NewInvalidArgumentError(
+			"Invalid filter expression",
+			fmt.Sprintf("Filter expression '%s' must evaluate to a boolean", filterExpr),
+		)
 	}
 
-	// Validate that we parsed something
-	if filter.PolicyType == nil && filter.Enabled == nil {
+	expr, exact, err := translateFilter(checked.NativeRep().Expr())
+	if err != nil {
 		return nil, NewInvalidArgumentError(
 			"Invalid filter expression",
-			fmt.Sprintf("Filter expression '%s' does not contain valid conditions. Supported fields: policy_type, enabled", filterExpr),
+			fmt.Sprintf("Filter expression '%s' is invalid: %s", filterExpr, err),
 		)
 	}
 
-	// Validate AND operator usage if present
-	if strings.Contains(filterExpr, " AND ") {
-		// If AND is present, both conditions should be present
-		parts := strings.Split(filterExpr, " AND ")
-		if len(parts) != 2 {
-			return nil, NewInvalidArgumentError(
-				"Invalid filter expression",
-				"Multiple AND operators are not supported",
-			)
+	filter := &store.PolicyFilter{Expr: expr}
+	if !exact {
+		program, err := filterEnv.Program(checked)
+		if err != nil {
+			return nil, NewInternalError("Failed to build filter program", err.Error(), err)
+		}
+		filter.Match = func(p *model.Policy) bool {
+			out, _, err := program.Eval(filterActivation(p))
+			return err == nil && out == types.True
 		}
 	}
-
 	return filter, nil
 }
+
+// filterActivation exposes a policy to a filter program
+func filterActivation(p *model.Policy) map[string]any {
+	labelSelector := p.LabelSelector
+	if labelSelector == nil {
+		labelSelector = map[string]string{}
+	}
+	activation := map[string]any{
+		"id":               p.ID,
+		"display_name":     p.DisplayName,
+		"description":      p.Description,
+		"policy_type":      p.PolicyType,
+		"priority":         int64(p.Priority),
+		"enabled":          p.Enabled,
+		"managed":          p.Managed,
+		labelSelectorField: labelSelector,
+		"create_time":      p.CreateTime,
+		"update_time":      p.UpdateTime,
+		activeField:        p.ActiveAt(time.Now()),
+	}
+	// Unset bounds are left out, so that conditions on them fail to evaluate and do not match
+	if p.EffectiveFrom != nil {
+		activation["effective_from"] = *p.EffectiveFrom
+	}
+	if p.EffectiveUntil != nil {
+		activation["effective_until"] = *p.EffectiveUntil
+	}
+	return activation
+}
+
+// translateFilter translates a checked CEL expression to a store filter expression.
+// exact is false when part of the expression could not be translated; the returned expression then
+// only narrows the result and the whole CEL expression must still be evaluated.
+// Only conjunctions can be translated partially.
+func translateFilter(e celast.Expr) (expr store.FilterExpr, exact bool, err error) {
+	switch e.Kind() {
+	case celast.IdentKind:
+		// A bare boolean field, e.g. `enabled`
+		if e.AsIdent() == activeField {
+			return store.ActiveExpr{At: time.Now()}, true, nil
+		}
+		if field := e.AsIdent(); field != labelSelectorField {
+			return store.CompareExpr{Field: field, Op: store.OpEq, Value: true}, true, nil
+		}
+	case celast.SelectKind:
+		if key, ok := labelKey(e); ok && e.AsSelect().IsTestOnly() {
+			return store.LabelExpr{Key: key}, true, nil
+		}
+	case celast.CallKind:
+		return translateCall(e.AsCall())
+	}
+	return nil, false, nil
+}
+
+func translateCall(call celast.CallExpr) (store.FilterExpr, bool, error) {
+	args := call.Args()
+	switch fn := call.FunctionName(); fn {
+	case operators.LogicalAnd:
+		var exprs []store.FilterExpr
+		exact := true
+		for _, arg := range args {
+			expr, argExact, err := translateFilter(arg)
+			if err != nil {
+				return nil, false, err
+			}
+			exact = exact && argExact
+			if expr != nil {
+				exprs = append(exprs, expr)
+			}
+		}
+		if len(exprs) == 0 {
+			return nil, false, nil
+		}
+		return store.AndExpr{Exprs: exprs}, exact, nil
+	case operators.LogicalOr:
+		exprs := make([]store.FilterExpr, 0, len(args))
+		for _, arg := range args {
+			expr, exact, err := translateFilter(arg)
+			if err != nil || !exact {
+				return nil, false, err
+			}
+			exprs = append(exprs, expr)
+		}
+		return store.OrExpr{Exprs: exprs}, true, nil
+	case operators.LogicalNot:
+		expr, exact, err := translateFilter(args[0])
+		if err != nil || !exact {
+			return nil, false, err
+		}
+		return store.NotExpr{Expr: expr}, true, nil
+	case operators.In:
+		return translateIn(args[0], args[1])
+	default:
+		if op, ok := comparisonOps[fn]; ok {
+			return translateComparison(op, args[0], args[1])
+		}
+		if kind, ok := matchFunctions[fn]; ok && call.IsMemberFunction() {
+			field, fieldOK := columnField(call.Target())
+			value, valueOK := literalValue(args[0])
+			if fieldOK && valueOK {
+				if s, ok := value.(string); ok {
+					return store.MatchExpr{Field: field, Kind: kind, Value: s}, true, nil
+				}
+			}
+		}
+	}
+	return nil, false, nil
+}
+
+func translateComparison(op store.CompareOp, lhs, rhs celast.Expr) (store.FilterExpr, bool, error) {
+	value, ok := literalValue(rhs)
+	if !ok {
+		if value, ok = literalValue(lhs); !ok {
+			return nil, false, nil
+		}
+		lhs = rhs
+		op = flippedOps[op]
+	}
+
+	if key, ok := labelKey(lhs); ok {
+		s, isString := value.(string)
+		if !isString || (op != store.OpEq && op != store.OpNe) {
+			return nil, false, nil
+		}
+		return store.LabelExpr{Key: key, Op: op, Value: &s}, true, nil
+	}
+
+	field, ok := columnField(lhs)
+	if !ok {
+		return nil, false, nil
+	}
+	if err := validateFilterValue(field, value); err != nil {
+		return nil, false, err
+	}
+	return store.CompareExpr{Field: field, Op: op, Value: value}, true, nil
+}
+
+func translateIn(element, collection celast.Expr) (store.FilterExpr, bool, error) {
+	// 'key' in label_selector
+	if collection.Kind() == celast.IdentKind && collection.AsIdent() == labelSelectorField {
+		if key, ok := literalValue(element); ok {
+			if s, ok := key.(string); ok {
+				return store.LabelExpr{Key: s}, true, nil
+			}
+		}
+		return nil, false, nil
+	}
+
+	// field in [values]
+	field, ok := columnField(element)
+	if !ok || collection.Kind() != celast.ListKind {
+		return nil, false, nil
+	}
+	elements := collection.AsList().Elements()
+	values := make([]any, 0, len(elements))
+	for _, el := range elements {
+		value, ok := literalValue(el)
+		if !ok {
+			return nil, false, nil
+		}
+		if err := validateFilterValue(field, value); err != nil {
+			return nil, false, err
+		}
+		values = append(values, value)
+	}
+	return store.InExpr{Field: field, Values: values}, true, nil
+}
+
+// columnField returns the column referenced by an identifier
+func columnField(e celast.Expr) (string, bool) {
+	if e.Kind() != celast.IdentKind || e.AsIdent() == labelSelectorField || e.AsIdent() == activeField {
+		return "", false
+	}
+	return e.AsIdent(), true
+}
+
+// labelKey returns the label key referenced by label_selector.key or label_selector['key']
+func labelKey(e celast.Expr) (string, bool) {
+	switch e.Kind() {
+	case celast.SelectKind:
+		sel := e.AsSelect()
+		if sel.Operand().Kind() == celast.IdentKind && sel.Operand().AsIdent() == labelSelectorField {
+			return sel.FieldName(), true
+		}
+	case celast.CallKind:
+		call := e.AsCall()
+		if call.FunctionName() != operators.Index {
+			return "", false
+		}
+		operand := call.Args()[0]
+		if operand.Kind() != celast.IdentKind || operand.AsIdent() != labelSelectorField {
+			return "", false
+		}
+		if key, ok := literalValue(call.Args()[1]); ok {
+			s, ok := key.(string)
+			return s, ok
+		}
+	}
+	return "", false
+}
+
+// literalValue returns the Go value of a constant expression: a literal or timestamp('...')
+func literalValue(e celast.Expr) (any, bool) {
+	switch e.Kind() {
+	case celast.LiteralKind:
+		switch v := e.AsLiteral().(type) {
+		case types.String:
+			return string(v), true
+		case types.Int:
+			return int64(v), true
+		case types.Bool:
+			return bool(v), true
+		}
+	case celast.CallKind:
+		call := e.AsCall()
+		if call.FunctionName() != overloads.TypeConvertTimestamp || len(call.Args()) != 1 {
+			return nil, false
+		}
+		value, ok := literalValue(call.Args()[0])
+		if !ok {
+			return nil, false
+		}
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, false
+		}
+		return t.UTC(), true
+	}
+	return nil, false
+}
+
+// validateFilterValue rejects values that can never match, such as unknown policy types
+func validateFilterValue(field string, value any) error {
+	if field == "policy_type" && value != "GLOBAL" && value != "USER" {
+		return fmt.Errorf("policy_type must be 'GLOBAL' or 'USER', got '%v'", value)
+	}
+	return nil
+}
+
+// normalizeFilter rewrites the legacy filter syntax (`=`, AND, OR, NOT) to CEL. String literals are left untouched.
+func normalizeFilter(filterExpr string) string {
+	var out strings.Builder
+	runes := []rune(filterExpr)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'' || r == '"':
+			// Copy the string literal, honouring escapes
+			out.WriteRune(r)
+			for i++; i < len(runes); i++ {
+				out.WriteRune(runes[i])
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					out.WriteRune(runes[i])
+					continue
+				}
+				if runes[i] == r {
+					break
+				}
+			}
+		case r == '=':
+			prevOp := i > 0 && strings.ContainsRune("=!<>", runes[i-1])
+			nextEq := i+1 < len(runes) && runes[i+1] == '='
+			if prevOp || nextEq {
+				out.WriteRune(r)
+				if nextEq {
+					i++
+					out.WriteRune('=')
+				}
+			} else {
+				out.WriteString("==")
+			}
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			word := string(runes[i:j])
+			switch word {
+			case "AND":
+				out.WriteString("&&")
+			case "OR":
+				out.WriteString("||")
+			case "NOT":
+				out.WriteString("!")
+			default:
+				out.WriteString(word)
+			}
+			i = j - 1
+		default:
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
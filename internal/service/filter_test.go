@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("PolicyService CEL filters", func() {
+	var (
+		db            *gorm.DB
+		policyService service.PolicyService
+		ctx           context.Context
+	)
+
+	listIDs := func(filter string) []string {
+		GinkgoHelper()
+		result, err := policyService.ListPolicies(ctx, &filter, nil, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		ids := make([]string, len(result.Policies))
+		for i, p := range result.Policies {
+			ids[i] = *p.Id
+		}
+		return ids
+	}
+
+	expectInvalid := func(filter string) {
+		GinkgoHelper()
+		_, err := policyService.ListPolicies(ctx, &filter, nil, nil, nil)
+		Expect(err).To(HaveOccurred())
+		serviceErr, ok := err.(*service.ServiceError)
+		Expect(ok).To(BeTrue())
+		Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
+		policyService = service.NewPolicyService(store.NewStore(db), opa.NewEngine())
+		ctx = context.Background()
+
+		policies := []struct {
+			id            string
+			displayName   string
+			description   string
+			policyType    v1alpha1.PolicyPolicyType
+			enabled       bool
+			priority      int32
+			labelSelector map[string]string
+		}{
+			{"region", "Region Enforcement", "Restrict regions", v1alpha1.GLOBAL, true, 100, map[string]string{"env": "prod"}},
+			{"quota", "Team Quota", "CPU quota per team", v1alpha1.USER, true, 150, map[string]string{"env": "dev", "team": "a"}},
+			{"cost", "Cost Guard", "", v1alpha1.GLOBAL, false, 300, nil},
+		}
+		for _, p := range policies {
+			policy := v1alpha1.Policy{
+				DisplayName: strPtr(p.displayName),
+				PolicyType:  policyTypePtr(p.policyType),
+				RegoCode:    strPtr("package " + p.id + "\nmain := {\"rejected\": false}"),
+				Enabled:     &p.enabled,
+				Priority:    &p.priority,
+			}
+			if p.description != "" {
+				policy.Description = strPtr(p.description)
+			}
+			if p.labelSelector != nil {
+				policy.LabelSelector = &p.labelSelector
+			}
+			id := p.id
+			_, err := policyService.CreatePolicy(ctx, policy, &id, service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	It("matches substrings, prefixes and suffixes case-sensitively", func() {
+		Expect(listIDs("display_name.contains('Quota')")).To(ConsistOf("quota"))
+		Expect(listIDs("display_name.contains('quota')")).To(BeEmpty())
+		Expect(listIDs("description.startsWith('Restrict')")).To(ConsistOf("region"))
+		Expect(listIDs("display_name.endsWith('Guard')")).To(ConsistOf("cost"))
+	})
+
+	It("supports priority ranges", func() {
+		Expect(listIDs("priority >= 100 && priority < 200")).To(ConsistOf("region", "quota"))
+		Expect(listIDs("200 < priority")).To(ConsistOf("cost"))
+	})
+
+	It("supports OR, NOT and IN", func() {
+		Expect(listIDs("policy_type == 'USER' || !enabled")).To(ConsistOf("quota", "cost"))
+		Expect(listIDs("!(policy_type == 'GLOBAL')")).To(ConsistOf("quota"))
+		Expect(listIDs("id in ['region', 'cost']")).To(ConsistOf("region", "cost"))
+	})
+
+	It("matches label selector keys and values", func() {
+		Expect(listIDs("label_selector.env == 'prod'")).To(ConsistOf("region"))
+		Expect(listIDs("label_selector['env'] != 'prod'")).To(ConsistOf("quota"))
+		Expect(listIDs("'team' in label_selector")).To(ConsistOf("quota"))
+		Expect(listIDs("has(label_selector.env)")).To(ConsistOf("region", "quota"))
+		Expect(listIDs("!has(label_selector.env)")).To(ConsistOf("cost"))
+	})
+
+	It("compares create_time and update_time with timestamps", func() {
+		past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		Expect(listIDs("create_time > timestamp('" + past + "')")).To(HaveLen(3))
+		Expect(listIDs("update_time > timestamp('" + future + "')")).To(BeEmpty())
+	})
+
+	It("filters by the effective window and the computed active state", func() {
+		past := time.Now().Add(-time.Hour)
+		future := time.Now().Add(time.Hour)
+		_, err := policyService.UpdatePolicy(ctx, "region", &v1alpha1.Policy{EffectiveUntil: &past}, service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = policyService.UpdatePolicy(ctx, "quota", &v1alpha1.Policy{EffectiveFrom: &past, EffectiveUntil: &future}, service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(listIDs("active")).To(ConsistOf("quota"))
+		Expect(listIDs("enabled && !active")).To(ConsistOf("region"))
+		Expect(listIDs("active == false")).To(ConsistOf("region", "cost"))
+		Expect(listIDs("effective_until < timestamp('" + future.UTC().Format(time.RFC3339) + "')")).To(ConsistOf("region"))
+		Expect(listIDs("effective_from.getFullYear() > 2000")).To(ConsistOf("quota"))
+	})
+
+	It("evaluates conditions that cannot be translated to SQL in memory", func() {
+		Expect(listIDs("display_name.size() > 10 && enabled")).To(ConsistOf("region"))
+		Expect(listIDs("label_selector.exists(k, k == 'team')")).To(ConsistOf("quota"))
+	})
+
+	It("paginates in-memory matches", func() {
+		filter := "display_name.size() > 0"
+		pageSize := int32(2)
+		result, err := policyService.ListPolicies(ctx, &filter, nil, nil, &pageSize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Policies).To(HaveLen(2))
+		Expect(result.NextPageToken).NotTo(BeNil())
+
+		result, err = policyService.ListPolicies(ctx, &filter, nil, result.NextPageToken, &pageSize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Policies).To(HaveLen(1))
+		Expect(result.NextPageToken).To(BeNil())
+	})
+
+	It("accepts the legacy syntax", func() {
+		Expect(listIDs("policy_type='GLOBAL' AND enabled=true")).To(ConsistOf("region"))
+		Expect(listIDs("policy_type='USER' OR NOT enabled=true")).To(ConsistOf("quota", "cost"))
+		Expect(listIDs("display_name='Team Quota' AND enabled=true AND priority=150")).To(ConsistOf("quota"))
+	})
+
+	It("rejects invalid expressions", func() {
+		expectInvalid("invalid_field == 'value'")
+		expectInvalid("priority == 'high'")
+		expectInvalid("display_name")
+		expectInvalid("policy_type == 'TENANT'")
+		expectInvalid("enabled ==")
+	})
+})
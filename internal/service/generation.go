@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dcm-project/policy-manager/internal/logging"
+)
+
+// engineGeneration is the generation of the stored policy set that the engine is compiled from. Every
+// write to the policy set advances the stored generation; a replica whose compiled generation is behind
+// it recompiles the engine from the store.
+type engineGeneration struct {
+	// mu serializes the writes changing the engine and advancing the generation with synchronizing the
+	// engine
+	mu       sync.Mutex
+	compiled atomic.Int64
+}
+
+// CompileAll loads all policies from the store and compiles them into the engine, recording the policy
+// set generation it is compiled from.
+func (s *PolicyServiceImpl) CompileAll(ctx context.Context) error {
+	g := s.generation
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	generation, err := s.compileStored(ctx)
+	if err != nil {
+		return err
+	}
+	g.compiled.Store(generation)
+	// Records the stored policy set that later events are relative to
+	s.publishEvents(ctx, false)
+	return nil
+}
+
+// SyncEngine recompiles the engine when the stored policy set is at a newer generation than the
+// compiled one, as after a change made through another replica. Watches receive the events of the
+// changes; webhooks are left to the replica that made them.
+func (s *PolicyServiceImpl) SyncEngine(ctx context.Context) error {
+	g := s.generation
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	current, err := s.store.Generation().Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read the policy set generation: %w", err)
+	}
+	compiled := g.compiled.Load()
+	if current <= compiled {
+		return nil
+	}
+
+	generation, err := s.compileStored(ctx)
+	if err != nil {
+		return err
+	}
+	g.compiled.Store(generation)
+	s.publishEvents(ctx, false)
+	logging.FromContext(ctx).Info("Engine synchronized with the stored policy set", "previous_generation", compiled, "generation", generation)
+	return nil
+}
+
+// CompiledGeneration returns the generation of the policy set the engine is compiled from
+func (s *PolicyServiceImpl) CompiledGeneration() int64 {
+	return s.generation.compiled.Load()
+}
+
+// compileStored compiles the stored policies and libraries into the engine and returns the policy set
+// generation they were read at. The generation is read first, so that a concurrent change yields a
+// newer generation and is compiled on the next synchronization.
+func (s *PolicyServiceImpl) compileStored(ctx context.Context) (int64, error) {
+	generation, err := s.store.Generation().Get(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the policy set generation: %w", err)
+	}
+	modules, err := s.storedModules(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list policies for recompilation: %w", err)
+	}
+	if err := s.engine.Compile(ctx, modules); err != nil {
+		return 0, err
+	}
+	return generation, nil
+}
+
+// lockGeneration takes the generation lock and returns the function releasing it. A write to the
+// policy set holds it from the change of the engine until the generation is advanced, so that no
+// synchronization compiles the engine from a policy set read before the write in between and then
+// reports the generation of the write as compiled. The service bound to a transaction has no lock.
+func (s *PolicyServiceImpl) lockGeneration() func() {
+	g := s.generation
+	if g == nil {
+		return func() {}
+	}
+	g.mu.Lock()
+	return g.mu.Unlock
+}
+
+// advanceGeneration advances the stored policy set generation after a write. The compiled generation
+// follows when the engine was at the previous generation; otherwise a change made through another
+// replica is missing from the engine and the next synchronization recompiles it. The service bound to
+// a transaction does not advance the generation; the service that commits it does. Must be called with
+// the generation lock held.
+func (s *PolicyServiceImpl) advanceGeneration(ctx context.Context) {
+	g := s.generation
+	if g == nil {
+		return
+	}
+
+	next, err := s.store.Generation().Increment(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to advance the policy set generation", "error", err)
+		return
+	}
+	g.compiled.CompareAndSwap(next-1, next)
+}
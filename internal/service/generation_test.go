@@ -0,0 +1,201 @@
+package service_test
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// pausingCompileEngine holds a compilation started while pause is set until release is closed
+type pausingCompileEngine struct {
+	opa.Engine
+	pause     atomic.Bool
+	compiling chan struct{}
+	release   chan struct{}
+}
+
+func (e *pausingCompileEngine) Compile(ctx context.Context, policies []opa.PolicyModule) error {
+	if e.pause.Load() {
+		close(e.compiling)
+		<-e.release
+	}
+	return e.Engine.Compile(ctx, policies)
+}
+
+var _ = Describe("PolicyService engine synchronization", func() {
+	var (
+		db        *gorm.DB
+		dataStore store.Store
+		ctx       context.Context
+
+		// Two replicas sharing the database, each with its own engine
+		engineA, engineB   opa.Engine
+		replicaA, replicaB *service.PolicyServiceImpl
+	)
+
+	create := func(replica *service.PolicyServiceImpl, id string, priority int32) {
+		GinkgoHelper()
+		_, err := replica.CreatePolicy(ctx, v1alpha1.Policy{
+			DisplayName: strPtr(id),
+			PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+			Priority:    &priority,
+			RegoCode:    strPtr("package policies." + id + "\n\nmain := {\"rejected\": false}\n"),
+		}, strPtr(id), service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	defined := func(engine opa.Engine, id string) bool {
+		GinkgoHelper()
+		result, err := engine.EvaluatePolicy(ctx, id, map[string]any{})
+		Expect(err).NotTo(HaveOccurred())
+		return result.Defined
+	}
+
+	storedGeneration := func() int64 {
+		GinkgoHelper()
+		generation, err := dataStore.Generation().Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		return generation
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
+
+		ctx = context.Background()
+		dataStore = store.NewStore(db)
+		engineA, engineB = opa.NewEngine(), opa.NewEngine()
+		replicaA = service.NewPolicyService(dataStore, engineA)
+		replicaB = service.NewPolicyService(dataStore, engineB)
+		Expect(replicaA.CompileAll(ctx)).To(Succeed())
+		Expect(replicaB.CompileAll(ctx)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	It("advances the generation on every change of the policy set", func() {
+		Expect(replicaA.CompiledGeneration()).To(Equal(int64(0)))
+
+		create(replicaA, "first", 10)
+		Expect(storedGeneration()).To(Equal(int64(1)))
+		Expect(replicaA.CompiledGeneration()).To(Equal(int64(1)))
+
+		_, err := replicaA.UpdatePolicy(ctx, "first", &v1alpha1.Policy{Enabled: boolPtr(false)}, service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replicaA.DeletePolicy(ctx, "first")).To(Succeed())
+		Expect(storedGeneration()).To(Equal(int64(3)))
+		Expect(replicaA.CompiledGeneration()).To(Equal(int64(3)))
+	})
+
+	It("does not advance the generation for a change that is rolled back", func() {
+		create(replicaA, "first", 10)
+
+		_, err := replicaA.CreatePolicy(ctx, v1alpha1.Policy{
+			DisplayName: strPtr("broken"),
+			PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+			Priority:    int32Ptr(20),
+			RegoCode:    strPtr("package policies.broken\n{invalid"),
+		}, strPtr("broken"), service.PolicyWriteOptions{})
+		Expect(err).To(HaveOccurred())
+		Expect(storedGeneration()).To(Equal(int64(1)))
+	})
+
+	It("recompiles a replica after a change made through another replica", func() {
+		create(replicaA, "first", 10)
+		Expect(defined(engineB, "first")).To(BeFalse())
+		Expect(replicaB.CompiledGeneration()).To(Equal(int64(0)))
+
+		Expect(replicaB.SyncEngine(ctx)).To(Succeed())
+		Expect(defined(engineB, "first")).To(BeTrue())
+		Expect(replicaB.CompiledGeneration()).To(Equal(int64(1)))
+	})
+
+	It("sends watch events for the changes it synchronizes", func() {
+		w, err := replicaB.WatchPolicies(ctx, "")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(w.Close)
+
+		create(replicaA, "first", 10)
+		Expect(replicaB.SyncEngine(ctx)).To(Succeed())
+
+		var event service.PolicyEvent
+		Eventually(w.Events).Should(Receive(&event))
+		Expect(event.ChangeType).To(Equal(service.PolicyCreated))
+		Expect(event.PolicyID).To(Equal("first"))
+	})
+
+	It("catches up when its own change follows one made through another replica", func() {
+		create(replicaB, "first", 10)
+		create(replicaA, "second", 20)
+
+		// The engine of replica A lacks the first policy, so it stays behind the stored generation
+		Expect(defined(engineA, "first")).To(BeFalse())
+		Expect(replicaA.CompiledGeneration()).To(Equal(int64(0)))
+
+		Expect(replicaA.SyncEngine(ctx)).To(Succeed())
+		Expect(defined(engineA, "first")).To(BeTrue())
+		Expect(defined(engineA, "second")).To(BeTrue())
+		Expect(replicaA.CompiledGeneration()).To(Equal(int64(2)))
+	})
+
+	It("keeps a local change made while synchronizing the engine", func() {
+		// A single connection shares the in-memory database between the goroutines
+		sqlDB, err := db.DB()
+		Expect(err).NotTo(HaveOccurred())
+		sqlDB.SetMaxOpenConns(1)
+
+		paused := &pausingCompileEngine{Engine: engineA, compiling: make(chan struct{}), release: make(chan struct{})}
+		replicaA = service.NewPolicyService(dataStore, paused)
+		Expect(replicaA.CompileAll(ctx)).To(Succeed())
+		create(replicaB, "remote", 10)
+
+		// The synchronization reads the policy set and then waits in the compilation
+		paused.pause.Store(true)
+		synced := make(chan error, 1)
+		go func() { synced <- replicaA.SyncEngine(ctx) }()
+		Eventually(paused.compiling).Should(BeClosed())
+		paused.pause.Store(false)
+
+		created := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(created)
+			create(replicaA, "local", 20)
+		}()
+		Consistently(created, "100ms").ShouldNot(BeClosed())
+
+		close(paused.release)
+		Eventually(synced).Should(Receive(BeNil()))
+		Eventually(created).Should(BeClosed())
+
+		// A synchronization at the current generation keeps the engine as it is, so a local change
+		// dropped by the concurrent synchronization would stay missing
+		Expect(replicaA.SyncEngine(ctx)).To(Succeed())
+		Expect(replicaA.CompiledGeneration()).To(Equal(storedGeneration()))
+		Expect(defined(paused, "remote")).To(BeTrue())
+		Expect(defined(paused, "local")).To(BeTrue())
+	})
+
+	It("does nothing when the engine is compiled from the current generation", func() {
+		create(replicaA, "first", 10)
+		Expect(replicaA.SyncEngine(ctx)).To(Succeed())
+		Expect(replicaA.CompiledGeneration()).To(Equal(int64(1)))
+	})
+})
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+)
+
+// checkLibrarySet compiles the given modules, the stored policy set with a library added, changed or
+// removed, so that a library change that breaks the policies depending on it is rejected before anything
+// is written
+func (s *PolicyServiceImpl) checkLibrarySet(ctx context.Context, modules []opa.PolicyModule) error {
+	if _, err := s.engine.CheckPolicies(ctx, modules, nil); err != nil {
+		var compileErr *opa.CompileError
+		if errors.As(err, &compileErr) {
+			return NewPolicySetCompileError(compileErr)
+		}
+		return handleEngineError(err, "validate")
+	}
+	return nil
+}
+
+// libraryPackage validates the Rego code of a library and returns its package
+func (s *PolicyServiceImpl) libraryPackage(ctx context.Context, regoCode string, operation string) (string, error) {
+	if err := s.engine.ValidateRego(ctx, regoCode); err != nil {
+		return "", handleEngineError(err, operation)
+	}
+	pkg, err := opa.ModulePackage(regoCode)
+	if err != nil {
+		return "", handleEngineError(err, operation)
+	}
+	return pkg, nil
+}
+
+// CreateLibrary creates a new library module.
+func (s *PolicyServiceImpl) CreateLibrary(ctx context.Context, library v1alpha1.Library, clientID *string) (*v1alpha1.Library, error) {
+	if err := s.checkDirectLibraryWrite(); err != nil {
+		return nil, err
+	}
+	libraryID, err := getResourceID(clientID, "library", "Library")
+	if err != nil {
+		return nil, err
+	}
+	return s.createLibrary(ctx, library, *libraryID, false)
+}
+
+// createLibrary creates a library with the given ID without the approval check, for direct writes and
+// approved proposals. With validateOnly, the library is validated against the policy set but not stored.
+func (s *PolicyServiceImpl) createLibrary(ctx context.Context, library v1alpha1.Library, libraryID string, validateOnly bool) (*v1alpha1.Library, error) {
+	if library.RegoCode == nil || strings.TrimSpace(*library.RegoCode) == "" {
+		return nil, NewInvalidArgumentError(
+			"rego_code is required",
+			"The rego_code field must be present and non-empty",
+		)
+	}
+
+	log := logging.FromContext(ctx)
+	log.Debug("Creating library", "library_id", libraryID)
+
+	dbLibrary := LibraryAPIToDBModel(library, libraryID)
+	var err error
+	dbLibrary.Package, err = s.libraryPackage(ctx, dbLibrary.RegoCode, "create")
+	if err != nil {
+		return nil, err
+	}
+
+	// Compile the policy set including the new library
+	modules, err := s.storedModules(ctx)
+	if err != nil {
+		return nil, NewInternalError("Failed to list policies", err.Error(), err)
+	}
+	modules = append(withoutModule(modules, dbLibrary.ID, true), opa.PolicyModule{ID: dbLibrary.ID, RegoCode: dbLibrary.RegoCode, Library: true})
+	if err := s.checkLibrarySet(ctx, modules); err != nil {
+		return nil, err
+	}
+	if validateOnly {
+		apiLibrary := LibraryDBToAPIModel(&dbLibrary)
+		return &apiLibrary, nil
+	}
+
+	created, err := s.store.Library().Create(ctx, dbLibrary)
+	if err != nil {
+		log.Error("Failed to create library in store", "library_id", libraryID, "error", err)
+		return nil, processLibraryStoreError(err, libraryID, "create")
+	}
+
+	if err := s.recompileEngine(ctx, nil); err != nil {
+		log.Error("Failed to recompile engine after library create, rolling back DB", "library_id", libraryID, "error", err)
+		if delErr := s.store.Library().Delete(ctx, libraryID); delErr != nil {
+			log.Error("Failed to rollback DB library after compile failure",
+				"library_id", libraryID,
+				"db_error", delErr,
+				"compile_error", err)
+		}
+		return nil, NewInternalError("Failed to compile policies after library create", err.Error(), err)
+	}
+
+	apiLibrary := LibraryDBToAPIModel(created)
+	log.Debug("Library created successfully", "library_id", libraryID)
+	return &apiLibrary, nil
+}
+
+// GetLibrary retrieves a library by ID.
+func (s *PolicyServiceImpl) GetLibrary(ctx context.Context, id string) (*v1alpha1.Library, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Getting library", "library_id", id)
+
+	dbLibrary, err := s.store.Library().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrLibraryNotFound) {
+			return nil, NewLibraryNotFoundError(id)
+		}
+		log.Error("Failed to get library from store", "library_id", id, "error", err)
+		return nil, NewInternalError("Failed to get library", err.Error(), err)
+	}
+
+	apiLibrary := LibraryDBToAPIModel(dbLibrary)
+	return &apiLibrary, nil
+}
+
+// ListLibraries lists all libraries ordered by ID.
+func (s *PolicyServiceImpl) ListLibraries(ctx context.Context) (*v1alpha1.LibraryList, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Listing libraries")
+
+	libraries, err := s.store.Library().ListAll(ctx)
+	if err != nil {
+		log.Error("Failed to list libraries from store", "error", err)
+		return nil, NewInternalError("Failed to list libraries", err.Error(), err)
+	}
+
+	apiLibraries := make([]v1alpha1.Library, len(libraries))
+	for i := range libraries {
+		apiLibraries[i] = LibraryDBToAPIModel(&libraries[i])
+	}
+	return &v1alpha1.LibraryList{Libraries: apiLibraries}, nil
+}
+
+// UpdateLibrary updates an existing library using partial merge (PATCH). Read-only fields in the patch are ignored.
+func (s *PolicyServiceImpl) UpdateLibrary(ctx context.Context, id string, patch *v1alpha1.Library) (*v1alpha1.Library, error) {
+	if err := s.checkDirectLibraryWrite(); err != nil {
+		return nil, err
+	}
+	return s.updateLibrary(ctx, id, patch, false)
+}
+
+// updateLibrary updates a library without the approval check, for direct writes and approved proposals.
+// With validateOnly, the change is validated against the policy set but not stored.
+func (s *PolicyServiceImpl) updateLibrary(ctx context.Context, id string, patch *v1alpha1.Library, validateOnly bool) (*v1alpha1.Library, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Updating library", "library_id", id)
+
+	if patch != nil && patch.RegoCode != nil && strings.TrimSpace(*patch.RegoCode) == "" {
+		return nil, NewInvalidArgumentError(
+			"rego_code cannot be empty",
+			"When rego_code is provided in the patch it must be non-empty",
+		)
+	}
+
+	existing, err := s.existingLibrary(ctx, id, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	merged := *existing
+	regoChanged := false
+	if patch != nil {
+		if patch.DisplayName != nil {
+			merged.DisplayName = *patch.DisplayName
+		}
+		if patch.Description != nil {
+			merged.Description = *patch.Description
+		}
+		if patch.RegoCode != nil {
+			merged.RegoCode = *patch.RegoCode
+			regoChanged = true
+		}
+	}
+
+	if regoChanged {
+		merged.Package, err = s.libraryPackage(ctx, merged.RegoCode, "update")
+		if err != nil {
+			return nil, err
+		}
+
+		// Compile the policy set with the changed library, rejecting changes that break dependent policies
+		modules, err := s.storedModules(ctx)
+		if err != nil {
+			return nil, NewInternalError("Failed to list policies", err.Error(), err)
+		}
+		modules = append(withoutModule(modules, id, true), opa.PolicyModule{ID: id, RegoCode: merged.RegoCode, Library: true})
+		if err := s.checkLibrarySet(ctx, modules); err != nil {
+			return nil, err
+		}
+	}
+	if validateOnly {
+		apiLibrary := LibraryDBToAPIModel(&merged)
+		return &apiLibrary, nil
+	}
+
+	updated, err := s.store.Library().Update(ctx, merged)
+	if err != nil {
+		log.Error("Failed to update library in store", "library_id", id, "error", err)
+		return nil, processLibraryStoreError(err, id, "update")
+	}
+
+	if regoChanged {
+		if err := s.recompileEngine(ctx, nil); err != nil {
+			log.Error("Failed to recompile engine after library update, rolling back DB", "library_id", id, "error", err)
+			if _, rollbackErr := s.store.Library().Update(ctx, *existing); rollbackErr != nil {
+				log.Error("Failed to rollback DB library after compile failure",
+					"library_id", id,
+					"db_error", rollbackErr,
+					"compile_error", err)
+			}
+			return nil, NewInternalError("Failed to compile policies after library update", err.Error(), err)
+		}
+	}
+
+	apiLibrary := LibraryDBToAPIModel(updated)
+	log.Debug("Library updated successfully", "library_id", id)
+	return &apiLibrary, nil
+}
+
+// DeleteLibrary deletes a library by ID. The deletion is rejected while policies depend on the library.
+func (s *PolicyServiceImpl) DeleteLibrary(ctx context.Context, id string) error {
+	if err := s.checkDirectLibraryWrite(); err != nil {
+		return err
+	}
+	return s.deleteLibrary(ctx, id, false)
+}
+
+// deleteLibrary deletes a library without the approval check, for direct writes and approved proposals.
+// With validateOnly, only the policies depending on the library are checked.
+func (s *PolicyServiceImpl) deleteLibrary(ctx context.Context, id string, validateOnly bool) error {
+	log := logging.FromContext(ctx)
+	log.Debug("Deleting library", "library_id", id)
+
+	if _, err := s.existingLibrary(ctx, id, "delete"); err != nil {
+		return err
+	}
+
+	// Compile the policy set without the library to find the policies that still depend on it
+	modules, err := s.storedModules(ctx)
+	if err != nil {
+		return NewInternalError("Failed to list policies", err.Error(), err)
+	}
+	if _, err := s.engine.CheckPolicies(ctx, withoutModule(modules, id, true), nil); err != nil {
+		var compileErr *opa.CompileError
+		if errors.As(err, &compileErr) {
+			return NewLibraryInUseError(id, compileErr)
+		}
+		return handleEngineError(err, "delete")
+	}
+	if validateOnly {
+		return nil
+	}
+
+	if err := s.store.Library().Delete(ctx, id); err != nil {
+		log.Error("Failed to delete library from store", "library_id", id, "error", err)
+		return processLibraryStoreError(err, id, "delete")
+	}
+
+	if err := s.recompileEngine(ctx, nil); err != nil {
+		log.Warn("Failed to recompile engine after library delete", "library_id", id, "error", err)
+	}
+
+	log.Debug("Library deleted successfully", "library_id", id)
+	return nil
+}
+
+// existingLibrary returns the stored library that an operation changes
+func (s *PolicyServiceImpl) existingLibrary(ctx context.Context, id string, operation string) (*model.Library, error) {
+	existing, err := s.store.Library().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrLibraryNotFound) {
+			return nil, NewLibraryNotFoundError(id)
+		}
+		logging.FromContext(ctx).Error("Failed to get existing library for "+operation, "library_id", id, "error", err)
+		return nil, NewInternalError("Failed to get existing library", err.Error(), err)
+	}
+	return existing, nil
+}
+
+// libraryModules returns the modules of the given libraries
+func libraryModules(libraries model.LibraryList) []opa.PolicyModule {
+	modules := make([]opa.PolicyModule, len(libraries))
+	for i, l := range libraries {
+		modules[i] = opa.PolicyModule{ID: l.ID, RegoCode: l.RegoCode, Library: true}
+	}
+	return modules
+}
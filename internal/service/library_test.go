@@ -0,0 +1,170 @@
+package service_test
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	regionsLibrary = `package lib.regions
+
+allowed(region) if region in {"us-east-1", "eu-west-1"}
+`
+	regionsPolicy = `package policies.regions
+
+import data.lib.regions
+
+default rejected := false
+
+rejected if not regions.allowed(input.spec.region)
+
+main := {"rejected": rejected}
+`
+)
+
+var _ = Describe("PolicyService libraries", func() {
+	var (
+		db            *gorm.DB
+		policyService *service.PolicyServiceImpl
+		ctx           context.Context
+	)
+
+	expectServiceError := func(err error, errorType service.ErrorType, message string) *service.ServiceError {
+		GinkgoHelper()
+		Expect(err).To(HaveOccurred())
+		serviceErr, ok := err.(*service.ServiceError)
+		Expect(ok).To(BeTrue())
+		Expect(serviceErr.Type).To(Equal(errorType))
+		Expect(serviceErr.Message).To(Equal(message))
+		return serviceErr
+	}
+
+	createRegionsPolicy := func() {
+		GinkgoHelper()
+		_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+			DisplayName: strPtr("Regions"),
+			PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+			RegoCode:    strPtr(regionsPolicy),
+		}, strPtr("regions"), service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
+
+		policyService = service.NewPolicyService(store.NewStore(db), opa.NewEngine())
+		ctx = context.Background()
+
+		_, err = policyService.CreateLibrary(ctx, v1alpha1.Library{RegoCode: strPtr(regionsLibrary)}, strPtr("regions"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	Describe("CreateLibrary", func() {
+		It("returns the library with its package", func() {
+			library, err := policyService.GetLibrary(ctx, "regions")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*library.Path).To(Equal("libraries/regions"))
+			Expect(*library.Package).To(Equal("lib.regions"))
+		})
+
+		It("lets policies import the library", func() {
+			createRegionsPolicy()
+		})
+
+		It("rejects a library outside the lib package", func() {
+			_, err := policyService.CreateLibrary(ctx, v1alpha1.Library{RegoCode: strPtr("package helpers\n\ndouble(x) := x * 2\n")}, strPtr("helpers"))
+
+			serviceErr := expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid Rego code")
+			Expect(serviceErr.Diagnostics).To(ConsistOf(SatisfyAll(
+				HaveField("LibraryId", HaveValue(Equal("helpers"))),
+				HaveField("Code", opa.CodeLibraryPackage),
+			)))
+		})
+
+		It("rejects a duplicate ID", func() {
+			_, err := policyService.CreateLibrary(ctx, v1alpha1.Library{RegoCode: strPtr(regionsLibrary)}, strPtr("regions"))
+
+			expectServiceError(err, service.ErrorTypeAlreadyExists, "Library already exists")
+		})
+	})
+
+	Describe("UpdateLibrary", func() {
+		BeforeEach(createRegionsPolicy)
+
+		It("applies a compatible change", func() {
+			patch := &v1alpha1.Library{
+				DisplayName: strPtr("Regions"),
+				RegoCode:    strPtr("package lib.regions\n\nallowed(region) if region in {\"us-east-1\"}\n"),
+			}
+
+			updated, err := policyService.UpdateLibrary(ctx, "regions", patch)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*updated.DisplayName).To(Equal("Regions"))
+			Expect(*updated.RegoCode).To(Equal(*patch.RegoCode))
+		})
+
+		It("rejects a change that breaks a dependent policy", func() {
+			patch := &v1alpha1.Library{RegoCode: strPtr("package lib.regions\n\nsupported := {\"us-east-1\"}\n")}
+
+			_, err := policyService.UpdateLibrary(ctx, "regions", patch)
+
+			serviceErr := expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid Rego code")
+			Expect(serviceErr.Diagnostics).To(ContainElement(SatisfyAll(
+				HaveField("PolicyId", HaveValue(Equal("regions"))),
+				HaveField("Message", "undefined function data.lib.regions.allowed"),
+			)))
+			library, err := policyService.GetLibrary(ctx, "regions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*library.RegoCode).To(Equal(regionsLibrary))
+		})
+
+		It("returns NOT_FOUND for an unknown library", func() {
+			_, err := policyService.UpdateLibrary(ctx, "missing", &v1alpha1.Library{DisplayName: strPtr("x")})
+
+			expectServiceError(err, service.ErrorTypeNotFound, "Library not found")
+		})
+	})
+
+	Describe("DeleteLibrary", func() {
+		It("deletes an unused library", func() {
+			Expect(policyService.DeleteLibrary(ctx, "regions")).To(Succeed())
+
+			_, err := policyService.GetLibrary(ctx, "regions")
+			expectServiceError(err, service.ErrorTypeNotFound, "Library not found")
+		})
+
+		It("rejects deleting a library that policies depend on", func() {
+			createRegionsPolicy()
+
+			err := policyService.DeleteLibrary(ctx, "regions")
+
+			serviceErr := expectServiceError(err, service.ErrorTypeFailedPrecondition, "Library is in use")
+			Expect(serviceErr.Diagnostics).To(ContainElement(HaveField("PolicyId", HaveValue(Equal("regions")))))
+			list, err := policyService.ListLibraries(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Libraries).To(HaveLen(1))
+		})
+	})
+})
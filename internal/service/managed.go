@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/bundle"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+)
+
+// managedPolicyChanged reports whether any stored attribute of a managed policy differs from its desired state
+func managedPolicyChanged(existing, desired model.Policy) bool {
+	return existing.DisplayName != desired.DisplayName ||
+		existing.Description != desired.Description ||
+		existing.PolicyType != desired.PolicyType ||
+		existing.Priority != desired.Priority ||
+		existing.RegoCode != desired.RegoCode ||
+		existing.TestCode != desired.TestCode ||
+		existing.Enabled != desired.Enabled ||
+		!equalTimes(existing.EffectiveFrom, desired.EffectiveFrom) ||
+		!equalTimes(existing.EffectiveUntil, desired.EffectiveUntil) ||
+		!equalInt32s(existing.RolloutPercentage, desired.RolloutPercentage) ||
+		existing.RolloutKey != desired.RolloutKey ||
+		existing.Entrypoint != desired.Entrypoint ||
+		!maps.Equal(existing.LabelSelector, desired.LabelSelector)
+}
+
+// equalTimes reports whether two optional timestamps are both unset or denote the same instant
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// equalInt32s reports whether two optional integers are both unset or equal
+func equalInt32s(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// ReconcileManagedPolicies makes the set of managed policies match policies, as loaded from the policy directory.
+// Missing policies are created, changed policies are updated and managed policies that are no longer
+// present are deleted. Policies created through the API are never touched; a directory policy that
+// reuses the ID of such a policy fails the reconciliation.
+// The reconciliation is all or nothing: the changes are compiled and linted against the policy set and
+// then written in one transaction, as for a bundle import.
+func (s *PolicyServiceImpl) ReconcileManagedPolicies(ctx context.Context, policies []bundle.Policy) error {
+	log := logging.FromContext(ctx)
+
+	desired, err := s.validateBundlePolicies(ctx, policies, "Invalid policy directory")
+	if err != nil {
+		return err
+	}
+
+	allPolicies, err := s.store.Policy().ListAll(ctx)
+	if err != nil {
+		return NewInternalError("Failed to list policies", err.Error(), err)
+	}
+	existing := make(map[string]model.Policy, len(allPolicies))
+	for _, p := range allPolicies {
+		existing[p.ID] = p
+	}
+
+	// The batch writes the deletes first, so that display names and priorities released by removed
+	// policies can be reused
+	desiredIDs := make(map[string]struct{}, len(policies))
+	for _, p := range policies {
+		desiredIDs[p.Metadata.ID] = struct{}{}
+	}
+	var changes []batchChange
+	for _, p := range allPolicies {
+		if _, ok := desiredIDs[p.ID]; ok || !p.Managed {
+			continue
+		}
+		previous := p
+		changes = append(changes, batchChange{id: p.ID, previous: &previous})
+	}
+
+	for i, p := range policies {
+		id := p.Metadata.ID
+		dbPolicy := APIToDBModel(desired[i], id)
+		dbPolicy.Managed = true
+
+		current, ok := existing[id]
+		switch {
+		case !ok:
+			changes = append(changes, batchChange{id: id, policy: &dbPolicy, lint: true, module: p.Path})
+		case !current.Managed:
+			return bundleModuleError(p.Path, NewFailedPreconditionError(
+				"Policy is not managed",
+				fmt.Sprintf("A policy with ID '%s' was created through the API and cannot be replaced by the policy directory", id),
+			))
+		case current.PolicyType != dbPolicy.PolicyType:
+			return bundleModuleError(p.Path, NewInvalidArgumentError(
+				"policy_type is immutable",
+				"The policy_type field cannot be changed after creation",
+			))
+		case managedPolicyChanged(current, dbPolicy):
+			// The decision of the policy depends on its Rego code and entrypoint
+			lint := dbPolicy.RegoCode != current.RegoCode || dbPolicy.Entrypoint != current.Entrypoint
+			changes = append(changes, batchChange{id: id, policy: &dbPolicy, previous: &current, lint: lint, module: p.Path})
+		}
+	}
+
+	if len(changes) == 0 {
+		log.Debug("Managed policies are up to date", "policy_count", len(policies))
+		return nil
+	}
+	if _, err := s.applyBatch(ctx, changes, nil, PolicyWriteOptions{}); err != nil {
+		return err
+	}
+
+	log.Info("Managed policies reconciled", "policy_count", len(policies), "change_count", len(changes))
+	return nil
+}
@@ -0,0 +1,205 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/bundle"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func dirPolicy(id, displayName, regoCode string, priority int32) bundle.Policy {
+	p := bundlePolicy(id, displayName, regoCode, priority)
+	p.Path = id + ".rego"
+	return p
+}
+
+var _ = Describe("PolicyService managed policies", func() {
+	var (
+		db            *gorm.DB
+		engine        opa.Engine
+		policyService *service.PolicyServiceImpl
+		ctx           context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
+
+		engine = opa.NewEngine()
+		policyService = service.NewPolicyService(store.NewStore(db), engine)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	Describe("ReconcileManagedPolicies", func() {
+		It("creates managed policies and compiles them into the engine", func() {
+			err := policyService.ReconcileManagedPolicies(ctx, []bundle.Policy{
+				dirPolicy("region", "Region", "package policies.region\nmain := {\"rejected\": false}", 10),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			got, err := policyService.GetPolicy(ctx, "region")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*got.Managed).To(BeTrue())
+
+			result, err := engine.EvaluatePolicy(ctx, "region", map[string]any{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Result).To(HaveKeyWithValue("rejected", false))
+		})
+
+		It("updates changed policies and deletes removed ones", func() {
+			Expect(policyService.ReconcileManagedPolicies(ctx, []bundle.Policy{
+				dirPolicy("region", "Region", "package policies.region\nmain := {\"rejected\": false}", 10),
+				dirPolicy("quota", "Quota", "package policies.quota\nmain := {\"rejected\": false}", 20),
+			})).To(Succeed())
+
+			err := policyService.ReconcileManagedPolicies(ctx, []bundle.Policy{
+				dirPolicy("region", "Region v2", "package policies.region\nmain := {\"rejected\": true}", 20),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			got, err := policyService.GetPolicy(ctx, "region")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*got.DisplayName).To(Equal("Region v2"))
+			Expect(*got.Priority).To(Equal(int32(20)))
+			_, err = policyService.GetPolicy(ctx, "quota")
+			Expect(err).To(HaveOccurred())
+
+			result, err := engine.EvaluatePolicy(ctx, "region", map[string]any{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Result).To(HaveKeyWithValue("rejected", true))
+		})
+
+		It("leaves policies created through the API untouched", func() {
+			id := "api-policy"
+			_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("API"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.api\nmain := {}"),
+			}, &id, service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(policyService.ReconcileManagedPolicies(ctx, nil)).To(Succeed())
+
+			got, err := policyService.GetPolicy(ctx, id)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*got.Managed).To(BeFalse())
+		})
+
+		It("refuses to take over a policy created through the API", func() {
+			id := "region"
+			_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("API"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.api\nmain := {}"),
+			}, &id, service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = policyService.ReconcileManagedPolicies(ctx, []bundle.Policy{
+				dirPolicy("region", "Region", "package policies.region\nmain := {}", 10),
+			})
+
+			var serviceErr *service.ServiceError
+			Expect(err).To(BeAssignableToTypeOf(serviceErr))
+			Expect(err.(*service.ServiceError).Type).To(Equal(service.ErrorTypeFailedPrecondition))
+		})
+
+		It("writes nothing when the policy set does not compile", func() {
+			Expect(policyService.ReconcileManagedPolicies(ctx, []bundle.Policy{
+				dirPolicy("shared-a", "Shared A", "package shared\nmain := {\"rejected\": false}", 10),
+				dirPolicy("region", "Region", "package policies.region\nmain := {}", 20),
+			})).To(Succeed())
+
+			err := policyService.ReconcileManagedPolicies(ctx, []bundle.Policy{
+				dirPolicy("shared-a", "Shared A", "package shared\nmain := {\"rejected\": false}", 10),
+				dirPolicy("shared-b", "Shared B", "package shared\nmain(x) := x", 30),
+			})
+
+			// The policy set is compiled before anything is written
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(err.(*service.ServiceError).Diagnostics).NotTo(BeEmpty())
+			_, err = policyService.GetPolicy(ctx, "region")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = policyService.GetPolicy(ctx, "shared-b")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("writes the changes in one transaction", func() {
+			Expect(policyService.ReconcileManagedPolicies(ctx, []bundle.Policy{
+				dirPolicy("region", "Region", "package policies.region\nmain := {}", 10),
+			})).To(Succeed())
+			// The database fails the update and every write after it, as when it goes away
+			failing := false
+			Expect(db.Callback().Update().Before("gorm:update").Register("test:fail_update", func(tx *gorm.DB) {
+				failing = true
+				_ = tx.AddError(errors.New("database is gone"))
+			})).To(Succeed())
+			Expect(db.Callback().Delete().Before("gorm:delete").Register("test:fail_delete", func(tx *gorm.DB) {
+				if failing {
+					_ = tx.AddError(errors.New("database is gone"))
+				}
+			})).To(Succeed())
+
+			err := policyService.ReconcileManagedPolicies(ctx, []bundle.Policy{
+				dirPolicy("quota", "Quota", "package policies.quota\nmain := {}", 20),
+				dirPolicy("region", "Region v2", "package policies.region\nmain := {}", 10),
+			})
+
+			Expect(err).To(HaveOccurred())
+			_, err = policyService.GetPolicy(ctx, "quota")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("API guards", func() {
+		BeforeEach(func() {
+			Expect(policyService.ReconcileManagedPolicies(ctx, []bundle.Policy{
+				dirPolicy("region", "Region", "package policies.region\nmain := {}", 10),
+			})).To(Succeed())
+		})
+
+		expectManagedError := func(err error) {
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeFailedPrecondition))
+		}
+
+		It("refuses to update a managed policy", func() {
+			_, err := policyService.UpdatePolicy(ctx, "region", &v1alpha1.Policy{DisplayName: strPtr("Changed")}, service.PolicyWriteOptions{})
+			expectManagedError(err)
+		})
+
+		It("refuses to delete a managed policy", func() {
+			expectManagedError(policyService.DeletePolicy(ctx, "region"))
+			_, err := policyService.GetPolicy(ctx, "region")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("refuses to overwrite a managed policy from a bundle", func() {
+			_, err := policyService.ImportBundle(ctx, writeBundle(
+				bundlePolicy("region", "Region", "package policies.region\nmain := {}", 10),
+			))
+			expectManagedError(err)
+		})
+	})
+})
@@ -3,11 +3,8 @@ package service
 import (
 	"fmt"
 	"strings"
-)
 
-const (
-	// DefaultOrderBy is the default ordering for policy listings
-	DefaultOrderBy = "policy_type ASC, priority ASC, id ASC"
+	"github.com/dcm-project/policy-manager/internal/store"
 )
 
 // Supported order by fields
@@ -17,27 +14,27 @@ var supportedOrderByFields = map[string]bool{
 	"create_time":  true,
 }
 
-// parseOrderBy parses an order_by parameter into GORM format.
+// parseOrderBy parses an order_by parameter into store order fields.
 // Supports single and multiple field ordering with asc/desc directions.
 //
 // Supported fields: priority, display_name, create_time
 //
 // Examples:
-//   - "priority asc" → "priority ASC"
-//   - "display_name desc" → "display_name DESC"
-//   - "create_time desc,priority asc" → "create_time DESC, priority ASC"
+//   - "priority asc" → priority ascending
+//   - "display_name desc" → display_name descending
+//   - "create_time desc,priority asc" → create_time descending, then priority ascending
 //
-// If orderBy is empty, returns the default ordering.
+// If orderBy is empty, returns nil and the store applies its default ordering.
 //
 // Returns an error for invalid fields or directions.
-func parseOrderBy(orderBy string) (string, error) {
+func parseOrderBy(orderBy string) ([]store.OrderField, error) {
 	if orderBy == "" {
-		return DefaultOrderBy, nil
+		return nil, nil
 	}
 
 	// Split by comma for multiple fields
 	parts := strings.Split(orderBy, ",")
-	var gormParts []string
+	var fields []store.OrderField
 
 	for _, part := range parts {
 		part = strings.TrimSpace(part)
@@ -56,7 +53,7 @@ func parseOrderBy(orderBy string) (string, error) {
 
 		// Validate field
 		if !supportedOrderByFields[field] {
-			return "", NewInvalidArgumentError(
+			return nil, NewInvalidArgumentError(
 				"Invalid order_by field",
 				fmt.Sprintf("Field '%s' is not supported for ordering. Supported fields: priority, display_name, create_time", field),
 			)
@@ -66,7 +63,7 @@ func parseOrderBy(orderBy string) (string, error) {
 		if len(tokens) > 1 {
 			dir := strings.ToUpper(tokens[1])
 			if dir != "ASC" && dir != "DESC" {
-				return "", NewInvalidArgumentError(
+				return nil, NewInvalidArgumentError(
 					"Invalid order_by direction",
 					fmt.Sprintf("Direction '%s' is not valid. Use 'asc' or 'desc'", tokens[1]),
 				)
@@ -76,18 +73,27 @@ func parseOrderBy(orderBy string) (string, error) {
 
 		// Validate no extra tokens
 		if len(tokens) > 2 {
-			return "", NewInvalidArgumentError(
+			return nil, NewInvalidArgumentError(
 				"Invalid order_by format",
 				fmt.Sprintf("Too many tokens in order_by clause '%s'. Expected format: 'field [asc|desc]'", part),
 			)
 		}
 
-		gormParts = append(gormParts, fmt.Sprintf("%s %s", field, direction))
+		fields = append(fields, store.OrderField{Field: field, Desc: direction == "DESC"})
 	}
 
-	if len(gormParts) == 0 {
-		return DefaultOrderBy, nil
-	}
+	return fields, nil
+}
 
-	return strings.Join(gormParts, ", "), nil
+// formatOrderBy returns the canonical form of parsed order fields, e.g. "create_time desc,priority asc"
+func formatOrderBy(fields []store.OrderField) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		direction := "asc"
+		if f.Desc {
+			direction = "desc"
+		}
+		parts[i] = f.Field + " " + direction
+	}
+	return strings.Join(parts, ",")
 }
@@ -5,13 +5,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/dcm-project/policy-manager/api/v1alpha1"
 	"github.com/dcm-project/policy-manager/internal/logging"
 	"github.com/dcm-project/policy-manager/internal/opa"
 	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
 	"github.com/google/uuid"
 )
 
@@ -24,30 +29,87 @@ const (
 // contain only lowercase letters, numbers, and hyphens, end with letter or number
 var idPattern = regexp.MustCompile(`^[a-z]([a-z0-9-]{0,61}[a-z0-9])?$`)
 
+// entrypointPattern matches the rule names allowed as policy entrypoints
+var entrypointPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,62}$`)
+
 // PolicyService defines the interface for policy business logic operations.
 type PolicyService interface {
 	CompileAll(ctx context.Context) error
-	CreatePolicy(ctx context.Context, policy v1alpha1.Policy, clientID *string) (*v1alpha1.Policy, error)
+	SyncEngine(ctx context.Context) error
+	CompiledGeneration() int64
+	CreatePolicy(ctx context.Context, policy v1alpha1.Policy, clientID *string, opts PolicyWriteOptions) (*v1alpha1.Policy, error)
 	GetPolicy(ctx context.Context, id string) (*v1alpha1.Policy, error)
 	ListPolicies(ctx context.Context, filter *string, orderBy *string, pageToken *string, pageSize *int32) (*v1alpha1.PolicyList, error)
-	UpdatePolicy(ctx context.Context, id string, patch *v1alpha1.Policy) (*v1alpha1.Policy, error)
+	UpdatePolicy(ctx context.Context, id string, patch *v1alpha1.Policy, opts PolicyWriteOptions) (*v1alpha1.Policy, error)
 	DeletePolicy(ctx context.Context, id string) error
+	TestPolicy(ctx context.Context, id string) (*v1alpha1.PolicyTestReport, error)
+	ImportBundle(ctx context.Context, r io.Reader) ([]v1alpha1.Policy, error)
+	ExportBundle(ctx context.Context, w io.Writer) error
+	CreateLibrary(ctx context.Context, library v1alpha1.Library, clientID *string) (*v1alpha1.Library, error)
+	GetLibrary(ctx context.Context, id string) (*v1alpha1.Library, error)
+	ListLibraries(ctx context.Context) (*v1alpha1.LibraryList, error)
+	UpdateLibrary(ctx context.Context, id string, patch *v1alpha1.Library) (*v1alpha1.Library, error)
+	DeleteLibrary(ctx context.Context, id string) error
+	ReorderPolicies(ctx context.Context, policyType string, ids []string) ([]v1alpha1.Policy, error)
+	BatchCreatePolicies(ctx context.Context, requests []v1alpha1.PolicyCreateRequest, opts PolicyWriteOptions) ([]v1alpha1.Policy, error)
+	BatchUpdatePolicies(ctx context.Context, requests []v1alpha1.PolicyUpdateRequest, opts PolicyWriteOptions) ([]v1alpha1.Policy, error)
+	BatchDeletePolicies(ctx context.Context, ids []string) error
+	WatchPolicies(ctx context.Context, lastEventID string) (*PolicyWatch, error)
+	CreateWebhook(ctx context.Context, webhook v1alpha1.Webhook, clientID *string) (*v1alpha1.Webhook, error)
+	GetWebhook(ctx context.Context, id string) (*v1alpha1.Webhook, error)
+	ListWebhooks(ctx context.Context) (*v1alpha1.WebhookList, error)
+	UpdateWebhook(ctx context.Context, id string, patch *v1alpha1.Webhook) (*v1alpha1.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	ListWebhookDeliveries(ctx context.Context, id string) (*v1alpha1.WebhookDeliveryList, error)
+	CreateProposal(ctx context.Context, proposal v1alpha1.PolicyProposal) (*v1alpha1.PolicyProposal, error)
+	GetProposal(ctx context.Context, id string) (*v1alpha1.PolicyProposal, error)
+	ListProposals(ctx context.Context, state string) (*v1alpha1.PolicyProposalList, error)
+	ApproveProposal(ctx context.Context, id string, comment string) (*v1alpha1.PolicyProposal, error)
+	RejectProposal(ctx context.Context, id string, comment string) (*v1alpha1.PolicyProposal, error)
+}
+
+// PolicyWriteOptions holds the per-request options of create and update operations.
+type PolicyWriteOptions struct {
+	// RequirePassingTests rejects the change when any test in the policy's test_code fails
+	RequirePassingTests bool
+	// ValidateOnly validates the change, including compilation and tests, without persisting it (AEP-163)
+	ValidateOnly bool
+	// Placement assigns the priority of a created policy relative to the other policies of its type:
+	// end, before:<policy-id> or after:<policy-id>. Empty keeps the priority of the request.
+	Placement string
+	// UpdateMask lists the fields changed by an update (AEP-134). A listed field absent from the patch is
+	// cleared. Empty changes the fields set in the patch.
+	UpdateMask []string
 }
 
 // PolicyServiceImpl implements the PolicyService interface.
 type PolicyServiceImpl struct {
-	store  store.Store
-	engine opa.Engine
+	store   store.Store
+	engine  opa.Engine
+	watcher *policyWatcher
+	// generation tracks the policy set generation compiled into the engine
+	generation *engineGeneration
+	// requireApproval rejects direct changes of the policy set; they are made through proposals
+	requireApproval bool
+	// validations caches the validation results of pending proposals
+	validations *proposalValidations
 }
 
 var _ PolicyService = (*PolicyServiceImpl)(nil)
 
 // NewPolicyService creates a new PolicyService instance.
-func NewPolicyService(store store.Store, engine opa.Engine) *PolicyServiceImpl {
-	return &PolicyServiceImpl{
-		store:  store,
-		engine: engine,
-	}
+func NewPolicyService(store store.Store, engine opa.Engine, opts ...PolicyServiceOption) *PolicyServiceImpl {
+	s := &PolicyServiceImpl{
+		store:       store,
+		engine:      engine,
+		watcher:     newPolicyWatcher(),
+		generation:  &engineGeneration{},
+		validations: newProposalValidations(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func validatePostInput(policy v1alpha1.Policy) error {
@@ -76,26 +138,43 @@ func validatePostInput(policy v1alpha1.Policy) error {
 		return err
 	}
 
+	if err := validateEffectiveWindow(policy.EffectiveFrom, policy.EffectiveUntil); err != nil {
+		return err
+	}
+
+	if err := validateRollout(policy); err != nil {
+		return err
+	}
+
+	if err := validateEntrypoint(policy.Entrypoint); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func getPolicyID(clientID *string) (*string, error) {
-	var policyID string
+	return getResourceID(clientID, "policy", "Policy")
+}
+
+// getResourceID validates a client-specified ID, or generates one when clientID is not set
+func getResourceID(clientID *string, kind, kindTitle string) (*string, error) {
+	var id string
 
 	if clientID != nil && *clientID != "" {
-		policyID = *clientID
+		id = *clientID
 		// Validate ID format (AEP-122 compliant) only for client-specified IDs
-		if !idPattern.MatchString(policyID) {
+		if !idPattern.MatchString(id) {
 			return nil, NewInvalidArgumentError(
-				"Invalid policy ID format",
-				fmt.Sprintf("Policy ID '%s' does not match required format: 1-63 characters, start with lowercase letter, contain only lowercase letters, numbers, and hyphens, end with letter or number", policyID),
+				fmt.Sprintf("Invalid %s ID format", kind),
+				fmt.Sprintf("%s ID '%s' does not match required format: 1-63 characters, start with lowercase letter, contain only lowercase letters, numbers, and hyphens, end with letter or number", kindTitle, id),
 			)
 		}
 	} else {
 		// Generate UUID for server-assigned ID
-		policyID = uuid.New().String()
+		id = uuid.New().String()
 	}
-	return &policyID, nil
+	return &id, nil
 }
 
 func validatePriority(priority *int32) error {
@@ -108,43 +187,170 @@ func validatePriority(priority *int32) error {
 	return nil
 }
 
-// CompileAll loads all policies from the store and compiles them into the engine.
-func (s *PolicyServiceImpl) CompileAll(ctx context.Context) error {
-	return s.recompileEngine(ctx)
+// validateEntrypoint rejects an entrypoint that is not a Rego rule name
+func validateEntrypoint(entrypoint *string) error {
+	if entrypoint != nil && !entrypointPattern.MatchString(*entrypoint) {
+		return NewInvalidArgumentError(
+			"Invalid entrypoint",
+			"The entrypoint field must name a rule of the policy package: 1-63 letters, digits and underscores, not starting with a digit",
+		)
+	}
+	return nil
 }
 
-// recompileEngine loads all policies from the store and recompiles the engine.
-func (s *PolicyServiceImpl) recompileEngine(ctx context.Context) error {
-	allPolicies, err := s.store.Policy().ListAll(ctx)
+// validateEffectiveWindow rejects an effective window that ends before it starts
+func validateEffectiveWindow(from, until *time.Time) error {
+	if from != nil && until != nil && !until.After(*from) {
+		return NewInvalidArgumentError(
+			"Invalid effective window",
+			"The effective_until field must be after effective_from",
+		)
+	}
+	return nil
+}
+
+// recompileEngine loads all policies and libraries from the store and recompiles the engine after a write
+// that changed the given policies.
+func (s *PolicyServiceImpl) recompileEngine(ctx context.Context, changed []string) error {
+	unlock := s.lockGeneration()
+	defer unlock()
+	return s.recompileEngineLocked(ctx, changed)
+}
+
+// recompileEngineLocked is recompileEngine for a caller holding the generation lock
+func (s *PolicyServiceImpl) recompileEngineLocked(ctx context.Context, changed []string) error {
+	modules, err := s.storedModules(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list policies for recompilation: %w", err)
 	}
 
-	modules := make([]opa.PolicyModule, len(allPolicies))
-	for i, p := range allPolicies {
-		modules[i] = opa.PolicyModule{
-			ID:       p.ID,
-			RegoCode: p.RegoCode,
+	if err := s.engine.Compile(ctx, modules); err != nil {
+		s.publishCompileFailure(ctx, err)
+		return err
+	}
+	s.publishChangesLocked(ctx, changed)
+	return nil
+}
+
+// updateEngine adds or replaces the upsert modules and removes the remove modules in the engine, which
+// only prepares the policies affected by the change again. The engine must hold the stored policy set
+// before the change. changed lists the policies the write changed besides those of the modules, such as
+// policies whose priority was shifted.
+func (s *PolicyServiceImpl) updateEngine(ctx context.Context, upsert, remove []opa.PolicyModule, changed ...string) error {
+	unlock := s.lockGeneration()
+	defer unlock()
+
+	if err := s.engine.Update(ctx, upsert, remove); err != nil {
+		s.publishCompileFailure(ctx, err)
+		return err
+	}
+	for _, m := range slices.Concat(upsert, remove) {
+		if !m.Library {
+			changed = append(changed, m.ID)
 		}
 	}
+	s.publishChangesLocked(ctx, changed)
+	return nil
+}
 
-	return s.engine.Compile(ctx, modules)
+// storedModules returns the modules of all stored policies and libraries
+func (s *PolicyServiceImpl) storedModules(ctx context.Context) ([]opa.PolicyModule, error) {
+	allPolicies, err := s.store.Policy().ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allLibraries, err := s.store.Library().ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := make([]opa.PolicyModule, 0, len(allPolicies)+len(allLibraries)+1)
+	for _, p := range allPolicies {
+		modules = append(modules, policyModule(p))
+	}
+	return append(modules, libraryModules(allLibraries)...), nil
+}
+
+// withoutModule returns modules without the policy or library module of the given ID
+func withoutModule(modules []opa.PolicyModule, id string, library bool) []opa.PolicyModule {
+	return slices.DeleteFunc(modules, func(m opa.PolicyModule) bool {
+		return m.ID == id && m.Library == library
+	})
+}
+
+// candidateModules returns the modules of the stored policy set with policy added to it or replacing
+// the stored version
+func (s *PolicyServiceImpl) candidateModules(ctx context.Context, policy model.Policy) ([]opa.PolicyModule, error) {
+	modules, err := s.storedModules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(withoutModule(modules, policy.ID, false), policyModule(policy)), nil
+}
+
+// policyModule returns the engine module of a policy
+func policyModule(p model.Policy) opa.PolicyModule {
+	return opa.PolicyModule{ID: p.ID, RegoCode: p.RegoCode, Entrypoint: p.Entrypoint}
+}
+
+// checkPolicySet compiles the stored policy set with policy added or replaced, so that cross-module
+// errors are reported before anything is written, and lints the output contract of policy.
+// Lint warnings are returned.
+func (s *PolicyServiceImpl) checkPolicySet(ctx context.Context, policy model.Policy) ([]v1alpha1.RegoDiagnostic, error) {
+	modules, err := s.candidateModules(ctx, policy)
+	if err != nil {
+		return nil, NewInternalError("Failed to list policies", err.Error(), err)
+	}
+
+	warnings, err := s.engine.CheckPolicies(ctx, modules, []string{policy.ID})
+	if err != nil {
+		var compileErr *opa.CompileError
+		if errors.As(err, &compileErr) {
+			return nil, NewPolicySetCompileError(compileErr)
+		}
+		return nil, handleEngineError(err, "validate")
+	}
+	if len(warnings) > 0 {
+		logging.FromContext(ctx).Warn("Policy has lint warnings", "policy_id", policy.ID, "warnings", len(warnings))
+	}
+	return diagnosticsToAPI(warnings), nil
 }
 
 // CreatePolicy creates a new policy resource.
 // Required fields (display_name, policy_type, rego_code) are enforced here since the schema has no required.
-func (s *PolicyServiceImpl) CreatePolicy(ctx context.Context, policy v1alpha1.Policy, clientID *string) (*v1alpha1.Policy, error) {
-	if err := validatePostInput(policy); err != nil {
+func (s *PolicyServiceImpl) CreatePolicy(ctx context.Context, policy v1alpha1.Policy, clientID *string, opts PolicyWriteOptions) (*v1alpha1.Policy, error) {
+	if err := s.checkDirectWrite(opts); err != nil {
 		return nil, err
 	}
-
 	policyID, err := getPolicyID(clientID)
 	if err != nil {
 		return nil, err
 	}
+	return s.createPolicy(ctx, policy, *policyID, opts)
+}
+
+// createPolicy creates a policy with the given ID, which has been validated or generated
+func (s *PolicyServiceImpl) createPolicy(ctx context.Context, policy v1alpha1.Policy, policyID string, opts PolicyWriteOptions) (*v1alpha1.Policy, error) {
+	if err := validatePostInput(policy); err != nil {
+		return nil, err
+	}
+
+	var place placement
+	if opts.Placement != "" {
+		if policy.Priority != nil {
+			return nil, NewInvalidArgumentError(
+				"priority and placement are mutually exclusive",
+				"Set either the priority field or the placement parameter, not both",
+			)
+		}
+		var err error
+		if place, err = parsePlacement(opts.Placement); err != nil {
+			return nil, err
+		}
+	}
 
 	log := logging.FromContext(ctx)
-	log.Debug("Creating policy", "policy_id", *policyID)
+	log.Debug("Creating policy", "policy_id", policyID)
 
 	// Validate Rego via engine
 	if err := s.engine.ValidateRego(ctx, *policy.RegoCode); err != nil {
@@ -152,23 +358,82 @@ func (s *PolicyServiceImpl) CreatePolicy(ctx context.Context, policy v1alpha1.Po
 	}
 
 	// Convert API model to DB model (includes RegoCode)
-	dbPolicy := APIToDBModel(policy, *policyID)
+	dbPolicy := APIToDBModel(policy, policyID)
 
-	// Create policy in store (duplicate ID fails here)
-	created, err := s.store.Policy().Create(ctx, dbPolicy)
+	// Compile the policy set including the new policy
+	warnings, err := s.checkPolicySet(ctx, dbPolicy)
 	if err != nil {
-		log.Error("Failed to create policy in store", "policy_id", *policyID, "error", err)
+		return nil, err
+	}
+
+	// Run the policy tests against the policy set including the new policy
+	if err := s.checkPolicyTests(ctx, dbPolicy, opts); err != nil {
+		return nil, err
+	}
+
+	// Assign the priority relative to the other policies of the type, shifting neighbours if needed
+	var shift priorityShift
+	if opts.Placement != "" {
+		dbPolicy.Priority, shift, err = s.placePriority(ctx, dbPolicy.PolicyType, place)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.ValidateOnly {
+		// A placed priority is still held by a neighbour that would be shifted
+		err := s.store.Policy().CheckUnique(ctx, dbPolicy, false)
+		if err != nil && !(len(shift.next) > 0 && errors.Is(err, store.ErrPriorityPolicyTypeTaken)) {
+			return nil, processPolicyStoreError(err, dbPolicy, "validate")
+		}
+		apiPolicy := DBToAPIModel(&dbPolicy)
+		apiPolicy.CreateTime = nil
+		apiPolicy.UpdateTime = nil
+		apiPolicy.Warnings = warningsPtr(warnings)
+		log.Debug("Policy creation validated", "policy_id", policyID)
+		return &apiPolicy, nil
+	}
+
+	// Make room for the placed policy and create it in one transaction (duplicate ID fails here)
+	var created *model.Policy
+	var shiftErr error
+	err = s.store.Transaction(ctx, func(tx store.Store) error {
+		if len(shift.next) > 0 {
+			if err := tx.Policy().SetPriorities(ctx, shift.next); err != nil {
+				shiftErr = err
+				return err
+			}
+		}
+		var err error
+		created, err = tx.Policy().Create(ctx, dbPolicy)
+		return err
+	})
+	if shiftErr != nil {
+		log.Error("Failed to shift policy priorities", "policy_id", policyID, "error", shiftErr)
+		return nil, NewInternalError("Failed to shift policy priorities", shiftErr.Error(), shiftErr)
+	}
+	if err != nil {
+		log.Error("Failed to create policy in store", "policy_id", policyID, "error", err)
 		return nil, processPolicyStoreError(err, dbPolicy, "create")
 	}
 
-	// Recompile the engine with the new policy
-	if err := s.recompileEngine(ctx); err != nil {
-		log.Error("Failed to recompile engine after create, rolling back DB", "policy_id", *policyID, "error", err)
-		// Rollback: Delete from DB since recompilation failed
-		if delErr := s.store.Policy().Delete(ctx, *policyID); delErr != nil {
+	// Add the new policy to the engine
+	if err := s.updateEngine(ctx, []opa.PolicyModule{policyModule(*created)}, nil, slices.Collect(maps.Keys(shift.next))...); err != nil {
+		log.Error("Failed to recompile engine after create, rolling back DB", "policy_id", policyID, "error", err)
+		// Rollback: Delete from DB and move the shifted policies back since recompilation failed
+		rollbackErr := s.store.Transaction(ctx, func(tx store.Store) error {
+			if err := tx.Policy().Delete(ctx, policyID); err != nil {
+				return err
+			}
+			if len(shift.previous) > 0 {
+				return tx.Policy().SetPriorities(ctx, shift.previous)
+			}
+			return nil
+		})
+		if rollbackErr != nil {
 			log.Error("Failed to rollback DB policy after compile failure",
-				"policy_id", *policyID,
-				"db_error", delErr,
+				"policy_id", policyID,
+				"db_error", rollbackErr,
 				"compile_error", err)
 		}
 		return nil, NewInternalError("Failed to compile policies after create", err.Error(), err)
@@ -176,8 +441,9 @@ func (s *PolicyServiceImpl) CreatePolicy(ctx context.Context, policy v1alpha1.Po
 
 	// Convert back to API model
 	apiPolicy := DBToAPIModel(created)
+	apiPolicy.Warnings = warningsPtr(warnings)
 
-	log.Debug("Policy created successfully", "policy_id", *policyID)
+	log.Debug("Policy created successfully", "policy_id", policyID)
 	return &apiPolicy, nil
 }
 
@@ -206,19 +472,21 @@ func getListOptions(filter *string, orderBy *string, pageToken *string, pageSize
 	// Parse filter expression
 	var policyFilter *store.PolicyFilter
 	var err error
+	filterStr := ""
 	if filter != nil && *filter != "" {
-		policyFilter, err = parseFilter(*filter)
+		filterStr = *filter
+		policyFilter, err = parseFilter(filterStr)
 		if err != nil {
 			return nil, err // Already a ServiceError
 		}
 	}
 
-	// Parse order by parameter (ParseOrderBy handles nil/empty with default)
+	// Parse order by parameter (nil/empty uses the store default ordering)
 	orderByStr := ""
 	if orderBy != nil {
 		orderByStr = *orderBy
 	}
-	orderByStr, err = parseOrderBy(orderByStr)
+	orderFields, err := parseOrderBy(orderByStr)
 	if err != nil {
 		return nil, err // Already a ServiceError
 	}
@@ -241,12 +509,22 @@ func getListOptions(filter *string, orderBy *string, pageToken *string, pageSize
 		pageSizeInt = int(*pageSize)
 	}
 
+	// Resume after the last policy of the previous page
+	var after *model.Policy
+	if pageToken != nil && *pageToken != "" {
+		after, err = decodePageToken(*pageToken, filterStr, formatOrderBy(orderFields))
+		if err != nil {
+			return nil, err // Already a ServiceError
+		}
+	}
+
 	// Build list options
 	return &store.PolicyListOptions{
-		Filter:    policyFilter,
-		OrderBy:   orderByStr,
-		PageToken: pageToken,
-		PageSize:  pageSizeInt,
+		Filter:     policyFilter,
+		OrderBy:    orderFields,
+		After:      after,
+		PageSize:   pageSizeInt,
+		CountTotal: true,
 	}, nil
 }
 
@@ -263,6 +541,9 @@ func (s *PolicyServiceImpl) ListPolicies(ctx context.Context, filter *string, or
 	// List policies from store
 	result, err := s.store.Policy().List(ctx, opts)
 	if err != nil {
+		if errors.Is(err, store.ErrInvalidFilter) {
+			return nil, NewInvalidArgumentError("Invalid filter expression", err.Error())
+		}
 		log.Error("Failed to list policies from store", "error", err)
 		return nil, NewInternalError("Failed to list policies", err.Error(), err)
 	}
@@ -274,25 +555,39 @@ func (s *PolicyServiceImpl) ListPolicies(ctx context.Context, filter *string, or
 	}
 
 	// Build response
+	totalSize := int32(result.TotalSize)
 	response := &v1alpha1.PolicyList{
-		Policies: apiPolicies,
+		Policies:  apiPolicies,
+		TotalSize: &totalSize,
 	}
 
-	if result.NextPageToken != "" {
-		response.NextPageToken = &result.NextPageToken
+	if result.HasMore {
+		filterStr := ""
+		if filter != nil {
+			filterStr = *filter
+		}
+		nextPageToken := encodePageToken(filterStr, formatOrderBy(opts.OrderBy), &result.Policies[len(result.Policies)-1])
+		response.NextPageToken = &nextPageToken
 	}
 
-	log.Debug("Policies listed", "count", len(apiPolicies), "has_next_page", result.NextPageToken != "")
+	log.Debug("Policies listed", "count", len(apiPolicies), "total_size", result.TotalSize, "has_next_page", result.HasMore)
 	return response, nil
 }
 
 // mergePolicyOntoPolicy merges a PATCH body (Policy) onto an existing policy per RFC 7396.
-// Only non-nil mutable fields in patch are applied. Read-only and immutable fields (path, id, policy_type, create_time, update_time) are ignored.
-func mergePolicyOntoPolicy(patch *v1alpha1.Policy, existing v1alpha1.Policy) v1alpha1.Policy {
+// Only non-nil mutable fields in patch are applied. Read-only and immutable fields (path, id, policy_type, managed, create_time, update_time) are ignored.
+// With an update mask only the listed fields are applied, and a listed field that is nil in patch is cleared.
+func mergePolicyOntoPolicy(patch *v1alpha1.Policy, existing v1alpha1.Policy, mask []string) v1alpha1.Policy {
 	merged := existing
 	if patch == nil {
 		return merged
 	}
+	if len(mask) > 0 {
+		for _, field := range mask {
+			copyPolicyField(&merged, *patch, field)
+		}
+		return merged
+	}
 	if patch.DisplayName != nil {
 		merged.DisplayName = patch.DisplayName
 	}
@@ -302,6 +597,21 @@ func mergePolicyOntoPolicy(patch *v1alpha1.Policy, existing v1alpha1.Policy) v1a
 	if patch.Enabled != nil {
 		merged.Enabled = patch.Enabled
 	}
+	if patch.EffectiveFrom != nil {
+		merged.EffectiveFrom = patch.EffectiveFrom
+	}
+	if patch.EffectiveUntil != nil {
+		merged.EffectiveUntil = patch.EffectiveUntil
+	}
+	if patch.RolloutPercentage != nil {
+		merged.RolloutPercentage = patch.RolloutPercentage
+	}
+	if patch.RolloutKey != nil {
+		merged.RolloutKey = patch.RolloutKey
+	}
+	if patch.Entrypoint != nil {
+		merged.Entrypoint = patch.Entrypoint
+	}
 	if patch.LabelSelector != nil {
 		merged.LabelSelector = patch.LabelSelector
 	}
@@ -311,10 +621,95 @@ func mergePolicyOntoPolicy(patch *v1alpha1.Policy, existing v1alpha1.Policy) v1a
 	if patch.RegoCode != nil {
 		merged.RegoCode = patch.RegoCode
 	}
-	// policy_type, path, id, create_time, update_time are immutable/read-only; do not merge
+	if patch.TestCode != nil {
+		merged.TestCode = patch.TestCode
+	}
+	// policy_type, path, id, managed, create_time, update_time are immutable/read-only; do not merge
 	return merged
 }
 
+// updateMaskFields are the fields of a policy that an update mask can list, and whether they can be cleared
+var updateMaskFields = map[string]bool{
+	"display_name":       false,
+	"description":        true,
+	"enabled":            true,
+	"effective_from":     true,
+	"effective_until":    true,
+	"rollout_percentage": true,
+	"rollout_key":        true,
+	"entrypoint":         true,
+	"label_selector":     true,
+	"priority":           true,
+	"rego_code":          false,
+	"test_code":          true,
+}
+
+// validateUpdateMask rejects an update mask listing an unknown or immutable field, or clearing a
+// required field.
+func validateUpdateMask(mask []string, patch *v1alpha1.Policy) error {
+	for _, field := range mask {
+		clearable, ok := updateMaskFields[field]
+		if !ok {
+			return NewInvalidArgumentError(
+				"Invalid update mask",
+				fmt.Sprintf("Field '%s' cannot be updated", field),
+			)
+		}
+		if clearable {
+			continue
+		}
+		if patch == nil || (field == "display_name" && patch.DisplayName == nil) || (field == "rego_code" && patch.RegoCode == nil) {
+			return NewInvalidArgumentError(
+				"Invalid update mask",
+				fmt.Sprintf("Field '%s' cannot be cleared", field),
+			)
+		}
+	}
+	return nil
+}
+
+// maskPolicyPatch returns the fields of patch listed in the update mask, or patch without a mask.
+func maskPolicyPatch(patch *v1alpha1.Policy, mask []string) *v1alpha1.Policy {
+	if patch == nil || len(mask) == 0 {
+		return patch
+	}
+	var masked v1alpha1.Policy
+	for _, field := range mask {
+		copyPolicyField(&masked, *patch, field)
+	}
+	return &masked
+}
+
+// copyPolicyField copies a mutable field named as in the API from src to dst, including a nil value.
+func copyPolicyField(dst *v1alpha1.Policy, src v1alpha1.Policy, field string) {
+	switch field {
+	case "display_name":
+		dst.DisplayName = src.DisplayName
+	case "description":
+		dst.Description = src.Description
+	case "enabled":
+		dst.Enabled = src.Enabled
+	case "effective_from":
+		dst.EffectiveFrom = src.EffectiveFrom
+	case "effective_until":
+		dst.EffectiveUntil = src.EffectiveUntil
+	case "rollout_percentage":
+		dst.RolloutPercentage = src.RolloutPercentage
+	case "rollout_key":
+		dst.RolloutKey = src.RolloutKey
+	case "entrypoint":
+		dst.Entrypoint = src.Entrypoint
+	case "label_selector":
+		dst.LabelSelector = src.LabelSelector
+	case "priority":
+		dst.Priority = src.Priority
+	case "rego_code":
+		dst.RegoCode = src.RegoCode
+	case "test_code":
+		dst.TestCode = src.TestCode
+	}
+}
+
 func validatePatchInput(patch *v1alpha1.Policy) error {
 	if patch == nil {
 		return nil
@@ -328,6 +723,12 @@ func validatePatchInput(patch *v1alpha1.Policy) error {
 	if err := validatePriority(patch.Priority); err != nil {
 		return err
 	}
+	if err := validateRollout(*patch); err != nil {
+		return err
+	}
+	if err := validateEntrypoint(patch.Entrypoint); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -362,6 +763,14 @@ func validatePatchImmutableFields(patch *v1alpha1.Policy, existing v1alpha1.Poli
 			)
 		}
 	}
+	if patch.Managed != nil {
+		if existing.Managed == nil || *patch.Managed != *existing.Managed {
+			return NewInvalidArgumentError(
+				"managed cannot be updated",
+				"The managed field is read-only and cannot be changed",
+			)
+		}
+	}
 	if patch.CreateTime != nil {
 		if existing.CreateTime == nil || !patch.CreateTime.Equal(*existing.CreateTime) {
 			return NewInvalidArgumentError(
@@ -382,13 +791,24 @@ func validatePatchImmutableFields(patch *v1alpha1.Policy, existing v1alpha1.Poli
 }
 
 // UpdatePolicy updates an existing policy using partial merge (PATCH).
-func (s *PolicyServiceImpl) UpdatePolicy(ctx context.Context, id string, patch *v1alpha1.Policy) (*v1alpha1.Policy, error) {
+func (s *PolicyServiceImpl) UpdatePolicy(ctx context.Context, id string, patch *v1alpha1.Policy, opts PolicyWriteOptions) (*v1alpha1.Policy, error) {
+	if err := s.checkDirectWrite(opts); err != nil {
+		return nil, err
+	}
+	return s.updatePolicy(ctx, id, patch, opts)
+}
+
+// updatePolicy updates a policy without the approval check, for direct writes and approved proposals
+func (s *PolicyServiceImpl) updatePolicy(ctx context.Context, id string, patch *v1alpha1.Policy, opts PolicyWriteOptions) (*v1alpha1.Policy, error) {
 	log := logging.FromContext(ctx)
 	log.Debug("Updating policy", "policy_id", id)
 
 	if err := validatePatchInput(patch); err != nil {
 		return nil, err
 	}
+	if err := validateUpdateMask(opts.UpdateMask, patch); err != nil {
+		return nil, err
+	}
 
 	existingDB, err := s.store.Policy().Get(ctx, id)
 	if err != nil {
@@ -398,11 +818,18 @@ func (s *PolicyServiceImpl) UpdatePolicy(ctx context.Context, id string, patch *
 		log.Error("Failed to get existing policy for update", "policy_id", id, "error", err)
 		return nil, NewInternalError("Failed to get existing policy", err.Error(), err)
 	}
+	if existingDB.Managed {
+		return nil, NewPolicyManagedError(id)
+	}
 	existing := DBToAPIModel(existingDB)
 	if err := validatePatchImmutableFields(patch, existing); err != nil {
 		return nil, err
 	}
-	merged := mergePolicyOntoPolicy(patch, existing)
+	patch = maskPolicyPatch(patch, opts.UpdateMask)
+	merged := mergePolicyOntoPolicy(patch, existing, opts.UpdateMask)
+	if err := validateEffectiveWindow(merged.EffectiveFrom, merged.EffectiveUntil); err != nil {
+		return nil, err
+	}
 
 	// If RegoCode is being updated, validate it
 	regoChanged := patch != nil && patch.RegoCode != nil
@@ -415,20 +842,50 @@ func (s *PolicyServiceImpl) UpdatePolicy(ctx context.Context, id string, patch *
 		log.Debug("Rego code validated", "policy_id", id)
 	}
 
+	// Convert API model to DB model
+	dbPolicy := APIToDBModel(merged, id)
+
+	// Compile the policy set including the updated policy, whose decision depends on its Rego code
+	// and entrypoint
+	moduleChanged := regoChanged || (patch != nil && patch.Entrypoint != nil) || slices.Contains(opts.UpdateMask, "entrypoint")
+	var warnings []v1alpha1.RegoDiagnostic
+	if moduleChanged {
+		warnings, err = s.checkPolicySet(ctx, dbPolicy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Run the policy tests against the policy set including the updated policy
+	if err := s.checkPolicyTests(ctx, dbPolicy, opts); err != nil {
+		return nil, err
+	}
+
+	if opts.ValidateOnly {
+		if err := s.store.Policy().CheckUnique(ctx, dbPolicy, true); err != nil {
+			return nil, processPolicyStoreError(err, dbPolicy, "validate")
+		}
+		dbPolicy.Managed = existingDB.Managed
+		dbPolicy.CreateTime = existingDB.CreateTime
+		dbPolicy.UpdateTime = existingDB.UpdateTime
+		apiPolicy := DBToAPIModel(&dbPolicy)
+		apiPolicy.Warnings = warningsPtr(warnings)
+		log.Debug("Policy update validated", "policy_id", id)
+		return &apiPolicy, nil
+	}
+
 	// Save the existing DB state for potential rollback
 	previousDB := *existingDB
 
-	// Convert API model to DB model and update store
-	dbPolicy := APIToDBModel(merged, id)
 	updated, err := s.store.Policy().Update(ctx, dbPolicy)
 	if err != nil {
 		log.Error("Failed to update policy in store", "policy_id", id, "error", err)
 		return nil, processPolicyStoreError(err, dbPolicy, "update")
 	}
 
-	// Recompile engine if Rego changed
-	if regoChanged {
-		if err := s.recompileEngine(ctx); err != nil {
+	// Replace the policy in the engine if its module changed
+	if moduleChanged {
+		if err := s.updateEngine(ctx, []opa.PolicyModule{policyModule(*updated)}, nil); err != nil {
 			log.Error("Failed to recompile engine after update, rolling back DB", "policy_id", id, "error", err)
 			// Rollback: restore previous DB state
 			if _, rollbackErr := s.store.Policy().Update(ctx, previousDB); rollbackErr != nil {
@@ -439,10 +896,13 @@ func (s *PolicyServiceImpl) UpdatePolicy(ctx context.Context, id string, patch *
 			}
 			return nil, NewInternalError("Failed to compile policies after update", err.Error(), err)
 		}
+	} else {
+		s.publishChanges(ctx, []string{id})
 	}
 
 	// Convert back to API model
 	apiPolicy := DBToAPIModel(updated)
+	apiPolicy.Warnings = warningsPtr(warnings)
 
 	log.Debug("Policy updated successfully", "policy_id", id)
 	return &apiPolicy, nil
@@ -450,11 +910,31 @@ func (s *PolicyServiceImpl) UpdatePolicy(ctx context.Context, id string, patch *
 
 // DeletePolicy deletes a policy by ID.
 func (s *PolicyServiceImpl) DeletePolicy(ctx context.Context, id string) error {
+	if err := s.checkDirectWrite(PolicyWriteOptions{}); err != nil {
+		return err
+	}
+	return s.deletePolicy(ctx, id)
+}
+
+// deletePolicy deletes a policy without the approval check, for direct writes and approved proposals
+func (s *PolicyServiceImpl) deletePolicy(ctx context.Context, id string) error {
 	log := logging.FromContext(ctx)
 	log.Debug("Deleting policy", "policy_id", id)
 
+	existingDB, err := s.store.Policy().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrPolicyNotFound) {
+			return NewPolicyNotFoundError(id)
+		}
+		log.Error("Failed to get existing policy for delete", "policy_id", id, "error", err)
+		return NewInternalError("Failed to get existing policy", err.Error(), err)
+	}
+	if existingDB.Managed {
+		return NewPolicyManagedError(id)
+	}
+
 	// Delete policy from store
-	err := s.store.Policy().Delete(ctx, id)
+	err = s.store.Policy().Delete(ctx, id)
 	if err != nil {
 		if errors.Is(err, store.ErrPolicyNotFound) {
 			return NewPolicyNotFoundError(id)
@@ -463,8 +943,8 @@ func (s *PolicyServiceImpl) DeletePolicy(ctx context.Context, id string) error {
 		return NewInternalError("Failed to delete policy", err.Error(), err)
 	}
 
-	// Recompile engine without the deleted policy
-	if err := s.recompileEngine(ctx); err != nil {
+	// Remove the deleted policy from the engine
+	if err := s.updateEngine(ctx, nil, []opa.PolicyModule{{ID: id}}); err != nil {
 		log.Warn("Failed to recompile engine after delete", "policy_id", id, "error", err)
 	}
 
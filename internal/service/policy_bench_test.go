@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func benchRegoCode(i, version int) string {
+	return fmt.Sprintf(`package policies.p%d
+
+import data.lib.regions
+
+main := {
+	"rejected": rejected,
+	"rejection_reason": "region not allowed",
+	"patch": {"metadata": {"version": %d}},
+}
+
+default rejected := false
+
+rejected if {
+	not regions.allowed(input.spec.region)
+	input.spec.labels.team == "team-%d"
+}
+`, i, version, i)
+}
+
+// benchService returns a policy service over an in-memory store holding n policies importing a library,
+// compiled into the engine
+func benchService(b *testing.B, n int) *service.PolicyServiceImpl {
+	b.Helper()
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		b.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		b.Fatal(err)
+	}
+	// Every connection to :memory: opens a database of its own
+	sqlDB.SetMaxOpenConns(1)
+	b.Cleanup(func() { _ = sqlDB.Close() })
+	if err := db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{}); err != nil {
+		b.Fatal(err)
+	}
+
+	dataStore := store.NewStore(db)
+	if _, err := dataStore.Library().Create(ctx, model.Library{
+		ID:       "regions",
+		Package:  "lib.regions",
+		RegoCode: "package lib.regions\n\nallowed(region) if region in {\"us-east-1\", \"eu-west-1\"}\n",
+	}); err != nil {
+		b.Fatal(err)
+	}
+	for i := range n {
+		if _, err := dataStore.Policy().Create(ctx, model.Policy{
+			ID:          fmt.Sprintf("policy-%d", i),
+			DisplayName: fmt.Sprintf("Policy %d", i),
+			PolicyType:  "GLOBAL",
+			Priority:    int32(i + 1),
+			RegoCode:    benchRegoCode(i, 0),
+			Enabled:     true,
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	policyService := service.NewPolicyService(dataStore, opa.NewEngine())
+	if err := policyService.CompileAll(ctx); err != nil {
+		b.Fatal(err)
+	}
+	return policyService
+}
+
+// BenchmarkCreatePolicy1000 creates a policy next to 999 stored policies, which checks the policy set
+// and adds the policy to the engine
+func BenchmarkCreatePolicy1000(b *testing.B) {
+	ctx := context.Background()
+	policyService := benchService(b, 999)
+	priority := int32(1000)
+	for i := 1; b.Loop(); i++ {
+		regoCode := benchRegoCode(999, i)
+		if _, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+			DisplayName: strPtr("Policy 999"),
+			PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+			Priority:    &priority,
+			RegoCode:    &regoCode,
+		}, strPtr("policy-999"), service.PolicyWriteOptions{}); err != nil {
+			b.Fatal(err)
+		}
+
+		b.StopTimer()
+		if err := policyService.DeletePolicy(ctx, "policy-999"); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+	}
+}
+
+// BenchmarkUpdatePolicy1000 replaces the Rego code of one of 1000 stored policies
+func BenchmarkUpdatePolicy1000(b *testing.B) {
+	ctx := context.Background()
+	policyService := benchService(b, 1000)
+	for i := 1; b.Loop(); i++ {
+		regoCode := benchRegoCode(0, i)
+		if _, err := policyService.UpdatePolicy(ctx, "policy-0", &v1alpha1.Policy{RegoCode: &regoCode}, service.PolicyWriteOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
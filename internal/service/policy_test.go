@@ -2,6 +2,8 @@ package service_test
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/dcm-project/policy-manager/api/v1alpha1"
@@ -35,7 +37,7 @@ var _ = Describe("PolicyService", func() {
 			Logger: logger.Default.LogMode(logger.Silent),
 		})
 		Expect(err).NotTo(HaveOccurred())
-		Expect(db.AutoMigrate(&model.Policy{})).To(Succeed())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
 
 		dataStore = store.NewStore(db)
 
@@ -54,7 +56,7 @@ var _ = Describe("PolicyService", func() {
 	Describe("CreatePolicy", func() {
 		It("should create policy with client-specified ID", func() {
 			clientID := "my-custom-policy"
-			regoCode := "package test\ndefault allow = true"
+			regoCode := "package test\ndefault allow = true\nmain := {\"rejected\": false}"
 
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
@@ -62,7 +64,7 @@ var _ = Describe("PolicyService", func() {
 				RegoCode:    &regoCode,
 			}
 
-			created, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			created, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).NotTo(BeNil())
@@ -79,7 +81,7 @@ var _ = Describe("PolicyService", func() {
 		})
 
 		It("should create policy with server-generated UUID", func() {
-			regoCode := "package test\ndefault allow = true"
+			regoCode := "package test\ndefault allow = true\nmain := {\"rejected\": false}"
 
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
@@ -87,7 +89,7 @@ var _ = Describe("PolicyService", func() {
 				RegoCode:    &regoCode,
 			}
 
-			created, err := policyService.CreatePolicy(ctx, policy, nil)
+			created, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).NotTo(BeNil())
@@ -104,7 +106,7 @@ var _ = Describe("PolicyService", func() {
 				RegoCode:    strPtr(""),
 			}
 
-			_, err := policyService.CreatePolicy(ctx, policy, nil)
+			_, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -118,11 +120,11 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
 
-			_, err := policyService.CreatePolicy(ctx, policy, nil)
+			_, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -136,11 +138,11 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
 
-			_, err := policyService.CreatePolicy(ctx, policy, nil)
+			_, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -154,11 +156,11 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy Min Priority"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
 
-			created, err := policyService.CreatePolicy(ctx, policy, nil)
+			created, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).NotTo(BeNil())
@@ -170,11 +172,11 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy Max Priority"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
 
-			created, err := policyService.CreatePolicy(ctx, policy, nil)
+			created, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).NotTo(BeNil())
@@ -186,11 +188,11 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
 
-			_, err := policyService.CreatePolicy(ctx, policy, nil)
+			_, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -204,11 +206,11 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy Mid Priority"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
 
-			created, err := policyService.CreatePolicy(ctx, policy, nil)
+			created, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).NotTo(BeNil())
@@ -222,7 +224,7 @@ var _ = Describe("PolicyService", func() {
 				RegoCode:    strPtr("   \n\t  "),
 			}
 
-			_, err := policyService.CreatePolicy(ctx, policy, nil)
+			_, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -235,10 +237,10 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
 
-			_, err := policyService.CreatePolicy(ctx, policy, &invalidID)
+			_, err := policyService.CreatePolicy(ctx, policy, &invalidID, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -252,15 +254,15 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
 
 			// Create first policy
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			// Try to create duplicate
-			_, err = policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err = policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -272,22 +274,22 @@ var _ = Describe("PolicyService", func() {
 
 		It("should preserve original Rego when duplicate ID create fails", func() {
 			clientID := "duplicate-rego-preserved"
-			originalRego := "package original\nallow = true"
+			originalRego := "package original\nallow = true\nmain := {\"rejected\": false}"
 			policy1 := v1alpha1.Policy{
 				DisplayName: strPtr("First Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
 				RegoCode:    strPtr(originalRego),
 			}
 
-			_, err := policyService.CreatePolicy(ctx, policy1, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy1, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			policy2 := v1alpha1.Policy{
 				DisplayName: strPtr("Second Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package overwrite\nallow = false"),
+				RegoCode:    strPtr("package overwrite\nallow = false\nmain := {\"rejected\": false}"),
 			}
-			_, err = policyService.CreatePolicy(ctx, policy2, &clientID)
+			_, err = policyService.CreatePolicy(ctx, policy2, &clientID, service.PolicyWriteOptions{})
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
@@ -303,14 +305,14 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Unique Display Name"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
 			id1 := "policy-dn-1"
-			_, err := policyService.CreatePolicy(ctx, policy, &id1)
+			_, err := policyService.CreatePolicy(ctx, policy, &id1, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			id2 := "policy-dn-2"
-			_, err = policyService.CreatePolicy(ctx, policy, &id2)
+			_, err = policyService.CreatePolicy(ctx, policy, &id2, service.PolicyWriteOptions{})
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
@@ -323,21 +325,21 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Policy One"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
 			id1 := "policy-prio-1"
-			_, err := policyService.CreatePolicy(ctx, policy, &id1)
+			_, err := policyService.CreatePolicy(ctx, policy, &id1, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			policy2 := v1alpha1.Policy{
 				DisplayName: strPtr("Policy Two"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
 			id2 := "policy-prio-2"
-			_, err = policyService.CreatePolicy(ctx, policy2, &id2)
+			_, err = policyService.CreatePolicy(ctx, policy2, &id2, service.PolicyWriteOptions{})
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
@@ -350,10 +352,10 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
 
-			created, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			created, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(*created.Enabled).To(BeTrue())
@@ -370,14 +372,14 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName:   strPtr("Test Policy"),
 				PolicyType:    policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:      strPtr("package test"),
+				RegoCode:      strPtr("package test\nmain := {\"rejected\": false}"),
 				Enabled:       &enabled,
 				Priority:      &priority,
 				Description:   &description,
 				LabelSelector: &labelSelector,
 			}
 
-			created, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			created, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(*created.Enabled).To(BeFalse())
@@ -386,6 +388,83 @@ var _ = Describe("PolicyService", func() {
 			Expect(*created.LabelSelector).To(Equal(map[string]string{"env": "prod"}))
 		})
 
+		It("should report a policy with a future effective window as inactive", func() {
+			clientID := "scheduled"
+			from := time.Now().Add(time.Hour)
+			policy := v1alpha1.Policy{
+				DisplayName:   strPtr("Test Policy"),
+				PolicyType:    policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:      strPtr("package test\nmain := {\"rejected\": false}"),
+				EffectiveFrom: &from,
+			}
+
+			created, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*created.Enabled).To(BeTrue())
+			Expect(*created.Active).To(BeFalse())
+			Expect(created.EffectiveFrom.Equal(from)).To(BeTrue())
+		})
+
+		It("should reject a rollout percentage above 100", func() {
+			percentage := int32(101)
+			policy := v1alpha1.Policy{
+				DisplayName:       strPtr("Test Policy"),
+				PolicyType:        policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:          strPtr("package test\nmain := {\"rejected\": false}"),
+				RolloutPercentage: &percentage,
+			}
+
+			_, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(serviceErr.Message).To(Equal("rollout_percentage must be between 0 and 100"))
+		})
+
+		It("should store a partial rollout and return a full one by default", func() {
+			percentage := int32(10)
+			policy := v1alpha1.Policy{
+				DisplayName:       strPtr("Canary Policy"),
+				PolicyType:        policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:          strPtr("package test\nmain := {\"rejected\": false}"),
+				RolloutPercentage: &percentage,
+				RolloutKey:        strPtr("user"),
+			}
+			canaryID := "canary"
+			created, err := policyService.CreatePolicy(ctx, policy, &canaryID, service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*created.RolloutPercentage).To(Equal(int32(10)))
+			Expect(*created.RolloutKey).To(Equal("user"))
+
+			full := int32(100)
+			updated, err := policyService.UpdatePolicy(ctx, canaryID, &v1alpha1.Policy{RolloutPercentage: &full}, service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*updated.RolloutPercentage).To(Equal(int32(100)))
+		})
+
+		It("should reject an effective window that ends before it starts", func() {
+			from := time.Now()
+			until := from.Add(-time.Hour)
+			policy := v1alpha1.Policy{
+				DisplayName:    strPtr("Test Policy"),
+				PolicyType:     policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:       strPtr("package test\nmain := {\"rejected\": false}"),
+				EffectiveFrom:  &from,
+				EffectiveUntil: &until,
+			}
+
+			_, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(serviceErr.Message).To(Equal("Invalid effective window"))
+		})
+
 		It("should reject invalid Rego code", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
@@ -393,7 +472,7 @@ var _ = Describe("PolicyService", func() {
 				RegoCode:    strPtr("package test\n{invalid rego"),
 			}
 
-			_, err := policyService.CreatePolicy(ctx, policy, nil)
+			_, err := policyService.CreatePolicy(ctx, policy, nil, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -404,14 +483,14 @@ var _ = Describe("PolicyService", func() {
 
 		It("should store rego_code in DB and return it on Get", func() {
 			clientID := "rego-store-test"
-			regoCode := "package test\ndefault allow = false"
+			regoCode := "package test\ndefault allow = false\nmain := {\"rejected\": false}"
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Store Rego Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
 				RegoCode:    strPtr(regoCode),
 			}
 
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			// Verify GET returns Rego from DB
@@ -420,18 +499,187 @@ var _ = Describe("PolicyService", func() {
 			Expect(retrieved.RegoCode).NotTo(BeNil())
 			Expect(*retrieved.RegoCode).To(Equal(regoCode))
 		})
+
+		It("should reject Rego code that conflicts with the stored policies with diagnostics", func() {
+			_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Shared"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package shared\nmain := {\"rejected\": false}"),
+			}, strPtr("shared"), service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Clash"),
+				PolicyType:  policyTypePtr(v1alpha1.USER),
+				RegoCode:    strPtr("package shared\n\nmain(x) := x"),
+			}, strPtr("clash"), service.PolicyWriteOptions{})
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(serviceErr.Message).To(Equal("Invalid Rego code"))
+			Expect(serviceErr.Diagnostics).NotTo(BeEmpty())
+			Expect(serviceErr.Diagnostics[0].Message).To(ContainSubstring("conflicting rules data.shared.main"))
+			_, err = policyService.GetPolicy(ctx, "clash")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a policy that violates the decision contract", func() {
+			policy := v1alpha1.Policy{
+				DisplayName: strPtr("No Decision"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.nodecision\n\nallow := true"),
+			}
+
+			_, err := policyService.CreatePolicy(ctx, policy, strPtr("no-decision"), service.PolicyWriteOptions{})
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(serviceErr.Diagnostics).To(ConsistOf(SatisfyAll(
+				HaveField("Severity", v1alpha1.SeverityError),
+				HaveField("Code", opa.CodeMissingMain),
+			)))
+		})
+
+		It("should create a policy with a custom entrypoint", func() {
+			policy := v1alpha1.Policy{
+				DisplayName: strPtr("Custom Entrypoint"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.custom\n\ndecision := {\"rejected\": true}"),
+				Entrypoint:  strPtr("decision"),
+			}
+
+			created, err := policyService.CreatePolicy(ctx, policy, strPtr("custom"), service.PolicyWriteOptions{})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*created.Entrypoint).To(Equal("decision"))
+			result, err := engine.EvaluatePolicy(ctx, "custom", map[string]any{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Result).To(HaveKeyWithValue("rejected", true))
+		})
+
+		It("should report the default entrypoint of a policy", func() {
+			policy := v1alpha1.Policy{
+				DisplayName: strPtr("Default Entrypoint"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.rules\n\ndeny contains \"always\""),
+			}
+
+			created, err := policyService.CreatePolicy(ctx, policy, strPtr("rules"), service.PolicyWriteOptions{})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*created.Entrypoint).To(Equal(opa.DefaultEntrypoint))
+		})
+
+		It("should reject an entrypoint that is not a rule name", func() {
+			policy := v1alpha1.Policy{
+				DisplayName: strPtr("Bad Entrypoint"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.bad\n\nmain := {\"rejected\": false}"),
+				Entrypoint:  strPtr("data.policies.bad.main"),
+			}
+
+			_, err := policyService.CreatePolicy(ctx, policy, strPtr("bad"), service.PolicyWriteOptions{})
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(serviceErr.Message).To(Equal("Invalid entrypoint"))
+		})
+
+		It("should return lint warnings with the created policy", func() {
+			policy := v1alpha1.Policy{
+				DisplayName: strPtr("Typo"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.typo\n\nmain := {\"rejected\": false, \"selected_providers\": \"aws\"}"),
+			}
+
+			created, err := policyService.CreatePolicy(ctx, policy, strPtr("typo"), service.PolicyWriteOptions{})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created.Warnings).NotTo(BeNil())
+			Expect(*created.Warnings).To(ConsistOf(SatisfyAll(
+				HaveField("Severity", v1alpha1.SeverityWarning),
+				HaveField("Code", opa.CodeUnknownDecisionKey),
+				HaveField("Rule", strPtr("main")),
+			)))
+			retrieved, err := policyService.GetPolicy(ctx, "typo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retrieved.Warnings).To(BeNil())
+		})
+
+		Context("with validate_only", func() {
+			It("should return the policy without storing it", func() {
+				policy := v1alpha1.Policy{
+					DisplayName: strPtr("Validated"),
+					PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+					RegoCode:    strPtr("package policies.validated\nmain := {\"rejected\": false}"),
+				}
+
+				validated, err := policyService.CreatePolicy(ctx, policy, strPtr("validated"), service.PolicyWriteOptions{ValidateOnly: true})
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(*validated.Id).To(Equal("validated"))
+				Expect(*validated.Priority).To(Equal(int32(500)))
+				Expect(validated.CreateTime).To(BeNil())
+				_, err = policyService.GetPolicy(ctx, "validated")
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should report compile errors with their position", func() {
+				policy := v1alpha1.Policy{
+					DisplayName: strPtr("Broken"),
+					PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+					RegoCode:    strPtr("package policies.broken\n\nmain := {\"rejected\": data.lib.missing.f(1)}"),
+				}
+
+				_, err := policyService.CreatePolicy(ctx, policy, strPtr("broken"), service.PolicyWriteOptions{ValidateOnly: true})
+
+				Expect(err).To(HaveOccurred())
+				serviceErr, ok := err.(*service.ServiceError)
+				Expect(ok).To(BeTrue())
+				Expect(serviceErr.Diagnostics).To(HaveLen(1))
+				diagnostic := serviceErr.Diagnostics[0]
+				Expect(diagnostic.PolicyId).To(Equal(strPtr("broken")))
+				Expect(diagnostic.Line).To(Equal(int32(3)))
+				Expect(diagnostic.Code).To(Equal("rego_type_error"))
+				Expect(diagnostic.Rule).To(Equal(strPtr("main")))
+			})
+
+			It("should report a policy that already exists", func() {
+				policy := v1alpha1.Policy{
+					DisplayName: strPtr("Existing"),
+					PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+					RegoCode:    strPtr("package policies.existing\nmain := {\"rejected\": false}"),
+				}
+				_, err := policyService.CreatePolicy(ctx, policy, strPtr("existing"), service.PolicyWriteOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				policy.DisplayName = strPtr("Other")
+				_, err = policyService.CreatePolicy(ctx, policy, strPtr("existing"), service.PolicyWriteOptions{ValidateOnly: true})
+
+				Expect(err).To(HaveOccurred())
+				serviceErr, ok := err.(*service.ServiceError)
+				Expect(ok).To(BeTrue())
+				Expect(serviceErr.Type).To(Equal(service.ErrorTypeAlreadyExists))
+			})
+		})
 	})
 
 	Describe("GetPolicy", func() {
 		It("should get existing policy with RegoCode from DB", func() {
 			clientID := "get-test"
-			regoCode := "package test\ndefault allow = true"
+			regoCode := "package test\ndefault allow = true\nmain := {\"rejected\": false}"
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
 				RegoCode:    strPtr(regoCode),
 			}
-			created, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			created, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			retrieved, err := policyService.GetPolicy(ctx, "get-test")
@@ -480,12 +728,12 @@ var _ = Describe("PolicyService", func() {
 				policy := v1alpha1.Policy{
 					DisplayName: &displayName,
 					PolicyType:  policyTypePtr(p.policyType),
-					RegoCode:    strPtr("package test"),
+					RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 					Enabled:     &enabled,
 					Priority:    &priority,
 				}
 				id := p.id
-				_, err := policyService.CreatePolicy(ctx, policy, &id)
+				_, err := policyService.CreatePolicy(ctx, policy, &id, service.PolicyWriteOptions{})
 				Expect(err).ToNot(HaveOccurred())
 			}
 		})
@@ -587,6 +835,79 @@ var _ = Describe("PolicyService", func() {
 			Expect(result2.NextPageToken).To(BeNil()) // No more pages
 		})
 
+		It("should return the total size across pages", func() {
+			filter := "enabled"
+			pageSize := int32(1)
+			result, err := policyService.ListPolicies(ctx, &filter, nil, nil, &pageSize)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(1))
+			Expect(result.TotalSize).NotTo(BeNil())
+			Expect(*result.TotalSize).To(Equal(int32(2)))
+		})
+
+		It("should resume from a page token with a custom ordering", func() {
+			orderBy := "priority desc"
+			pageSize := int32(3)
+			result, err := policyService.ListPolicies(ctx, nil, &orderBy, nil, &pageSize)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.NextPageToken).NotTo(BeNil())
+
+			result, err = policyService.ListPolicies(ctx, nil, &orderBy, result.NextPageToken, &pageSize)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(1))
+			Expect(*result.Policies[0].Id).To(Equal("policy-1"))
+		})
+
+		Describe("page token validation", func() {
+			var token string
+
+			expectInvalidToken := func(filter, orderBy *string, pageToken string) {
+				GinkgoHelper()
+				pageSize := int32(2)
+				_, err := policyService.ListPolicies(ctx, filter, orderBy, &pageToken, &pageSize)
+				Expect(err).To(HaveOccurred())
+				serviceErr, ok := err.(*service.ServiceError)
+				Expect(ok).To(BeTrue())
+				Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+				Expect(serviceErr.Message).To(Equal("Invalid page token"))
+			}
+
+			BeforeEach(func() {
+				pageSize := int32(2)
+				result, err := policyService.ListPolicies(ctx, nil, nil, nil, &pageSize)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.NextPageToken).NotTo(BeNil())
+				token = *result.NextPageToken
+			})
+
+			It("should reject a malformed token", func() {
+				expectInvalidToken(nil, nil, "not-a-token")
+			})
+
+			It("should reject a legacy offset token", func() {
+				expectInvalidToken(nil, nil, "Mg==")
+			})
+
+			It("should reject a modified token", func() {
+				payload, checksum, _ := strings.Cut(token, ".")
+				tampered := []byte(payload)
+				tampered[len(tampered)/2] ^= 1
+				expectInvalidToken(nil, nil, string(tampered)+"."+checksum)
+			})
+
+			It("should reject a token issued for a different filter", func() {
+				filter := "enabled"
+				expectInvalidToken(&filter, nil, token)
+			})
+
+			It("should reject a token issued for a different ordering", func() {
+				orderBy := "priority desc"
+				expectInvalidToken(nil, &orderBy, token)
+			})
+		})
+
 		It("should validate page size minimum", func() {
 			pageSize := int32(0)
 			_, err := policyService.ListPolicies(ctx, nil, nil, nil, &pageSize)
@@ -639,11 +960,11 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Original Name"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package original"),
+				RegoCode:    strPtr("package original\nmain := {\"rejected\": false}"),
 				Enabled:     &enabled,
 				Priority:    &priority,
 			}
-			created, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			created, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			// PATCH: only update display_name, enabled, priority, description
@@ -658,7 +979,7 @@ var _ = Describe("PolicyService", func() {
 				Description: &newDescription,
 			}
 
-			updated, err := policyService.UpdatePolicy(ctx, "update-test", patch)
+			updated, err := policyService.UpdatePolicy(ctx, "update-test", patch, service.PolicyWriteOptions{})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(updated.DisplayName).NotTo(BeNil())
@@ -671,21 +992,42 @@ var _ = Describe("PolicyService", func() {
 			Expect(updated.UpdateTime).NotTo(Equal(created.UpdateTime)) // UpdateTime changed
 		})
 
+		It("should recompile the engine when the entrypoint changes", func() {
+			policy := v1alpha1.Policy{
+				DisplayName: strPtr("Two Decisions"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.two\n\nmain := {\"rejected\": false}\n\nstrict := {\"rejected\": true}"),
+			}
+			_, err := policyService.CreatePolicy(ctx, policy, strPtr("two"), service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = policyService.UpdatePolicy(ctx, "two", &v1alpha1.Policy{Entrypoint: strPtr("strict")}, service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			result, err := engine.EvaluatePolicy(ctx, "two", map[string]any{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Result).To(HaveKeyWithValue("rejected", true))
+
+			_, err = policyService.UpdatePolicy(ctx, "two", &v1alpha1.Policy{Entrypoint: strPtr("missing")}, service.PolicyWriteOptions{})
+			var serviceErr *service.ServiceError
+			Expect(errors.As(err, &serviceErr)).To(BeTrue())
+			Expect(serviceErr.Diagnostics).To(ConsistOf(HaveField("Code", opa.CodeMissingMain)))
+		})
+
 		It("should update Rego code and recompile engine", func() {
 			clientID := "update-rego-test"
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test\ndefault allow = false"),
+				RegoCode:    strPtr("package test\ndefault allow = false\nmain := {\"rejected\": false}"),
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
-			newRego := "package test\ndefault allow = true"
+			newRego := "package test\ndefault allow = true\nmain := {\"rejected\": false}"
 			patch := &v1alpha1.Policy{
 				RegoCode: &newRego,
 			}
-			_, err = policyService.UpdatePolicy(ctx, clientID, patch)
+			_, err = policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			// Verify GET returns new Rego
@@ -699,9 +1041,9 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			emptyRego := ""
@@ -709,7 +1051,7 @@ var _ = Describe("PolicyService", func() {
 				RegoCode: &emptyRego,
 			}
 
-			_, err = policyService.UpdatePolicy(ctx, "update-rego-empty-test", patch)
+			_, err = policyService.UpdatePolicy(ctx, "update-rego-empty-test", patch, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -722,16 +1064,16 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			invalidRego := "package test\n{invalid rego"
 			patch := &v1alpha1.Policy{
 				RegoCode: &invalidRego,
 			}
-			_, err = policyService.UpdatePolicy(ctx, clientID, patch)
+			_, err = policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -741,7 +1083,7 @@ var _ = Describe("PolicyService", func() {
 			// Verify old Rego still in DB
 			retrieved, err := policyService.GetPolicy(ctx, clientID)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(*retrieved.RegoCode).To(Equal("package test"))
+			Expect(*retrieved.RegoCode).To(Equal("package test\nmain := {\"rejected\": false}"))
 		})
 
 		It("should not recompile when RegoCode not in patch", func() {
@@ -749,15 +1091,15 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			patch := &v1alpha1.Policy{
 				DisplayName: strPtr("Updated Name"),
 			}
-			updated, err := policyService.UpdatePolicy(ctx, clientID, patch)
+			updated, err := policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(updated).NotTo(BeNil())
@@ -765,7 +1107,7 @@ var _ = Describe("PolicyService", func() {
 			// Rego should be preserved
 			retrieved, err := policyService.GetPolicy(ctx, clientID)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(*retrieved.RegoCode).To(Equal("package test"))
+			Expect(*retrieved.RegoCode).To(Equal("package test\nmain := {\"rejected\": false}"))
 		})
 
 		It("should return NotFound error for non-existent policy", func() {
@@ -774,7 +1116,7 @@ var _ = Describe("PolicyService", func() {
 				DisplayName: &displayName,
 			}
 
-			_, err := policyService.UpdatePolicy(ctx, "non-existent", patch)
+			_, err := policyService.UpdatePolicy(ctx, "non-existent", patch, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -783,7 +1125,7 @@ var _ = Describe("PolicyService", func() {
 		})
 
 		It("should return AlreadyExists when updating to another policy's display_name and policy_type", func() {
-			regoCode := "package test"
+			regoCode := "package test\nmain := {\"rejected\": false}"
 			prioA := int32(200)
 			prioB := int32(300)
 			idA := "update-dn-a"
@@ -793,14 +1135,14 @@ var _ = Describe("PolicyService", func() {
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
 				RegoCode:    &regoCode,
 				Priority:    &prioA,
-			}, &idA)
+			}, &idA, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 			_, err = policyService.CreatePolicy(ctx, v1alpha1.Policy{
 				DisplayName: strPtr("Name B"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
 				RegoCode:    &regoCode,
 				Priority:    &prioB,
-			}, &idB)
+			}, &idB, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			displayNameA := "Name A"
@@ -808,7 +1150,7 @@ var _ = Describe("PolicyService", func() {
 				DisplayName: &displayNameA,
 				Priority:    &prioB,
 			}
-			_, err = policyService.UpdatePolicy(ctx, "update-dn-b", patch)
+			_, err = policyService.UpdatePolicy(ctx, "update-dn-b", patch, service.PolicyWriteOptions{})
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
@@ -817,7 +1159,7 @@ var _ = Describe("PolicyService", func() {
 		})
 
 		It("should return AlreadyExists when updating to another policy's priority and policy_type", func() {
-			regoCode := "package test"
+			regoCode := "package test\nmain := {\"rejected\": false}"
 			prio200 := int32(200)
 			prio300 := int32(300)
 			idA := "update-prio-a"
@@ -827,14 +1169,14 @@ var _ = Describe("PolicyService", func() {
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
 				RegoCode:    &regoCode,
 				Priority:    &prio200,
-			}, &idA)
+			}, &idA, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 			_, err = policyService.CreatePolicy(ctx, v1alpha1.Policy{
 				DisplayName: strPtr("Policy B"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
 				RegoCode:    &regoCode,
 				Priority:    &prio300,
-			}, &idB)
+			}, &idB, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			displayNameB := "Policy B"
@@ -842,7 +1184,7 @@ var _ = Describe("PolicyService", func() {
 				DisplayName: &displayNameB,
 				Priority:    &prio200,
 			}
-			_, err = policyService.UpdatePolicy(ctx, "update-prio-b", patch)
+			_, err = policyService.UpdatePolicy(ctx, "update-prio-b", patch, service.PolicyWriteOptions{})
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
@@ -856,10 +1198,10 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			invalidPriority := int32(0)
@@ -867,7 +1209,7 @@ var _ = Describe("PolicyService", func() {
 				Priority: &invalidPriority,
 			}
 
-			_, err = policyService.UpdatePolicy(ctx, "update-prio-min-test", patch)
+			_, err = policyService.UpdatePolicy(ctx, "update-prio-min-test", patch, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -882,10 +1224,10 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			invalidPriority := int32(1001)
@@ -893,7 +1235,7 @@ var _ = Describe("PolicyService", func() {
 				Priority: &invalidPriority,
 			}
 
-			_, err = policyService.UpdatePolicy(ctx, "update-prio-max-test", patch)
+			_, err = policyService.UpdatePolicy(ctx, "update-prio-max-test", patch, service.PolicyWriteOptions{})
 
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
@@ -908,10 +1250,10 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test Policy"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 				Priority:    &priority,
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			newPriority := int32(800)
@@ -919,22 +1261,104 @@ var _ = Describe("PolicyService", func() {
 				Priority: &newPriority,
 			}
 
-			updated, err := policyService.UpdatePolicy(ctx, "update-prio-valid-test", patch)
+			updated, err := policyService.UpdatePolicy(ctx, "update-prio-valid-test", patch, service.PolicyWriteOptions{})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(updated).NotTo(BeNil())
 			Expect(*updated.Priority).To(Equal(int32(800)))
 		})
 
+		It("should validate the effective window merged with the existing policy", func() {
+			clientID := "update-window-test"
+			from := time.Now().Add(time.Hour)
+			policy := v1alpha1.Policy{
+				DisplayName:   strPtr("Test Policy"),
+				PolicyType:    policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:      strPtr("package test\nmain := {\"rejected\": false}"),
+				EffectiveFrom: &from,
+			}
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			until := time.Now()
+			_, err = policyService.UpdatePolicy(ctx, clientID, &v1alpha1.Policy{EffectiveUntil: &until}, service.PolicyWriteOptions{})
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Message).To(Equal("Invalid effective window"))
+
+			past := time.Now().Add(-time.Hour)
+			updated, err := policyService.UpdatePolicy(ctx, clientID, &v1alpha1.Policy{EffectiveFrom: &past}, service.PolicyWriteOptions{})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*updated.Active).To(BeTrue())
+		})
+
+		It("should clear the fields listed in the update mask and absent from the patch", func() {
+			clientID := "update-mask-test"
+			from := time.Now().Add(time.Hour)
+			until := from.Add(time.Hour)
+			policy := v1alpha1.Policy{
+				DisplayName:    strPtr("Test Policy"),
+				Description:    strPtr("Before"),
+				PolicyType:     policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:       strPtr("package test\nmain := {\"rejected\": false}"),
+				EffectiveFrom:  &from,
+				EffectiveUntil: &until,
+			}
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// The description is not in the mask, so it is ignored
+			updated, err := policyService.UpdatePolicy(ctx, clientID, &v1alpha1.Policy{Description: strPtr("After")},
+				service.PolicyWriteOptions{UpdateMask: []string{"effective_from", "effective_until"}})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated.EffectiveFrom).To(BeNil())
+			Expect(updated.EffectiveUntil).To(BeNil())
+			Expect(*updated.Description).To(Equal("Before"))
+			Expect(*updated.Active).To(BeTrue())
+			fetched, err := policyService.GetPolicy(ctx, clientID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fetched.EffectiveFrom).To(BeNil())
+			Expect(fetched.EffectiveUntil).To(BeNil())
+		})
+
+		It("should reject an update mask listing an unknown field or clearing a required one", func() {
+			clientID := "update-mask-invalid-test"
+			policy := v1alpha1.Policy{
+				DisplayName: strPtr("Test Policy"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
+			}
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			for mask, detail := range map[string]string{
+				"policy_type":  "Field 'policy_type' cannot be updated",
+				"display_name": "Field 'display_name' cannot be cleared",
+			} {
+				_, err = policyService.UpdatePolicy(ctx, clientID, &v1alpha1.Policy{}, service.PolicyWriteOptions{UpdateMask: []string{mask}})
+
+				Expect(err).To(HaveOccurred())
+				serviceErr, ok := err.(*service.ServiceError)
+				Expect(ok).To(BeTrue())
+				Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+				Expect(serviceErr.Message).To(Equal("Invalid update mask"))
+				Expect(serviceErr.Detail).To(Equal(detail))
+			}
+		})
+
 		// Immutable/readOnly field validation: patch must not change path, id, policy_type, create_time, update_time.
 		It("should reject patch when path is different from existing", func() {
 			clientID := "immutable-path-test"
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Path Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			wrongPath := "policies/other-id"
@@ -942,7 +1366,7 @@ var _ = Describe("PolicyService", func() {
 				Path:        &wrongPath,
 				DisplayName: strPtr("Updated"),
 			}
-			_, err = policyService.UpdatePolicy(ctx, clientID, patch)
+			_, err = policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
@@ -955,9 +1379,9 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Path Same Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			created, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			created, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created.Path).NotTo(BeNil())
 
@@ -965,7 +1389,7 @@ var _ = Describe("PolicyService", func() {
 				Path:        created.Path,
 				DisplayName: strPtr("Updated Name"),
 			}
-			updated, err := policyService.UpdatePolicy(ctx, clientID, patch)
+			updated, err := policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(*updated.DisplayName).To(Equal("Updated Name"))
 			Expect(*updated.Path).To(Equal("policies/" + clientID))
@@ -976,9 +1400,9 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("ID Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			wrongID := "other-id"
@@ -986,7 +1410,7 @@ var _ = Describe("PolicyService", func() {
 				Id:          &wrongID,
 				DisplayName: strPtr("Updated"),
 			}
-			_, err = policyService.UpdatePolicy(ctx, clientID, patch)
+			_, err = policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
@@ -999,16 +1423,16 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Type Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			patch := &v1alpha1.Policy{
 				PolicyType:  policyTypePtr(v1alpha1.USER),
 				DisplayName: strPtr("Updated"),
 			}
-			_, err = policyService.UpdatePolicy(ctx, clientID, patch)
+			_, err = policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
@@ -1021,16 +1445,16 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Type Same Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			created, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			created, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			patch := &v1alpha1.Policy{
 				PolicyType:  created.PolicyType,
 				DisplayName: strPtr("Updated Name"),
 			}
-			updated, err := policyService.UpdatePolicy(ctx, clientID, patch)
+			updated, err := policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(*updated.DisplayName).To(Equal("Updated Name"))
 			Expect(*updated.PolicyType).To(Equal(v1alpha1.GLOBAL))
@@ -1041,9 +1465,9 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("CreateTime Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			otherTime := time.Now().Add(-24 * time.Hour)
@@ -1051,7 +1475,7 @@ var _ = Describe("PolicyService", func() {
 				CreateTime:  &otherTime,
 				DisplayName: strPtr("Updated"),
 			}
-			_, err = policyService.UpdatePolicy(ctx, clientID, patch)
+			_, err = policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
@@ -1064,9 +1488,9 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("UpdateTime Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			created, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			created, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			otherTime := time.Now().Add(24 * time.Hour)
@@ -1074,7 +1498,7 @@ var _ = Describe("PolicyService", func() {
 				UpdateTime:  &otherTime,
 				DisplayName: strPtr("Updated"),
 			}
-			_, err = policyService.UpdatePolicy(ctx, clientID, patch)
+			_, err = policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 			Expect(err).To(HaveOccurred())
 			serviceErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
@@ -1093,16 +1517,16 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Mutable Only"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			patch := &v1alpha1.Policy{
 				DisplayName: strPtr("Updated Display"),
 				Description: strPtr("New description"),
 			}
-			updated, err := policyService.UpdatePolicy(ctx, clientID, patch)
+			updated, err := policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(*updated.DisplayName).To(Equal("Updated Display"))
 			Expect(updated.Description).NotTo(BeNil())
@@ -1114,16 +1538,16 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Nil Fields Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			created, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			created, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			// Patch omits path, id, policy_type, create_time, update_time (all nil)
 			patch := &v1alpha1.Policy{
 				DisplayName: strPtr("New Name"),
 			}
-			updated, err := policyService.UpdatePolicy(ctx, clientID, patch)
+			updated, err := policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(*updated.DisplayName).To(Equal("New Name"))
 			Expect(*updated.Id).To(Equal(*created.Id))
@@ -1132,6 +1556,55 @@ var _ = Describe("PolicyService", func() {
 			Expect(updated.CreateTime).NotTo(BeNil())
 			Expect(updated.CreateTime.Equal(*created.CreateTime)).To(BeTrue())
 		})
+
+		It("should reject a change that breaks a dependent policy with diagnostics", func() {
+			_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Helper"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package policies.helper\n\ndouble(x) := x * 2\nmain := {\"rejected\": false}"),
+			}, strPtr("helper"), service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Dependent"),
+				PolicyType:  policyTypePtr(v1alpha1.USER),
+				RegoCode:    strPtr("package policies.dependent\n\nmain := {\"rejected\": data.policies.helper.double(1) > 1}"),
+			}, strPtr("dependent"), service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			patch := &v1alpha1.Policy{RegoCode: strPtr("package policies.helper\n\ntriple(x) := x * 3\nmain := {\"rejected\": false}")}
+			_, err = policyService.UpdatePolicy(ctx, "helper", patch, service.PolicyWriteOptions{ValidateOnly: true})
+
+			Expect(err).To(HaveOccurred())
+			serviceErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+			Expect(serviceErr.Diagnostics).To(ContainElement(SatisfyAll(
+				HaveField("PolicyId", HaveValue(Equal("dependent"))),
+				HaveField("Line", int32(3)),
+				HaveField("Message", "undefined function data.policies.helper.double"),
+			)))
+		})
+
+		It("should validate without changing the policy when validate_only is set", func() {
+			clientID := "validate-update-test"
+			created, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Before"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
+			}, &clientID, service.PolicyWriteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			patch := &v1alpha1.Policy{DisplayName: strPtr("After"), RegoCode: strPtr("package test\nmain := {}")}
+			validated, err := policyService.UpdatePolicy(ctx, clientID, patch, service.PolicyWriteOptions{ValidateOnly: true})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*validated.DisplayName).To(Equal("After"))
+			Expect(validated.UpdateTime.Equal(*created.UpdateTime)).To(BeTrue())
+			stored, err := policyService.GetPolicy(ctx, clientID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*stored.DisplayName).To(Equal("Before"))
+			Expect(*stored.RegoCode).To(Equal("package test\nmain := {\"rejected\": false}"))
+		})
 	})
 
 	Describe("DeletePolicy", func() {
@@ -1140,9 +1613,9 @@ var _ = Describe("PolicyService", func() {
 			policy := v1alpha1.Policy{
 				DisplayName: strPtr("Test"),
 				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
-				RegoCode:    strPtr("package test"),
+				RegoCode:    strPtr("package test\nmain := {\"rejected\": false}"),
 			}
-			_, err := policyService.CreatePolicy(ctx, policy, &clientID)
+			_, err := policyService.CreatePolicy(ctx, policy, &clientID, service.PolicyWriteOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = policyService.DeletePolicy(ctx, "delete-test")
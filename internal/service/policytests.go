@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+)
+
+// runPolicyTests runs the tests of policy against the stored policy set, with policy added to it or
+// replacing the stored version
+func (s *PolicyServiceImpl) runPolicyTests(ctx context.Context, policy model.Policy) ([]opa.TestResult, error) {
+	modules, err := s.candidateModules(ctx, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policies for testing: %w", err)
+	}
+	return s.engine.RunTests(ctx, modules, opa.TestModule{PolicyID: policy.ID, RegoCode: policy.TestCode})
+}
+
+// checkPolicyTests runs the tests of a policy before it is written. A test module that does not compile
+// is always rejected; failing tests are rejected when opts.RequirePassingTests is set and logged otherwise.
+func (s *PolicyServiceImpl) checkPolicyTests(ctx context.Context, policy model.Policy, opts PolicyWriteOptions) error {
+	if strings.TrimSpace(policy.TestCode) == "" {
+		return nil
+	}
+	modules, err := s.candidateModules(ctx, policy)
+	if err != nil {
+		return NewInternalError("Failed to list policies", err.Error(), err)
+	}
+	return s.checkPolicyTestsIn(ctx, modules, policy, opts)
+}
+
+// checkPolicyTestsIn runs the tests of a policy against the given policy set, as checkPolicyTests does
+func (s *PolicyServiceImpl) checkPolicyTestsIn(ctx context.Context, modules []opa.PolicyModule, policy model.Policy, opts PolicyWriteOptions) error {
+	if strings.TrimSpace(policy.TestCode) == "" {
+		return nil
+	}
+	log := logging.FromContext(ctx)
+
+	results, err := s.engine.RunTests(ctx, modules, opa.TestModule{PolicyID: policy.ID, RegoCode: policy.TestCode})
+	if err != nil {
+		if errors.Is(err, opa.ErrInvalidTests) {
+			return NewInvalidArgumentError("Invalid test code", err.Error())
+		}
+		return handleEngineError(err, "test")
+	}
+
+	failures := failedTests(results)
+	if len(failures) == 0 {
+		log.Debug("Policy tests passed", "policy_id", policy.ID, "test_count", len(results))
+		return nil
+	}
+	if opts.RequirePassingTests {
+		return NewPolicyTestsFailedError(policy.ID, failures)
+	}
+	log.Warn("Policy tests failed", "policy_id", policy.ID, "failed_tests", len(failures), "test_count", len(results))
+	return nil
+}
+
+// TestPolicy runs the tests of a stored policy against the current policy set.
+func (s *PolicyServiceImpl) TestPolicy(ctx context.Context, id string) (*v1alpha1.PolicyTestReport, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Testing policy", "policy_id", id)
+
+	dbPolicy, err := s.store.Policy().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrPolicyNotFound) {
+			return nil, NewPolicyNotFoundError(id)
+		}
+		log.Error("Failed to get policy for testing", "policy_id", id, "error", err)
+		return nil, NewInternalError("Failed to get policy", err.Error(), err)
+	}
+
+	var results []opa.TestResult
+	if strings.TrimSpace(dbPolicy.TestCode) != "" {
+		results, err = s.runPolicyTests(ctx, *dbPolicy)
+		if err != nil {
+			if errors.Is(err, opa.ErrInvalidTests) || errors.Is(err, opa.ErrInvalidRego) {
+				return nil, NewFailedPreconditionError("Policy tests do not compile", err.Error())
+			}
+			log.Error("Failed to run policy tests", "policy_id", id, "error", err)
+			return nil, NewInternalError("Failed to run policy tests", err.Error(), err)
+		}
+	}
+
+	report := testReport(results)
+	log.Debug("Policy tests completed", "policy_id", id, "test_count", len(results), "passed", report.Passed)
+	return &report, nil
+}
+
+// failedTests returns the tests that failed or errored
+func failedTests(results []opa.TestResult) []opa.TestResult {
+	var failures []opa.TestResult
+	for _, r := range results {
+		if !r.Passed && !r.Skipped {
+			failures = append(failures, r)
+		}
+	}
+	return failures
+}
+
+func testReport(results []opa.TestResult) v1alpha1.PolicyTestReport {
+	report := v1alpha1.PolicyTestReport{
+		Passed:  len(failedTests(results)) == 0,
+		Results: make([]v1alpha1.PolicyTestResult, len(results)),
+	}
+	for i, r := range results {
+		result := v1alpha1.PolicyTestResult{
+			Name:     r.Name,
+			Status:   testStatus(r),
+			Duration: formatDuration(r.Duration),
+		}
+		if r.Message != "" {
+			result.Message = &r.Message
+		}
+		if r.Output != "" {
+			result.Output = &r.Output
+		}
+		report.Results[i] = result
+	}
+	return report
+}
+
+func testStatus(r opa.TestResult) v1alpha1.PolicyTestResultStatus {
+	switch {
+	case r.Skipped:
+		return v1alpha1.SKIPPED
+	case r.Passed:
+		return v1alpha1.PASSED
+	case r.Errored:
+		return v1alpha1.ERROR
+	default:
+		return v1alpha1.FAILED
+	}
+}
+
+// formatDuration formats d as seconds with an "s" suffix (AEP-142)
+func formatDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
@@ -0,0 +1,202 @@
+package service_test
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	regionRego = `package policies.region
+
+main := {"rejected": input.region != "us-east-1"}
+`
+	passingRegionTests = `package policies.region_test
+
+import data.policies.region
+
+test_allows_us_east if {
+	not region.main.rejected with input as {"region": "us-east-1"}
+}
+
+todo_test_eu if false
+`
+	failingRegionTest = `
+test_allows_eu if {
+	not region.main.rejected with input as {"region": "eu-west-1"}
+}
+`
+	failingRegionTests = `package policies.region_test
+
+import data.policies.region
+` + failingRegionTest
+)
+
+var _ = Describe("PolicyService tests", func() {
+	var (
+		db            *gorm.DB
+		policyService *service.PolicyServiceImpl
+		ctx           context.Context
+	)
+
+	newRegionPolicy := func(testCode string) v1alpha1.Policy {
+		policyType := v1alpha1.GLOBAL
+		return v1alpha1.Policy{
+			DisplayName: strPtr("Region"),
+			PolicyType:  &policyType,
+			RegoCode:    strPtr(regionRego),
+			TestCode:    strPtr(testCode),
+		}
+	}
+
+	expectServiceError := func(err error, errorType service.ErrorType, message string) {
+		GinkgoHelper()
+		Expect(err).To(HaveOccurred())
+		serviceErr, ok := err.(*service.ServiceError)
+		Expect(ok).To(BeTrue())
+		Expect(serviceErr.Type).To(Equal(errorType))
+		Expect(serviceErr.Message).To(Equal(message))
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
+
+		policyService = service.NewPolicyService(store.NewStore(db), opa.NewEngine())
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	Describe("CreatePolicy", func() {
+		It("stores the test code", func() {
+			created, err := policyService.CreatePolicy(ctx, newRegionPolicy(passingRegionTests), strPtr("region"), service.PolicyWriteOptions{RequirePassingTests: true})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.TestCode).NotTo(BeNil())
+			Expect(*created.TestCode).To(Equal(passingRegionTests))
+		})
+
+		It("saves a policy with failing tests by default", func() {
+			_, err := policyService.CreatePolicy(ctx, newRegionPolicy(failingRegionTests), strPtr("region"), service.PolicyWriteOptions{})
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a policy with failing tests when passing tests are required", func() {
+			_, err := policyService.CreatePolicy(ctx, newRegionPolicy(failingRegionTests), strPtr("region"), service.PolicyWriteOptions{RequirePassingTests: true})
+
+			expectServiceError(err, service.ErrorTypeFailedPrecondition, "Policy tests failed")
+			Expect(err.(*service.ServiceError).Detail).To(ContainSubstring("data.policies.region_test.test_allows_eu"))
+			_, getErr := policyService.GetPolicy(ctx, "region")
+			expectServiceError(getErr, service.ErrorTypeNotFound, "Policy not found")
+		})
+
+		It("rejects test code that does not compile", func() {
+			_, err := policyService.CreatePolicy(ctx, newRegionPolicy("package policies.region_test\ntest_x if undefined_rule"), strPtr("region"), service.PolicyWriteOptions{})
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid test code")
+		})
+	})
+
+	Describe("UpdatePolicy", func() {
+		BeforeEach(func() {
+			_, err := policyService.CreatePolicy(ctx, newRegionPolicy(passingRegionTests), strPtr("region"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a Rego change that breaks the tests when passing tests are required", func() {
+			patch := &v1alpha1.Policy{RegoCode: strPtr("package policies.region\n\nmain := {\"rejected\": true}\n")}
+
+			_, err := policyService.UpdatePolicy(ctx, "region", patch, service.PolicyWriteOptions{RequirePassingTests: true})
+
+			expectServiceError(err, service.ErrorTypeFailedPrecondition, "Policy tests failed")
+			policy, err := policyService.GetPolicy(ctx, "region")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*policy.RegoCode).To(Equal(regionRego))
+		})
+
+		It("removes the tests when test_code is set to an empty string", func() {
+			updated, err := policyService.UpdatePolicy(ctx, "region", &v1alpha1.Policy{TestCode: strPtr("")}, service.PolicyWriteOptions{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.TestCode).To(BeNil())
+		})
+	})
+
+	Describe("TestPolicy", func() {
+		It("reports the outcome of every test", func() {
+			_, err := policyService.CreatePolicy(ctx, newRegionPolicy(passingRegionTests+failingRegionTest), strPtr("region"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			report, err := policyService.TestPolicy(ctx, "region")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Passed).To(BeFalse())
+			statuses := map[string]v1alpha1.PolicyTestResultStatus{}
+			for _, r := range report.Results {
+				statuses[r.Name] = r.Status
+				Expect(r.Duration).To(HaveSuffix("s"))
+			}
+			Expect(statuses).To(Equal(map[string]v1alpha1.PolicyTestResultStatus{
+				"data.policies.region_test.test_allows_us_east": v1alpha1.PASSED,
+				"data.policies.region_test.todo_test_eu":        v1alpha1.SKIPPED,
+				"data.policies.region_test.test_allows_eu":      v1alpha1.FAILED,
+			}))
+		})
+
+		It("returns an empty passing report for a policy without tests", func() {
+			_, err := policyService.CreatePolicy(ctx, newRegionPolicy(""), strPtr("region"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			report, err := policyService.TestPolicy(ctx, "region")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Passed).To(BeTrue())
+			Expect(report.Results).To(BeEmpty())
+		})
+
+		It("returns FAILED_PRECONDITION when the tests no longer compile", func() {
+			helperRego := "package policies.helper\n\nmain := {\"rejected\": false}\n\ndouble(x) := x * 2\n"
+			testCode := "package policies.region_test\n\ntest_double if data.policies.helper.double(4) == 8\n"
+			policyType := v1alpha1.GLOBAL
+			priority := int32(10)
+			_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("Helper"),
+				PolicyType:  &policyType,
+				Priority:    &priority,
+				RegoCode:    strPtr(helperRego),
+			}, strPtr("helper"), service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = policyService.CreatePolicy(ctx, newRegionPolicy(testCode), strPtr("region"), service.PolicyWriteOptions{RequirePassingTests: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policyService.DeletePolicy(ctx, "helper")).To(Succeed())
+
+			_, err = policyService.TestPolicy(ctx, "region")
+
+			expectServiceError(err, service.ErrorTypeFailedPrecondition, "Policy tests do not compile")
+		})
+
+		It("returns NOT_FOUND for an unknown policy", func() {
+			_, err := policyService.TestPolicy(ctx, "missing")
+
+			expectServiceError(err, service.ErrorTypeNotFound, "Policy not found")
+		})
+	})
+})
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+)
+
+// ConstraintPreviewRequest is a partial service instance request to preview the constraints of
+type ConstraintPreviewRequest struct {
+	ServiceInstance map[string]any
+	RequestLabels   map[string]string
+}
+
+// ConstraintPreview holds the constraints the applicable policies set for a partial request
+type ConstraintPreview struct {
+	// Schema is a JSON Schema document of the policy input holding the merged constraints
+	Schema map[string]any
+	// Rejections lists the policies that rejected the partial request, in evaluation order
+	Rejections []PolicyRejection
+	// CanaryPolicies lists the matching policies rolled out to a share of requests
+	CanaryPolicies []CanaryPolicy
+	// Generation is the policy set generation the engine was compiled from when the preview started
+	Generation int64
+}
+
+// PolicyRejection is the rejection of a partial request by a policy
+type PolicyRejection struct {
+	PolicyID string
+	Reason   string
+}
+
+// PreviewConstraints evaluates the policies applicable to a partial request and merges the constraints
+// they set, as EvaluateRequest would. Patches and provider selections are not applied, so every policy
+// sees the partial spec, and rejections are collected instead of ending the evaluation. Constraints
+// conflicting with those of higher-priority policies still fail the preview.
+func (s *evaluationService) PreviewConstraints(ctx context.Context, req *ConstraintPreviewRequest) (*ConstraintPreview, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Starting constraint preview", "label_count", len(req.RequestLabels))
+
+	ctx, cancel := s.withDeadline(ctx)
+	defer cancel()
+
+	var generation int64
+	if s.generation != nil {
+		generation = s.generation()
+	}
+
+	constraintCtx := NewConstraintContext()
+	rejections := []PolicyRejection{}
+	selection, err := s.forEachApplicablePolicy(ctx, req.ServiceInstance, req.RequestLabels, func(policy *model.Policy) error {
+		evalResult, err := s.evaluate(ctx, policy, policyInput(req.ServiceInstance, "", constraintCtx), nil)
+		if err != nil {
+			return err
+		}
+		if !evalResult.Defined {
+			return nil
+		}
+
+		decision := opa.ParsePolicyDecision(evalResult.Result)
+		if decision.Rejected {
+			rejections = append(rejections, PolicyRejection{PolicyID: policy.ID, Reason: decision.RejectionReason})
+			return nil
+		}
+		return mergeDecisionConstraints(constraintCtx, decision, policy.ID)
+	})
+	if err != nil {
+		log.Warn("Constraint preview failed", "error", err)
+		return nil, err
+	}
+
+	log.Info("Constraint preview completed", "rejections", len(rejections), "policies_skipped", selection.skipped)
+	return &ConstraintPreview{
+		Schema:         constraintCtx.JSONSchema(),
+		Rejections:     rejections,
+		CanaryPolicies: selection.canaries,
+		Generation:     generation,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreviewConstraints", func() {
+	var (
+		ctx       context.Context
+		mockStore *mockPolicyStore
+		engine    opa.Engine
+		service   EvaluationService
+		request   *ConstraintPreviewRequest
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		engine = opa.NewEngine()
+		mockStore = &mockPolicyStore{}
+		service = NewEvaluationService(mockStore, engine, WithCompiledGeneration(func() int64 { return 3 }))
+		request = &ConstraintPreviewRequest{
+			ServiceInstance: map[string]any{"service_type": "vm", "cpu": 4},
+			RequestLabels:   map[string]string{"service_type": "vm"},
+		}
+	})
+
+	// givenPolicies compiles and stores policies in evaluation order
+	givenPolicies := func(policies ...opa.PolicyModule) {
+		Expect(engine.Compile(ctx, policies)).To(Succeed())
+		mockStore.policies = nil
+		for i, p := range policies {
+			mockStore.policies = append(mockStore.policies, model.Policy{ID: p.ID, Enabled: true, PolicyType: "GLOBAL", Priority: int32(i + 1)})
+		}
+	}
+
+	It("merges the constraints of the applicable policies without applying patches", func() {
+		givenPolicies(
+			opa.PolicyModule{ID: "regions", RegoCode: `package regions
+main := {
+	"rejected": false,
+	"patch": {"region": "eu-west-1"},
+	"constraints": {"region": {"enum": ["eu-west-1", "eu-central-1"]}},
+	"service_provider_constraints": {"allow_list": ["aws", "gcp"]},
+}`},
+			opa.PolicyModule{ID: "cpu", RegoCode: `package cpu
+# The patch of the first policy is not applied
+main := {"rejected": false, "constraints": {"cpu": {"maximum": 8}}} if not input.spec.region`},
+		)
+
+		preview, err := service.PreviewConstraints(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(preview.Generation).To(Equal(int64(3)))
+		Expect(preview.Rejections).To(BeEmpty())
+		properties := preview.Schema["properties"].(map[string]any)
+		Expect(properties["spec"]).To(Equal(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"region": map[string]any{"enum": []any{"eu-west-1", "eu-central-1"}},
+				"cpu":    map[string]any{"maximum": json.Number("8")},
+			},
+		}))
+		Expect(properties["provider"]).To(HaveKeyWithValue("enum", []string{"aws", "gcp"}))
+	})
+
+	It("reports rejections and goes on with the other policies", func() {
+		givenPolicies(
+			opa.PolicyModule{ID: "too-many-cpus", RegoCode: `package too_many_cpus
+main := {"rejected": true, "rejection_reason": "at most 2 CPUs"} if input.spec.cpu > 2`},
+			opa.PolicyModule{ID: "cpu", RegoCode: `package cpu
+main := {"rejected": false, "constraints": {"cpu": {"maximum": 2}}}`},
+		)
+
+		preview, err := service.PreviewConstraints(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(preview.Rejections).To(Equal([]PolicyRejection{{PolicyID: "too-many-cpus", Reason: "at most 2 CPUs"}}))
+		spec := preview.Schema["properties"].(map[string]any)["spec"].(map[string]any)
+		Expect(spec["properties"]).To(HaveKey("cpu"))
+	})
+
+	It("skips policies whose label selector does not match", func() {
+		givenPolicies(opa.PolicyModule{ID: "storage", RegoCode: `package storage
+main := {"rejected": false, "constraints": {"size": {"maximum": 100}}}`})
+		mockStore.policies[0].LabelSelector = map[string]string{"service_type": "storage"}
+
+		preview, err := service.PreviewConstraints(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		spec := preview.Schema["properties"].(map[string]any)["spec"].(map[string]any)
+		Expect(spec).NotTo(HaveKey("properties"))
+	})
+
+	It("fails on constraints loosening those of higher-priority policies", func() {
+		givenPolicies(
+			opa.PolicyModule{ID: "strict", RegoCode: `package strict
+main := {"rejected": false, "constraints": {"cpu": {"maximum": 2}}}`},
+			opa.PolicyModule{ID: "loose", RegoCode: `package loose
+main := {"rejected": false, "constraints": {"cpu": {"maximum": 16}}}`},
+		)
+
+		_, err := service.PreviewConstraints(ctx, request)
+		var serviceErr *ServiceError
+		Expect(err).To(BeAssignableToTypeOf(serviceErr))
+		Expect(err.(*ServiceError).Type).To(Equal(ErrorTypePolicyConflict))
+	})
+})
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+)
+
+// Positions of a placement relative to the other policies of the same type
+const (
+	placementEnd    = "end"
+	placementBefore = "before"
+	placementAfter  = "after"
+)
+
+// placement is a parsed placement option: end, before:<policy-id> or after:<policy-id>
+type placement struct {
+	position string
+	anchor   string
+}
+
+func parsePlacement(s string) (placement, error) {
+	if s == placementEnd {
+		return placement{position: placementEnd}, nil
+	}
+	position, anchor, ok := strings.Cut(s, ":")
+	if !ok || (position != placementBefore && position != placementAfter) || !idPattern.MatchString(anchor) {
+		return placement{}, NewInvalidArgumentError(
+			"Invalid placement",
+			fmt.Sprintf("Placement '%s' must be 'end', 'before:<policy-id>' or 'after:<policy-id>'", s),
+		)
+	}
+	return placement{position: position, anchor: anchor}, nil
+}
+
+// policiesByPriority returns the stored policies of a policy type in evaluation order
+func (s *PolicyServiceImpl) policiesByPriority(ctx context.Context, policyType string) (model.PolicyList, error) {
+	all, err := s.store.Policy().ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policies := slices.DeleteFunc(all, func(p model.Policy) bool { return p.PolicyType != policyType })
+	slices.SortFunc(policies, func(a, b model.Policy) int { return cmp.Compare(a.Priority, b.Priority) })
+	return policies, nil
+}
+
+// priorityShift records the policies moved by one priority to make room for a placed policy
+type priorityShift struct {
+	next     map[string]int32
+	previous map[string]int32
+}
+
+// placePriority returns the priority of a new policy of policyType placed as requested, and the
+// policies that have to be shifted by one to make room for it. The priority is taken
+// from the middle of the free range between the neighbouring policies, so that later insertions find room.
+func (s *PolicyServiceImpl) placePriority(ctx context.Context, policyType string, p placement) (int32, priorityShift, error) {
+	policies, err := s.policiesByPriority(ctx, policyType)
+	if err != nil {
+		return 0, priorityShift{}, NewInternalError("Failed to list policies", err.Error(), err)
+	}
+
+	// k is the index the new policy is inserted at
+	k := len(policies)
+	if p.position != placementEnd {
+		k = slices.IndexFunc(policies, func(policy model.Policy) bool { return policy.ID == p.anchor })
+		if k < 0 {
+			return 0, priorityShift{}, NewInvalidArgumentError(
+				"Invalid placement",
+				fmt.Sprintf("Placement policy '%s' is not a %s policy", p.anchor, policyType),
+			)
+		}
+		if p.position == placementAfter {
+			k++
+		}
+	}
+
+	// priorityAt returns the priority at index i, bounded by the free range outside the list
+	priorityAt := func(i int) int32 {
+		switch {
+		case i < 0:
+			return MinPriority - 1
+		case i >= len(policies):
+			return MaxPriority + 1
+		default:
+			return policies[i].Priority
+		}
+	}
+
+	if lo, hi := priorityAt(k-1), priorityAt(k); hi-lo >= 2 {
+		return lo + (hi-lo)/2, priorityShift{}, nil
+	}
+
+	// No free priority between the neighbours: shift the policies after the insertion point up to the
+	// next gap, or else the policies before it down to the previous gap
+	for j := k; j < len(policies); j++ {
+		if priorityAt(j+1)-priorityAt(j) >= 2 {
+			shifted, err := shiftPriorities(policies[k:j+1], 1)
+			return priorityAt(k), shifted, err
+		}
+	}
+	for i := k - 1; i >= 0; i-- {
+		if priorityAt(i)-priorityAt(i-1) >= 2 {
+			shifted, err := shiftPriorities(policies[i:k], -1)
+			return priorityAt(k - 1), shifted, err
+		}
+	}
+	return 0, priorityShift{}, NewFailedPreconditionError(
+		"No free priority",
+		fmt.Sprintf("All priorities between %d and %d are taken by %s policies", MinPriority, MaxPriority, policyType),
+	)
+}
+
+// shiftPriorities moves policies by delta. Managed policies cannot be moved.
+func shiftPriorities(policies model.PolicyList, delta int32) (priorityShift, error) {
+	shift := priorityShift{
+		next:     make(map[string]int32, len(policies)),
+		previous: make(map[string]int32, len(policies)),
+	}
+	for _, p := range policies {
+		if p.Managed {
+			return priorityShift{}, NewPolicyManagedError(p.ID)
+		}
+		shift.next[p.ID] = p.Priority + delta
+		shift.previous[p.ID] = p.Priority
+	}
+	return shift, nil
+}
+
+// ReorderPolicies sets the evaluation order of all policies of a policy type. The priorities held by the
+// policies are reassigned in ascending order following ids, in one transaction.
+func (s *PolicyServiceImpl) ReorderPolicies(ctx context.Context, policyType string, ids []string) ([]v1alpha1.Policy, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Reordering policies", "policy_type", policyType, "policy_count", len(ids))
+
+	if err := s.checkDirectWrite(PolicyWriteOptions{}); err != nil {
+		return nil, err
+	}
+	if t := v1alpha1.PolicyPolicyType(policyType); t != v1alpha1.GLOBAL && t != v1alpha1.USER {
+		return nil, NewInvalidArgumentError("Invalid policy type", "The policy_type field must be GLOBAL or USER")
+	}
+	if len(ids) == 0 {
+		return nil, NewInvalidArgumentError("policy_ids is required", "The policy_ids field must list the policies to reorder")
+	}
+
+	all, err := s.store.Policy().ListAll(ctx)
+	if err != nil {
+		log.Error("Failed to list policies for reorder", "error", err)
+		return nil, NewInternalError("Failed to list policies", err.Error(), err)
+	}
+	byID := make(map[string]model.Policy, len(all))
+	for _, p := range all {
+		byID[p.ID] = p
+	}
+
+	listed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		p, ok := byID[id]
+		switch {
+		case !ok:
+			return nil, NewPolicyNotFoundError(id)
+		case p.PolicyType != policyType:
+			return nil, NewInvalidArgumentError("Invalid policy order", fmt.Sprintf("Policy '%s' is not a %s policy", id, policyType))
+		case listed[id]:
+			return nil, NewInvalidArgumentError("Invalid policy order", fmt.Sprintf("Policy '%s' is listed more than once", id))
+		}
+		listed[id] = true
+	}
+
+	policies, err := s.policiesByPriority(ctx, policyType)
+	if err != nil {
+		return nil, NewInternalError("Failed to list policies", err.Error(), err)
+	}
+	var missing []string
+	for _, p := range policies {
+		if !listed[p.ID] {
+			missing = append(missing, p.ID)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, NewInvalidArgumentError(
+			"Invalid policy order",
+			fmt.Sprintf("policy_ids must list every %s policy; missing: %s", policyType, strings.Join(missing, ", ")),
+		)
+	}
+
+	// policies is ordered by priority, so policies[i].Priority is the i-th smallest priority of the type
+	changed := make(map[string]int32)
+	for i, id := range ids {
+		p := byID[id]
+		if p.Priority == policies[i].Priority {
+			continue
+		}
+		if p.Managed {
+			return nil, NewPolicyManagedError(id)
+		}
+		changed[id] = policies[i].Priority
+	}
+
+	if len(changed) > 0 {
+		// A reorder changes no Rego code, so the engine is not recompiled. The generation lock is held from
+		// the write until the change is published, as for any other write.
+		unlock := s.lockGeneration()
+		defer unlock()
+		err := s.store.Transaction(ctx, func(tx store.Store) error {
+			return tx.Policy().SetPriorities(ctx, changed)
+		})
+		if err != nil {
+			log.Error("Failed to set policy priorities", "policy_type", policyType, "error", err)
+			return nil, NewInternalError("Failed to reorder policies", err.Error(), err)
+		}
+		s.publishChangesLocked(ctx, slices.Collect(maps.Keys(changed)))
+	}
+
+	reordered, err := s.policiesByPriority(ctx, policyType)
+	if err != nil {
+		return nil, NewInternalError("Failed to list policies", err.Error(), err)
+	}
+	apiPolicies := make([]v1alpha1.Policy, len(reordered))
+	for i := range reordered {
+		apiPolicies[i] = DBToAPIModel(&reordered[i])
+	}
+
+	log.Debug("Policies reordered", "policy_type", policyType, "changed", len(changed))
+	return apiPolicies, nil
+}
@@ -0,0 +1,218 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("PolicyService priorities", func() {
+	var (
+		db            *gorm.DB
+		policyService *service.PolicyServiceImpl
+		ctx           context.Context
+	)
+
+	expectServiceError := func(err error, errorType service.ErrorType, message string) {
+		GinkgoHelper()
+		Expect(err).To(HaveOccurred())
+		serviceErr, ok := err.(*service.ServiceError)
+		Expect(ok).To(BeTrue())
+		Expect(serviceErr.Type).To(Equal(errorType))
+		Expect(serviceErr.Message).To(Equal(message))
+	}
+
+	newPolicy := func(id string, priority *int32) v1alpha1.Policy {
+		return v1alpha1.Policy{
+			DisplayName: strPtr(id),
+			PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+			Priority:    priority,
+			RegoCode:    strPtr("package policies." + id + "\n\nmain := {\"rejected\": false}\n"),
+		}
+	}
+
+	createPolicy := func(id string, priority int32) {
+		GinkgoHelper()
+		_, err := policyService.CreatePolicy(ctx, newPolicy(id, &priority), strPtr(id), service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	priorityOf := func(id string) int32 {
+		GinkgoHelper()
+		policy, err := policyService.GetPolicy(ctx, id)
+		Expect(err).NotTo(HaveOccurred())
+		return *policy.Priority
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
+
+		policyService = service.NewPolicyService(store.NewStore(db), opa.NewEngine())
+		ctx = context.Background()
+
+		createPolicy("first", 10)
+		createPolicy("second", 11)
+		createPolicy("third", 20)
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	Describe("ReorderPolicies", func() {
+		It("reassigns the priorities held by the policies in the listed order", func() {
+			reordered, err := policyService.ReorderPolicies(ctx, "GLOBAL", []string{"third", "first", "second"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reordered).To(HaveLen(3))
+			Expect(*reordered[0].Id).To(Equal("third"))
+			Expect(*reordered[0].Priority).To(Equal(int32(10)))
+			Expect(priorityOf("first")).To(Equal(int32(11)))
+			Expect(priorityOf("second")).To(Equal(int32(20)))
+		})
+
+		It("reorders without recompiling the engine", func() {
+			engine := &failingCompileEngine{Engine: opa.NewEngine()}
+			policyService = service.NewPolicyService(store.NewStore(db), engine)
+			Expect(policyService.CompileAll(ctx)).To(Succeed())
+			engine.fail = true
+
+			_, err := policyService.ReorderPolicies(ctx, "GLOBAL", []string{"third", "first", "second"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(priorityOf("third")).To(Equal(int32(10)))
+		})
+
+		It("rejects a list that misses a policy of the type", func() {
+			_, err := policyService.ReorderPolicies(ctx, "GLOBAL", []string{"second", "first"})
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid policy order")
+			Expect(err.(*service.ServiceError).Detail).To(ContainSubstring("missing: third"))
+			Expect(priorityOf("first")).To(Equal(int32(10)))
+		})
+
+		It("rejects a policy listed twice", func() {
+			_, err := policyService.ReorderPolicies(ctx, "GLOBAL", []string{"first", "first", "second", "third"})
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid policy order")
+		})
+
+		It("rejects a policy of another type", func() {
+			_, err := policyService.ReorderPolicies(ctx, "USER", []string{"first"})
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid policy order")
+		})
+
+		It("returns NOT_FOUND for an unknown policy", func() {
+			_, err := policyService.ReorderPolicies(ctx, "GLOBAL", []string{"missing"})
+
+			expectServiceError(err, service.ErrorTypeNotFound, "Policy not found")
+		})
+
+		It("rejects moving a managed policy", func() {
+			Expect(db.Model(&model.Policy{}).Where("id = ?", "first").Update("managed", true).Error).To(Succeed())
+
+			_, err := policyService.ReorderPolicies(ctx, "GLOBAL", []string{"second", "first", "third"})
+
+			expectServiceError(err, service.ErrorTypeFailedPrecondition, "Policy is managed")
+		})
+	})
+
+	Describe("CreatePolicy with placement", func() {
+		create := func(id, placement string) (*v1alpha1.Policy, error) {
+			return policyService.CreatePolicy(ctx, newPolicy(id, nil), strPtr(id), service.PolicyWriteOptions{Placement: placement})
+		}
+
+		It("appends the policy after the last one", func() {
+			created, err := create("last", "end")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*created.Priority).To(Equal(int32(510)))
+		})
+
+		It("inserts the policy in the free range between its neighbours", func() {
+			created, err := create("between", "after:second")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*created.Priority).To(Equal(int32(15)))
+		})
+
+		It("shifts the following policies when there is no free priority", func() {
+			created, err := create("between", "before:second")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*created.Priority).To(Equal(int32(11)))
+			Expect(priorityOf("first")).To(Equal(int32(10)))
+			Expect(priorityOf("second")).To(Equal(int32(12)))
+			Expect(priorityOf("third")).To(Equal(int32(20)))
+		})
+
+		It("does not shift anything when validating only", func() {
+			validated, err := policyService.CreatePolicy(ctx, newPolicy("between", nil), strPtr("between"),
+				service.PolicyWriteOptions{Placement: "before:second", ValidateOnly: true})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*validated.Priority).To(Equal(int32(11)))
+			Expect(priorityOf("second")).To(Equal(int32(11)))
+		})
+
+		It("restores the shifted policies when the create fails", func() {
+			policy := newPolicy("between", nil)
+			policy.DisplayName = strPtr("first")
+
+			_, err := policyService.CreatePolicy(ctx, policy, strPtr("between"), service.PolicyWriteOptions{Placement: "before:second"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(priorityOf("second")).To(Equal(int32(11)))
+		})
+
+		It("shifts the policies in the transaction of the create", func() {
+			// The database fails the create and every write after it, as when it goes away
+			failing := false
+			Expect(db.Callback().Create().Before("gorm:create").Register("test:fail_create", func(tx *gorm.DB) {
+				failing = true
+				_ = tx.AddError(errors.New("database is gone"))
+			})).To(Succeed())
+			Expect(db.Callback().Update().Before("gorm:update").Register("test:fail_update", func(tx *gorm.DB) {
+				if failing {
+					_ = tx.AddError(errors.New("database is gone"))
+				}
+			})).To(Succeed())
+
+			_, err := create("between", "before:second")
+
+			Expect(err).To(HaveOccurred())
+			Expect(priorityOf("second")).To(Equal(int32(11)))
+			Expect(priorityOf("third")).To(Equal(int32(20)))
+		})
+
+		It("rejects a placement together with a priority", func() {
+			priority := int32(30)
+			_, err := policyService.CreatePolicy(ctx, newPolicy("both", &priority), strPtr("both"), service.PolicyWriteOptions{Placement: "end"})
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "priority and placement are mutually exclusive")
+		})
+
+		It("rejects a placement next to an unknown policy", func() {
+			_, err := create("orphan", "after:missing")
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid placement")
+		})
+	})
+})
@@ -0,0 +1,722 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/principal"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	"github.com/google/uuid"
+)
+
+// maxProposalTextLength is the maximum length of the description of a proposal and of a review comment
+const maxProposalTextLength = 1000
+
+// proposalValidations caches the results of validating pending proposals against the policy set. A
+// result holds for the stored policy set generation it was computed at; every write to the policy set
+// advances the generation, so that the proposals are validated again on their next read.
+type proposalValidations struct {
+	mu      sync.Mutex
+	results map[string]proposalValidation
+}
+
+type proposalValidation struct {
+	generation int64
+	result     v1alpha1.ProposalValidation
+}
+
+func newProposalValidations() *proposalValidations {
+	return &proposalValidations{results: make(map[string]proposalValidation)}
+}
+
+// get returns the validation result of a proposal computed at generation
+func (v *proposalValidations) get(id string, generation int64) (v1alpha1.ProposalValidation, bool) {
+	if v == nil {
+		return v1alpha1.ProposalValidation{}, false
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	cached, ok := v.results[id]
+	if !ok || cached.generation != generation {
+		return v1alpha1.ProposalValidation{}, false
+	}
+	return cached.result, true
+}
+
+// put records the validation result of a proposal computed at generation
+func (v *proposalValidations) put(id string, generation int64, result v1alpha1.ProposalValidation) {
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.results[id] = proposalValidation{generation: generation, result: result}
+}
+
+// forget drops the validation result of a proposal that is no longer pending
+func (v *proposalValidations) forget(id string) {
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.results, id)
+}
+
+// PolicyServiceOption configures a PolicyServiceImpl
+type PolicyServiceOption func(*PolicyServiceImpl)
+
+// WithRequireApproval makes every change of the policy set through the API go through a proposal that
+// another principal approves. Direct writes are rejected, except for validate-only requests.
+func WithRequireApproval() PolicyServiceOption {
+	return func(s *PolicyServiceImpl) {
+		s.requireApproval = true
+	}
+}
+
+// checkDirectWrite rejects a direct change of the policy set when changes require approval
+func (s *PolicyServiceImpl) checkDirectWrite(opts PolicyWriteOptions) error {
+	if s.requireApproval && !opts.ValidateOnly {
+		return NewApprovalRequiredError()
+	}
+	return nil
+}
+
+// checkDirectLibraryWrite rejects a change of a library when changes require approval, since a library
+// changes the decisions of the policies importing it
+func (s *PolicyServiceImpl) checkDirectLibraryWrite() error {
+	if s.requireApproval {
+		return NewLibraryApprovalRequiredError()
+	}
+	return nil
+}
+
+// requirePrincipal returns the principal of the request, which proposals and reviews are recorded for
+func requirePrincipal(ctx context.Context) (string, error) {
+	name := principal.FromContext(ctx)
+	if name == "" {
+		return "", NewUnauthenticatedError(
+			"Principal required",
+			"Proposals and reviews must identify the caller, but the request carries no principal",
+		)
+	}
+	return name, nil
+}
+
+func validateProposalText(field, text string) error {
+	if len(text) > maxProposalTextLength {
+		return NewInvalidArgumentError(
+			fmt.Sprintf("Invalid %s", field),
+			fmt.Sprintf("The %s field must be at most 1000 characters long", field),
+		)
+	}
+	return nil
+}
+
+// withoutReadOnlyFields returns policy without the fields that are set by the server
+func withoutReadOnlyFields(policy v1alpha1.Policy) v1alpha1.Policy {
+	policy.Path = nil
+	policy.Id = nil
+	policy.CreateTime = nil
+	policy.UpdateTime = nil
+	policy.Managed = nil
+	policy.Active = nil
+	policy.Warnings = nil
+	return policy
+}
+
+// withoutReadOnlyLibraryFields returns library without the fields that are set by the server
+func withoutReadOnlyLibraryFields(library v1alpha1.Library) v1alpha1.Library {
+	library.Path = nil
+	library.Id = nil
+	library.Package = nil
+	library.CreateTime = nil
+	library.UpdateTime = nil
+	return library
+}
+
+// CreateProposal records a proposed change of a policy or library. The change is validated against the
+// current policy set, as a validate-only request, but not applied.
+func (s *PolicyServiceImpl) CreateProposal(ctx context.Context, proposal v1alpha1.PolicyProposal) (*v1alpha1.PolicyProposal, error) {
+	proposer, err := requirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Operation == nil {
+		return nil, NewInvalidArgumentError(
+			"operation is required",
+			"The operation field must be present (CREATE, UPDATE or DELETE)",
+		)
+	}
+	dbProposal := model.PolicyProposal{
+		ID:        uuid.New().String(),
+		Operation: string(*proposal.Operation),
+		State:     model.ProposalPending,
+		Proposer:  proposer,
+	}
+	if proposal.Description != nil {
+		dbProposal.Description = *proposal.Description
+		if err := validateProposalText("description", dbProposal.Description); err != nil {
+			return nil, err
+		}
+	}
+
+	switch *proposal.Operation {
+	case v1alpha1.ProposalCreate, v1alpha1.ProposalUpdate, v1alpha1.ProposalDelete:
+	default:
+		return nil, NewInvalidArgumentError(
+			"Invalid operation",
+			fmt.Sprintf("Operation '%s' must be one of CREATE, UPDATE or DELETE", *proposal.Operation),
+		)
+	}
+	if proposal.LibraryId != nil || proposal.Library != nil {
+		if proposal.PolicyId != nil || proposal.Policy != nil {
+			return nil, NewInvalidArgumentError(
+				"Invalid proposal",
+				"A proposal changes either a policy or a library: set policy_id and policy, or library_id and library",
+			)
+		}
+		err = s.proposeLibrary(ctx, &dbProposal, proposal)
+	} else {
+		err = s.proposePolicy(ctx, &dbProposal, proposal)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log := logging.FromContext(ctx).With("proposal_id", dbProposal.ID, "policy_id", dbProposal.PolicyID, "library_id", dbProposal.LibraryID)
+	log.Debug("Creating proposal", "operation", dbProposal.Operation)
+
+	generation, err := s.store.Generation().Get(ctx)
+	if err != nil {
+		log.Error("Failed to read the policy set generation", "error", err)
+		return nil, NewInternalError("Failed to create proposal", err.Error(), err)
+	}
+	// A change that cannot be applied to the current policy set is not worth reviewing
+	validated, err := s.applyProposal(ctx, &dbProposal, true)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.store.Proposal().Create(ctx, dbProposal)
+	if err != nil {
+		log.Error("Failed to create proposal in store", "error", err)
+		return nil, NewInternalError("Failed to create proposal", err.Error(), err)
+	}
+	s.validations.put(created.ID, generation, validProposal(validated))
+
+	log.Info("Proposal created", "operation", created.Operation, "proposer", proposer)
+	return s.describeProposal(ctx, created)
+}
+
+// proposePolicy records the policy change of a proposal in dbProposal
+func (s *PolicyServiceImpl) proposePolicy(ctx context.Context, dbProposal *model.PolicyProposal, proposal v1alpha1.PolicyProposal) error {
+	if *proposal.Operation == v1alpha1.ProposalCreate {
+		if proposal.Policy == nil {
+			return NewInvalidArgumentError("policy is required", "A CREATE proposal must hold the policy to create")
+		}
+		policyID, err := getPolicyID(proposal.PolicyId)
+		if err != nil {
+			return err
+		}
+		dbProposal.PolicyID = *policyID
+	} else {
+		if proposal.PolicyId == nil || *proposal.PolicyId == "" {
+			return NewInvalidArgumentError(
+				"policy_id is required",
+				fmt.Sprintf("A %s proposal must name the policy to change", *proposal.Operation),
+			)
+		}
+		if *proposal.Operation == v1alpha1.ProposalUpdate && proposal.Policy == nil {
+			return NewInvalidArgumentError("policy is required", "An UPDATE proposal must hold the patch to apply")
+		}
+		if *proposal.Operation == v1alpha1.ProposalDelete && proposal.Policy != nil {
+			return NewInvalidArgumentError("policy must not be set", "A DELETE proposal does not hold a policy")
+		}
+		existing, err := s.existingPolicy(ctx, *proposal.PolicyId, "proposal")
+		if err != nil {
+			return err
+		}
+		dbProposal.PolicyID = existing.ID
+		dbProposal.BaseUpdateTime = &existing.UpdateTime
+	}
+	if proposal.Policy != nil {
+		data, err := json.Marshal(withoutReadOnlyFields(*proposal.Policy))
+		if err != nil {
+			return NewInternalError("Failed to encode proposed policy", err.Error(), err)
+		}
+		dbProposal.Policy = string(data)
+	}
+	return nil
+}
+
+// proposeLibrary records the library change of a proposal in dbProposal
+func (s *PolicyServiceImpl) proposeLibrary(ctx context.Context, dbProposal *model.PolicyProposal, proposal v1alpha1.PolicyProposal) error {
+	if *proposal.Operation == v1alpha1.ProposalCreate {
+		if proposal.Library == nil {
+			return NewInvalidArgumentError("library is required", "A CREATE proposal must hold the library to create")
+		}
+		libraryID, err := getResourceID(proposal.LibraryId, "library", "Library")
+		if err != nil {
+			return err
+		}
+		dbProposal.LibraryID = *libraryID
+	} else {
+		if proposal.LibraryId == nil || *proposal.LibraryId == "" {
+			return NewInvalidArgumentError(
+				"library_id is required",
+				fmt.Sprintf("A %s proposal must name the library to change", *proposal.Operation),
+			)
+		}
+		if *proposal.Operation == v1alpha1.ProposalUpdate && proposal.Library == nil {
+			return NewInvalidArgumentError("library is required", "An UPDATE proposal must hold the patch to apply")
+		}
+		if *proposal.Operation == v1alpha1.ProposalDelete && proposal.Library != nil {
+			return NewInvalidArgumentError("library must not be set", "A DELETE proposal does not hold a library")
+		}
+		existing, err := s.existingLibrary(ctx, *proposal.LibraryId, "proposal")
+		if err != nil {
+			return err
+		}
+		dbProposal.LibraryID = existing.ID
+		dbProposal.BaseUpdateTime = &existing.UpdateTime
+	}
+	if proposal.Library != nil {
+		data, err := json.Marshal(withoutReadOnlyLibraryFields(*proposal.Library))
+		if err != nil {
+			return NewInternalError("Failed to encode proposed library", err.Error(), err)
+		}
+		dbProposal.Library = string(data)
+	}
+	return nil
+}
+
+// GetProposal retrieves a proposal by ID. A pending proposal is validated against the current policy set.
+func (s *PolicyServiceImpl) GetProposal(ctx context.Context, id string) (*v1alpha1.PolicyProposal, error) {
+	logging.FromContext(ctx).Debug("Getting proposal", "proposal_id", id)
+
+	proposal, err := s.getProposal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.describeProposal(ctx, proposal)
+}
+
+// ListProposals lists the proposals in the given state, or all proposals if state is empty, newest
+// first. Pending proposals are validated against the current policy set.
+func (s *PolicyServiceImpl) ListProposals(ctx context.Context, state string) (*v1alpha1.PolicyProposalList, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Listing proposals", "state", state)
+
+	switch state {
+	case "", model.ProposalPending, model.ProposalApproved, model.ProposalRejected:
+	default:
+		return nil, NewInvalidArgumentError(
+			"Invalid state",
+			fmt.Sprintf("State '%s' must be one of PENDING, APPROVED or REJECTED", state),
+		)
+	}
+
+	proposals, err := s.store.Proposal().List(ctx, state)
+	if err != nil {
+		log.Error("Failed to list proposals from store", "error", err)
+		return nil, NewInternalError("Failed to list proposals", err.Error(), err)
+	}
+
+	apiProposals := make([]v1alpha1.PolicyProposal, len(proposals))
+	for i := range proposals {
+		apiProposal, err := s.describeProposal(ctx, &proposals[i])
+		if err != nil {
+			return nil, err
+		}
+		apiProposals[i] = *apiProposal
+	}
+	return &v1alpha1.PolicyProposalList{Proposals: apiProposals}, nil
+}
+
+// ApproveProposal approves a pending proposal of another principal and applies its change. If the change
+// fails, the proposal is left pending and the error is returned.
+func (s *PolicyServiceImpl) ApproveProposal(ctx context.Context, id string, comment string) (*v1alpha1.PolicyProposal, error) {
+	log := logging.FromContext(ctx).With("proposal_id", id)
+
+	reviewed, err := s.reviewProposal(ctx, id, model.ProposalApproved, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.applyProposal(ctx, reviewed, false); err != nil {
+		log.Warn("Approved proposal failed to apply, returning it to pending", "error", err)
+		pending := *reviewed
+		pending.State = model.ProposalPending
+		pending.Reviewer = ""
+		pending.ReviewComment = ""
+		pending.ReviewTime = nil
+		if _, revertErr := s.store.Proposal().Review(ctx, pending, model.ProposalApproved); revertErr != nil {
+			log.Error("Failed to return proposal to pending", "db_error", revertErr, "apply_error", err)
+		}
+		return nil, err
+	}
+
+	log.Info("Proposal approved and applied", "policy_id", reviewed.PolicyID, "library_id", reviewed.LibraryID, "operation", reviewed.Operation, "reviewer", reviewed.Reviewer)
+	return s.describeProposal(ctx, reviewed)
+}
+
+// RejectProposal rejects a pending proposal of another principal
+func (s *PolicyServiceImpl) RejectProposal(ctx context.Context, id string, comment string) (*v1alpha1.PolicyProposal, error) {
+	reviewed, err := s.reviewProposal(ctx, id, model.ProposalRejected, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("Proposal rejected", "proposal_id", id, "policy_id", reviewed.PolicyID, "library_id", reviewed.LibraryID, "reviewer", reviewed.Reviewer)
+	return s.describeProposal(ctx, reviewed)
+}
+
+// reviewProposal moves a pending proposal to state on behalf of the principal of the request, who must
+// not be the proposer
+func (s *PolicyServiceImpl) reviewProposal(ctx context.Context, id string, state string, comment string) (*model.PolicyProposal, error) {
+	reviewer, err := requirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateProposalText("comment", comment); err != nil {
+		return nil, err
+	}
+
+	proposal, err := s.getProposal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.State != model.ProposalPending {
+		return nil, newProposalNotPendingError(id, proposal.State)
+	}
+	if proposal.Proposer == reviewer {
+		return nil, NewPermissionDeniedError(
+			"Proposal cannot be reviewed by its proposer",
+			fmt.Sprintf("Proposal '%s' was made by '%s' and must be reviewed by another principal", id, reviewer),
+		)
+	}
+
+	now := time.Now().UTC()
+	proposal.State = state
+	proposal.Reviewer = reviewer
+	proposal.ReviewComment = comment
+	proposal.ReviewTime = &now
+	reviewed, err := s.store.Proposal().Review(ctx, *proposal, model.ProposalPending)
+	if err != nil {
+		if errors.Is(err, store.ErrProposalStateChanged) {
+			return nil, newProposalNotPendingError(id, "")
+		}
+		logging.FromContext(ctx).Error("Failed to review proposal in store", "proposal_id", id, "error", err)
+		return nil, NewInternalError("Failed to review proposal", err.Error(), err)
+	}
+	return reviewed, nil
+}
+
+func newProposalNotPendingError(id, state string) *ServiceError {
+	detail := fmt.Sprintf("Proposal '%s' was already reviewed", id)
+	if state != "" {
+		detail = fmt.Sprintf("Proposal '%s' is %s", id, state)
+	}
+	return NewFailedPreconditionError("Proposal is not pending", detail)
+}
+
+func (s *PolicyServiceImpl) getProposal(ctx context.Context, id string) (*model.PolicyProposal, error) {
+	proposal, err := s.store.Proposal().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrProposalNotFound) {
+			return nil, NewProposalNotFoundError(id)
+		}
+		logging.FromContext(ctx).Error("Failed to get proposal from store", "proposal_id", id, "error", err)
+		return nil, NewInternalError("Failed to get proposal", err.Error(), err)
+	}
+	return proposal, nil
+}
+
+// proposedPolicy decodes the policy or patch held by a proposal
+func proposedPolicy(proposal *model.PolicyProposal) (*v1alpha1.Policy, error) {
+	if proposal.Policy == "" {
+		return nil, nil
+	}
+	var policy v1alpha1.Policy
+	if err := json.Unmarshal([]byte(proposal.Policy), &policy); err != nil {
+		return nil, NewInternalError("Failed to decode proposed policy", err.Error(), err)
+	}
+	return &policy, nil
+}
+
+// proposedLibrary decodes the library or patch held by a proposal
+func proposedLibrary(proposal *model.PolicyProposal) (*v1alpha1.Library, error) {
+	if proposal.Library == "" {
+		return nil, nil
+	}
+	var library v1alpha1.Library
+	if err := json.Unmarshal([]byte(proposal.Library), &library); err != nil {
+		return nil, NewInternalError("Failed to decode proposed library", err.Error(), err)
+	}
+	return &library, nil
+}
+
+// applyProposal applies the change of a proposal through the policy or library methods, or validates it
+// with validateOnly. The change of an UPDATE or DELETE is refused if the policy or library changed since
+// the proposal was made, as it was reviewed against the previous version. The policy of a policy change
+// is returned.
+func (s *PolicyServiceImpl) applyProposal(ctx context.Context, proposal *model.PolicyProposal, validateOnly bool) (*v1alpha1.Policy, error) {
+	if proposal.LibraryID != "" {
+		return nil, s.applyLibraryProposal(ctx, proposal, validateOnly)
+	}
+	policy, err := proposedPolicy(proposal)
+	if err != nil {
+		return nil, err
+	}
+
+	if proposal.BaseUpdateTime != nil {
+		existing, err := s.existingPolicy(ctx, proposal.PolicyID, "proposal")
+		if err != nil {
+			return nil, err
+		}
+		if !existing.UpdateTime.Equal(*proposal.BaseUpdateTime) {
+			return nil, NewFailedPreconditionError(
+				"Proposal is outdated",
+				fmt.Sprintf("Policy '%s' changed since the proposal was made; propose the change again", proposal.PolicyID),
+			)
+		}
+	}
+
+	// The proposal is the approval, so the change is made without the approval check
+	opts := PolicyWriteOptions{ValidateOnly: validateOnly}
+	switch proposal.Operation {
+	case model.ProposalCreate:
+		return s.createPolicy(ctx, *policy, proposal.PolicyID, opts)
+	case model.ProposalUpdate:
+		return s.updatePolicy(ctx, proposal.PolicyID, policy, opts)
+	default:
+		if validateOnly {
+			// The existence of the policy was checked above
+			return nil, nil
+		}
+		return nil, s.deletePolicy(ctx, proposal.PolicyID)
+	}
+}
+
+// applyLibraryProposal applies or validates the change of a proposal that changes a library
+func (s *PolicyServiceImpl) applyLibraryProposal(ctx context.Context, proposal *model.PolicyProposal, validateOnly bool) error {
+	library, err := proposedLibrary(proposal)
+	if err != nil {
+		return err
+	}
+
+	if proposal.BaseUpdateTime != nil {
+		existing, err := s.existingLibrary(ctx, proposal.LibraryID, "proposal")
+		if err != nil {
+			return err
+		}
+		if !existing.UpdateTime.Equal(*proposal.BaseUpdateTime) {
+			return NewFailedPreconditionError(
+				"Proposal is outdated",
+				fmt.Sprintf("Library '%s' changed since the proposal was made; propose the change again", proposal.LibraryID),
+			)
+		}
+	}
+
+	switch proposal.Operation {
+	case model.ProposalCreate:
+		_, err = s.createLibrary(ctx, *library, proposal.LibraryID, validateOnly)
+	case model.ProposalUpdate:
+		_, err = s.updateLibrary(ctx, proposal.LibraryID, library, validateOnly)
+	default:
+		err = s.deleteLibrary(ctx, proposal.LibraryID, validateOnly)
+	}
+	return err
+}
+
+// describeProposal converts a proposal to the API model with the current version of its policy or
+// library, the changed fields and, while it is pending, the result of validating it against the current
+// policy set
+func (s *PolicyServiceImpl) describeProposal(ctx context.Context, proposal *model.PolicyProposal) (*v1alpha1.PolicyProposal, error) {
+	api := ProposalDBToAPIModel(proposal)
+	var err error
+	if proposal.LibraryID != "" {
+		err = s.describeLibraryChange(ctx, proposal, &api)
+	} else {
+		err = s.describePolicyChange(ctx, proposal, &api)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if proposal.State != model.ProposalPending {
+		s.validations.forget(proposal.ID)
+		return &api, nil
+	}
+	validation, err := s.validateProposal(ctx, proposal)
+	if err != nil {
+		return nil, err
+	}
+	api.Validation = &validation
+	return &api, nil
+}
+
+// describePolicyChange sets the proposed and current policy and the changed fields of a policy proposal
+func (s *PolicyServiceImpl) describePolicyChange(ctx context.Context, proposal *model.PolicyProposal, api *v1alpha1.PolicyProposal) error {
+	policy, err := proposedPolicy(proposal)
+	if err != nil {
+		return err
+	}
+	api.Policy = policy
+
+	var current *v1alpha1.Policy
+	if proposal.Operation != model.ProposalCreate {
+		existing, err := s.store.Policy().Get(ctx, proposal.PolicyID)
+		switch {
+		case err == nil:
+			p := DBToAPIModel(existing)
+			current = &p
+		case !errors.Is(err, store.ErrPolicyNotFound):
+			logging.FromContext(ctx).Error("Failed to get policy of proposal", "proposal_id", proposal.ID, "error", err)
+			return NewInternalError("Failed to get policy of proposal", err.Error(), err)
+		}
+	}
+	api.Current = current
+
+	proposed, err := policyFields(policy)
+	if err != nil {
+		return NewInternalError("Failed to compare proposed policy", err.Error(), err)
+	}
+	existing, err := policyFields(current)
+	if err != nil {
+		return NewInternalError("Failed to compare proposed policy", err.Error(), err)
+	}
+	changed := changedFields(proposed, existing)
+	api.ChangedFields = &changed
+	return nil
+}
+
+// describeLibraryChange sets the proposed and current library and the changed fields of a library
+// proposal
+func (s *PolicyServiceImpl) describeLibraryChange(ctx context.Context, proposal *model.PolicyProposal, api *v1alpha1.PolicyProposal) error {
+	library, err := proposedLibrary(proposal)
+	if err != nil {
+		return err
+	}
+	api.Library = library
+
+	var current *v1alpha1.Library
+	if proposal.Operation != model.ProposalCreate {
+		existing, err := s.store.Library().Get(ctx, proposal.LibraryID)
+		switch {
+		case err == nil:
+			l := LibraryDBToAPIModel(existing)
+			current = &l
+		case !errors.Is(err, store.ErrLibraryNotFound):
+			logging.FromContext(ctx).Error("Failed to get library of proposal", "proposal_id", proposal.ID, "error", err)
+			return NewInternalError("Failed to get library of proposal", err.Error(), err)
+		}
+	}
+	api.CurrentLibrary = current
+
+	proposed, err := libraryFields(library)
+	if err != nil {
+		return NewInternalError("Failed to compare proposed library", err.Error(), err)
+	}
+	existing, err := libraryFields(current)
+	if err != nil {
+		return NewInternalError("Failed to compare proposed library", err.Error(), err)
+	}
+	changed := changedFields(proposed, existing)
+	api.ChangedFields = &changed
+	return nil
+}
+
+// validateProposal returns the result of validating a pending proposal against the current policy set.
+// The proposal is validated again only when the policy set changed since its last validation.
+func (s *PolicyServiceImpl) validateProposal(ctx context.Context, proposal *model.PolicyProposal) (v1alpha1.ProposalValidation, error) {
+	generation, err := s.store.Generation().Get(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to read the policy set generation", "proposal_id", proposal.ID, "error", err)
+		return v1alpha1.ProposalValidation{}, NewInternalError("Failed to validate proposal", err.Error(), err)
+	}
+	if validation, ok := s.validations.get(proposal.ID, generation); ok {
+		return validation, nil
+	}
+
+	validated, err := s.applyProposal(ctx, proposal, true)
+	if err != nil {
+		var serviceErr *ServiceError
+		if !errors.As(err, &serviceErr) || serviceErr.Type == ErrorTypeInternal {
+			return v1alpha1.ProposalValidation{}, err
+		}
+		validation := v1alpha1.ProposalValidation{
+			Valid:   false,
+			Message: &serviceErr.Message,
+			Detail:  &serviceErr.Detail,
+		}
+		if len(serviceErr.Diagnostics) > 0 {
+			validation.Diagnostics = &serviceErr.Diagnostics
+		}
+		s.validations.put(proposal.ID, generation, validation)
+		return validation, nil
+	}
+	validation := validProposal(validated)
+	s.validations.put(proposal.ID, generation, validation)
+	return validation, nil
+}
+
+// validProposal is the validation result of a proposal whose change validated as policy
+func validProposal(policy *v1alpha1.Policy) v1alpha1.ProposalValidation {
+	validation := v1alpha1.ProposalValidation{Valid: true}
+	if policy != nil {
+		validation.Warnings = policy.Warnings
+	}
+	return validation
+}
+
+// changedFields returns the names of the proposed fields that differ from the current ones, ordered by
+// name. Without current fields, every proposed field is changed.
+func changedFields(proposed, current map[string]any) []string {
+	changed := []string{}
+	for _, name := range slices.Sorted(maps.Keys(proposed)) {
+		if value, ok := current[name]; !ok || !reflect.DeepEqual(value, proposed[name]) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+// policyFields returns the fields of a policy keyed by their JSON names, or none without a policy
+func policyFields(policy *v1alpha1.Policy) (map[string]any, error) {
+	if policy == nil {
+		return nil, nil
+	}
+	return jsonFields(withoutReadOnlyFields(*policy))
+}
+
+// libraryFields returns the fields of a library keyed by their JSON names, or none without a library
+func libraryFields(library *v1alpha1.Library) (map[string]any, error) {
+	if library == nil {
+		return nil, nil
+	}
+	return jsonFields(withoutReadOnlyLibraryFields(*library))
+}
+
+func jsonFields(value any) (map[string]any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,274 @@
+package service_test
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/principal"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// checkCountingEngine counts the policy set checks, as made to validate a change
+type checkCountingEngine struct {
+	opa.Engine
+	checks atomic.Int32
+}
+
+func (e *checkCountingEngine) CheckPolicies(ctx context.Context, policies []opa.PolicyModule, lint []string) ([]opa.Diagnostic, error) {
+	e.checks.Add(1)
+	return e.Engine.CheckPolicies(ctx, policies, lint)
+}
+
+var _ = Describe("PolicyService proposals", func() {
+	var (
+		db            *gorm.DB
+		policyService *service.PolicyServiceImpl
+		alice         context.Context
+		bob           context.Context
+	)
+
+	expectServiceError := func(err error, errorType service.ErrorType, message string) {
+		GinkgoHelper()
+		Expect(err).To(HaveOccurred())
+		serviceErr, ok := err.(*service.ServiceError)
+		Expect(ok).To(BeTrue())
+		Expect(serviceErr.Type).To(Equal(errorType))
+		Expect(serviceErr.Message).To(Equal(message))
+	}
+
+	newPolicy := func(id string) v1alpha1.Policy {
+		priority := int32(10)
+		return v1alpha1.Policy{
+			DisplayName: strPtr(id),
+			PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+			Priority:    &priority,
+			RegoCode:    strPtr("package policies." + id + "\n\nmain := {\"rejected\": false}\n"),
+		}
+	}
+
+	propose := func(ctx context.Context, op v1alpha1.PolicyProposalOperation, policyID string, policy *v1alpha1.Policy) *v1alpha1.PolicyProposal {
+		GinkgoHelper()
+		proposal, err := policyService.CreateProposal(ctx, v1alpha1.PolicyProposal{
+			Operation: &op,
+			PolicyId:  &policyID,
+			Policy:    policy,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return proposal
+	}
+
+	// createApproved creates a policy through an approved proposal
+	createApproved := func(id string) {
+		GinkgoHelper()
+		policy := newPolicy(id)
+		proposal := propose(alice, v1alpha1.ProposalCreate, id, &policy)
+		_, err := policyService.ApproveProposal(bob, *proposal.Id, "")
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicyProposal{}, &model.PolicySetGeneration{})).To(Succeed())
+
+		policyService = service.NewPolicyService(store.NewStore(db), opa.NewEngine(), service.WithRequireApproval())
+		alice = principal.NewContext(context.Background(), "alice")
+		bob = principal.NewContext(context.Background(), "bob")
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	It("should reject direct writes but allow validate-only requests", func() {
+		_, err := policyService.CreatePolicy(alice, newPolicy("region"), strPtr("region"), service.PolicyWriteOptions{})
+		expectServiceError(err, service.ErrorTypeFailedPrecondition, "Approval required")
+
+		validated, err := policyService.CreatePolicy(alice, newPolicy("region"), strPtr("region"), service.PolicyWriteOptions{ValidateOnly: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*validated.Id).To(Equal("region"))
+
+		err = policyService.BatchDeletePolicies(alice, []string{"region"})
+		expectServiceError(err, service.ErrorTypeFailedPrecondition, "Approval required")
+	})
+
+	It("should reject direct library changes but apply proposed ones on approval", func() {
+		regoCode := "package lib.regions\n\nallowed(region) if region == \"eu-west-1\"\n"
+		_, err := policyService.CreateLibrary(alice, v1alpha1.Library{RegoCode: &regoCode}, strPtr("regions"))
+		expectServiceError(err, service.ErrorTypeFailedPrecondition, "Approval required")
+
+		proposeLibrary := func(op v1alpha1.PolicyProposalOperation, library *v1alpha1.Library) (*v1alpha1.PolicyProposal, error) {
+			return policyService.CreateProposal(alice, v1alpha1.PolicyProposal{Operation: &op, LibraryId: strPtr("regions"), Library: library})
+		}
+		created, err := proposeLibrary(v1alpha1.ProposalCreate, &v1alpha1.Library{RegoCode: &regoCode})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.Validation.Valid).To(BeTrue())
+		Expect(*created.ChangedFields).To(Equal([]string{"rego_code"}))
+		_, err = policyService.GetLibrary(alice, "regions")
+		expectServiceError(err, service.ErrorTypeNotFound, "Library not found")
+		_, err = policyService.ApproveProposal(bob, *created.Id, "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = policyService.GetLibrary(alice, "regions")
+		Expect(err).NotTo(HaveOccurred())
+
+		updated, err := proposeLibrary(v1alpha1.ProposalUpdate, &v1alpha1.Library{DisplayName: strPtr("Regions"), RegoCode: &regoCode})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*updated.ChangedFields).To(Equal([]string{"display_name"}))
+		Expect(*updated.CurrentLibrary.RegoCode).To(Equal(regoCode))
+		_, err = policyService.ApproveProposal(bob, *updated.Id, "")
+		Expect(err).NotTo(HaveOccurred())
+		library, err := policyService.GetLibrary(alice, "regions")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*library.DisplayName).To(Equal("Regions"))
+
+		policy := newPolicy("region")
+		policy.RegoCode = strPtr("package policies.region\n\nimport data.lib.regions\n\nmain := {\"rejected\": regions.allowed(input.region) == false}\n")
+		proposal := propose(alice, v1alpha1.ProposalCreate, "region", &policy)
+		_, err = policyService.ApproveProposal(bob, *proposal.Id, "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = proposeLibrary(v1alpha1.ProposalDelete, nil)
+		expectServiceError(err, service.ErrorTypeFailedPrecondition, "Library is in use")
+	})
+
+	It("should reject a proposal that changes both a policy and a library", func() {
+		op := v1alpha1.ProposalUpdate
+		_, err := policyService.CreateProposal(alice, v1alpha1.PolicyProposal{Operation: &op, PolicyId: strPtr("region"), LibraryId: strPtr("regions")})
+		expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid proposal")
+	})
+
+	It("should require a principal to propose", func() {
+		op := v1alpha1.ProposalCreate
+		policy := newPolicy("region")
+		_, err := policyService.CreateProposal(context.Background(), v1alpha1.PolicyProposal{Operation: &op, Policy: &policy})
+		expectServiceError(err, service.ErrorTypeUnauthenticated, "Principal required")
+	})
+
+	It("should apply a created policy only once another principal approves", func() {
+		policy := newPolicy("region")
+		proposal := propose(alice, v1alpha1.ProposalCreate, "region", &policy)
+		Expect(*proposal.State).To(Equal(v1alpha1.ProposalPending))
+		Expect(*proposal.Proposer).To(Equal("alice"))
+		Expect(proposal.Validation.Valid).To(BeTrue())
+		Expect(*proposal.ChangedFields).To(ConsistOf("display_name", "policy_type", "priority", "rego_code"))
+
+		_, err := policyService.GetPolicy(alice, "region")
+		expectServiceError(err, service.ErrorTypeNotFound, "Policy not found")
+
+		_, err = policyService.ApproveProposal(alice, *proposal.Id, "")
+		expectServiceError(err, service.ErrorTypePermissionDenied, "Proposal cannot be reviewed by its proposer")
+
+		approved, err := policyService.ApproveProposal(bob, *proposal.Id, "lgtm")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*approved.State).To(Equal(v1alpha1.ProposalApproved))
+		Expect(*approved.Reviewer).To(Equal("bob"))
+		Expect(*approved.ReviewComment).To(Equal("lgtm"))
+		Expect(approved.Validation).To(BeNil())
+
+		created, err := policyService.GetPolicy(alice, "region")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*created.DisplayName).To(Equal("region"))
+
+		_, err = policyService.ApproveProposal(bob, *proposal.Id, "")
+		expectServiceError(err, service.ErrorTypeFailedPrecondition, "Proposal is not pending")
+	})
+
+	It("should reject a change that does not compile when it is proposed", func() {
+		policy := newPolicy("region")
+		policy.RegoCode = strPtr("package policies.region\n{invalid rego")
+		op := v1alpha1.ProposalCreate
+		_, err := policyService.CreateProposal(alice, v1alpha1.PolicyProposal{Operation: &op, Policy: &policy})
+		expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid Rego code")
+	})
+
+	It("should show the diff of an update against the current policy", func() {
+		createApproved("region")
+
+		proposal := propose(alice, v1alpha1.ProposalUpdate, "region", &v1alpha1.Policy{
+			DisplayName: strPtr("region"),
+			Description: strPtr("Restrict regions"),
+		})
+		Expect(*proposal.ChangedFields).To(Equal([]string{"description"}))
+		Expect(*proposal.Current.DisplayName).To(Equal("region"))
+		Expect(proposal.BaseUpdateTime).NotTo(BeNil())
+
+		_, err := policyService.ApproveProposal(bob, *proposal.Id, "")
+		Expect(err).NotTo(HaveOccurred())
+		updated, err := policyService.GetPolicy(alice, "region")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*updated.Description).To(Equal("Restrict regions"))
+	})
+
+	It("should keep an outdated proposal pending and refuse to approve it", func() {
+		createApproved("region")
+		stale := propose(alice, v1alpha1.ProposalUpdate, "region", &v1alpha1.Policy{Description: strPtr("first")})
+		other := propose(bob, v1alpha1.ProposalUpdate, "region", &v1alpha1.Policy{Description: strPtr("second")})
+		_, err := policyService.ApproveProposal(alice, *other.Id, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		pending, err := policyService.ListProposals(alice, model.ProposalPending)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending.Proposals).To(HaveLen(1))
+		Expect(pending.Proposals[0].Validation.Valid).To(BeFalse())
+		Expect(*pending.Proposals[0].Validation.Message).To(Equal("Proposal is outdated"))
+
+		_, err = policyService.ApproveProposal(bob, *stale.Id, "")
+		expectServiceError(err, service.ErrorTypeFailedPrecondition, "Proposal is outdated")
+		stillPending, err := policyService.GetProposal(alice, *stale.Id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*stillPending.State).To(Equal(v1alpha1.ProposalPending))
+		Expect(stillPending.Reviewer).To(BeNil())
+	})
+
+	It("should validate a pending proposal again only once the policy set changed", func() {
+		engine := &checkCountingEngine{Engine: opa.NewEngine()}
+		policyService = service.NewPolicyService(store.NewStore(db), engine, service.WithRequireApproval())
+		policy := newPolicy("region")
+		proposal := propose(alice, v1alpha1.ProposalCreate, "region", &policy)
+		checks := engine.checks.Load()
+
+		for range 3 {
+			got, err := policyService.GetProposal(bob, *proposal.Id)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got.Validation.Valid).To(BeTrue())
+			_, err = policyService.ListProposals(bob, "")
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(engine.checks.Load()).To(Equal(checks))
+
+		// other takes the priority of the proposed policy
+		createApproved("other")
+		got, err := policyService.GetProposal(bob, *proposal.Id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Validation.Valid).To(BeFalse())
+	})
+
+	It("should delete a policy on approval and not on rejection", func() {
+		createApproved("region")
+
+		rejected := propose(alice, v1alpha1.ProposalDelete, "region", nil)
+		result, err := policyService.RejectProposal(bob, *rejected.Id, "still needed")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*result.State).To(Equal(v1alpha1.ProposalRejected))
+		_, err = policyService.GetPolicy(alice, "region")
+		Expect(err).NotTo(HaveOccurred())
+
+		approved := propose(alice, v1alpha1.ProposalDelete, "region", nil)
+		_, err = policyService.ApproveProposal(bob, *approved.Id, "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = policyService.GetPolicy(alice, "region")
+		expectServiceError(err, service.ErrorTypeNotFound, "Policy not found")
+	})
+})
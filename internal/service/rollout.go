@@ -0,0 +1,62 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+)
+
+// maxRolloutKeyLength is the maximum length of the rollout_key field
+const maxRolloutKeyLength = 63
+
+// CanaryPolicy records whether a policy rolled out to a share of requests was evaluated for a request
+type CanaryPolicy struct {
+	PolicyID          string
+	RolloutPercentage int32
+	Applied           bool
+}
+
+func validateRollout(policy v1alpha1.Policy) error {
+	if p := policy.RolloutPercentage; p != nil && (*p < 0 || *p > 100) {
+		return NewInvalidArgumentError(
+			"rollout_percentage must be between 0 and 100",
+			"The rollout_percentage field must be a value between 0 and 100",
+		)
+	}
+	if policy.RolloutKey != nil && len(*policy.RolloutKey) > maxRolloutKeyLength {
+		return NewInvalidArgumentError(
+			"Invalid rollout_key",
+			"The rollout_key field must name a request label of at most 63 characters",
+		)
+	}
+	return nil
+}
+
+// rolloutRequestKey identifies a request for policy rollouts: the hash of its service instance spec.
+// encoding/json sorts map keys, so repeats of the same request get the same key.
+func rolloutRequestKey(serviceInstance map[string]any) string {
+	data, err := json.Marshal(serviceInstance)
+	if err != nil {
+		// The spec was decoded from JSON, so it always encodes
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// inRollout reports whether a request is part of the rollout of a policy. The value of the rollout_key
+// label is hashed with the policy ID, or requestKey when the policy has no rollout key or the request
+// does not carry the label. Hashing the policy ID gives each policy its own share of requests.
+func inRollout(policy *model.Policy, labels map[string]string, requestKey string) bool {
+	key := requestKey
+	if value, ok := labels[policy.RolloutKey]; ok && policy.RolloutKey != "" {
+		key = "label:" + value
+	}
+	sum := sha256.Sum256([]byte(policy.ID + "\x00" + key))
+	bucket := binary.BigEndian.Uint64(sum[:8]) % 100
+	return bucket < uint64(*policy.RolloutPercentage)
+}
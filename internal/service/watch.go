@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/metrics"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+)
+
+// PolicyChangeType is the kind of change reported by a policy event
+type PolicyChangeType string
+
+const (
+	PolicyCreated  PolicyChangeType = "CREATED"
+	PolicyUpdated  PolicyChangeType = "UPDATED"
+	PolicyDeleted  PolicyChangeType = "DELETED"
+	PolicyEnabled  PolicyChangeType = "ENABLED"
+	PolicyDisabled PolicyChangeType = "DISABLED"
+)
+
+const (
+	// watchHistorySize is the number of past events kept to resume watches
+	watchHistorySize = 1000
+	// watchBufferSize is the number of events buffered per watch. A watch that falls further behind is closed
+	// and can resume from its last event.
+	watchBufferSize = 256
+)
+
+// PolicyEvent is a change of a stored policy
+type PolicyEvent struct {
+	// ID identifies the event to resume a watch after it
+	ID         string
+	ChangeType PolicyChangeType
+	PolicyID   string
+	// Revision is the update time of the policy after the change; nil for a delete
+	Revision *time.Time
+}
+
+// PolicyWatch is a subscription to policy events
+type PolicyWatch struct {
+	// Resync is set when the events after the last seen event are no longer available. The watcher has to
+	// list the policies again; the watch continues with new events.
+	Resync bool
+	// Backlog holds the events that happened after the last seen event
+	Backlog []PolicyEvent
+	// Events receives new events. It is closed when the watch falls behind or the service shuts down.
+	Events <-chan PolicyEvent
+
+	cancel func()
+}
+
+// Close ends the watch
+func (w *PolicyWatch) Close() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+}
+
+// policyWatcher turns the changes of the stored policy set into events. Changes are detected by comparing
+// the stored policies with a snapshot of the policy set: the writes of this replica compare the policies
+// they changed, an engine synchronization compares the whole policy set.
+type policyWatcher struct {
+	mu sync.Mutex
+	// epoch distinguishes the event IDs of this process from those of a previous one
+	epoch string
+	seq   uint64
+	// snapshot is the stored policy set at the last check, nil before the first one
+	snapshot    map[string]model.Policy
+	history     []PolicyEvent
+	subscribers map[chan PolicyEvent]struct{}
+	closed      bool
+}
+
+func newPolicyWatcher() *policyWatcher {
+	return &policyWatcher{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		subscribers: make(map[chan PolicyEvent]struct{}),
+	}
+}
+
+// storedSnapshot returns the stored policies ordered by ID, and keyed by ID
+func (s *PolicyServiceImpl) storedSnapshot(ctx context.Context) (model.PolicyList, map[string]model.Policy, error) {
+	policies, err := s.store.Policy().ListAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	snapshot := make(map[string]model.Policy, len(policies))
+	for _, p := range policies {
+		snapshot[p.ID] = p
+	}
+	return policies, snapshot, nil
+}
+
+// publishChanges announces a write to the policy set: the policy set generation is advanced so that the
+// other replicas recompile, and an event for each of the changed policies is sent to the watches and
+// webhooks. It is called after every write to the policy set that does not change the engine; writes
+// that do call publishChangesLocked after changing the engine, holding the generation lock throughout.
+func (s *PolicyServiceImpl) publishChanges(ctx context.Context, changed []string) {
+	unlock := s.lockGeneration()
+	defer unlock()
+	s.publishChangesLocked(ctx, changed)
+}
+
+// publishChangesLocked is publishChanges for a caller holding the generation lock
+func (s *PolicyServiceImpl) publishChangesLocked(ctx context.Context, changed []string) {
+	s.advanceGeneration(ctx)
+	s.publishPolicyEvents(ctx, changed)
+}
+
+// publishPolicyEvents compares the stored versions of the changed policies with the snapshot and sends
+// an event for each change to the watches and webhooks. The metrics of deleted policies are dropped.
+// Until the snapshot is taken, no event is sent.
+func (s *PolicyServiceImpl) publishPolicyEvents(ctx context.Context, changed []string) {
+	if s.watcher == nil || len(changed) == 0 {
+		return
+	}
+	w := s.watcher
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var events []PolicyEvent
+	for _, id := range slices.Compact(slices.Sorted(slices.Values(changed))) {
+		p, err := s.store.Policy().Get(ctx, id)
+		switch {
+		case errors.Is(err, store.ErrPolicyNotFound):
+			metrics.DeletePolicy(id)
+			if _, existed := w.snapshot[id]; existed {
+				events = append(events, w.publish(PolicyDeleted, model.Policy{ID: id}))
+				delete(w.snapshot, id)
+			}
+		case err != nil:
+			logging.FromContext(ctx).Error("Failed to get policy for change events", "policy_id", id, "error", err)
+		case w.snapshot == nil:
+			// No events until the snapshot is taken
+		default:
+			previous, existed := w.snapshot[id]
+			if changeType, ok := policyChange(previous, existed, *p); ok {
+				events = append(events, w.publish(changeType, *p))
+			}
+			w.snapshot[id] = *p
+		}
+	}
+	s.enqueuePolicyWebhooks(ctx, events)
+}
+
+// policyChange returns the change from previous, the policy in the snapshot, to p, or false when the
+// policy did not change. existed is false when the policy is not in the snapshot.
+func policyChange(previous model.Policy, existed bool, p model.Policy) (PolicyChangeType, bool) {
+	switch {
+	case !existed:
+		return PolicyCreated, true
+	case previous.Enabled != p.Enabled:
+		if p.Enabled {
+			return PolicyEnabled, true
+		}
+		return PolicyDisabled, true
+	case !previous.UpdateTime.Equal(p.UpdateTime) || managedPolicyChanged(previous, p):
+		return PolicyUpdated, true
+	}
+	return "", false
+}
+
+// publishEvents compares the whole stored policy set with the snapshot and sends an event for each change
+// to the watches, and to the webhooks if webhooks is set. It is used when the engine is compiled from the
+// store, which may hold changes made through other replicas. Until the first call, there is no snapshot
+// and nothing is sent.
+func (s *PolicyServiceImpl) publishEvents(ctx context.Context, webhooks bool) {
+	if s.watcher == nil {
+		return
+	}
+	w := s.watcher
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	policies, current, err := s.storedSnapshot(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list policies for change events", "error", err)
+		return
+	}
+	if w.snapshot == nil {
+		w.snapshot = current
+		return
+	}
+
+	// ListAll returns the policies ordered by ID, so events of one change are ordered too
+	var events []PolicyEvent
+	for _, p := range policies {
+		previous, existed := w.snapshot[p.ID]
+		if changeType, ok := policyChange(previous, existed, p); ok {
+			events = append(events, w.publish(changeType, p))
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(w.snapshot)) {
+		if _, ok := current[id]; !ok {
+			events = append(events, w.publish(PolicyDeleted, model.Policy{ID: id}))
+			// Also drops the series of a policy deleted through another replica
+			metrics.DeletePolicy(id)
+		}
+	}
+	w.snapshot = current
+	if webhooks {
+		s.enqueuePolicyWebhooks(ctx, events)
+	}
+}
+
+// publish records an event and sends it to all watches. Must be called with mu held.
+func (w *policyWatcher) publish(changeType PolicyChangeType, p model.Policy) PolicyEvent {
+	w.seq++
+	event := PolicyEvent{
+		ID:         fmt.Sprintf("%s-%d", w.epoch, w.seq),
+		ChangeType: changeType,
+		PolicyID:   p.ID,
+	}
+	if changeType != PolicyDeleted {
+		revision := p.UpdateTime
+		event.Revision = &revision
+	}
+
+	w.history = append(w.history, event)
+	if len(w.history) > watchHistorySize {
+		w.history = w.history[len(w.history)-watchHistorySize:]
+	}
+	for ch := range w.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// The watch fell behind; it resumes from its last event after reconnecting
+			delete(w.subscribers, ch)
+			close(ch)
+		}
+	}
+	return event
+}
+
+// close ends all watches and refuses new ones
+func (w *policyWatcher) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	for ch := range w.subscribers {
+		delete(w.subscribers, ch)
+		close(ch)
+	}
+}
+
+// WatchPolicies subscribes to policy events. With a lastEventID, the events after it are returned as a
+// backlog, or Resync is set when they are no longer available.
+func (s *PolicyServiceImpl) WatchPolicies(ctx context.Context, lastEventID string) (*PolicyWatch, error) {
+	w := s.watcher
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil, NewFailedPreconditionError("Service shutting down", "Policy events are no longer available")
+	}
+	if w.snapshot == nil {
+		_, snapshot, err := s.storedSnapshot(ctx)
+		if err != nil {
+			return nil, NewInternalError("Failed to list policies", err.Error(), err)
+		}
+		w.snapshot = snapshot
+	}
+
+	watch := &PolicyWatch{}
+	if lastEventID != "" {
+		epoch, seqStr, ok := strings.Cut(lastEventID, "-")
+		seq, err := strconv.ParseUint(seqStr, 10, 64)
+		if !ok || err != nil {
+			return nil, NewInvalidArgumentError(
+				"Invalid last event ID",
+				fmt.Sprintf("Last event ID '%s' was not issued by this service", lastEventID),
+			)
+		}
+		watch.Backlog, watch.Resync = w.eventsAfter(epoch, seq)
+	}
+
+	ch := make(chan PolicyEvent, watchBufferSize)
+	w.subscribers[ch] = struct{}{}
+	watch.Events = ch
+	watch.cancel = func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if _, ok := w.subscribers[ch]; ok {
+			delete(w.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	logging.FromContext(ctx).Debug("Policy watch started", "last_event_id", lastEventID, "backlog", len(watch.Backlog), "resync", watch.Resync)
+	return watch, nil
+}
+
+// eventsAfter returns the recorded events after event seq of epoch, or resync when some of them are
+// no longer recorded. Must be called with mu held.
+func (w *policyWatcher) eventsAfter(epoch string, seq uint64) (events []PolicyEvent, resync bool) {
+	if epoch != w.epoch || seq > w.seq {
+		// The event was issued by a previous process
+		return nil, true
+	}
+	missed := w.seq - seq
+	if missed > uint64(len(w.history)) {
+		return nil, true
+	}
+	return append([]PolicyEvent(nil), w.history[uint64(len(w.history))-missed:]...), false
+}
+
+// CloseWatches ends all policy watches, so that they do not hold up a shutdown
+func (s *PolicyServiceImpl) CloseWatches() {
+	s.watcher.close()
+}
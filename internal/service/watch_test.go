@@ -0,0 +1,224 @@
+package service_test
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/metrics"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("PolicyService watches", func() {
+	var (
+		db            *gorm.DB
+		policyService *service.PolicyServiceImpl
+		ctx           context.Context
+	)
+
+	newPolicy := func(id string, priority int32) v1alpha1.Policy {
+		return v1alpha1.Policy{
+			DisplayName: strPtr(id),
+			PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+			Priority:    &priority,
+			RegoCode:    strPtr("package policies." + id + "\n\nmain := {\"rejected\": false}\n"),
+		}
+	}
+
+	create := func(id string, priority int32) {
+		GinkgoHelper()
+		_, err := policyService.CreatePolicy(ctx, newPolicy(id, priority), strPtr(id), service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	watch := func(lastEventID string) *service.PolicyWatch {
+		GinkgoHelper()
+		w, err := policyService.WatchPolicies(ctx, lastEventID)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(w.Close)
+		return w
+	}
+
+	receive := func(w *service.PolicyWatch) service.PolicyEvent {
+		GinkgoHelper()
+		var event service.PolicyEvent
+		Eventually(w.Events).Should(Receive(&event))
+		return event
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
+
+		policyService = service.NewPolicyService(store.NewStore(db), opa.NewEngine())
+		ctx = context.Background()
+		create("existing", 10)
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	It("sends an event for a created policy", func() {
+		w := watch("")
+		Expect(w.Resync).To(BeFalse())
+		Expect(w.Backlog).To(BeEmpty())
+
+		create("new", 20)
+
+		event := receive(w)
+		Expect(event.ChangeType).To(Equal(service.PolicyCreated))
+		Expect(event.PolicyID).To(Equal("new"))
+		Expect(event.ID).NotTo(BeEmpty())
+		Expect(event.Revision).NotTo(BeNil())
+		Consistently(w.Events).ShouldNot(Receive())
+	})
+
+	It("sends an event for an updated policy with its new revision", func() {
+		w := watch("")
+
+		updated, err := policyService.UpdatePolicy(ctx, "existing", &v1alpha1.Policy{DisplayName: strPtr("renamed")}, service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		event := receive(w)
+		Expect(event.ChangeType).To(Equal(service.PolicyUpdated))
+		Expect(event.PolicyID).To(Equal("existing"))
+		Expect(event.Revision).NotTo(BeNil())
+		Expect(event.Revision.Equal(*updated.UpdateTime)).To(BeTrue())
+	})
+
+	It("sends enable and disable events", func() {
+		w := watch("")
+
+		_, err := policyService.UpdatePolicy(ctx, "existing", &v1alpha1.Policy{Enabled: boolPtr(false)}, service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(receive(w).ChangeType).To(Equal(service.PolicyDisabled))
+
+		_, err = policyService.UpdatePolicy(ctx, "existing", &v1alpha1.Policy{Enabled: boolPtr(true)}, service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(receive(w).ChangeType).To(Equal(service.PolicyEnabled))
+	})
+
+	It("sends an event for a deleted policy without a revision", func() {
+		w := watch("")
+
+		Expect(policyService.DeletePolicy(ctx, "existing")).To(Succeed())
+
+		event := receive(w)
+		Expect(event.ChangeType).To(Equal(service.PolicyDeleted))
+		Expect(event.PolicyID).To(Equal("existing"))
+		Expect(event.Revision).To(BeNil())
+	})
+
+	It("drops the metrics of a deleted policy", func() {
+		metrics.PolicyRejections.WithLabelValues("existing", "GLOBAL").Inc()
+		series := testutil.CollectAndCount(metrics.PolicyRejections)
+
+		Expect(policyService.DeletePolicy(ctx, "existing")).To(Succeed())
+
+		Expect(testutil.CollectAndCount(metrics.PolicyRejections)).To(Equal(series - 1))
+	})
+
+	It("sends an event for each policy of a batch", func() {
+		w := watch("")
+
+		_, err := policyService.BatchCreatePolicies(ctx, []v1alpha1.PolicyCreateRequest{
+			{Id: strPtr("first"), Policy: newPolicy("first", 20)},
+			{Id: strPtr("second"), Policy: newPolicy("second", 30)},
+		}, service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(receive(w).PolicyID).To(Equal("first"))
+		Expect(receive(w).PolicyID).To(Equal("second"))
+		Consistently(w.Events).ShouldNot(Receive())
+	})
+
+	It("sends an event for the policies shifted by a placed policy", func() {
+		create("next", 11)
+		w := watch("")
+
+		placed := newPolicy("between", 0)
+		placed.Priority = nil
+		_, err := policyService.CreatePolicy(ctx, placed, strPtr("between"), service.PolicyWriteOptions{Placement: "after:existing"})
+		Expect(err).NotTo(HaveOccurred())
+
+		changes := map[string]service.PolicyChangeType{}
+		for range 2 {
+			event := receive(w)
+			changes[event.PolicyID] = event.ChangeType
+		}
+		Expect(changes).To(Equal(map[string]service.PolicyChangeType{
+			"between": service.PolicyCreated,
+			"next":    service.PolicyUpdated,
+		}))
+		Consistently(w.Events).ShouldNot(Receive())
+	})
+
+	It("sends no event for a validate-only request", func() {
+		w := watch("")
+
+		_, err := policyService.CreatePolicy(ctx, newPolicy("dry", 20), strPtr("dry"), service.PolicyWriteOptions{ValidateOnly: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		Consistently(w.Events).ShouldNot(Receive())
+	})
+
+	It("resumes with the events after the last seen event", func() {
+		first := watch("")
+		create("a", 20)
+		seen := receive(first)
+		create("b", 30)
+		Expect(policyService.DeletePolicy(ctx, "a")).To(Succeed())
+
+		resumed := watch(seen.ID)
+
+		Expect(resumed.Resync).To(BeFalse())
+		Expect(resumed.Backlog).To(HaveLen(2))
+		Expect(resumed.Backlog[0].PolicyID).To(Equal("b"))
+		Expect(resumed.Backlog[0].ChangeType).To(Equal(service.PolicyCreated))
+		Expect(resumed.Backlog[1].PolicyID).To(Equal("a"))
+		Expect(resumed.Backlog[1].ChangeType).To(Equal(service.PolicyDeleted))
+	})
+
+	It("asks for a resync when the last seen event was issued by another process", func() {
+		w := watch("previous-42")
+
+		Expect(w.Resync).To(BeTrue())
+		Expect(w.Backlog).To(BeEmpty())
+
+		create("new", 20)
+		Expect(receive(w).PolicyID).To(Equal("new"))
+	})
+
+	It("rejects a malformed last event ID", func() {
+		_, err := policyService.WatchPolicies(ctx, "not-an-event")
+
+		Expect(err).To(HaveOccurred())
+		serviceErr, ok := err.(*service.ServiceError)
+		Expect(ok).To(BeTrue())
+		Expect(serviceErr.Type).To(Equal(service.ErrorTypeInvalidArgument))
+	})
+
+	It("ends all watches on shutdown", func() {
+		w := watch("")
+
+		policyService.CloseWatches()
+
+		Eventually(w.Events).Should(BeClosed())
+		_, err := policyService.WatchPolicies(ctx, "")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	"github.com/google/uuid"
+)
+
+const (
+	// minWebhookSecretLength is the minimum length of a webhook secret
+	minWebhookSecretLength = 16
+	// maxWebhookURLLength is the maximum length of a webhook URL
+	maxWebhookURLLength = 2048
+)
+
+// validateWebhook checks the fields of a webhook to be stored
+func validateWebhook(webhook model.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(webhook.URL) > maxWebhookURLLength {
+		return NewInvalidArgumentError(
+			"Invalid url",
+			"The url field must be an absolute http or https URL of at most 2048 characters",
+		)
+	}
+	if len(webhook.EventTypes) == 0 {
+		return NewInvalidArgumentError(
+			"event_types is required",
+			"The event_types field must list at least one event type",
+		)
+	}
+	for _, t := range webhook.EventTypes {
+		switch v1alpha1.WebhookEventType(t) {
+		case v1alpha1.POLICYCREATED, v1alpha1.POLICYUPDATED, v1alpha1.POLICYDELETED, v1alpha1.COMPILEFAILED:
+		default:
+			return NewInvalidArgumentError(
+				"Invalid event type",
+				fmt.Sprintf("Event type '%s' must be one of POLICY_CREATED, POLICY_UPDATED, POLICY_DELETED or COMPILE_FAILED", t),
+			)
+		}
+	}
+	if len(webhook.Secret) < minWebhookSecretLength {
+		return NewInvalidArgumentError(
+			"Invalid secret",
+			"The secret field must be at least 16 characters long",
+		)
+	}
+	return nil
+}
+
+// CreateWebhook creates a new webhook subscription.
+func (s *PolicyServiceImpl) CreateWebhook(ctx context.Context, webhook v1alpha1.Webhook, clientID *string) (*v1alpha1.Webhook, error) {
+	webhookID, err := getResourceID(clientID, "webhook", "Webhook")
+	if err != nil {
+		return nil, err
+	}
+
+	log := logging.FromContext(ctx)
+	log.Debug("Creating webhook", "webhook_id", *webhookID)
+
+	dbWebhook := WebhookAPIToDBModel(webhook, *webhookID)
+	dbWebhook.EventTypes = slices.Compact(slices.Sorted(slices.Values(dbWebhook.EventTypes)))
+	if err := validateWebhook(dbWebhook); err != nil {
+		return nil, err
+	}
+
+	created, err := s.store.Webhook().Create(ctx, dbWebhook)
+	if err != nil {
+		log.Error("Failed to create webhook in store", "webhook_id", *webhookID, "error", err)
+		return nil, processWebhookStoreError(err, *webhookID, "create")
+	}
+
+	apiWebhook := WebhookDBToAPIModel(created)
+	log.Debug("Webhook created successfully", "webhook_id", *webhookID)
+	return &apiWebhook, nil
+}
+
+// GetWebhook retrieves a webhook by ID.
+func (s *PolicyServiceImpl) GetWebhook(ctx context.Context, id string) (*v1alpha1.Webhook, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Getting webhook", "webhook_id", id)
+
+	dbWebhook, err := s.store.Webhook().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrWebhookNotFound) {
+			return nil, NewWebhookNotFoundError(id)
+		}
+		log.Error("Failed to get webhook from store", "webhook_id", id, "error", err)
+		return nil, NewInternalError("Failed to get webhook", err.Error(), err)
+	}
+
+	apiWebhook := WebhookDBToAPIModel(dbWebhook)
+	return &apiWebhook, nil
+}
+
+// ListWebhooks lists all webhooks ordered by ID.
+func (s *PolicyServiceImpl) ListWebhooks(ctx context.Context) (*v1alpha1.WebhookList, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Listing webhooks")
+
+	webhooks, err := s.store.Webhook().ListAll(ctx)
+	if err != nil {
+		log.Error("Failed to list webhooks from store", "error", err)
+		return nil, NewInternalError("Failed to list webhooks", err.Error(), err)
+	}
+
+	apiWebhooks := make([]v1alpha1.Webhook, len(webhooks))
+	for i := range webhooks {
+		apiWebhooks[i] = WebhookDBToAPIModel(&webhooks[i])
+	}
+	return &v1alpha1.WebhookList{Webhooks: apiWebhooks}, nil
+}
+
+// UpdateWebhook updates an existing webhook using partial merge (PATCH). Read-only fields in the patch are ignored.
+func (s *PolicyServiceImpl) UpdateWebhook(ctx context.Context, id string, patch *v1alpha1.Webhook) (*v1alpha1.Webhook, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Updating webhook", "webhook_id", id)
+
+	existing, err := s.store.Webhook().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrWebhookNotFound) {
+			return nil, NewWebhookNotFoundError(id)
+		}
+		log.Error("Failed to get existing webhook for update", "webhook_id", id, "error", err)
+		return nil, NewInternalError("Failed to get existing webhook", err.Error(), err)
+	}
+
+	merged := *existing
+	if patch != nil {
+		if patch.DisplayName != nil {
+			merged.DisplayName = *patch.DisplayName
+		}
+		if patch.Url != nil {
+			merged.URL = *patch.Url
+		}
+		if patch.EventTypes != nil {
+			merged.EventTypes = slices.Compact(slices.Sorted(slices.Values(webhookEventTypesToDB(*patch.EventTypes))))
+		}
+		if patch.Secret != nil {
+			merged.Secret = *patch.Secret
+		}
+	}
+	if err := validateWebhook(merged); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.Webhook().Update(ctx, merged)
+	if err != nil {
+		log.Error("Failed to update webhook in store", "webhook_id", id, "error", err)
+		return nil, processWebhookStoreError(err, id, "update")
+	}
+
+	apiWebhook := WebhookDBToAPIModel(updated)
+	log.Debug("Webhook updated successfully", "webhook_id", id)
+	return &apiWebhook, nil
+}
+
+// DeleteWebhook deletes a webhook and its delivery log.
+func (s *PolicyServiceImpl) DeleteWebhook(ctx context.Context, id string) error {
+	log := logging.FromContext(ctx)
+	log.Debug("Deleting webhook", "webhook_id", id)
+
+	if err := s.store.Webhook().Delete(ctx, id); err != nil {
+		if !errors.Is(err, store.ErrWebhookNotFound) {
+			log.Error("Failed to delete webhook from store", "webhook_id", id, "error", err)
+		}
+		return processWebhookStoreError(err, id, "delete")
+	}
+
+	log.Debug("Webhook deleted successfully", "webhook_id", id)
+	return nil
+}
+
+// ListWebhookDeliveries lists the delivery log of a webhook, newest first.
+func (s *PolicyServiceImpl) ListWebhookDeliveries(ctx context.Context, id string) (*v1alpha1.WebhookDeliveryList, error) {
+	log := logging.FromContext(ctx)
+	log.Debug("Listing webhook deliveries", "webhook_id", id)
+
+	if _, err := s.store.Webhook().Get(ctx, id); err != nil {
+		if errors.Is(err, store.ErrWebhookNotFound) {
+			return nil, NewWebhookNotFoundError(id)
+		}
+		log.Error("Failed to get webhook from store", "webhook_id", id, "error", err)
+		return nil, NewInternalError("Failed to get webhook", err.Error(), err)
+	}
+
+	deliveries, err := s.store.Webhook().ListDeliveries(ctx, id)
+	if err != nil {
+		log.Error("Failed to list webhook deliveries from store", "webhook_id", id, "error", err)
+		return nil, NewInternalError("Failed to list webhook deliveries", err.Error(), err)
+	}
+
+	apiDeliveries := make([]v1alpha1.WebhookDelivery, len(deliveries))
+	for i := range deliveries {
+		apiDeliveries[i] = WebhookDeliveryDBToAPIModel(&deliveries[i])
+	}
+	return &v1alpha1.WebhookDeliveryList{Deliveries: apiDeliveries}, nil
+}
+
+// webhookEventType returns the webhook event type of a policy change
+func webhookEventType(changeType PolicyChangeType) v1alpha1.WebhookEventType {
+	switch changeType {
+	case PolicyCreated:
+		return v1alpha1.POLICYCREATED
+	case PolicyDeleted:
+		return v1alpha1.POLICYDELETED
+	default:
+		return v1alpha1.POLICYUPDATED
+	}
+}
+
+// enqueuePolicyWebhooks queues the delivery of policy events to the webhooks subscribed to them
+func (s *PolicyServiceImpl) enqueuePolicyWebhooks(ctx context.Context, events []PolicyEvent) {
+	if len(events) == 0 {
+		return
+	}
+	now := time.Now().UTC()
+	payloads := make([]v1alpha1.WebhookPayload, len(events))
+	for i, e := range events {
+		changeType := string(e.ChangeType)
+		payloads[i] = v1alpha1.WebhookPayload{
+			EventId:    uuid.New().String(),
+			EventType:  webhookEventType(e.ChangeType),
+			EventTime:  now,
+			PolicyId:   &e.PolicyID,
+			ChangeType: &changeType,
+			Revision:   e.Revision,
+		}
+	}
+	s.enqueueWebhooks(ctx, payloads)
+}
+
+// publishCompileFailure queues the delivery of a compile failure of the stored policy set to the webhooks
+// subscribed to it. Transactional copies of the service leave this to the service that created them,
+// since their writes are rolled back with the transaction.
+func (s *PolicyServiceImpl) publishCompileFailure(ctx context.Context, compileErr error) {
+	if s.watcher == nil {
+		return
+	}
+	message := compileErr.Error()
+	s.enqueueWebhooks(ctx, []v1alpha1.WebhookPayload{{
+		EventId:   uuid.New().String(),
+		EventType: v1alpha1.COMPILEFAILED,
+		EventTime: time.Now().UTC(),
+		Error:     &message,
+	}})
+}
+
+// enqueueWebhooks stores a pending delivery of each payload for every webhook subscribed to its event
+// type. The deliveries are sent by the WebhookDispatcher. Failures are logged, as the change that
+// caused the event has already been made.
+func (s *PolicyServiceImpl) enqueueWebhooks(ctx context.Context, payloads []v1alpha1.WebhookPayload) {
+	log := logging.FromContext(ctx)
+	webhooks, err := s.store.Webhook().ListAll(ctx)
+	if err != nil {
+		log.Error("Failed to list webhooks for event delivery", "error", err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	var deliveries []model.WebhookDelivery
+	for _, payload := range payloads {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			log.Error("Failed to encode webhook payload", "event_type", payload.EventType, "error", err)
+			continue
+		}
+		for _, w := range webhooks {
+			if !slices.Contains(w.EventTypes, string(payload.EventType)) {
+				continue
+			}
+			delivery := model.WebhookDelivery{
+				// Time-ordered, so that the deliveries queued together are sent in order
+				ID:              uuid.Must(uuid.NewV7()).String(),
+				WebhookID:       w.ID,
+				EventID:         payload.EventId,
+				EventType:       string(payload.EventType),
+				Payload:         string(body),
+				Status:          model.WebhookDeliveryPending,
+				NextAttemptTime: &payload.EventTime,
+			}
+			if payload.PolicyId != nil {
+				delivery.PolicyID = *payload.PolicyId
+			}
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	if err := s.store.Webhook().CreateDeliveries(ctx, deliveries); err != nil {
+		log.Error("Failed to queue webhook deliveries", "delivery_count", len(deliveries), "error", err)
+		return
+	}
+	if len(deliveries) > 0 {
+		log.Debug("Webhook deliveries queued", "delivery_count", len(deliveries))
+	}
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/logging"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+)
+
+// Headers of webhook delivery requests
+const (
+	WebhookIDHeader        = "X-DCM-Webhook-Id"
+	WebhookDeliveryHeader  = "X-DCM-Delivery-Id"
+	WebhookEventTypeHeader = "X-DCM-Event-Type"
+	WebhookSignatureHeader = "X-DCM-Signature"
+)
+
+const (
+	// webhookDeliveryBatchSize is the maximum number of deliveries attempted in one round
+	webhookDeliveryBatchSize = 100
+	// webhookDeliveryLogSize is the number of completed deliveries kept per webhook
+	webhookDeliveryLogSize = 100
+	// maxWebhookBackoff caps the delay between two attempts of a delivery
+	maxWebhookBackoff = time.Hour
+	// maxWebhookErrorBody is the number of bytes of an error response recorded in the delivery log
+	maxWebhookErrorBody = 512
+)
+
+// WebhookDeliveryOptions configures the delivery of webhook events
+type WebhookDeliveryOptions struct {
+	// MaxAttempts is the number of attempts after which a delivery fails
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles with each further retry, up to an hour.
+	Backoff time.Duration
+	// Timeout bounds each attempt
+	Timeout time.Duration
+	// PollInterval is the interval at which due deliveries are looked up
+	PollInterval time.Duration
+}
+
+// WebhookDispatcher sends the pending webhook deliveries queued by the policy service. Deliveries are
+// claimed in the store before they are sent, so several replicas can run a dispatcher.
+type WebhookDispatcher struct {
+	store  store.Store
+	client *http.Client
+	opts   WebhookDeliveryOptions
+}
+
+func NewWebhookDispatcher(store store.Store, opts WebhookDeliveryOptions) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		store: store,
+		client: &http.Client{
+			Timeout: opts.Timeout,
+			// A redirect is reported as a failed attempt instead of posting the event elsewhere
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		opts: opts,
+	}
+}
+
+// Run delivers due webhook events until ctx is cancelled
+func (d *WebhookDispatcher) Run(ctx context.Context) error {
+	log := logging.FromContext(ctx)
+	log.Info("Webhook dispatcher started", "poll_interval", d.opts.PollInterval, "max_attempts", d.opts.MaxAttempts)
+
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := d.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			log.Error("Failed to deliver webhook events", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			log.Info("Webhook dispatcher stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue attempts the deliveries of the webhooks whose oldest pending delivery is due. Deliveries to
+// different webhooks are sent concurrently, those to the same webhook in the order they were queued, up to
+// the first that fails. The later deliveries wait until the failed one is retried or given up.
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) error {
+	due, err := d.store.Webhook().ListDueDeliveries(ctx, time.Now(), webhookDeliveryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	byWebhook := make(map[string][]model.WebhookDelivery)
+	for _, delivery := range due {
+		byWebhook[delivery.WebhookID] = append(byWebhook[delivery.WebhookID], delivery)
+	}
+	var wg sync.WaitGroup
+	for webhookID, deliveries := range byWebhook {
+		wg.Go(func() {
+			d.deliverAll(ctx, webhookID, deliveries)
+		})
+	}
+	wg.Wait()
+	return nil
+}
+
+// deliverAll attempts the pending deliveries of a webhook in order. It stops at the first delivery that
+// cannot be sent, so that the later deliveries do not overtake it.
+func (d *WebhookDispatcher) deliverAll(ctx context.Context, webhookID string, deliveries []model.WebhookDelivery) {
+	log := logging.FromContext(ctx).With("webhook_id", webhookID)
+
+	webhook, err := d.store.Webhook().Get(ctx, webhookID)
+	if err != nil {
+		if !errors.Is(err, store.ErrWebhookNotFound) {
+			log.Error("Failed to get webhook for delivery", "error", err)
+		}
+		return
+	}
+
+	attempted := false
+	for _, delivery := range deliveries {
+		// Should this replica stop during the attempt, the delivery is retried after the claim expires
+		claimed, err := d.store.Webhook().ClaimDelivery(ctx, delivery, time.Now().Add(d.opts.Timeout+d.opts.Backoff))
+		if err != nil {
+			log.Error("Failed to claim webhook delivery", "delivery_id", delivery.ID, "error", err)
+			break
+		}
+		if !claimed {
+			// Claimed or completed by another replica since it was listed; the rest waits for the next round
+			break
+		}
+		delivery.Attempts++
+		d.attempt(ctx, webhook, &delivery)
+		attempted = true
+
+		if err := d.store.Webhook().UpdateDelivery(ctx, delivery); err != nil && !errors.Is(err, store.ErrWebhookNotFound) {
+			log.Error("Failed to record webhook delivery", "delivery_id", delivery.ID, "error", err)
+		}
+		if delivery.Status != model.WebhookDeliverySucceeded {
+			break
+		}
+	}
+
+	if attempted {
+		if err := d.store.Webhook().PruneDeliveries(ctx, webhookID, webhookDeliveryLogSize); err != nil {
+			log.Error("Failed to prune webhook delivery log", "error", err)
+		}
+	}
+}
+
+// attempt sends a delivery once and records the outcome in it
+func (d *WebhookDispatcher) attempt(ctx context.Context, webhook *model.Webhook, delivery *model.WebhookDelivery) {
+	log := logging.FromContext(ctx).With("webhook_id", webhook.ID, "delivery_id", delivery.ID, "attempt", delivery.Attempts)
+
+	statusCode, err := d.send(ctx, webhook, delivery)
+	delivery.ResponseCode = int32(statusCode)
+	if err == nil {
+		delivery.Status = model.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		delivery.NextAttemptTime = nil
+		log.Debug("Webhook event delivered", "event_type", delivery.EventType, "status_code", statusCode)
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if int(delivery.Attempts) >= d.opts.MaxAttempts {
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.NextAttemptTime = nil
+		log.Warn("Webhook delivery failed, giving up", "event_type", delivery.EventType, "error", err)
+		return
+	}
+	next := time.Now().Add(webhookBackoff(d.opts.Backoff, delivery.Attempts))
+	delivery.NextAttemptTime = &next
+	log.Info("Webhook delivery failed, retrying", "event_type", delivery.EventType, "error", err, "next_attempt_time", next)
+}
+
+// send posts the payload of a delivery to the webhook and returns the response status
+func (d *WebhookDispatcher) send(ctx context.Context, webhook *model.Webhook, delivery *model.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookIDHeader, webhook.ID)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(WebhookEventTypeHeader, delivery.EventType)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, time.Now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorBody))
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// webhookBackoff returns the delay before the retry that follows the given number of attempts
+func webhookBackoff(backoff time.Duration, attempts int32) time.Duration {
+	delay := backoff
+	for i := int32(1); i < attempts && delay < maxWebhookBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxWebhookBackoff)
+}
+
+// SignWebhookPayload returns the signature header of a webhook request body sent at the given time:
+// t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<body>" keyed with the webhook secret>
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,403 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/policy-manager/api/v1alpha1"
+	"github.com/dcm-project/policy-manager/internal/opa"
+	"github.com/dcm-project/policy-manager/internal/service"
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// failingCompileEngine fails Compile and Update while fail is set, as for a policy set that no longer compiles
+type failingCompileEngine struct {
+	opa.Engine
+	fail bool
+}
+
+func (e *failingCompileEngine) Compile(ctx context.Context, policies []opa.PolicyModule) error {
+	if e.fail {
+		return errors.New("rego_type_error: conflicting rules")
+	}
+	return e.Engine.Compile(ctx, policies)
+}
+
+func (e *failingCompileEngine) Update(ctx context.Context, upsert []opa.PolicyModule, remove []opa.PolicyModule) error {
+	if e.fail {
+		return errors.New("rego_type_error: conflicting rules")
+	}
+	return e.Engine.Update(ctx, upsert, remove)
+}
+
+// webhookRequest is a request received by the webhook stand-in
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+var _ = Describe("PolicyService webhooks", func() {
+	const secret = "0123456789abcdef"
+
+	var (
+		db            *gorm.DB
+		engine        *failingCompileEngine
+		policyService *service.PolicyServiceImpl
+		dispatcher    *service.WebhookDispatcher
+		ctx           context.Context
+
+		mu        sync.Mutex
+		received  []webhookRequest
+		responses []int
+		receiver  *httptest.Server
+	)
+
+	expectServiceError := func(err error, errorType service.ErrorType, message string) {
+		GinkgoHelper()
+		Expect(err).To(HaveOccurred())
+		serviceErr, ok := err.(*service.ServiceError)
+		Expect(ok).To(BeTrue())
+		Expect(serviceErr.Type).To(Equal(errorType))
+		Expect(serviceErr.Message).To(Equal(message))
+	}
+
+	newWebhook := func(eventTypes ...v1alpha1.WebhookEventType) v1alpha1.Webhook {
+		return v1alpha1.Webhook{
+			Url:        strPtr(receiver.URL + "/hook"),
+			EventTypes: &eventTypes,
+			Secret:     strPtr(secret),
+		}
+	}
+
+	createPolicyAt := func(id string, priority int32) {
+		GinkgoHelper()
+		_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+			DisplayName: strPtr(id),
+			PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+			Priority:    &priority,
+			RegoCode:    strPtr("package policies." + id + "\n\nmain := {\"rejected\": false}\n"),
+		}, strPtr(id), service.PolicyWriteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	createPolicy := func(id string) {
+		GinkgoHelper()
+		createPolicyAt(id, 10)
+	}
+
+	// receivedPolicies returns the policy IDs of the received events in the order they were received
+	receivedPolicies := func() []string {
+		GinkgoHelper()
+		mu.Lock()
+		defer mu.Unlock()
+		ids := make([]string, len(received))
+		for i, r := range received {
+			var payload v1alpha1.WebhookPayload
+			Expect(json.Unmarshal(r.body, &payload)).To(Succeed())
+			ids[i] = *payload.PolicyId
+		}
+		return ids
+	}
+
+	deliveries := func(webhookID string) []v1alpha1.WebhookDelivery {
+		GinkgoHelper()
+		list, err := policyService.ListWebhookDeliveries(ctx, webhookID)
+		Expect(err).NotTo(HaveOccurred())
+		return list.Deliveries
+	}
+
+	// makeDue moves the next attempt of all pending deliveries to now, skipping the backoff
+	makeDue := func() {
+		GinkgoHelper()
+		Expect(db.Model(&model.WebhookDelivery{}).
+			Where("status = ?", model.WebhookDeliveryPending).
+			Update("next_attempt_time", time.Now().Add(-time.Second)).Error).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicySetGeneration{})).To(Succeed())
+
+		ctx = context.Background()
+		engine = &failingCompileEngine{Engine: opa.NewEngine()}
+		dataStore := store.NewStore(db)
+		policyService = service.NewPolicyService(dataStore, engine)
+		// As on startup, the first compile records the stored policy set that later events are relative to
+		Expect(policyService.CompileAll(ctx)).To(Succeed())
+		dispatcher = service.NewWebhookDispatcher(dataStore, service.WebhookDeliveryOptions{
+			MaxAttempts:  3,
+			Backoff:      time.Minute,
+			Timeout:      5 * time.Second,
+			PollInterval: time.Second,
+		})
+
+		received, responses = nil, nil
+		receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, webhookRequest{header: r.Header.Clone(), body: body})
+			status := http.StatusNoContent
+			if len(responses) > 0 {
+				status, responses = responses[0], responses[1:]
+			}
+			w.WriteHeader(status)
+		}))
+		DeferCleanup(receiver.Close)
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	Describe("CreateWebhook", func() {
+		It("creates a webhook without returning its secret", func() {
+			created, err := policyService.CreateWebhook(ctx, newWebhook(v1alpha1.POLICYUPDATED, v1alpha1.POLICYCREATED, v1alpha1.POLICYCREATED), strPtr("chat"))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*created.Path).To(Equal("webhooks/chat"))
+			Expect(created.Secret).To(BeNil())
+			Expect(*created.EventTypes).To(Equal([]v1alpha1.WebhookEventType{v1alpha1.POLICYCREATED, v1alpha1.POLICYUPDATED}))
+		})
+
+		It("rejects a URL that is not absolute http or https", func() {
+			webhook := newWebhook(v1alpha1.POLICYCREATED)
+			webhook.Url = strPtr("ftp://example.com/hook")
+
+			_, err := policyService.CreateWebhook(ctx, webhook, nil)
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid url")
+		})
+
+		It("rejects an unknown event type", func() {
+			_, err := policyService.CreateWebhook(ctx, newWebhook("POLICY_RENAMED"), nil)
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid event type")
+		})
+
+		It("rejects a short secret", func() {
+			webhook := newWebhook(v1alpha1.POLICYCREATED)
+			webhook.Secret = strPtr("short")
+
+			_, err := policyService.CreateWebhook(ctx, webhook, nil)
+
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "Invalid secret")
+		})
+
+		It("rejects a duplicate ID", func() {
+			_, err := policyService.CreateWebhook(ctx, newWebhook(v1alpha1.POLICYCREATED), strPtr("chat"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = policyService.CreateWebhook(ctx, newWebhook(v1alpha1.POLICYCREATED), strPtr("chat"))
+
+			expectServiceError(err, service.ErrorTypeAlreadyExists, "Webhook already exists")
+		})
+	})
+
+	Describe("UpdateWebhook", func() {
+		It("merges the patch and validates the result", func() {
+			_, err := policyService.CreateWebhook(ctx, newWebhook(v1alpha1.POLICYCREATED), strPtr("chat"))
+			Expect(err).NotTo(HaveOccurred())
+
+			updated, err := policyService.UpdateWebhook(ctx, "chat", &v1alpha1.Webhook{DisplayName: strPtr("Chat")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*updated.DisplayName).To(Equal("Chat"))
+			Expect(*updated.EventTypes).To(Equal([]v1alpha1.WebhookEventType{v1alpha1.POLICYCREATED}))
+
+			_, err = policyService.UpdateWebhook(ctx, "chat", &v1alpha1.Webhook{EventTypes: &[]v1alpha1.WebhookEventType{}})
+			expectServiceError(err, service.ErrorTypeInvalidArgument, "event_types is required")
+		})
+
+		It("returns not found for a missing webhook", func() {
+			_, err := policyService.UpdateWebhook(ctx, "missing", &v1alpha1.Webhook{})
+
+			expectServiceError(err, service.ErrorTypeNotFound, "Webhook not found")
+		})
+	})
+
+	Describe("Delivery", func() {
+		BeforeEach(func() {
+			_, err := policyService.CreateWebhook(ctx, newWebhook(v1alpha1.POLICYCREATED, v1alpha1.POLICYDELETED, v1alpha1.COMPILEFAILED), strPtr("chat"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("delivers a signed payload of a subscribed event", func() {
+			createPolicy("region")
+
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			Expect(received).To(HaveLen(1))
+			request := received[0]
+			Expect(request.header.Get(service.WebhookIDHeader)).To(Equal("chat"))
+			Expect(request.header.Get(service.WebhookEventTypeHeader)).To(Equal("POLICY_CREATED"))
+			signature := request.header.Get(service.WebhookSignatureHeader)
+			Expect(signature).To(HavePrefix("t="))
+			var ts int64
+			_, err := fmt.Sscanf(signature, "t=%d,", &ts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signature).To(Equal(service.SignWebhookPayload(secret, time.Unix(ts, 0), request.body)))
+
+			var payload v1alpha1.WebhookPayload
+			Expect(json.Unmarshal(request.body, &payload)).To(Succeed())
+			Expect(payload.EventType).To(Equal(v1alpha1.POLICYCREATED))
+			Expect(*payload.PolicyId).To(Equal("region"))
+			Expect(*payload.ChangeType).To(Equal("CREATED"))
+			Expect(payload.Revision).NotTo(BeNil())
+
+			log := deliveries("chat")
+			Expect(log).To(HaveLen(1))
+			Expect(*log[0].Status).To(Equal(v1alpha1.DeliverySucceeded))
+			Expect(*log[0].Attempts).To(Equal(int32(1)))
+			Expect(*log[0].ResponseCode).To(Equal(int32(204)))
+			Expect(*log[0].Id).To(Equal(request.header.Get(service.WebhookDeliveryHeader)))
+		})
+
+		It("does not deliver events the webhook is not subscribed to", func() {
+			createPolicy("region")
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+			received = nil
+
+			_, err := policyService.UpdatePolicy(ctx, "region", &v1alpha1.Policy{Description: strPtr("changed")}, service.PolicyWriteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			Expect(received).To(BeEmpty())
+			Expect(deliveries("chat")).To(HaveLen(1))
+		})
+
+		It("retries a failed delivery with backoff", func() {
+			responses = []int{http.StatusServiceUnavailable}
+			createPolicy("region")
+
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			log := deliveries("chat")
+			Expect(*log[0].Status).To(Equal(v1alpha1.DeliveryPending))
+			Expect(*log[0].ResponseCode).To(Equal(int32(503)))
+			Expect(*log[0].LastError).To(ContainSubstring("unexpected response status 503"))
+			Expect(log[0].NextAttemptTime.After(time.Now().Add(50 * time.Second))).To(BeTrue())
+
+			// Not due before the backoff has passed
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+			Expect(received).To(HaveLen(1))
+
+			makeDue()
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			Expect(received).To(HaveLen(2))
+			Expect(received[1].body).To(Equal(received[0].body))
+			log = deliveries("chat")
+			Expect(*log[0].Status).To(Equal(v1alpha1.DeliverySucceeded))
+			Expect(*log[0].Attempts).To(Equal(int32(2)))
+			Expect(log[0].LastError).To(BeNil())
+			Expect(log[0].NextAttemptTime).To(BeNil())
+		})
+
+		It("does not send the later deliveries of a round after a failed one", func() {
+			responses = []int{http.StatusServiceUnavailable}
+			createPolicy("region")
+			createPolicyAt("quota", 20)
+
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			Expect(receivedPolicies()).To(Equal([]string{"region"}))
+			log := deliveries("chat")
+			Expect(log).To(HaveLen(2))
+			for _, delivery := range log {
+				Expect(*delivery.Status).To(Equal(v1alpha1.DeliveryPending))
+			}
+
+			makeDue()
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			Expect(receivedPolicies()).To(Equal([]string{"region", "region", "quota"}))
+			for _, delivery := range deliveries("chat") {
+				Expect(*delivery.Status).To(Equal(v1alpha1.DeliverySucceeded))
+			}
+		})
+
+		It("holds the later deliveries back in the next rounds until the failed one is retried", func() {
+			responses = []int{http.StatusServiceUnavailable}
+			createPolicy("region")
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			// quota is due at once, but region waits for its retry
+			createPolicyAt("quota", 20)
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			Expect(receivedPolicies()).To(Equal([]string{"region"}))
+
+			makeDue()
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			Expect(receivedPolicies()).To(Equal([]string{"region", "region", "quota"}))
+		})
+
+		It("gives up after the maximum number of attempts", func() {
+			responses = []int{500, 500, 500}
+			createPolicy("region")
+
+			for range 3 {
+				makeDue()
+				Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+			}
+			makeDue()
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			Expect(received).To(HaveLen(3))
+			log := deliveries("chat")
+			Expect(*log[0].Status).To(Equal(v1alpha1.DeliveryFailed))
+			Expect(*log[0].Attempts).To(Equal(int32(3)))
+		})
+
+		It("delivers a compile failure of the stored policy set", func() {
+			engine.fail = true
+			priority := int32(10)
+			_, err := policyService.CreatePolicy(ctx, v1alpha1.Policy{
+				DisplayName: strPtr("region"),
+				PolicyType:  policyTypePtr(v1alpha1.GLOBAL),
+				Priority:    &priority,
+				RegoCode:    strPtr("package policies.region\n\nmain := {\"rejected\": false}\n"),
+			}, strPtr("region"), service.PolicyWriteOptions{})
+			Expect(err).To(HaveOccurred())
+
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			Expect(received).To(HaveLen(1))
+			var payload v1alpha1.WebhookPayload
+			Expect(json.Unmarshal(received[0].body, &payload)).To(Succeed())
+			Expect(payload.EventType).To(Equal(v1alpha1.COMPILEFAILED))
+			Expect(*payload.Error).To(ContainSubstring("conflicting rules"))
+			Expect(payload.PolicyId).To(BeNil())
+		})
+
+		It("drops the deliveries of a deleted webhook", func() {
+			createPolicy("region")
+
+			Expect(policyService.DeleteWebhook(ctx, "chat")).To(Succeed())
+			Expect(dispatcher.DeliverDue(ctx)).To(Succeed())
+
+			Expect(received).To(BeEmpty())
+			_, err := policyService.ListWebhookDeliveries(ctx, "chat")
+			expectServiceError(err, service.ErrorTypeNotFound, "Webhook not found")
+		})
+	})
+})
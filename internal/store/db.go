@@ -58,7 +58,7 @@ func InitDB(cfg *config.Config) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(100)
 
 	// Auto-migrate schema
-	if err := db.AutoMigrate(&model.Policy{}); err != nil {
+	if err := db.AutoMigrate(&model.Policy{}, &model.Library{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.PolicyProposal{}, &model.PolicySetGeneration{}); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
@@ -0,0 +1,302 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidFilter indicates that a filter expression cannot be translated to SQL
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// FilterExpr is a boolean expression over policy fields that the store translates to SQL.
+// Field names are the column names of the policies table.
+type FilterExpr interface {
+	isFilterExpr()
+}
+
+// CompareOp is a comparison operator
+type CompareOp string
+
+const (
+	OpEq CompareOp = "="
+	OpNe CompareOp = "<>"
+	OpLt CompareOp = "<"
+	OpLe CompareOp = "<="
+	OpGt CompareOp = ">"
+	OpGe CompareOp = ">="
+)
+
+// MatchKind selects how MatchExpr compares strings
+type MatchKind int
+
+const (
+	MatchContains MatchKind = iota
+	MatchPrefix
+	MatchSuffix
+)
+
+// ActiveExpr is true when a policy is enabled and At is within its effective window (see model.Policy.ActiveAt)
+type ActiveExpr struct {
+	At time.Time
+}
+
+// AndExpr is true when all of its expressions are true
+type AndExpr struct {
+	Exprs []FilterExpr
+}
+
+// OrExpr is true when any of its expressions is true
+type OrExpr struct {
+	Exprs []FilterExpr
+}
+
+// NotExpr negates an expression
+type NotExpr struct {
+	Expr FilterExpr
+}
+
+// CompareExpr compares a field with a value
+type CompareExpr struct {
+	Field string
+	Op    CompareOp
+	Value any
+}
+
+// InExpr is true when a field equals one of the values
+type InExpr struct {
+	Field  string
+	Values []any
+}
+
+// MatchExpr matches a string field against a substring, prefix or suffix (case-sensitive)
+type MatchExpr struct {
+	Field string
+	Kind  MatchKind
+	Value string
+}
+
+// LabelExpr tests a label selector entry. A nil Value tests that the key is present;
+// otherwise the value of the key is compared using Op (OpEq or OpNe). A missing key never matches.
+type LabelExpr struct {
+	Key   string
+	Op    CompareOp
+	Value *string
+}
+
+func (AndExpr) isFilterExpr()     {}
+func (OrExpr) isFilterExpr()      {}
+func (NotExpr) isFilterExpr()     {}
+func (CompareExpr) isFilterExpr() {}
+func (InExpr) isFilterExpr()      {}
+func (MatchExpr) isFilterExpr()   {}
+func (LabelExpr) isFilterExpr()   {}
+func (ActiveExpr) isFilterExpr()  {}
+
+// filterColumns are the columns that filter expressions may reference
+var filterColumns = map[string]struct{}{
+	"id":           {},
+	"display_name": {},
+	"description":  {},
+	"policy_type":  {},
+	"priority":     {},
+	"enabled":      {},
+	"managed":      {},
+	"create_time":  {},
+	"update_time":  {},
+	// Policies without an effective window never match comparisons on its bounds
+	"effective_from":  {},
+	"effective_until": {},
+}
+
+// PolicyFilter contains optional fields for filtering policy queries.
+// nil fields are ignored (not filtered); all non-nil fields must match.
+type PolicyFilter struct {
+	PolicyType *string
+	Enabled    *bool
+	// ActiveAt selects the policies that are enabled and effective at the given time
+	ActiveAt *time.Time
+	// Expr is translated to SQL
+	Expr FilterExpr
+	// Match is evaluated in memory for conditions that cannot be translated to SQL.
+	// Rows are fetched without pagination and paginated after matching.
+	Match func(*model.Policy) bool
+}
+
+// applyFilter adds the SQL conditions of filter to query
+func applyFilter(db *gorm.DB, query *gorm.DB, filter *PolicyFilter) (*gorm.DB, error) {
+	if filter.PolicyType != nil {
+		query = query.Where("policy_type = ?", *filter.PolicyType)
+	}
+	if filter.Enabled != nil {
+		query = query.Where("enabled = ?", *filter.Enabled)
+	}
+	if filter.ActiveAt != nil {
+		b := &filterBuilder{dialect: db.Name()}
+		b.active(*filter.ActiveAt)
+		query = query.Where(clause.Expr{SQL: b.sql.String(), Vars: b.vars})
+	}
+	if filter.Expr != nil {
+		b := &filterBuilder{dialect: db.Name()}
+		if err := b.build(filter.Expr); err != nil {
+			return nil, err
+		}
+		query = query.Where(clause.Expr{SQL: b.sql.String(), Vars: b.vars})
+	}
+	return query, nil
+}
+
+// filterBuilder renders a FilterExpr as a SQL condition with positional parameters
+type filterBuilder struct {
+	dialect string
+	sql     strings.Builder
+	vars    []any
+}
+
+func (b *filterBuilder) build(expr FilterExpr) error {
+	switch e := expr.(type) {
+	case AndExpr:
+		return b.join(e.Exprs, " AND ", "1 = 1")
+	case OrExpr:
+		return b.join(e.Exprs, " OR ", "1 = 0")
+	case NotExpr:
+		b.sql.WriteString("NOT (")
+		if err := b.build(e.Expr); err != nil {
+			return err
+		}
+		b.sql.WriteString(")")
+	case CompareExpr:
+		if err := validateColumn(e.Field); err != nil {
+			return err
+		}
+		if err := validateOp(e.Op); err != nil {
+			return err
+		}
+		if _, isTime := e.Value.(time.Time); isTime && b.dialect != "postgres" {
+			// SQLite stores timestamps as text that may carry different UTC offsets
+			fmt.Fprintf(&b.sql, "julianday(%s) %s julianday(?)", e.Field, e.Op)
+		} else {
+			fmt.Fprintf(&b.sql, "%s %s ?", e.Field, e.Op)
+		}
+		b.vars = append(b.vars, e.Value)
+	case InExpr:
+		if err := validateColumn(e.Field); err != nil {
+			return err
+		}
+		if len(e.Values) == 0 {
+			b.sql.WriteString("1 = 0")
+			return nil
+		}
+		fmt.Fprintf(&b.sql, "%s IN ?", e.Field)
+		b.vars = append(b.vars, e.Values)
+	case MatchExpr:
+		if err := validateColumn(e.Field); err != nil {
+			return err
+		}
+		b.match(e)
+	case LabelExpr:
+		return b.label(e)
+	case ActiveExpr:
+		b.active(e.At)
+	default:
+		return fmt.Errorf("%w: unsupported expression %T", ErrInvalidFilter, expr)
+	}
+	return nil
+}
+
+func (b *filterBuilder) join(exprs []FilterExpr, sep, empty string) error {
+	if len(exprs) == 0 {
+		b.sql.WriteString(empty)
+		return nil
+	}
+	for i, expr := range exprs {
+		if i > 0 {
+			b.sql.WriteString(sep)
+		}
+		b.sql.WriteString("(")
+		if err := b.build(expr); err != nil {
+			return err
+		}
+		b.sql.WriteString(")")
+	}
+	return nil
+}
+
+// match renders a case-sensitive substring, prefix or suffix match. LIKE is avoided because its
+// case sensitivity differs between SQLite and PostgreSQL.
+func (b *filterBuilder) match(e MatchExpr) {
+	switch e.Kind {
+	case MatchPrefix:
+		fmt.Fprintf(&b.sql, "substr(%s, 1, length(?)) = ?", e.Field)
+		b.vars = append(b.vars, e.Value, e.Value)
+	case MatchSuffix:
+		fmt.Fprintf(&b.sql, "substr(%s, length(%s) - length(?) + 1) = ?", e.Field, e.Field)
+		b.vars = append(b.vars, e.Value, e.Value)
+	default:
+		if b.dialect == "postgres" {
+			fmt.Fprintf(&b.sql, "strpos(%s, ?) > 0", e.Field)
+		} else {
+			fmt.Fprintf(&b.sql, "instr(%s, ?) > 0", e.Field)
+		}
+		b.vars = append(b.vars, e.Value)
+	}
+}
+
+// active renders the condition of ActiveExpr. The condition is never NULL, so that it can be negated.
+func (b *filterBuilder) active(at time.Time) {
+	from, until, param := "effective_from", "effective_until", "?"
+	if b.dialect != "postgres" {
+		// SQLite stores timestamps as text that may carry different UTC offsets
+		from, until, param = "julianday(effective_from)", "julianday(effective_until)", "julianday(?)"
+	}
+	fmt.Fprintf(&b.sql, "enabled = ? AND (effective_from IS NULL OR %s <= %s) AND (effective_until IS NULL OR %s > %s)",
+		from, param, until, param)
+	b.vars = append(b.vars, true, at.UTC(), at.UTC())
+}
+
+func (b *filterBuilder) label(e LabelExpr) error {
+	// label_selector is stored as a JSON object in a text column
+	var value string
+	if b.dialect == "postgres" {
+		value = "(label_selector::jsonb ->> ?)"
+		b.vars = append(b.vars, e.Key)
+	} else {
+		if strings.ContainsAny(e.Key, `"\`) {
+			return fmt.Errorf("%w: unsupported label key %q", ErrInvalidFilter, e.Key)
+		}
+		value = "json_extract(label_selector, ?)"
+		b.vars = append(b.vars, `$."`+e.Key+`"`)
+	}
+
+	if e.Value == nil {
+		b.sql.WriteString(value + " IS NOT NULL")
+		return nil
+	}
+	if e.Op != OpEq && e.Op != OpNe {
+		return fmt.Errorf("%w: unsupported label operator %q", ErrInvalidFilter, e.Op)
+	}
+	fmt.Fprintf(&b.sql, "%s %s ?", value, e.Op)
+	b.vars = append(b.vars, *e.Value)
+	return nil
+}
+
+func validateColumn(field string) error {
+	if _, ok := filterColumns[field]; !ok {
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, field)
+	}
+	return nil
+}
+
+func validateOp(op CompareOp) error {
+	switch op {
+	case OpEq, OpNe, OpLt, OpLe, OpGt, OpGe:
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported operator %q", ErrInvalidFilter, op)
+	}
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PolicySetChannel is the PostgreSQL notification channel announcing new policy set generations
+const PolicySetChannel = "policy_set_changed"
+
+// ErrListenUnsupported is returned by Listen when the database has no change notifications
+var ErrListenUnsupported = errors.New("database does not support change notifications")
+
+type Generation interface {
+	// Get returns the current generation of the policy set, 0 before its first change
+	Get(ctx context.Context) (int64, error)
+	// Increment advances the generation of the policy set and returns the new generation. On PostgreSQL,
+	// the new generation is announced on PolicySetChannel when the surrounding transaction commits.
+	Increment(ctx context.Context) (int64, error)
+	// Listen calls notify with every generation announced on PolicySetChannel until ctx is cancelled or
+	// the connection fails. It returns ErrListenUnsupported on databases other than PostgreSQL.
+	Listen(ctx context.Context, notify func(generation int64)) error
+}
+
+type GenerationStore struct {
+	db *gorm.DB
+}
+
+var _ Generation = (*GenerationStore)(nil)
+
+func NewGeneration(db *gorm.DB) Generation {
+	return &GenerationStore{db: db}
+}
+
+func (s *GenerationStore) Get(ctx context.Context) (int64, error) {
+	var row model.PolicySetGeneration
+	err := s.db.WithContext(ctx).Where("id = ?", model.PolicySetGenerationID).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return row.Generation, nil
+}
+
+func (s *GenerationStore) Increment(ctx context.Context) (int64, error) {
+	row := model.PolicySetGeneration{ID: model.PolicySetGenerationID, Generation: 1, UpdateTime: time.Now().UTC()}
+	err := s.db.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "generation"}, Value: gorm.Expr("policy_set_generations.generation + 1")},
+				{Column: clause.Column{Name: "update_time"}, Value: row.UpdateTime},
+			},
+		},
+		clause.Returning{},
+	).Create(&row).Error
+	if err != nil {
+		return 0, err
+	}
+
+	if s.db.Name() == "postgres" {
+		if err := s.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", PolicySetChannel, strconv.FormatInt(row.Generation, 10)).Error; err != nil {
+			return 0, err
+		}
+	}
+	return row.Generation, nil
+}
+
+func (s *GenerationStore) Listen(ctx context.Context, notify func(generation int64)) error {
+	if s.db.Name() != "postgres" {
+		return ErrListenUnsupported
+	}
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	var listenErr error
+	// The connection keeps listening after use, so it is discarded rather than returned to the pool
+	_ = conn.Raw(func(driverConn any) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			listenErr = fmt.Errorf("unexpected PostgreSQL driver connection %T", driverConn)
+			return driver.ErrBadConn
+		}
+		pgConn := stdlibConn.Conn()
+		if _, err := pgConn.Exec(ctx, "LISTEN "+PolicySetChannel); err != nil {
+			listenErr = err
+			return driver.ErrBadConn
+		}
+		for {
+			notification, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					listenErr = err
+				}
+				return driver.ErrBadConn
+			}
+			generation, err := strconv.ParseInt(notification.Payload, 10, 64)
+			if err != nil {
+				continue
+			}
+			notify(generation)
+		}
+	})
+	return listenErr
+}
@@ -0,0 +1,74 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("Generation Store", func() {
+	var (
+		db              *gorm.DB
+		generationStore store.Generation
+		ctx             context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.PolicySetGeneration{})).To(Succeed())
+
+		generationStore = store.NewGeneration(db)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	It("should start at generation 0 and count increments", func() {
+		generation, err := generationStore.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generation).To(Equal(int64(0)))
+
+		for want := int64(1); want <= 3; want++ {
+			generation, err = generationStore.Increment(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(generation).To(Equal(want))
+		}
+
+		generation, err = generationStore.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generation).To(Equal(int64(3)))
+	})
+
+	It("should not keep an increment of a rolled back transaction", func() {
+		rollback := errors.New("rollback")
+		err := store.NewStore(db).Transaction(ctx, func(tx store.Store) error {
+			_, err := tx.Generation().Increment(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			return rollback
+		})
+		Expect(err).To(MatchError(rollback))
+
+		generation, err := generationStore.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generation).To(Equal(int64(0)))
+	})
+
+	It("should not support listening on SQLite", func() {
+		err := generationStore.Listen(ctx, func(int64) {})
+		Expect(err).To(MatchError(store.ErrListenUnsupported))
+	})
+})
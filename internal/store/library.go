@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrLibraryNotFound = errors.New("library not found")
+	ErrLibraryIDTaken  = errors.New("library ID already taken")
+)
+
+type Library interface {
+	ListAll(ctx context.Context) (model.LibraryList, error)
+	Create(ctx context.Context, library model.Library) (*model.Library, error)
+	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, library model.Library) (*model.Library, error)
+	Get(ctx context.Context, id string) (*model.Library, error)
+}
+
+type LibraryStore struct {
+	db *gorm.DB
+}
+
+var _ Library = (*LibraryStore)(nil)
+
+func NewLibrary(db *gorm.DB) Library {
+	return &LibraryStore{db: db}
+}
+
+func (s *LibraryStore) ListAll(ctx context.Context) (model.LibraryList, error) {
+	var libraries model.LibraryList
+	if err := s.db.WithContext(ctx).Order("id ASC").Find(&libraries).Error; err != nil {
+		return nil, err
+	}
+	if libraries == nil {
+		libraries = model.LibraryList{}
+	}
+	return libraries, nil
+}
+
+func (s *LibraryStore) Create(ctx context.Context, library model.Library) (*model.Library, error) {
+	if err := s.db.WithContext(ctx).Clauses(clause.Returning{}).Select("*").Create(&library).Error; err != nil {
+		// The ID is the only unique column
+		if _, getErr := s.Get(ctx, library.ID); getErr == nil {
+			return nil, ErrLibraryIDTaken
+		}
+		return nil, err
+	}
+	return &library, nil
+}
+
+func (s *LibraryStore) Delete(ctx context.Context, id string) error {
+	result := s.db.WithContext(ctx).Where("id = ?", id).Delete(&model.Library{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLibraryNotFound
+	}
+	return nil
+}
+
+func (s *LibraryStore) Update(ctx context.Context, library model.Library) (*model.Library, error) {
+	// Immutable fields (id, create_time) are not updated
+	result := s.db.WithContext(ctx).Model(&library).
+		Select("display_name", "description", "package", "rego_code").
+		Clauses(clause.Returning{}).
+		Updates(&library)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrLibraryNotFound
+	}
+	return &library, nil
+}
+
+func (s *LibraryStore) Get(ctx context.Context, id string) (*model.Library, error) {
+	var library model.Library
+	if err := s.db.WithContext(ctx).First(&library, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLibraryNotFound
+		}
+		return nil, err
+	}
+	return &library, nil
+}
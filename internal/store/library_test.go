@@ -0,0 +1,90 @@
+package store_test
+
+import (
+	"context"
+
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("Library Store", func() {
+	var (
+		db           *gorm.DB
+		libraryStore store.Library
+		ctx          context.Context
+	)
+
+	newLibrary := func(id string) model.Library {
+		return model.Library{ID: id, Package: "lib." + id, RegoCode: "package lib." + id}
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Library{})).To(Succeed())
+
+		libraryStore = store.NewLibrary(db)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	It("creates, lists and gets libraries", func() {
+		_, err := libraryStore.Create(ctx, newLibrary("strings"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = libraryStore.Create(ctx, newLibrary("regions"))
+		Expect(err).NotTo(HaveOccurred())
+
+		libraries, err := libraryStore.ListAll(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(libraries).To(HaveLen(2))
+		Expect(libraries[0].ID).To(Equal("regions"))
+
+		library, err := libraryStore.Get(ctx, "strings")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(library.Package).To(Equal("lib.strings"))
+	})
+
+	It("rejects duplicate IDs", func() {
+		_, err := libraryStore.Create(ctx, newLibrary("regions"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = libraryStore.Create(ctx, newLibrary("regions"))
+		Expect(err).To(Equal(store.ErrLibraryIDTaken))
+	})
+
+	It("updates the mutable fields", func() {
+		_, err := libraryStore.Create(ctx, newLibrary("regions"))
+		Expect(err).NotTo(HaveOccurred())
+
+		library := newLibrary("regions")
+		library.DisplayName = "Regions"
+		library.RegoCode = "package lib.regions\n\nx := 1"
+		_, err = libraryStore.Update(ctx, library)
+		Expect(err).NotTo(HaveOccurred())
+
+		stored, err := libraryStore.Get(ctx, "regions")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.DisplayName).To(Equal("Regions"))
+		Expect(stored.RegoCode).To(Equal(library.RegoCode))
+	})
+
+	It("returns ErrLibraryNotFound for unknown libraries", func() {
+		_, err := libraryStore.Get(ctx, "missing")
+		Expect(err).To(Equal(store.ErrLibraryNotFound))
+		Expect(libraryStore.Delete(ctx, "missing")).To(Equal(store.ErrLibraryNotFound))
+		_, err = libraryStore.Update(ctx, newLibrary("missing"))
+		Expect(err).To(Equal(store.ErrLibraryNotFound))
+	})
+})
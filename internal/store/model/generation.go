@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+)
+
+// PolicySetGenerationID is the ID of the single row holding the policy set generation
+const PolicySetGenerationID = 1
+
+// PolicySetGeneration counts the changes of the stored policy set, so that every replica can tell
+// whether its engine is compiled from the current set
+type PolicySetGeneration struct {
+	ID         int       `gorm:"primaryKey;autoIncrement:false"`
+	Generation int64     `gorm:"column:generation;not null"`
+	UpdateTime time.Time `gorm:"column:update_time"`
+}
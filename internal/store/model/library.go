@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+)
+
+type Library struct {
+	ID          string    `gorm:"primaryKey;type:varchar(63)"`
+	DisplayName string    `gorm:"column:display_name"`
+	Description string    `gorm:"column:description"`
+	Package     string    `gorm:"column:package;not null"`
+	RegoCode    string    `gorm:"column:rego_code;type:text;not null"`
+	CreateTime  time.Time `gorm:"column:create_time;autoCreateTime"`
+	UpdateTime  time.Time `gorm:"column:update_time;autoUpdateTime"`
+}
+
+type LibraryList []Library
@@ -6,16 +6,35 @@ import (
 )
 
 type Policy struct {
-	ID            string            `gorm:"primaryKey;type:varchar(63)"`
-	DisplayName   string            `gorm:"column:display_name;not null;uniqueIndex:idx_display_name_policy_type"`
-	Description   string            `gorm:"column:description"`
-	PolicyType    string            `gorm:"column:policy_type;not null;uniqueIndex:idx_display_name_policy_type;uniqueIndex:idx_priority_policy_type"`
-	LabelSelector map[string]string `gorm:"column:label_selector;serializer:json"`
-	Priority      int32             `gorm:"column:priority;not null;uniqueIndex:idx_priority_policy_type"`
-	RegoCode      string            `gorm:"column:rego_code;type:text;not null"`
-	Enabled       bool              `gorm:"column:enabled;not null"`
-	CreateTime    time.Time         `gorm:"column:create_time;autoCreateTime"`
-	UpdateTime    time.Time         `gorm:"column:update_time;autoUpdateTime"`
+	ID                string            `gorm:"primaryKey;type:varchar(63)"`
+	DisplayName       string            `gorm:"column:display_name;not null;uniqueIndex:idx_display_name_policy_type"`
+	Description       string            `gorm:"column:description"`
+	PolicyType        string            `gorm:"column:policy_type;not null;uniqueIndex:idx_display_name_policy_type;uniqueIndex:idx_priority_policy_type"`
+	LabelSelector     map[string]string `gorm:"column:label_selector;serializer:json"`
+	Priority          int32             `gorm:"column:priority;not null;uniqueIndex:idx_priority_policy_type"`
+	RegoCode          string            `gorm:"column:rego_code;type:text;not null"`
+	TestCode          string            `gorm:"column:test_code;type:text;not null;default:''"`
+	Enabled           bool              `gorm:"column:enabled;not null"`
+	EffectiveFrom     *time.Time        `gorm:"column:effective_from"`
+	EffectiveUntil    *time.Time        `gorm:"column:effective_until"`
+	RolloutPercentage *int32            `gorm:"column:rollout_percentage"`
+	RolloutKey        string            `gorm:"column:rollout_key;not null;default:''"`
+	Entrypoint        string            `gorm:"column:entrypoint;not null;default:''"`
+	Managed           bool              `gorm:"column:managed;not null;default:false"`
+	CreateTime        time.Time         `gorm:"column:create_time;autoCreateTime"`
+	UpdateTime        time.Time         `gorm:"column:update_time;autoUpdateTime"`
 }
 
 type PolicyList []Policy
+
+// ActiveAt reports whether the policy is enabled and t is within its effective window.
+// effective_from is inclusive and effective_until is exclusive; an unset bound is open.
+func (p *Policy) ActiveAt(t time.Time) bool {
+	if !p.Enabled {
+		return false
+	}
+	if p.EffectiveFrom != nil && t.Before(*p.EffectiveFrom) {
+		return false
+	}
+	return p.EffectiveUntil == nil || t.Before(*p.EffectiveUntil)
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+)
+
+// Proposal operations
+const (
+	ProposalCreate = "CREATE"
+	ProposalUpdate = "UPDATE"
+	ProposalDelete = "DELETE"
+)
+
+// Proposal states
+const (
+	ProposalPending  = "PENDING"
+	ProposalApproved = "APPROVED"
+	ProposalRejected = "REJECTED"
+)
+
+type PolicyProposal struct {
+	ID        string `gorm:"primaryKey;type:varchar(63)"`
+	Operation string `gorm:"column:operation;not null"`
+	PolicyID  string `gorm:"column:policy_id;type:varchar(63);not null;index"`
+	// Policy is the JSON of the proposed policy for a create, and of the merge patch for an update
+	Policy string `gorm:"column:policy;type:text;not null;default:''"`
+	// LibraryID is set instead of PolicyID by a proposal that changes a library
+	LibraryID string `gorm:"column:library_id;type:varchar(63);not null;default:'';index"`
+	// Library is the JSON of the proposed library for a create, and of the merge patch for an update
+	Library        string     `gorm:"column:library;type:text;not null;default:''"`
+	BaseUpdateTime *time.Time `gorm:"column:base_update_time"`
+	Description    string     `gorm:"column:description;type:text;not null;default:''"`
+	State          string     `gorm:"column:state;not null;index"`
+	Proposer       string     `gorm:"column:proposer;not null"`
+	Reviewer       string     `gorm:"column:reviewer;not null;default:''"`
+	ReviewComment  string     `gorm:"column:review_comment;type:text;not null;default:''"`
+	ReviewTime     *time.Time `gorm:"column:review_time"`
+	CreateTime     time.Time  `gorm:"column:create_time;autoCreateTime"`
+	UpdateTime     time.Time  `gorm:"column:update_time;autoUpdateTime"`
+}
+
+type PolicyProposalList []PolicyProposal
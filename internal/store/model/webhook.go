@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+)
+
+type Webhook struct {
+	ID          string    `gorm:"primaryKey;type:varchar(63)"`
+	DisplayName string    `gorm:"column:display_name"`
+	URL         string    `gorm:"column:url;not null"`
+	EventTypes  []string  `gorm:"column:event_types;serializer:json;not null"`
+	Secret      string    `gorm:"column:secret;not null"`
+	CreateTime  time.Time `gorm:"column:create_time;autoCreateTime"`
+	UpdateTime  time.Time `gorm:"column:update_time;autoUpdateTime"`
+}
+
+type WebhookList []Webhook
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "PENDING"
+	WebhookDeliverySucceeded = "SUCCEEDED"
+	WebhookDeliveryFailed    = "FAILED"
+)
+
+type WebhookDelivery struct {
+	ID              string     `gorm:"primaryKey;type:varchar(63)"`
+	WebhookID       string     `gorm:"column:webhook_id;type:varchar(63);not null;index"`
+	EventID         string     `gorm:"column:event_id;not null"`
+	EventType       string     `gorm:"column:event_type;not null"`
+	PolicyID        string     `gorm:"column:policy_id;not null;default:''"`
+	Payload         string     `gorm:"column:payload;type:text;not null"`
+	Status          string     `gorm:"column:status;not null"`
+	Attempts        int32      `gorm:"column:attempts;not null;default:0"`
+	ResponseCode    int32      `gorm:"column:response_code;not null;default:0"`
+	LastError       string     `gorm:"column:last_error;type:text;not null;default:''"`
+	NextAttemptTime *time.Time `gorm:"column:next_attempt_time;index"`
+	CreateTime      time.Time  `gorm:"column:create_time;autoCreateTime"`
+	UpdateTime      time.Time  `gorm:"column:update_time;autoUpdateTime"`
+}
+
+type WebhookDeliveryList []WebhookDelivery
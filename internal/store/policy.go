@@ -2,9 +2,8 @@ package store
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
-	"strconv"
+	"slices"
 	"strings"
 
 	"github.com/dcm-project/policy-manager/internal/store/model"
@@ -19,25 +18,33 @@ var (
 	ErrPriorityPolicyTypeTaken    = errors.New("priority and policy_type combination already taken")
 )
 
-// PolicyFilter contains optional fields for filtering policy queries.
-// nil fields are ignored (not filtered).
-type PolicyFilter struct {
-	PolicyType *string
-	Enabled    *bool
+// OrderField is a column to order a listing by
+type OrderField struct {
+	Field string
+	Desc  bool
 }
 
+// DefaultOrderBy is the ordering used when PolicyListOptions.OrderBy is empty
+var DefaultOrderBy = []OrderField{{Field: "policy_type"}, {Field: "priority"}, {Field: "id"}}
+
 // PolicyListOptions contains options for listing policies.
 type PolicyListOptions struct {
-	Filter    *PolicyFilter
-	OrderBy   string
-	PageToken *string
-	PageSize  int
+	Filter  *PolicyFilter
+	OrderBy []OrderField
+	// After is the last policy of the previous page. Only policies ordered after it are returned (keyset pagination).
+	After    *model.Policy
+	PageSize int
+	// CountTotal requests the total number of policies matching the filter
+	CountTotal bool
 }
 
 // PolicyListResult contains the result of a List operation.
 type PolicyListResult struct {
-	Policies      model.PolicyList
-	NextPageToken string
+	Policies model.PolicyList
+	// HasMore is set when policies exist after the last returned one
+	HasMore bool
+	// TotalSize is the number of policies matching the filter, across all pages. Only set when CountTotal is requested.
+	TotalSize int64
 }
 
 type Policy interface {
@@ -47,6 +54,10 @@ type Policy interface {
 	Delete(ctx context.Context, id string) error
 	Update(ctx context.Context, policy model.Policy) (*model.Policy, error)
 	Get(ctx context.Context, id string) (*model.Policy, error)
+	// CheckUnique returns the sentinel error of the first uniqueness constraint that writing policy would violate
+	CheckUnique(ctx context.Context, policy model.Policy, isUpdate bool) error
+	// SetPriorities assigns new priorities to the given policies, keyed by ID, in one transaction
+	SetPriorities(ctx context.Context, priorities map[string]int32) error
 }
 
 type PolicyStore struct {
@@ -60,85 +71,219 @@ func NewPolicy(db *gorm.DB) Policy {
 }
 
 func (s *PolicyStore) List(ctx context.Context, opts *PolicyListOptions) (*PolicyListResult, error) {
-	var policies model.PolicyList
-	query := s.db.WithContext(ctx)
+	if opts == nil {
+		opts = &PolicyListOptions{}
+	}
 
 	// Default page size
 	pageSize := 50
-	if opts != nil && opts.PageSize > 0 {
+	if opts.PageSize > 0 {
 		pageSize = opts.PageSize
 	}
 
-	// Decode page token to get offset
-	offset := 0
-	if opts != nil && opts.PageToken != nil && *opts.PageToken != "" {
-		decoded, err := base64.StdEncoding.DecodeString(*opts.PageToken)
-		if err == nil {
-			if parsedOffset, err := strconv.Atoi(string(decoded)); err == nil {
-				offset = parsedOffset
-			}
+	order := keysetOrder(opts.OrderBy)
+	for _, f := range order {
+		if err := validateColumn(f.Field); err != nil {
+			return nil, err
 		}
 	}
 
-	if opts != nil {
-		if opts.Filter != nil {
-			if opts.Filter.PolicyType != nil {
-				query = query.Where("policy_type = ?", *opts.Filter.PolicyType)
-			}
-			if opts.Filter.Enabled != nil {
-				query = query.Where("enabled = ?", *opts.Filter.Enabled)
-			}
+	query := s.db.WithContext(ctx).Model(&model.Policy{})
+	var match func(*model.Policy) bool
+	if opts.Filter != nil {
+		var err error
+		query, err = applyFilter(s.db, query, opts.Filter)
+		if err != nil {
+			return nil, err
 		}
+		match = opts.Filter.Match
+	}
 
-		// Apply ordering
-		if opts.OrderBy != "" {
-			query = query.Order(opts.OrderBy)
-		} else {
-			// Default order by policy_type, priority, id ascending
-			query = query.Order("policy_type ASC, priority ASC, id ASC")
+	result := &PolicyListResult{}
+	if opts.CountTotal {
+		total, err := countPolicies(query, match)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		// Default order when no options provided
-		query = query.Order("policy_type ASC, priority ASC, id ASC")
+		result.TotalSize = total
 	}
 
-	// Query with limit+1 to detect if there are more results
-	query = query.Limit(pageSize + 1).Offset(offset)
-
-	if err := query.Find(&policies).Error; err != nil {
-		return nil, err
+	query = query.Session(&gorm.Session{})
+	for _, f := range order {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: f.Field}, Desc: f.Desc})
 	}
 
-	// Generate next page token if there are more results
-	result := &PolicyListResult{
-		Policies: policies,
+	// Query with limit+1 to detect if there are more results
+	policies, err := findPolicies(query.Session(&gorm.Session{}), order, opts.After, match, pageSize+1)
+	if err != nil {
+		return nil, err
 	}
 
+	result.Policies = policies
 	if len(policies) > pageSize {
 		// Trim to requested page size
 		result.Policies = policies[:pageSize]
-		// Encode next offset as page token
-		nextOffset := offset + pageSize
-		result.NextPageToken = base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(nextOffset)))
+		result.HasMore = true
 	}
 
 	return result, nil
 }
 
+// findPolicies returns up to limit policies selected by the ordered query after the cursor. Conditions
+// that could not be translated to SQL are matched in memory, on chunks of limit policies read along
+// the keyset until enough of them match or no policies are left.
+func findPolicies(query *gorm.DB, order []OrderField, after *model.Policy, match func(*model.Policy) bool, limit int) (model.PolicyList, error) {
+	var found model.PolicyList
+	for {
+		chunk := query
+		if after != nil {
+			chunk = chunk.Where(keysetCondition(order, after))
+		}
+		var policies model.PolicyList
+		if err := chunk.Limit(limit).Find(&policies).Error; err != nil {
+			return nil, err
+		}
+		if match == nil {
+			return policies, nil
+		}
+		found = append(found, matchPolicies(policies, match, limit-len(found))...)
+		if len(found) == limit || len(policies) < limit {
+			return found, nil
+		}
+		after = &policies[len(policies)-1]
+	}
+}
+
+// countPolicies counts the policies selected by query that also satisfy match
+func countPolicies(query *gorm.DB, match func(*model.Policy) bool) (int64, error) {
+	query = query.Session(&gorm.Session{})
+	if match == nil {
+		var total int64
+		err := query.Count(&total).Error
+		return total, err
+	}
+
+	var policies model.PolicyList
+	if err := query.Find(&policies).Error; err != nil {
+		return 0, err
+	}
+	return int64(len(matchPolicies(policies, match, len(policies)))), nil
+}
+
+// matchPolicies returns up to limit policies that satisfy match, preserving their order
+func matchPolicies(policies model.PolicyList, match func(*model.Policy) bool, limit int) model.PolicyList {
+	matched := make(model.PolicyList, 0, min(limit, len(policies)))
+	for i := range policies {
+		if len(matched) == limit {
+			break
+		}
+		if match(&policies[i]) {
+			matched = append(matched, policies[i])
+		}
+	}
+	return matched
+}
+
+// keysetOrder returns the ordering to paginate by. The unique id column is appended as a
+// tie-breaker so that the position of every row is well defined.
+func keysetOrder(orderBy []OrderField) []OrderField {
+	if len(orderBy) == 0 {
+		orderBy = DefaultOrderBy
+	}
+	for _, f := range orderBy {
+		if f.Field == "id" {
+			return orderBy
+		}
+	}
+	return append(slices.Clone(orderBy), OrderField{Field: "id"})
+}
+
+// keysetCondition selects the rows ordered after the cursor:
+// (a > ?) OR (a = ? AND b > ?) OR (a = ? AND b = ? AND c > ?) ..., using < for descending columns.
+func keysetCondition(order []OrderField, cursor *model.Policy) clause.Expression {
+	var sql strings.Builder
+	var vars []any
+	for i, f := range order {
+		if i > 0 {
+			sql.WriteString(" OR ")
+		}
+		sql.WriteString("(")
+		for _, prev := range order[:i] {
+			sql.WriteString(prev.Field + " = ? AND ")
+			vars = append(vars, orderValue(prev.Field, cursor))
+		}
+		op := ">"
+		if f.Desc {
+			op = "<"
+		}
+		sql.WriteString(f.Field + " " + op + " ?)")
+		vars = append(vars, orderValue(f.Field, cursor))
+	}
+	return clause.Expr{SQL: sql.String(), Vars: vars}
+}
+
+// orderValue returns the value of an order column for a policy
+func orderValue(field string, p *model.Policy) any {
+	switch field {
+	case "display_name":
+		return p.DisplayName
+	case "description":
+		return p.Description
+	case "policy_type":
+		return p.PolicyType
+	case "priority":
+		return p.Priority
+	case "enabled":
+		return p.Enabled
+	case "managed":
+		return p.Managed
+	case "create_time":
+		return p.CreateTime
+	case "update_time":
+		return p.UpdateTime
+	default:
+		return p.ID
+	}
+}
+
 // mapUniqueConstraintError maps a DB unique constraint violation to a store sentinel error.
 // by querying the DB to see which constraint would be violated (ID, display_name+policy_type, or priority+policy_type).
 func (s *PolicyStore) mapUniqueConstraintError(ctx context.Context, err error, attempted model.Policy, isUpdate bool) error {
 	if err == nil {
 		return nil
 	}
-	if !errors.Is(err, gorm.ErrDuplicatedKey) {
-		// Raw driver error (e.g. tests without TranslateError)
-		if !strings.Contains(strings.ToLower(err.Error()), "unique") &&
-			!strings.Contains(err.Error(), "duplicate key") {
-			return err
-		}
+	if !isUniqueViolation(err) {
+		return err
 	}
 
+	conflict, dberr := s.findUniqueConflict(ctx, attempted, isUpdate)
+	if dberr != nil || conflict == nil {
+		return err
+	}
+	return conflict
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation
+func isUniqueViolation(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	// Raw driver error (e.g. tests without TranslateError)
+	return strings.Contains(strings.ToLower(err.Error()), "unique") ||
+		strings.Contains(err.Error(), "duplicate key")
+}
+
+func (s *PolicyStore) CheckUnique(ctx context.Context, policy model.Policy, isUpdate bool) error {
+	conflict, err := s.findUniqueConflict(ctx, policy, isUpdate)
+	if err != nil {
+		return err
+	}
+	return conflict
+}
+
+// findUniqueConflict returns the sentinel error of the first uniqueness constraint (ID, display_name+policy_type
+// or priority+policy_type) that another row shares with attempted, or nil if there is none.
+func (s *PolicyStore) findUniqueConflict(ctx context.Context, attempted model.Policy, isUpdate bool) (conflict error, err error) {
 	checks := []struct {
 		sentinel error
 		query    *gorm.DB
@@ -156,14 +301,14 @@ func (s *PolicyStore) mapUniqueConstraintError(ctx context.Context, err error, a
 		var row model.Policy
 		dberr := query.First(&row).Error
 		if dberr == nil {
-			return c.sentinel
+			return c.sentinel, nil
 		}
 		if !errors.Is(dberr, gorm.ErrRecordNotFound) {
-			return err
+			return nil, dberr
 		}
 	}
 
-	return err
+	return nil, nil
 }
 
 func (s *PolicyStore) Create(ctx context.Context, policy model.Policy) (*model.Policy, error) {
@@ -186,9 +331,10 @@ func (s *PolicyStore) Delete(ctx context.Context, id string) error {
 
 func (s *PolicyStore) Update(ctx context.Context, policy model.Policy) (*model.Policy, error) {
 	// Use Select to update all mutable fields including zero values
-	// Immutable fields (id, policy_type, create_time) are not updated
+	// Immutable fields (id, policy_type, managed, create_time) are not updated
 	result := s.db.WithContext(ctx).Model(&policy).
-		Select("display_name", "description", "label_selector", "priority", "rego_code", "enabled").
+		Select("display_name", "description", "label_selector", "priority", "rego_code", "test_code", "enabled",
+			"effective_from", "effective_until", "rollout_percentage", "rollout_key", "entrypoint").
 		Clauses(clause.Returning{}).
 		Updates(&policy)
 	if result.Error != nil {
@@ -200,6 +346,45 @@ func (s *PolicyStore) Update(ctx context.Context, policy model.Policy) (*model.P
 	return &policy, nil
 }
 
+// SetPriorities assigns new priorities to the given policies in one transaction. The policies are first
+// moved to temporary negative priorities, so that the unique (priority, policy_type) index holds while
+// priorities are swapped or shifted between them.
+func (s *PolicyStore) SetPriorities(ctx context.Context, priorities map[string]int32) error {
+	ids := make([]string, 0, len(priorities))
+	for id := range priorities {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := setPriority(tx, id, int32(-(i + 1))); err != nil {
+				return err
+			}
+		}
+		for _, id := range ids {
+			if err := setPriority(tx, id, priorities[id]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func setPriority(tx *gorm.DB, id string, priority int32) error {
+	result := tx.Model(&model.Policy{}).Where("id = ?", id).Update("priority", priority)
+	if result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			return ErrPriorityPolicyTypeTaken
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPolicyNotFound
+	}
+	return nil
+}
+
 func (s *PolicyStore) ListAll(ctx context.Context) (model.PolicyList, error) {
 	var policies model.PolicyList
 	if err := s.db.WithContext(ctx).Order("id ASC").Find(&policies).Error; err != nil {
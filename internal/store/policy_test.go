@@ -2,6 +2,8 @@ package store_test
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/dcm-project/policy-manager/internal/store"
 	"github.com/dcm-project/policy-manager/internal/store/model"
@@ -116,7 +118,7 @@ var _ = Describe("Policy Store", func() {
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result.Policies).To(HaveLen(2))
-			Expect(result.NextPageToken).To(BeEmpty())
+			Expect(result.HasMore).To(BeFalse())
 		})
 
 		It("filters by policy type", func() {
@@ -197,6 +199,152 @@ var _ = Describe("Policy Store", func() {
 			Expect(result.Policies[0].ID).To(Equal("global-enabled"))
 		})
 
+		It("filters by activity at a time", func() {
+			now := time.Now()
+			earlier, later := now.Add(-time.Hour), now.Add(time.Hour)
+
+			always := newPolicy("always")
+			always.Enabled = true
+			scheduled := newPolicy("scheduled")
+			scheduled.Enabled = true
+			scheduled.EffectiveFrom = &later
+			expired := newPolicy("expired")
+			expired.Enabled = true
+			expired.EffectiveUntil = &earlier
+			current := newPolicy("current")
+			current.Enabled = true
+			current.EffectiveFrom = &earlier
+			current.EffectiveUntil = &later
+			disabled := newPolicy("disabled")
+			disabled.Enabled = false
+			for _, p := range []model.Policy{always, scheduled, expired, current, disabled} {
+				_, err := policyStore.Create(ctx, p)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			result, err := policyStore.List(ctx, &store.PolicyListOptions{
+				Filter: &store.PolicyFilter{ActiveAt: &now},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(2))
+			Expect([]string{result.Policies[0].ID, result.Policies[1].ID}).To(ConsistOf("always", "current"))
+
+			result, err = policyStore.List(ctx, &store.PolicyListOptions{
+				Filter: &store.PolicyFilter{Expr: store.NotExpr{Expr: store.ActiveExpr{At: now}}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(3))
+		})
+
+		It("filters by an expression translated to SQL", func() {
+			p1 := newPolicy("prod-region")
+			p1.Priority = 100
+			p1.LabelSelector = map[string]string{"env": "prod"}
+			_, err := policyStore.Create(ctx, p1)
+			Expect(err).NotTo(HaveOccurred())
+
+			p2 := newPolicy("dev-region")
+			p2.Priority = 200
+			p2.LabelSelector = map[string]string{"env": "dev"}
+			_, err = policyStore.Create(ctx, p2)
+			Expect(err).NotTo(HaveOccurred())
+
+			p3 := newPolicy("quota")
+			p3.Priority = 300
+			_, err = policyStore.Create(ctx, p3)
+			Expect(err).NotTo(HaveOccurred())
+
+			prod := "prod"
+			opts := &store.PolicyListOptions{
+				Filter: &store.PolicyFilter{
+					Expr: store.OrExpr{Exprs: []store.FilterExpr{
+						store.LabelExpr{Key: "env", Op: store.OpEq, Value: &prod},
+						store.AndExpr{Exprs: []store.FilterExpr{
+							store.MatchExpr{Field: "id", Kind: store.MatchSuffix, Value: "ta"},
+							store.CompareExpr{Field: "priority", Op: store.OpGt, Value: int64(250)},
+						}},
+					}},
+				},
+			}
+			result, err := policyStore.List(ctx, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(2))
+			Expect(result.Policies[0].ID).To(Equal("prod-region"))
+			Expect(result.Policies[1].ID).To(Equal("quota"))
+		})
+
+		It("matches in memory before paginating", func() {
+			for i, id := range []string{"a-1", "b-1", "a-2", "a-3"} {
+				p := newPolicy(id)
+				p.Priority = int32(i + 1)
+				_, err := policyStore.Create(ctx, p)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			opts := &store.PolicyListOptions{
+				Filter: &store.PolicyFilter{
+					Match: func(p *model.Policy) bool { return p.ID[0] == 'a' },
+				},
+				PageSize: 2,
+			}
+			result, err := policyStore.List(ctx, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(2))
+			Expect(result.Policies[0].ID).To(Equal("a-1"))
+			Expect(result.Policies[1].ID).To(Equal("a-2"))
+			Expect(result.HasMore).To(BeTrue())
+
+			opts.After = &result.Policies[len(result.Policies)-1]
+			result, err = policyStore.List(ctx, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(1))
+			Expect(result.Policies[0].ID).To(Equal("a-3"))
+			Expect(result.HasMore).To(BeFalse())
+		})
+
+		It("reads the policies in chunks of the page size until the page is full when matching in memory", func() {
+			ids := []string{"a-1", "b-1", "b-2", "b-3", "a-2", "a-3", "b-4", "b-5", "b-6", "a-4"}
+			for i, id := range ids {
+				p := newPolicy(id)
+				p.Priority = int32(i + 1)
+				_, err := policyStore.Create(ctx, p)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			read := 0
+			Expect(db.Callback().Query().After("gorm:query").Register("test:count_rows", func(tx *gorm.DB) {
+				read += int(tx.RowsAffected)
+			})).To(Succeed())
+
+			result, err := policyStore.List(ctx, &store.PolicyListOptions{
+				Filter: &store.PolicyFilter{
+					Match: func(p *model.Policy) bool { return p.ID[0] == 'a' },
+				},
+				PageSize: 2,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(2))
+			Expect(result.Policies[0].ID).To(Equal("a-1"))
+			Expect(result.Policies[1].ID).To(Equal("a-2"))
+			Expect(result.HasMore).To(BeTrue())
+			// Two chunks of page size + 1 policies hold the page and the next matching policy
+			Expect(read).To(Equal(6))
+		})
+
+		It("rejects filter expressions on unknown columns", func() {
+			opts := &store.PolicyListOptions{
+				Filter: &store.PolicyFilter{
+					Expr: store.CompareExpr{Field: "rego_code; DROP TABLE policies", Op: store.OpEq, Value: "x"},
+				},
+			}
+			_, err := policyStore.List(ctx, opts)
+
+			Expect(errors.Is(err, store.ErrInvalidFilter)).To(BeTrue())
+		})
+
 		It("orders policies by priority ascending by default", func() {
 			p1 := newPolicy("low-priority")
 			p1.Priority = 800
@@ -234,7 +382,7 @@ var _ = Describe("Policy Store", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			opts := &store.PolicyListOptions{
-				OrderBy: "display_name ASC",
+				OrderBy: []store.OrderField{{Field: "display_name"}},
 			}
 			result, err := policyStore.List(ctx, opts)
 
@@ -261,10 +409,10 @@ var _ = Describe("Policy Store", func() {
 			Expect(result.Policies).To(HaveLen(2))
 			Expect(result.Policies[0].Priority).To(Equal(int32(100)))
 			Expect(result.Policies[1].Priority).To(Equal(int32(200)))
-			Expect(result.NextPageToken).NotTo(BeEmpty())
+			Expect(result.HasMore).To(BeTrue())
 		})
 
-		It("uses page token for pagination across multiple pages", func() {
+		It("continues after the last policy of the previous page", func() {
 			for i := 1; i <= 5; i++ {
 				p := newPolicy("policy-" + string(rune('0'+i)))
 				p.Priority = int32(i * 100)
@@ -282,12 +430,12 @@ var _ = Describe("Policy Store", func() {
 			Expect(result.Policies).To(HaveLen(2))
 			Expect(result.Policies[0].Priority).To(Equal(int32(100)))
 			Expect(result.Policies[1].Priority).To(Equal(int32(200)))
-			Expect(result.NextPageToken).NotTo(BeEmpty())
+			Expect(result.HasMore).To(BeTrue())
 
-			// Second page using page token
+			// Second page after the last policy of the first page
 			opts = &store.PolicyListOptions{
-				PageSize:  2,
-				PageToken: &result.NextPageToken,
+				PageSize: 2,
+				After:    &result.Policies[len(result.Policies)-1],
 			}
 			result, err = policyStore.List(ctx, opts)
 
@@ -295,19 +443,106 @@ var _ = Describe("Policy Store", func() {
 			Expect(result.Policies).To(HaveLen(2))
 			Expect(result.Policies[0].Priority).To(Equal(int32(300)))
 			Expect(result.Policies[1].Priority).To(Equal(int32(400)))
-			Expect(result.NextPageToken).NotTo(BeEmpty())
+			Expect(result.HasMore).To(BeTrue())
 
 			// Third page (last page with 1 item)
 			opts = &store.PolicyListOptions{
-				PageSize:  2,
-				PageToken: &result.NextPageToken,
+				PageSize: 2,
+				After:    &result.Policies[len(result.Policies)-1],
 			}
 			result, err = policyStore.List(ctx, opts)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result.Policies).To(HaveLen(1))
 			Expect(result.Policies[0].Priority).To(Equal(int32(500)))
-			Expect(result.NextPageToken).To(BeEmpty())
+			Expect(result.HasMore).To(BeFalse())
+		})
+
+		It("does not skip policies when earlier policies are deleted between pages", func() {
+			for i := 1; i <= 4; i++ {
+				p := newPolicy("policy-" + string(rune('0'+i)))
+				p.Priority = int32(i * 100)
+				_, err := policyStore.Create(ctx, p)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			result, err := policyStore.List(ctx, &store.PolicyListOptions{PageSize: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policyStore.Delete(ctx, "policy-1")).To(Succeed())
+
+			result, err = policyStore.List(ctx, &store.PolicyListOptions{
+				PageSize: 2,
+				After:    &result.Policies[len(result.Policies)-1],
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(2))
+			Expect(result.Policies[0].ID).To(Equal("policy-3"))
+			Expect(result.Policies[1].ID).To(Equal("policy-4"))
+			Expect(result.HasMore).To(BeFalse())
+		})
+
+		It("paginates descending orderings with ties broken by id", func() {
+			for _, id := range []string{"b", "a", "c"} {
+				p := newPolicy(id)
+				p.PolicyType = "USER"
+				_, err := policyStore.Create(ctx, p)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			p := newPolicy("d")
+			p.PolicyType = "GLOBAL"
+			_, err := policyStore.Create(ctx, p)
+			Expect(err).NotTo(HaveOccurred())
+
+			var ids []string
+			opts := &store.PolicyListOptions{
+				OrderBy:  []store.OrderField{{Field: "policy_type", Desc: true}},
+				PageSize: 1,
+			}
+			for {
+				result, err := policyStore.List(ctx, opts)
+				Expect(err).NotTo(HaveOccurred())
+				for _, p := range result.Policies {
+					ids = append(ids, p.ID)
+				}
+				if !result.HasMore {
+					break
+				}
+				opts.After = &result.Policies[len(result.Policies)-1]
+			}
+
+			Expect(ids).To(Equal([]string{"a", "b", "c", "d"}))
+		})
+
+		It("counts all matching policies when requested", func() {
+			for i := 1; i <= 5; i++ {
+				p := newPolicy("policy-" + string(rune('0'+i)))
+				p.Priority = int32(i * 100)
+				p.Enabled = i%2 == 1
+				_, err := policyStore.Create(ctx, p)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			enabled := true
+			result, err := policyStore.List(ctx, &store.PolicyListOptions{
+				Filter:     &store.PolicyFilter{Enabled: &enabled},
+				PageSize:   1,
+				CountTotal: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(1))
+			Expect(result.TotalSize).To(Equal(int64(3)))
+
+			result, err = policyStore.List(ctx, &store.PolicyListOptions{
+				Filter: &store.PolicyFilter{
+					Match: func(p *model.Policy) bool { return p.Priority > 300 },
+				},
+				PageSize:   1,
+				CountTotal: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(HaveLen(1))
+			Expect(result.TotalSize).To(Equal(int64(2)))
 		})
 
 		It("uses default page size of 50 when not specified", func() {
@@ -323,7 +558,7 @@ var _ = Describe("Policy Store", func() {
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result.Policies).To(HaveLen(50))
-			Expect(result.NextPageToken).NotTo(BeEmpty())
+			Expect(result.HasMore).To(BeTrue())
 		})
 	})
 
@@ -422,6 +657,73 @@ var _ = Describe("Policy Store", func() {
 		})
 	})
 
+	Describe("CheckUnique", func() {
+		BeforeEach(func() {
+			_, err := policyStore.Create(ctx, newPolicy("existing"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns nil when no constraint would be violated", func() {
+			Expect(policyStore.CheckUnique(ctx, newPolicy("other"), false)).To(Succeed())
+		})
+
+		It("returns the sentinel error of the violated constraint", func() {
+			existing := newPolicy("existing")
+			Expect(policyStore.CheckUnique(ctx, existing, false)).To(Equal(store.ErrPolicyIDTaken))
+
+			sameName := newPolicy("other")
+			sameName.DisplayName = existing.DisplayName
+			Expect(policyStore.CheckUnique(ctx, sameName, false)).To(Equal(store.ErrDisplayNamePolicyTypeTaken))
+
+			samePriority := newPolicy("other")
+			samePriority.Priority = existing.Priority
+			Expect(policyStore.CheckUnique(ctx, samePriority, false)).To(Equal(store.ErrPriorityPolicyTypeTaken))
+		})
+
+		It("ignores the policy itself on update", func() {
+			Expect(policyStore.CheckUnique(ctx, newPolicy("existing"), true)).To(Succeed())
+		})
+	})
+
+	Describe("SetPriorities", func() {
+		var first, second model.Policy
+
+		BeforeEach(func() {
+			first, second = newPolicy("first"), newPolicy("second")
+			first.Priority, second.Priority = 10, 11
+			for _, p := range []model.Policy{first, second} {
+				_, err := policyStore.Create(ctx, p)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("swaps the priorities of two policies", func() {
+			Expect(policyStore.SetPriorities(ctx, map[string]int32{"first": 11, "second": 10})).To(Succeed())
+
+			got, err := policyStore.Get(ctx, "first")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got.Priority).To(Equal(int32(11)))
+			got, err = policyStore.Get(ctx, "second")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got.Priority).To(Equal(int32(10)))
+		})
+
+		It("rolls back every change when one fails", func() {
+			err := policyStore.SetPriorities(ctx, map[string]int32{"first": 12, "missing": 13})
+
+			Expect(err).To(Equal(store.ErrPolicyNotFound))
+			got, err := policyStore.Get(ctx, "first")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got.Priority).To(Equal(int32(10)))
+		})
+
+		It("returns ErrPriorityPolicyTypeTaken when a priority is held by another policy", func() {
+			err := policyStore.SetPriorities(ctx, map[string]int32{"first": 11})
+
+			Expect(err).To(Equal(store.ErrPriorityPolicyTypeTaken))
+		})
+	})
+
 	Describe("Update", func() {
 		It("modifies existing policy", func() {
 			p := newPolicy("to-update")
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrProposalNotFound     = errors.New("proposal not found")
+	ErrProposalStateChanged = errors.New("proposal state changed")
+)
+
+type Proposal interface {
+	// List returns the proposals in the given state, or all proposals if state is empty, newest first
+	List(ctx context.Context, state string) (model.PolicyProposalList, error)
+	Create(ctx context.Context, proposal model.PolicyProposal) (*model.PolicyProposal, error)
+	Get(ctx context.Context, id string) (*model.PolicyProposal, error)
+	// Review moves a proposal from state from to the state of proposal, recording its reviewer, review
+	// comment and review time. It returns ErrProposalStateChanged when the proposal is no longer in state
+	// from, so that only one of several concurrent reviews succeeds.
+	Review(ctx context.Context, proposal model.PolicyProposal, from string) (*model.PolicyProposal, error)
+}
+
+type ProposalStore struct {
+	db *gorm.DB
+}
+
+var _ Proposal = (*ProposalStore)(nil)
+
+func NewProposal(db *gorm.DB) Proposal {
+	return &ProposalStore{db: db}
+}
+
+func (s *ProposalStore) List(ctx context.Context, state string) (model.PolicyProposalList, error) {
+	query := s.db.WithContext(ctx)
+	if state != "" {
+		query = query.Where("state = ?", state)
+	}
+	var proposals model.PolicyProposalList
+	if err := query.Order("create_time DESC, id DESC").Find(&proposals).Error; err != nil {
+		return nil, err
+	}
+	if proposals == nil {
+		proposals = model.PolicyProposalList{}
+	}
+	return proposals, nil
+}
+
+func (s *ProposalStore) Create(ctx context.Context, proposal model.PolicyProposal) (*model.PolicyProposal, error) {
+	if err := s.db.WithContext(ctx).Clauses(clause.Returning{}).Select("*").Create(&proposal).Error; err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+func (s *ProposalStore) Get(ctx context.Context, id string) (*model.PolicyProposal, error) {
+	var proposal model.PolicyProposal
+	if err := s.db.WithContext(ctx).First(&proposal, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProposalNotFound
+		}
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+func (s *ProposalStore) Review(ctx context.Context, proposal model.PolicyProposal, from string) (*model.PolicyProposal, error) {
+	result := s.db.WithContext(ctx).Model(&model.PolicyProposal{}).
+		Where("id = ? AND state = ?", proposal.ID, from).
+		Updates(map[string]any{
+			"state":          proposal.State,
+			"reviewer":       proposal.Reviewer,
+			"review_comment": proposal.ReviewComment,
+			"review_time":    proposal.ReviewTime,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := s.Get(ctx, proposal.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrProposalStateChanged
+	}
+	return s.Get(ctx, proposal.ID)
+}
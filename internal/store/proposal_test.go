@@ -0,0 +1,106 @@
+package store_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("Proposal Store", func() {
+	var (
+		db            *gorm.DB
+		proposalStore store.Proposal
+		ctx           context.Context
+	)
+
+	newProposal := func(id string, state string) model.PolicyProposal {
+		return model.PolicyProposal{
+			ID:        id,
+			Operation: model.ProposalDelete,
+			PolicyID:  "region",
+			State:     state,
+			Proposer:  "alice",
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.PolicyProposal{})).To(Succeed())
+
+		proposalStore = store.NewProposal(db)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	It("should list proposals newest first, optionally in one state", func() {
+		_, err := proposalStore.Create(ctx, newProposal("p1", model.ProposalPending))
+		Expect(err).NotTo(HaveOccurred())
+		time.Sleep(10 * time.Millisecond)
+		_, err = proposalStore.Create(ctx, newProposal("p2", model.ProposalRejected))
+		Expect(err).NotTo(HaveOccurred())
+
+		all, err := proposalStore.List(ctx, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(all).To(HaveLen(2))
+		Expect(all[0].ID).To(Equal("p2"))
+
+		pending, err := proposalStore.List(ctx, model.ProposalPending)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(HaveLen(1))
+		Expect(pending[0].ID).To(Equal("p1"))
+	})
+
+	It("should return ErrProposalNotFound for an unknown proposal", func() {
+		_, err := proposalStore.Get(ctx, "missing")
+		Expect(err).To(MatchError(store.ErrProposalNotFound))
+	})
+
+	Describe("Review", func() {
+		It("should record the review of a pending proposal", func() {
+			_, err := proposalStore.Create(ctx, newProposal("p1", model.ProposalPending))
+			Expect(err).NotTo(HaveOccurred())
+
+			now := time.Now().UTC()
+			review := newProposal("p1", model.ProposalApproved)
+			review.Reviewer = "bob"
+			review.ReviewComment = "lgtm"
+			review.ReviewTime = &now
+			reviewed, err := proposalStore.Review(ctx, review, model.ProposalPending)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reviewed.State).To(Equal(model.ProposalApproved))
+			Expect(reviewed.Reviewer).To(Equal("bob"))
+			Expect(reviewed.ReviewComment).To(Equal("lgtm"))
+			Expect(reviewed.ReviewTime).NotTo(BeNil())
+		})
+
+		It("should let only one of two reviews succeed", func() {
+			_, err := proposalStore.Create(ctx, newProposal("p1", model.ProposalPending))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = proposalStore.Review(ctx, newProposal("p1", model.ProposalApproved), model.ProposalPending)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = proposalStore.Review(ctx, newProposal("p1", model.ProposalRejected), model.ProposalPending)
+			Expect(err).To(MatchError(store.ErrProposalStateChanged))
+		})
+
+		It("should return ErrProposalNotFound for an unknown proposal", func() {
+			_, err := proposalStore.Review(ctx, newProposal("missing", model.ProposalApproved), model.ProposalPending)
+			Expect(err).To(MatchError(store.ErrProposalNotFound))
+		})
+	})
+})
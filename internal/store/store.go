@@ -1,21 +1,40 @@
 package store
 
-import "gorm.io/gorm"
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
 
 type Store interface {
 	Close() error
 	Policy() Policy
+	Library() Library
+	Webhook() Webhook
+	Proposal() Proposal
+	Generation() Generation
+	// Transaction runs fn with a Store bound to a database transaction. The transaction is committed
+	// when fn returns nil and rolled back otherwise.
+	Transaction(ctx context.Context, fn func(tx Store) error) error
 }
 
 type DataStore struct {
-	db     *gorm.DB
-	policy Policy
+	db         *gorm.DB
+	policy     Policy
+	library    Library
+	webhook    Webhook
+	proposal   Proposal
+	generation Generation
 }
 
 func NewStore(db *gorm.DB) Store {
 	return &DataStore{
-		db:     db,
-		policy: NewPolicy(db),
+		db:         db,
+		policy:     NewPolicy(db),
+		library:    NewLibrary(db),
+		webhook:    NewWebhook(db),
+		proposal:   NewProposal(db),
+		generation: NewGeneration(db),
 	}
 }
 
@@ -30,3 +49,25 @@ func (s *DataStore) Close() error {
 func (s *DataStore) Policy() Policy {
 	return s.policy
 }
+
+func (s *DataStore) Library() Library {
+	return s.library
+}
+
+func (s *DataStore) Webhook() Webhook {
+	return s.webhook
+}
+
+func (s *DataStore) Proposal() Proposal {
+	return s.proposal
+}
+
+func (s *DataStore) Generation() Generation {
+	return s.generation
+}
+
+func (s *DataStore) Transaction(ctx context.Context, fn func(tx Store) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewStore(tx))
+	})
+}
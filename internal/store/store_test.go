@@ -1,7 +1,11 @@
 package store_test
 
 import (
+	"context"
+	"errors"
+
 	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"gorm.io/driver/sqlite"
@@ -29,6 +33,40 @@ var _ = Describe("Store", func() {
 		})
 	})
 
+	Describe("Transaction", func() {
+		var s store.Store
+
+		BeforeEach(func() {
+			Expect(db.AutoMigrate(&model.Policy{}, &model.Library{})).To(Succeed())
+			s = store.NewStore(db)
+		})
+
+		It("commits the changes made through the transaction store", func() {
+			err := s.Transaction(context.Background(), func(tx store.Store) error {
+				_, err := tx.Policy().Create(context.Background(), newPolicy("committed"))
+				return err
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, err = s.Policy().Get(context.Background(), "committed")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rolls back every change when fn fails", func() {
+			failure := errors.New("failure")
+			err := s.Transaction(context.Background(), func(tx store.Store) error {
+				if _, err := tx.Policy().Create(context.Background(), newPolicy("rolled-back")); err != nil {
+					return err
+				}
+				return failure
+			})
+
+			Expect(err).To(Equal(failure))
+			_, err = s.Policy().Get(context.Background(), "rolled-back")
+			Expect(err).To(Equal(store.ErrPolicyNotFound))
+		})
+	})
+
 	Describe("Close", func() {
 		It("closes the database connection", func() {
 			s := store.NewStore(db)
@@ -0,0 +1,200 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrWebhookIDTaken  = errors.New("webhook ID already taken")
+)
+
+type Webhook interface {
+	ListAll(ctx context.Context) (model.WebhookList, error)
+	Create(ctx context.Context, webhook model.Webhook) (*model.Webhook, error)
+	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, webhook model.Webhook) (*model.Webhook, error)
+	Get(ctx context.Context, id string) (*model.Webhook, error)
+
+	CreateDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error
+	// ListDeliveries returns the deliveries of a webhook, newest first
+	ListDeliveries(ctx context.Context, webhookID string) (model.WebhookDeliveryList, error)
+	// ListDueDeliveries returns at most limit pending deliveries of the webhooks whose oldest pending
+	// delivery is due at now, in the order they were queued. A webhook is skipped while its oldest pending
+	// delivery waits for a retry, so that the later deliveries do not overtake it.
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) (model.WebhookDeliveryList, error)
+	// ClaimDelivery starts an attempt of a delivery: it counts the attempt and moves the next attempt to
+	// retryAt, in case the attempt is never completed. It returns false when the delivery was claimed or
+	// completed by someone else since it was listed.
+	ClaimDelivery(ctx context.Context, delivery model.WebhookDelivery, retryAt time.Time) (bool, error)
+	UpdateDelivery(ctx context.Context, delivery model.WebhookDelivery) error
+	// PruneDeliveries deletes the completed deliveries of a webhook except for the newest keep deliveries
+	PruneDeliveries(ctx context.Context, webhookID string, keep int) error
+}
+
+type WebhookStore struct {
+	db *gorm.DB
+}
+
+var _ Webhook = (*WebhookStore)(nil)
+
+func NewWebhook(db *gorm.DB) Webhook {
+	return &WebhookStore{db: db}
+}
+
+func (s *WebhookStore) ListAll(ctx context.Context) (model.WebhookList, error) {
+	var webhooks model.WebhookList
+	if err := s.db.WithContext(ctx).Order("id ASC").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	if webhooks == nil {
+		webhooks = model.WebhookList{}
+	}
+	return webhooks, nil
+}
+
+func (s *WebhookStore) Create(ctx context.Context, webhook model.Webhook) (*model.Webhook, error) {
+	if err := s.db.WithContext(ctx).Clauses(clause.Returning{}).Select("*").Create(&webhook).Error; err != nil {
+		// The ID is the only unique column
+		if _, getErr := s.Get(ctx, webhook.ID); getErr == nil {
+			return nil, ErrWebhookIDTaken
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// Delete deletes a webhook together with its deliveries
+func (s *WebhookStore) Delete(ctx context.Context, id string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", id).Delete(&model.Webhook{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrWebhookNotFound
+		}
+		return tx.Where("webhook_id = ?", id).Delete(&model.WebhookDelivery{}).Error
+	})
+}
+
+func (s *WebhookStore) Update(ctx context.Context, webhook model.Webhook) (*model.Webhook, error) {
+	// Immutable fields (id, create_time) are not updated
+	result := s.db.WithContext(ctx).Model(&webhook).
+		Select("display_name", "url", "event_types", "secret").
+		Clauses(clause.Returning{}).
+		Updates(&webhook)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrWebhookNotFound
+	}
+	return &webhook, nil
+}
+
+func (s *WebhookStore) Get(ctx context.Context, id string) (*model.Webhook, error) {
+	var webhook model.Webhook
+	if err := s.db.WithContext(ctx).First(&webhook, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (s *WebhookStore) CreateDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return s.db.WithContext(ctx).Create(&deliveries).Error
+}
+
+func (s *WebhookStore) ListDeliveries(ctx context.Context, webhookID string) (model.WebhookDeliveryList, error) {
+	var deliveries model.WebhookDeliveryList
+	err := s.db.WithContext(ctx).
+		Where("webhook_id = ?", webhookID).
+		Order("create_time DESC, id DESC").
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = model.WebhookDeliveryList{}
+	}
+	return deliveries, nil
+}
+
+func (s *WebhookStore) ListDueDeliveries(ctx context.Context, now time.Time, limit int) (model.WebhookDeliveryList, error) {
+	due := "oldest.next_attempt_time <= ?"
+	if s.db.Name() != "postgres" {
+		// SQLite stores timestamps as text, which only compares in order within one time zone
+		due = "julianday(oldest.next_attempt_time) <= julianday(?)"
+	}
+	earlier := s.db.Table("webhook_deliveries AS earlier").
+		Select("1").
+		Where("earlier.webhook_id = oldest.webhook_id AND earlier.status = ?", model.WebhookDeliveryPending).
+		Where("(earlier.create_time < oldest.create_time OR (earlier.create_time = oldest.create_time AND earlier.id < oldest.id))")
+	dueWebhooks := s.db.Table("webhook_deliveries AS oldest").
+		Select("oldest.webhook_id").
+		Where("oldest.status = ?", model.WebhookDeliveryPending).
+		Where(due, now).
+		Where("NOT EXISTS (?)", earlier)
+
+	var deliveries model.WebhookDeliveryList
+	err := s.db.WithContext(ctx).
+		Where("status = ? AND webhook_id IN (?)", model.WebhookDeliveryPending, dueWebhooks).
+		Order("create_time ASC, id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (s *WebhookStore) ClaimDelivery(ctx context.Context, delivery model.WebhookDelivery, retryAt time.Time) (bool, error) {
+	// The attempt count serves as version: only one of several concurrent claims matches it
+	result := s.db.WithContext(ctx).Model(&model.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ?", delivery.ID, model.WebhookDeliveryPending, delivery.Attempts).
+		Updates(map[string]any{
+			"attempts":          delivery.Attempts + 1,
+			"next_attempt_time": retryAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (s *WebhookStore) UpdateDelivery(ctx context.Context, delivery model.WebhookDelivery) error {
+	result := s.db.WithContext(ctx).Model(&delivery).
+		Select("status", "attempts", "response_code", "last_error", "next_attempt_time").
+		Updates(&delivery)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// The webhook was deleted during the attempt
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (s *WebhookStore) PruneDeliveries(ctx context.Context, webhookID string, keep int) error {
+	newest := s.db.Model(&model.WebhookDelivery{}).
+		Select("id").
+		Where("webhook_id = ?", webhookID).
+		Order("create_time DESC, id DESC").
+		Limit(keep)
+	return s.db.WithContext(ctx).
+		Where("webhook_id = ? AND status <> ? AND id NOT IN (?)", webhookID, model.WebhookDeliveryPending, newest).
+		Delete(&model.WebhookDelivery{}).Error
+}
@@ -0,0 +1,150 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dcm-project/policy-manager/internal/store"
+	"github.com/dcm-project/policy-manager/internal/store/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("Webhook Store", func() {
+	var (
+		db           *gorm.DB
+		webhookStore store.Webhook
+		ctx          context.Context
+	)
+
+	newDelivery := func(id string, status string, next *time.Time) model.WebhookDelivery {
+		return model.WebhookDelivery{
+			ID:              id,
+			WebhookID:       "chat",
+			EventID:         "event-" + id,
+			EventType:       "POLICY_CREATED",
+			Payload:         "{}",
+			Status:          status,
+			NextAttemptTime: next,
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Webhook{}, &model.WebhookDelivery{})).To(Succeed())
+
+		webhookStore = store.NewWebhook(db)
+		ctx = context.Background()
+
+		_, err = webhookStore.Create(ctx, model.Webhook{
+			ID:         "chat",
+			URL:        "http://localhost/hook",
+			EventTypes: []string{"POLICY_CREATED"},
+			Secret:     "0123456789abcdef",
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	It("creates and gets webhooks with their event types", func() {
+		webhook, err := webhookStore.Get(ctx, "chat")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(webhook.EventTypes).To(Equal([]string{"POLICY_CREATED"}))
+
+		_, err = webhookStore.Create(ctx, model.Webhook{ID: "chat", URL: "http://localhost/other"})
+		Expect(err).To(MatchError(store.ErrWebhookIDTaken))
+	})
+
+	It("lists the pending deliveries of the webhooks whose oldest pending delivery is due", func() {
+		past := time.Now().Add(-time.Minute)
+		future := time.Now().Add(time.Hour)
+		queued := func(delivery model.WebhookDelivery, webhookID string, age time.Duration) model.WebhookDelivery {
+			delivery.WebhookID = webhookID
+			delivery.CreateTime = time.Now().Add(-age)
+			return delivery
+		}
+		Expect(webhookStore.CreateDeliveries(ctx, []model.WebhookDelivery{
+			queued(newDelivery("done", model.WebhookDeliverySucceeded, nil), "chat", 3*time.Second),
+			queued(newDelivery("second", model.WebhookDeliveryPending, &future), "chat", time.Second),
+			queued(newDelivery("first", model.WebhookDeliveryPending, &past), "chat", 2*time.Second),
+			// The oldest delivery to ops waits for a retry, so the later one must not overtake it
+			queued(newDelivery("retrying", model.WebhookDeliveryPending, &future), "ops", 2*time.Second),
+			queued(newDelivery("waiting", model.WebhookDeliveryPending, &past), "ops", time.Second),
+		})).To(Succeed())
+
+		due, err := webhookStore.ListDueDeliveries(ctx, time.Now(), 10)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(due).To(HaveLen(2))
+		Expect(due[0].ID).To(Equal("first"))
+		Expect(due[1].ID).To(Equal("second"))
+	})
+
+	It("lets only one of several concurrent claims of a delivery succeed", func() {
+		past := time.Now().Add(-time.Minute)
+		Expect(webhookStore.CreateDeliveries(ctx, []model.WebhookDelivery{
+			newDelivery("due", model.WebhookDeliveryPending, &past),
+		})).To(Succeed())
+		due, err := webhookStore.ListDueDeliveries(ctx, time.Now(), 10)
+		Expect(err).NotTo(HaveOccurred())
+
+		claimed, err := webhookStore.ClaimDelivery(ctx, due[0], time.Now().Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).To(BeTrue())
+		claimed, err = webhookStore.ClaimDelivery(ctx, due[0], time.Now().Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).To(BeFalse())
+
+		due, err = webhookStore.ListDueDeliveries(ctx, time.Now(), 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(due).To(BeEmpty())
+	})
+
+	It("prunes completed deliveries beyond the newest ones", func() {
+		var deliveries []model.WebhookDelivery
+		for i := range 5 {
+			delivery := newDelivery(fmt.Sprintf("done-%d", i), model.WebhookDeliverySucceeded, nil)
+			delivery.CreateTime = time.Now().Add(time.Duration(i) * time.Second)
+			deliveries = append(deliveries, delivery)
+		}
+		past := time.Now().Add(-time.Minute)
+		pending := newDelivery("pending", model.WebhookDeliveryPending, &past)
+		pending.CreateTime = time.Now().Add(-time.Hour)
+		Expect(webhookStore.CreateDeliveries(ctx, append(deliveries, pending))).To(Succeed())
+
+		Expect(webhookStore.PruneDeliveries(ctx, "chat", 2)).To(Succeed())
+
+		remaining, err := webhookStore.ListDeliveries(ctx, "chat")
+		Expect(err).NotTo(HaveOccurred())
+		ids := make([]string, len(remaining))
+		for i, d := range remaining {
+			ids[i] = d.ID
+		}
+		Expect(ids).To(Equal([]string{"done-4", "done-3", "pending"}))
+	})
+
+	It("deletes a webhook together with its deliveries", func() {
+		Expect(webhookStore.CreateDeliveries(ctx, []model.WebhookDelivery{
+			newDelivery("done", model.WebhookDeliverySucceeded, nil),
+		})).To(Succeed())
+
+		Expect(webhookStore.Delete(ctx, "chat")).To(Succeed())
+
+		deliveries, err := webhookStore.ListDeliveries(ctx, "chat")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deliveries).To(BeEmpty())
+		Expect(webhookStore.Delete(ctx, "chat")).To(MatchError(store.ErrWebhookNotFound))
+	})
+})
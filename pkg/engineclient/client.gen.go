@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	. "github.com/dcm-project/policy-manager/api/v1alpha1/engine"
+	"github.com/oapi-codegen/runtime"
 )
 
 // RequestEditorFn  is the function signature for the RequestEditor callback function
@@ -90,13 +91,18 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 // The interface specification for the client above.
 type ClientInterface interface {
 	// EvaluateRequestWithBody request with any body
-	EvaluateRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	EvaluateRequestWithBody(ctx context.Context, params *EvaluateRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	EvaluateRequest(ctx context.Context, body EvaluateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	EvaluateRequest(ctx context.Context, params *EvaluateRequestParams, body EvaluateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PreviewConstraintsWithBody request with any body
+	PreviewConstraintsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PreviewConstraints(ctx context.Context, body PreviewConstraintsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) EvaluateRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewEvaluateRequestRequestWithBody(c.Server, contentType, body)
+func (c *Client) EvaluateRequestWithBody(ctx context.Context, params *EvaluateRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEvaluateRequestRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -107,8 +113,32 @@ func (c *Client) EvaluateRequestWithBody(ctx context.Context, contentType string
 	return c.Client.Do(req)
 }
 
-func (c *Client) EvaluateRequest(ctx context.Context, body EvaluateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewEvaluateRequestRequest(c.Server, body)
+func (c *Client) EvaluateRequest(ctx context.Context, params *EvaluateRequestParams, body EvaluateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEvaluateRequestRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PreviewConstraintsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPreviewConstraintsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PreviewConstraints(ctx context.Context, body PreviewConstraintsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPreviewConstraintsRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -120,18 +150,18 @@ func (c *Client) EvaluateRequest(ctx context.Context, body EvaluateRequestJSONRe
 }
 
 // NewEvaluateRequestRequest calls the generic EvaluateRequest builder with application/json body
-func NewEvaluateRequestRequest(server string, body EvaluateRequestJSONRequestBody) (*http.Request, error) {
+func NewEvaluateRequestRequest(server string, params *EvaluateRequestParams, body EvaluateRequestJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewEvaluateRequestRequestWithBody(server, "application/json", bodyReader)
+	return NewEvaluateRequestRequestWithBody(server, params, "application/json", bodyReader)
 }
 
 // NewEvaluateRequestRequestWithBody generates requests for EvaluateRequest with any type of body
-func NewEvaluateRequestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+func NewEvaluateRequestRequestWithBody(server string, params *EvaluateRequestParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -149,6 +179,68 @@ func NewEvaluateRequestRequestWithBody(server string, contentType string, body i
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Explain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "explain", runtime.ParamLocationQuery, *params.Explain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPreviewConstraintsRequest calls the generic PreviewConstraints builder with application/json body
+func NewPreviewConstraintsRequest(server string, body PreviewConstraintsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPreviewConstraintsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPreviewConstraintsRequestWithBody generates requests for PreviewConstraints with any type of body
+func NewPreviewConstraintsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/policies:previewConstraints")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
@@ -203,9 +295,14 @@ func WithBaseURL(baseURL string) ClientOption {
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// EvaluateRequestWithBodyWithResponse request with any body
-	EvaluateRequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EvaluateRequestResponse, error)
+	EvaluateRequestWithBodyWithResponse(ctx context.Context, params *EvaluateRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EvaluateRequestResponse, error)
+
+	EvaluateRequestWithResponse(ctx context.Context, params *EvaluateRequestParams, body EvaluateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*EvaluateRequestResponse, error)
+
+	// PreviewConstraintsWithBodyWithResponse request with any body
+	PreviewConstraintsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PreviewConstraintsResponse, error)
 
-	EvaluateRequestWithResponse(ctx context.Context, body EvaluateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*EvaluateRequestResponse, error)
+	PreviewConstraintsWithResponse(ctx context.Context, body PreviewConstraintsJSONRequestBody, reqEditors ...RequestEditorFn) (*PreviewConstraintsResponse, error)
 }
 
 type EvaluateRequestResponse struct {
@@ -218,6 +315,7 @@ type EvaluateRequestResponse struct {
 	JSON406      *Rejected
 	JSON409      *PolicyConflict
 	JSON500      *InternalServerError
+	JSON504      *EvaluationTimeout
 }
 
 // Status returns HTTPResponse.Status
@@ -236,23 +334,68 @@ func (r EvaluateRequestResponse) StatusCode() int {
 	return 0
 }
 
+type PreviewConstraintsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ConstraintsPreview
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON409      *PolicyConflict
+	JSON500      *InternalServerError
+	JSON504      *EvaluationTimeout
+}
+
+// Status returns HTTPResponse.Status
+func (r PreviewConstraintsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PreviewConstraintsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // EvaluateRequestWithBodyWithResponse request with arbitrary body returning *EvaluateRequestResponse
-func (c *ClientWithResponses) EvaluateRequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EvaluateRequestResponse, error) {
-	rsp, err := c.EvaluateRequestWithBody(ctx, contentType, body, reqEditors...)
+func (c *ClientWithResponses) EvaluateRequestWithBodyWithResponse(ctx context.Context, params *EvaluateRequestParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EvaluateRequestResponse, error) {
+	rsp, err := c.EvaluateRequestWithBody(ctx, params, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseEvaluateRequestResponse(rsp)
 }
 
-func (c *ClientWithResponses) EvaluateRequestWithResponse(ctx context.Context, body EvaluateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*EvaluateRequestResponse, error) {
-	rsp, err := c.EvaluateRequest(ctx, body, reqEditors...)
+func (c *ClientWithResponses) EvaluateRequestWithResponse(ctx context.Context, params *EvaluateRequestParams, body EvaluateRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*EvaluateRequestResponse, error) {
+	rsp, err := c.EvaluateRequest(ctx, params, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseEvaluateRequestResponse(rsp)
 }
 
+// PreviewConstraintsWithBodyWithResponse request with arbitrary body returning *PreviewConstraintsResponse
+func (c *ClientWithResponses) PreviewConstraintsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PreviewConstraintsResponse, error) {
+	rsp, err := c.PreviewConstraintsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePreviewConstraintsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PreviewConstraintsWithResponse(ctx context.Context, body PreviewConstraintsJSONRequestBody, reqEditors ...RequestEditorFn) (*PreviewConstraintsResponse, error) {
+	rsp, err := c.PreviewConstraints(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePreviewConstraintsResponse(rsp)
+}
+
 // ParseEvaluateRequestResponse parses an HTTP response from a EvaluateRequestWithResponse call
 func ParseEvaluateRequestResponse(rsp *http.Response) (*EvaluateRequestResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -316,6 +459,81 @@ func ParseEvaluateRequestResponse(rsp *http.Response) (*EvaluateRequestResponse,
 		}
 		response.JSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest EvaluationTimeout
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePreviewConstraintsResponse parses an HTTP response from a PreviewConstraintsWithResponse call
+func ParsePreviewConstraintsResponse(rsp *http.Response) (*PreviewConstraintsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PreviewConstraintsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ConstraintsPreview
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest PolicyConflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalServerError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest EvaluationTimeout
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
 	}
 
 	return response, nil
@@ -48,7 +48,7 @@ var _ = Describe("Engine API - Policy Evaluation", func() {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 				Expect(resp.JSON200).NotTo(BeNil())
@@ -106,7 +106,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 				Expect(resp.JSON200).NotTo(BeNil())
@@ -118,6 +118,110 @@ main := {
 			})
 		})
 
+		Context("when explaining the evaluation", func() {
+			var policyID string
+
+			BeforeEach(func() {
+				regoCode := `package policies.test_explain
+
+main := {"rejected": false} if {
+	print("service type:", input.spec.service_type)
+	trace("checked service type")
+}`
+				policyID = "test-explain-policy"
+				displayName := "Test Explain Policy"
+				policyType := v1alpha1.GLOBAL
+				enabled := true
+				priority := int32(100)
+
+				createResp, err := policyClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{
+					Id: &policyID,
+				}, v1alpha1.Policy{
+					DisplayName: &displayName,
+					PolicyType:  &policyType,
+					RegoCode:    &regoCode,
+					Enabled:     &enabled,
+					Priority:    &priority,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createResp.StatusCode()).To(Equal(http.StatusCreated))
+			})
+
+			AfterEach(func() {
+				policyClient.DeletePolicyWithResponse(ctx, policyID)
+			})
+
+			It("should return the print output and trace notes of the policy", func() {
+				request := engineapi.EvaluateRequest{
+					ServiceInstance: engineapi.ServiceInstance{
+						Spec: map[string]any{"service_type": "test-service"},
+					},
+				}
+				explain := engineapi.Notes
+
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, &engineapi.EvaluateRequestParams{Explain: &explain}, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+				Expect(resp.JSON200).NotTo(BeNil())
+				Expect(resp.JSON200.Explanations).NotTo(BeNil())
+				Expect(*resp.JSON200.Explanations).To(ContainElement(And(
+					HaveField("PolicyId", policyID),
+					HaveField("PrintOutput", []string{"service type: test-service"}),
+					HaveField("Trace", ContainElement(ContainSubstring(`Note "checked service type"`))),
+				)))
+			})
+		})
+
+		Context("when previewing constraints", func() {
+			var policyID string
+
+			BeforeEach(func() {
+				regoCode := `package policies.test_preview
+
+main := {
+	"rejected": false,
+	"patch": {"region": "eu-west-1"},
+	"constraints": {"region": {"enum": ["eu-west-1", "eu-central-1"]}}
+}`
+				policyID = "test-preview-policy"
+				displayName := "Test Preview Policy"
+				policyType := v1alpha1.GLOBAL
+				enabled := true
+				priority := int32(100)
+
+				createResp, err := policyClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{
+					Id: &policyID,
+				}, v1alpha1.Policy{
+					DisplayName: &displayName,
+					PolicyType:  &policyType,
+					RegoCode:    &regoCode,
+					Enabled:     &enabled,
+					Priority:    &priority,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createResp.StatusCode()).To(Equal(http.StatusCreated))
+			})
+
+			AfterEach(func() {
+				policyClient.DeletePolicyWithResponse(ctx, policyID)
+			})
+
+			It("should return the merged constraints as a JSON Schema", func() {
+				resp, err := engineClient.PreviewConstraintsWithResponse(ctx, engineapi.PreviewConstraintsRequest{
+					ServiceInstance: engineapi.ServiceInstance{
+						Spec: map[string]any{"service_type": "test-service"},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+				Expect(resp.JSON200).NotTo(BeNil())
+				Expect(resp.JSON200.Rejections).To(BeEmpty())
+				Expect(resp.JSON200.Schema).To(HaveKeyWithValue("properties", HaveKeyWithValue("spec",
+					HaveKeyWithValue("properties", HaveKeyWithValue("region",
+						HaveKeyWithValue("enum", ConsistOf("eu-west-1", "eu-central-1")))))))
+			})
+		})
+
 		Context("when policy rejects the request", func() {
 			var policyID string
 
@@ -161,7 +265,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusNotAcceptable))
 				Expect(resp.JSON406).NotTo(BeNil())
@@ -170,6 +274,74 @@ main := {
 			})
 		})
 
+		Context("when a multi-rule policy denies the request", func() {
+			var policyID string
+
+			BeforeEach(func() {
+				// Create a policy using deny and patch rules instead of main
+				regoCode := `package policies.test_multi_rule
+
+deny contains "GPU instances are not allowed" if input.spec.gpu
+
+patch := {"region": "us-east-1"}`
+				policyID = "test-multi-rule-policy"
+				displayName := "Test Multi-Rule Policy"
+				policyType := v1alpha1.GLOBAL
+				enabled := true
+				priority := int32(100)
+
+				createResp, err := policyClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{
+					Id: &policyID,
+				}, v1alpha1.Policy{
+					DisplayName: &displayName,
+					PolicyType:  &policyType,
+					RegoCode:    &regoCode,
+					Enabled:     &enabled,
+					Priority:    &priority,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createResp.StatusCode()).To(Equal(http.StatusCreated))
+			})
+
+			AfterEach(func() {
+				policyClient.DeletePolicyWithResponse(ctx, policyID)
+			})
+
+			It("should return 406 Not Acceptable with the deny message", func() {
+				request := engineapi.EvaluateRequest{
+					ServiceInstance: engineapi.ServiceInstance{
+						Spec: map[string]any{
+							"service_type": "test-service",
+							"gpu":          true,
+						},
+					},
+				}
+
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode()).To(Equal(http.StatusNotAcceptable))
+				Expect(resp.JSON406).NotTo(BeNil())
+				Expect(resp.JSON406.Detail).NotTo(BeNil())
+				Expect(*resp.JSON406.Detail).To(ContainSubstring("GPU instances are not allowed"))
+			})
+
+			It("should apply the patch rule when nothing is denied", func() {
+				request := engineapi.EvaluateRequest{
+					ServiceInstance: engineapi.ServiceInstance{
+						Spec: map[string]any{
+							"service_type": "test-service",
+						},
+					},
+				}
+
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+				Expect(resp.JSON200).NotTo(BeNil())
+				Expect(resp.JSON200.EvaluatedServiceInstance.Spec).To(HaveKeyWithValue("region", "us-east-1"))
+			})
+		})
+
 		Context("when lower-priority policy violates explicit constraint", func() {
 			var policy1ID, policy2ID string
 
@@ -248,7 +420,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusConflict))
 				Expect(resp.JSON409).NotTo(BeNil())
@@ -331,7 +503,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusConflict))
 				Expect(resp.JSON409).NotTo(BeNil())
@@ -409,7 +581,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusConflict))
 				Expect(resp.JSON409).NotTo(BeNil())
@@ -492,7 +664,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 				Expect(resp.JSON200).NotTo(BeNil())
@@ -574,7 +746,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusConflict))
 				Expect(resp.JSON409).NotTo(BeNil())
@@ -654,7 +826,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 				Expect(resp.JSON200).NotTo(BeNil())
@@ -711,7 +883,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 				Expect(resp.JSON200).NotTo(BeNil())
@@ -802,7 +974,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 				Expect(resp.JSON200).NotTo(BeNil())
@@ -884,7 +1056,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusConflict))
 				Expect(resp.JSON409).NotTo(BeNil())
@@ -962,7 +1134,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusConflict))
 				Expect(resp.JSON409).NotTo(BeNil())
@@ -1026,7 +1198,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 				Expect(resp.JSON200.Status).To(Equal(engineapi.MODIFIED))
@@ -1048,7 +1220,7 @@ main := {
 					},
 				}
 
-				resp, err := engineClient.EvaluateRequestWithResponse(ctx, request)
+				resp, err := engineClient.EvaluateRequestWithResponse(ctx, nil, request)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 				Expect(resp.JSON200.Status).To(Equal(engineapi.APPROVED))
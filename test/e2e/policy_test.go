@@ -38,7 +38,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(100)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -59,7 +59,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(101)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{Id: &clientID}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -75,7 +75,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(102)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -101,7 +101,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(103)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -116,7 +116,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				Priority:    ptr(int32(600)),
 			}
 
-			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, updatedPolicy)
+			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, updatedPolicy)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(updateResp.StatusCode()).To(Equal(http.StatusOK))
 			Expect(updateResp.JSON200).NotTo(BeNil())
@@ -132,7 +132,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(104)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -166,7 +166,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 					PolicyType:  ptr(v1alpha1.GLOBAL),
 					Priority:    ptr(int32(110 + i)),
 					Enabled:     ptr(true),
-					RegoCode:    ptr("package test\nallow = true"),
+					RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 				}
 
 				resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{Id: &id}, policy)
@@ -194,7 +194,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 					PolicyType:  ptr(v1alpha1.GLOBAL),
 					Priority:    ptr(int32(120 + i)),
 					Enabled:     ptr(true),
-					RegoCode:    ptr("package test\nallow = true"),
+					RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 				}
 
 				resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{Id: &id}, policy)
@@ -209,7 +209,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(130)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -229,7 +229,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(140)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			// Create first policy
@@ -253,7 +253,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(150)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			// Create first policy
@@ -279,7 +279,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(201)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respA, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyA)
 			Expect(err).NotTo(HaveOccurred())
@@ -291,7 +291,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.USER),
 				Priority:    ptr(int32(202)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respB, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyB)
 			Expect(err).NotTo(HaveOccurred())
@@ -306,7 +306,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(prio),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respA, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyA)
 			Expect(err).NotTo(HaveOccurred())
@@ -318,7 +318,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.USER),
 				Priority:    ptr(prio),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respB, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyB)
 			Expect(err).NotTo(HaveOccurred())
@@ -332,7 +332,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(203)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respA, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyA)
 			Expect(err).NotTo(HaveOccurred())
@@ -344,7 +344,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(204)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respB, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyB)
 			Expect(err).NotTo(HaveOccurred())
@@ -361,7 +361,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(220)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respA, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyA)
 			Expect(err).NotTo(HaveOccurred())
@@ -373,7 +373,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(220)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respB, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyB)
 			Expect(err).NotTo(HaveOccurred())
@@ -390,7 +390,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(301)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respA, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyA)
 			Expect(err).NotTo(HaveOccurred())
@@ -402,7 +402,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(302)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respB, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyB)
 			Expect(err).NotTo(HaveOccurred())
@@ -411,7 +411,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 			createdPolicyIDs = append(createdPolicyIDs, policyBID)
 
 			patch := v1alpha1.Policy{DisplayName: ptr("Name A")}
-			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyBID, patch)
+			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyBID, nil, patch)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(updateResp.StatusCode()).To(Equal(http.StatusConflict))
 			Expect(updateResp.JSON409).NotTo(BeNil())
@@ -426,7 +426,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(401)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respA, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyA)
 			Expect(err).NotTo(HaveOccurred())
@@ -438,7 +438,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(402)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			respB, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policyB)
 			Expect(err).NotTo(HaveOccurred())
@@ -447,7 +447,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 			createdPolicyIDs = append(createdPolicyIDs, policyBID)
 
 			patch := v1alpha1.Policy{Priority: ptr(int32(401))}
-			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyBID, patch)
+			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyBID, nil, patch)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(updateResp.StatusCode()).To(Equal(http.StatusConflict))
 			Expect(updateResp.JSON409).NotTo(BeNil())
@@ -462,7 +462,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(310)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -471,7 +471,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 			createdPolicyIDs = append(createdPolicyIDs, policyID)
 
 			patch := v1alpha1.Policy{Description: ptr("Updated")}
-			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, patch)
+			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, patch)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(updateResp.StatusCode()).To(Equal(http.StatusOK))
 		})
@@ -482,7 +482,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(410)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -491,7 +491,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 			createdPolicyIDs = append(createdPolicyIDs, policyID)
 
 			patch := v1alpha1.Policy{DisplayName: ptr("Stable Renamed")}
-			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, patch)
+			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, patch)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(updateResp.StatusCode()).To(Equal(http.StatusOK))
 		})
@@ -506,7 +506,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 					PolicyType:  ptr(v1alpha1.GLOBAL),
 					Priority:    ptr(int32(160 + i)),
 					Enabled:     ptr(true),
-					RegoCode:    ptr("package test\nallow = true"),
+					RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 				}
 				resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 				Expect(err).NotTo(HaveOccurred())
@@ -586,21 +586,21 @@ var _ = Describe("Policy CRUD Operations", func() {
 					PolicyType:  ptr(v1alpha1.GLOBAL),
 					Priority:    ptr(int32(170)),
 					Enabled:     ptr(true),
-					RegoCode:    ptr("package test\nallow = true"),
+					RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 				},
 				{
 					DisplayName: ptr("Filter User Policy 1"),
 					PolicyType:  ptr(v1alpha1.USER),
 					Priority:    ptr(int32(100)),
 					Enabled:     ptr(true),
-					RegoCode:    ptr("package test\nallow = true"),
+					RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 				},
 				{
 					DisplayName: ptr("Filter Disabled Policy"),
 					PolicyType:  ptr(v1alpha1.GLOBAL),
 					Priority:    ptr(int32(171)),
 					Enabled:     ptr(false),
-					RegoCode:    ptr("package test\nallow = true"),
+					RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 				},
 			}
 
@@ -699,14 +699,31 @@ var _ = Describe("Policy CRUD Operations", func() {
 			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
 
-		It("should reject filter with multiple AND operators", func() {
+		It("should support multiple AND operators", func() {
 			filter := "policy_type='GLOBAL' AND enabled=true AND enabled=false"
 			params := &v1alpha1.ListPoliciesParams{
 				Filter: &filter,
 			}
 			resp, err := apiClient.ListPoliciesWithResponse(ctx, params)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
+			Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+			Expect(resp.JSON200.Policies).To(BeEmpty())
+		})
+
+		It("should support CEL expressions", func() {
+			filter := "policy_type == 'USER' || (priority >= 100 && !enabled)"
+			params := &v1alpha1.ListPoliciesParams{
+				Filter: &filter,
+			}
+			resp, err := apiClient.ListPoliciesWithResponse(ctx, params)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+			for _, policy := range resp.JSON200.Policies {
+				if *policy.PolicyType != v1alpha1.USER {
+					Expect(*policy.Priority).To(BeNumerically(">=", 100))
+					Expect(*policy.Enabled).To(BeFalse())
+				}
+			}
 		})
 	})
 
@@ -719,21 +736,21 @@ var _ = Describe("Policy CRUD Operations", func() {
 					PolicyType:  ptr(v1alpha1.GLOBAL),
 					Priority:    ptr(int32(180)),
 					Enabled:     ptr(true),
-					RegoCode:    ptr("package test\nallow = true"),
+					RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 				},
 				{
 					DisplayName: ptr("A Order Policy"),
 					PolicyType:  ptr(v1alpha1.GLOBAL),
 					Priority:    ptr(int32(181)),
 					Enabled:     ptr(true),
-					RegoCode:    ptr("package test\nallow = true"),
+					RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 				},
 				{
 					DisplayName: ptr("M Order Policy"),
 					PolicyType:  ptr(v1alpha1.GLOBAL),
 					Priority:    ptr(int32(182)),
 					Enabled:     ptr(true),
-					RegoCode:    ptr("package test\nallow = true"),
+					RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 				},
 			}
 
@@ -865,7 +882,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(minPolicyPriority - 1), // Below minimum
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -879,7 +896,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(maxPolicyPriority + 1), // Above maximum
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -894,7 +911,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.USER),
 				Priority:    ptr(int32(101)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -911,7 +928,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(300)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -920,7 +937,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 			createdPolicyIDs = append(createdPolicyIDs, policyID)
 
 			patch := v1alpha1.Policy{RegoCode: ptr("")}
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, patch)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, patch)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
@@ -931,7 +948,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(301)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -940,7 +957,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 			createdPolicyIDs = append(createdPolicyIDs, policyID)
 
 			patch := v1alpha1.Policy{RegoCode: ptr("   \t\n ")}
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, patch)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, patch)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
@@ -951,7 +968,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(302)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -960,7 +977,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 			createdPolicyIDs = append(createdPolicyIDs, policyID)
 
 			patch := v1alpha1.Policy{Priority: ptr(int32(0))}
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, patch)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, patch)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
@@ -971,7 +988,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(303)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -980,7 +997,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 			createdPolicyIDs = append(createdPolicyIDs, policyID)
 
 			patch := v1alpha1.Policy{Priority: ptr(int32(1001))}
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, patch)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, patch)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
@@ -994,7 +1011,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(200)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -1007,7 +1024,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType: ptr(v1alpha1.USER),
 			}
 
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, update)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, update)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
@@ -1018,7 +1035,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(210)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -1029,7 +1046,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				Path:        ptr("policies/other-id"),
 				DisplayName: ptr("Updated"),
 			}
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, update)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, update)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
@@ -1040,7 +1057,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(211)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -1051,7 +1068,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				Id:          ptr("other-id"),
 				DisplayName: ptr("Updated"),
 			}
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, update)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, update)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
@@ -1062,7 +1079,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(212)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -1074,7 +1091,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				CreateTime:  ptr(otherTime),
 				DisplayName: ptr("Updated"),
 			}
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, update)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, update)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
@@ -1085,7 +1102,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(213)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -1097,7 +1114,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				UpdateTime:  ptr(otherTime),
 				DisplayName: ptr("Updated"),
 			}
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, update)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, update)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
@@ -1108,7 +1125,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(214)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
 			Expect(err).NotTo(HaveOccurred())
@@ -1120,7 +1137,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  createResp.JSON201.PolicyType,
 				DisplayName: ptr("Same Value Updated"),
 			}
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, update)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, update)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 			Expect(resp.JSON200).NotTo(BeNil())
@@ -1139,7 +1156,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				LabelSelector: &map[string]string{
 					"env": "dev",
 				},
-				RegoCode: ptr("package test\nallow = true"),
+				RegoCode: ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -1156,10 +1173,10 @@ var _ = Describe("Policy CRUD Operations", func() {
 				LabelSelector: &map[string]string{
 					"env": "prod",
 				},
-				RegoCode: ptr("package updated\nallow = false"),
+				RegoCode: ptr("package updated\nallow = false\nmain := {\"rejected\": false}"),
 			}
 
-			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, update)
+			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, update)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(updateResp.StatusCode()).To(Equal(http.StatusOK))
 			Expect(*updateResp.JSON200.DisplayName).To(Equal("Mutable Updated Name"))
@@ -1180,7 +1197,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 			update := v1alpha1.Policy{
 				DisplayName: ptr("Update Non-Existent"),
 			}
-			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, "non-existent-id", update)
+			resp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, "non-existent-id", nil, update)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.StatusCode()).To(Equal(http.StatusNotFound))
 		})
@@ -1203,7 +1220,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 					"env":  "production",
 					"team": "platform",
 				},
-				RegoCode: ptr("package test\nallow = true"),
+				RegoCode: ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -1222,7 +1239,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(211)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -1242,7 +1259,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				LabelSelector: &map[string]string{
 					"env": "dev",
 				},
-				RegoCode: ptr("package test\nallow = true"),
+				RegoCode: ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			createResp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -1258,7 +1275,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				},
 			}
 
-			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, update)
+			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, update)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(updateResp.StatusCode()).To(Equal(http.StatusOK))
 			Expect((*updateResp.JSON200.LabelSelector)["env"]).To(Equal("prod"))
@@ -1282,7 +1299,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(1)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			resp1, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, minPolicy)
@@ -1295,7 +1312,7 @@ var _ = Describe("Policy CRUD Operations", func() {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(1000)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			resp2, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, maxPolicy)
@@ -1334,6 +1351,8 @@ allow if {
     input.resource.type == "comment"
     input.method in ["DELETE", "PUT"]
 }
+
+main := {"rejected": allow == false}
 `
 			policy := v1alpha1.Policy{
 				DisplayName: ptr("Long Rego Policy"),
@@ -1356,7 +1375,7 @@ allow if {
 				PolicyType:  ptr(v1alpha1.GLOBAL),
 				Priority:    ptr(int32(221)),
 				Enabled:     ptr(true),
-				RegoCode:    ptr("package test\nallow = true"),
+				RegoCode:    ptr("package test\nallow = true\nmain := {\"rejected\": false}"),
 			}
 
 			resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
@@ -1371,7 +1390,7 @@ allow if {
 
 	Describe("OPA Integration", func() {
 		It("should store and retrieve Rego code", func() {
-			regoCode := "package authz\n\ndefault allow = false\n\nallow if {\n\tinput.user == \"admin\"\n}"
+			regoCode := "package authz\n\ndefault allow = false\n\nallow if {\n\tinput.user == \"admin\"\n}\n\nmain := {\"rejected\": false}"
 			policy := v1alpha1.Policy{
 				DisplayName: ptr("OPA Test Policy"),
 				PolicyType:  ptr(v1alpha1.GLOBAL),
@@ -1412,8 +1431,26 @@ allow if {
 			Expect(string(resp.JSON400.Type)).To(Equal("INVALID_ARGUMENT"))
 		})
 
+		It("should reject Rego code calling a restricted built-in", func() {
+			regoCode := "package network\n\nmain := {\"rejected\": false} if {\n\thttp.send({\"method\": \"get\", \"url\": \"http://inventory.local\"})\n}"
+			policy := v1alpha1.Policy{
+				DisplayName: ptr("Network Policy"),
+				PolicyType:  ptr(v1alpha1.GLOBAL),
+				Priority:    ptr(int32(309)),
+				Enabled:     ptr(true),
+				RegoCode:    &regoCode,
+			}
+
+			resp, err := apiClient.CreatePolicyWithResponse(ctx, &v1alpha1.CreatePolicyParams{}, policy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
+			Expect(resp.JSON400).NotTo(BeNil())
+			Expect(resp.JSON400.Diagnostics).NotTo(BeNil())
+			Expect(*resp.JSON400.Diagnostics).To(ContainElement(HaveField("Code", "dcm_disallowed_builtin")))
+		})
+
 		It("should update Rego code", func() {
-			originalRego := "package authz\n\ndefault allow = false"
+			originalRego := "package authz\n\ndefault allow = false\n\nmain := {\"rejected\": false}"
 			policy := v1alpha1.Policy{
 				DisplayName: ptr("Update Rego Test"),
 				PolicyType:  ptr(v1alpha1.GLOBAL),
@@ -1430,12 +1467,12 @@ allow if {
 			createdPolicyIDs = append(createdPolicyIDs, policyID)
 
 			// Update Rego code
-			updatedRego := "package authz\n\ndefault allow = true"
+			updatedRego := "package authz\n\ndefault allow = true\n\nmain := {\"rejected\": false}"
 			patch := v1alpha1.Policy{
 				RegoCode: &updatedRego,
 			}
 
-			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, patch)
+			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, patch)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(updateResp.StatusCode()).To(Equal(http.StatusOK))
 
@@ -1448,7 +1485,7 @@ allow if {
 		})
 
 		It("should reject invalid Rego on update and preserve original code", func() {
-			originalRego := "package authz\n\ndefault allow = false\n\nallow if { input.user == \"admin\" }"
+			originalRego := "package authz\n\ndefault allow = false\n\nallow if { input.user == \"admin\" }\n\nmain := {\"rejected\": false}"
 			policy := v1alpha1.Policy{
 				DisplayName: ptr("Invalid Update Rego Test"),
 				PolicyType:  ptr(v1alpha1.GLOBAL),
@@ -1465,7 +1502,7 @@ allow if {
 
 			invalidRego := "this is not valid rego syntax!!!"
 			patch := v1alpha1.Policy{RegoCode: &invalidRego}
-			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, patch)
+			updateResp, err := apiClient.UpdatePolicyWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, policyID, nil, patch)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(updateResp.StatusCode()).To(Equal(http.StatusBadRequest), "Should reject invalid Rego on update")
 			Expect(updateResp.JSON400).NotTo(BeNil())
@@ -1479,7 +1516,7 @@ allow if {
 		})
 
 		It("should delete policy and remove Rego from OPA", func() {
-			regoCode := "package authz\n\ndefault allow = false\n\nallow if { input.role == \"admin\" }"
+			regoCode := "package authz\n\ndefault allow = false\n\nallow if { input.role == \"admin\" }\n\nmain := {\"rejected\": false}"
 			policy := v1alpha1.Policy{
 				DisplayName: ptr("Delete OPA Test Policy"),
 				PolicyType:  ptr(v1alpha1.GLOBAL),